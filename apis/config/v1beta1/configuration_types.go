@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
@@ -66,6 +67,22 @@ type Configuration struct {
 	// QueueVisibility is configuration to expose the information about the top
 	// pending workloads.
 	QueueVisibility *QueueVisibility `json:"queueVisibility,omitempty"`
+
+	// EventExporter is configuration to publish the workload lifecycle
+	// transitions as CloudEvents to an external sink.
+	EventExporter *EventExporter `json:"eventExporter,omitempty"`
+
+	// AuditLog is configuration to persist a structured record of the
+	// admission and preemption decisions taken by the scheduler.
+	AuditLog *AuditLog `json:"auditLog,omitempty"`
+
+	// UsageAccounting is configuration to periodically record the resources
+	// consumed by the workloads of every LocalQueue, e.g. for chargeback.
+	UsageAccounting *UsageAccounting `json:"usageAccounting,omitempty"`
+
+	// Resources provide configuration options for accounting the usage of the
+	// resources of the workloads.
+	Resources *Resources `json:"resources,omitempty"`
 }
 
 type ControllerManager struct {
@@ -98,6 +115,11 @@ type ControllerManager struct {
 	// registered within this manager.
 	// +optional
 	Controller *ControllerConfigurationSpec `json:"controller,omitempty"`
+
+	// Cache contains the configuration of the informers that the manager uses
+	// to watch and cache objects.
+	// +optional
+	Cache *ControllerCache `json:"cache,omitempty"`
 }
 
 // ControllerWebhook defines the webhook server for the controller.
@@ -132,6 +154,15 @@ type ControllerMetrics struct {
 	// metrics will be reported.
 	// +optional
 	EnableClusterQueueResources bool `json:"enableClusterQueueResources,omitempty"`
+
+	// EnableSnapshotEndpoint, if true the in-memory snapshot of the cache, including
+	// the cohorts, the ClusterQueues usage and the heads of the pending workloads,
+	// is served as JSON at /debug/snapshot on the metrics server.
+	// The requests to the endpoint are authenticated and authorized against the
+	// API server, so callers need to be granted get on the /debug/snapshot
+	// non-resource URL.
+	// +optional
+	EnableSnapshotEndpoint bool `json:"enableSnapshotEndpoint,omitempty"`
 }
 
 // ControllerHealth defines the health configs.
@@ -151,6 +182,33 @@ type ControllerHealth struct {
 	LivenessEndpointName string `json:"livenessEndpointName,omitempty"`
 }
 
+// ControllerCache defines the configuration of the manager informers.
+type ControllerCache struct {
+	// ByObject restricts the objects watched and cached for some kinds.
+	// Objects not matching the restrictions are invisible to Kueue, so the
+	// restrictions must not leave out objects that Kueue manages.
+	// For example, on a cluster with many Pods not managed by Kueue, the
+	// informer for Pods can be restricted to the Pods with the
+	// kueue.x-k8s.io/managed=true label.
+	// +optional
+	ByObject []CacheByObject `json:"byObject,omitempty"`
+}
+
+// CacheByObject restricts the objects of a kind that are watched and cached.
+type CacheByObject struct {
+	// APIVersion is the group and version of the kind, for example "v1" or
+	// "batch/v1".
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the objects, for example "Pod".
+	Kind string `json:"kind"`
+
+	// LabelSelector restricts the cached objects to the ones having matching
+	// labels.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
 // ControllerConfigurationSpec defines the global configuration for
 // controllers registered with the manager.
 type ControllerConfigurationSpec struct {
@@ -164,6 +222,8 @@ type ControllerConfigurationSpec struct {
 	//
 	// The key is expected to be consistent in form with GroupKind.String(),
 	// e.g. ReplicaSet in apps group (regardless of version) would be `ReplicaSet.apps`.
+	// Kinds in the core group don't have a suffix, e.g. `Pod`.
+	// The values must be greater than zero.
 	//
 	// +optional
 	GroupKindConcurrency map[string]int `json:"groupKindConcurrency,omitempty"`
@@ -216,6 +276,7 @@ type ClientConnection struct {
 	QPS *float32 `json:"qps,omitempty"`
 
 	// Burst allows extra queries to accumulate when a client is exceeding its rate.
+	// Must be greater than or equal to zero.
 	Burst *int32 `json:"burst,omitempty"`
 }
 
@@ -235,6 +296,15 @@ type Integrations struct {
 	Frameworks []string `json:"frameworks,omitempty"`
 	// PodOptions defines kueue controller behaviour for pod objects
 	PodOptions *PodIntegrationOptions `json:"podOptions,omitempty"`
+	// LabelKeyPrefixesToCopy is the list of prefixes of the label keys that
+	// are copied from the jobs to their Workloads, and kept in sync while the
+	// jobs are running, for example "example.com/team". The labels in the
+	// kueue.x-k8s.io domain are never copied.
+	LabelKeyPrefixesToCopy []string `json:"labelKeyPrefixesToCopy,omitempty"`
+	// AnnotationKeyPrefixesToCopy is the list of prefixes of the annotation
+	// keys that are copied from the jobs to their Workloads, like the
+	// labelKeyPrefixesToCopy.
+	AnnotationKeyPrefixesToCopy []string `json:"annotationKeyPrefixesToCopy,omitempty"`
 }
 
 type PodIntegrationOptions struct {
@@ -242,13 +312,43 @@ type PodIntegrationOptions struct {
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 	// PodSelector can be used to choose what pods to reconcile
 	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+	// GroupEvents controls the events emitted when a pod group is started or
+	// stopped. Possible values are:
+	// - Aggregated: a single event is emitted on the Workload of the group,
+	//   including the number of pods in the group.
+	// - PerPod: the events are emitted on the individual pods.
+	// Defaults to Aggregated.
+	GroupEvents PodGroupEvents `json:"groupEvents,omitempty"`
+	// ReclaimablePodsUpdateWindow is the minimum time between two updates of
+	// the reclaimable pods in the status of the Workload of a pod group. The
+	// pods that succeed within the window are counted in a single update at
+	// the end of it, so that large groups don't update their Workload for
+	// every pod. Defaults to 0, which updates the Workload right away.
+	ReclaimablePodsUpdateWindow *metav1.Duration `json:"reclaimablePodsUpdateWindow,omitempty"`
 }
 
+type PodGroupEvents string
+
+const (
+	PodGroupEventsAggregated PodGroupEvents = "Aggregated"
+	PodGroupEventsPerPod     PodGroupEvents = "PerPod"
+)
+
 type QueueVisibility struct {
 	// ClusterQueues is configuration to expose the information
 	// about the top pending workloads in the cluster queue.
 	ClusterQueues *ClusterQueueVisibility `json:"clusterQueues,omitempty"`
 
+	// LocalQueues is configuration to expose the information
+	// about the top pending workloads in the local queue.
+	LocalQueues *LocalQueueVisibility `json:"localQueues,omitempty"`
+
+	// IncludePositions indicates whether the positions of the pending workloads
+	// in the cluster queue and in the local queue are exposed along with the
+	// workloads.
+	// Defaults to false.
+	IncludePositions bool `json:"includePositions,omitempty"`
+
 	// UpdateIntervalSeconds specifies the time interval for updates to the structure
 	// of the top pending workloads in the queues.
 	// The minimum value is 1.
@@ -264,3 +364,201 @@ type ClusterQueueVisibility struct {
 	// Defaults to 10.
 	MaxCount int32 `json:"maxCount,omitempty"`
 }
+
+type LocalQueueVisibility struct {
+	// MaxCount indicates the maximal number of pending workloads exposed in the
+	// local queue status.  When the value is set to 0, then LocalQueue
+	// visibility updates are disabled.
+	// The maximal value is 4000.
+	// Defaults to 10.
+	MaxCount int32 `json:"maxCount,omitempty"`
+}
+
+type EventExporter struct {
+	// Enable indicates whether the workload lifecycle transitions (queued,
+	// admitted, evicted, preempted and finished) are published.
+	// Defaults to false.
+	Enable bool `json:"enable,omitempty"`
+
+	// Sink is the HTTP(S) endpoint which receives the events, encoded in the
+	// CloudEvents structured content mode. Kafka topics can be targeted through
+	// an HTTP bridge accepting CloudEvents, such as the Knative KafkaSink.
+	// Required when the exporter is enabled.
+	Sink string `json:"sink,omitempty"`
+
+	// Headers are additional HTTP headers sent with every event, e.g. to
+	// authenticate against the sink.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Source is the value of the CloudEvents source attribute.
+	// Defaults to kueue.
+	Source *string `json:"source,omitempty"`
+
+	// Timeout is the timeout for delivering a single event.
+	// Defaults to 10s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// MaxRetries is the number of retries for an event which failed to be
+	// delivered, before the event is dropped.
+	// Defaults to 3.
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// BufferSize is the maximum number of events waiting to be delivered.
+	// Events are dropped when the buffer is full.
+	// Defaults to 1000.
+	BufferSize *int32 `json:"bufferSize,omitempty"`
+}
+
+type AuditLog struct {
+	// Enable indicates whether the decision records are persisted.
+	// Defaults to false.
+	Enable bool `json:"enable,omitempty"`
+
+	// Path is the file where the records are appended, one JSON object per
+	// line. To keep the records in an object storage bucket, mount the bucket
+	// in the controller container, e.g. using a CSI driver, and point the path
+	// to it.
+	// Defaults to /var/log/kueue/audit.log.
+	Path string `json:"path,omitempty"`
+
+	// MaxSizeMB is the size in megabytes the file can reach before it is
+	// rotated.
+	// Defaults to 100.
+	MaxSizeMB *int32 `json:"maxSizeMB,omitempty"`
+
+	// MaxBackups is the number of rotated files to retain. The oldest files
+	// are removed first.
+	// Defaults to 5.
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+}
+
+type UsageAccounting struct {
+	// Enable indicates whether the usage records are produced.
+	// Defaults to false.
+	Enable bool `json:"enable,omitempty"`
+
+	// Interval is the period covered by every record. At the end of every
+	// period, a record with the resource-hours consumed by the workloads
+	// holding a quota reservation is produced for every LocalQueue.
+	// Defaults to 1h.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Sink is the HTTP(S) endpoint which receives the records of every
+	// period, as a JSON array. When empty, the records are appended to the
+	// file at path.
+	Sink string `json:"sink,omitempty"`
+
+	// Path is the file where the records are appended, one JSON object per
+	// line, when no sink is set.
+	// Defaults to /var/log/kueue/usage.log.
+	Path string `json:"path,omitempty"`
+
+	// MaxSizeMB is the size in megabytes the file can reach before it is
+	// rotated.
+	// Defaults to 100.
+	MaxSizeMB *int32 `json:"maxSizeMB,omitempty"`
+
+	// MaxBackups is the number of rotated files to retain. The oldest files
+	// are removed first.
+	// Defaults to 5.
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+}
+
+type Resources struct {
+	// Accounting lists the resources whose quota usage is not accounted from
+	// the requests of the containers. The usage of the resources that are not
+	// listed is accounted from the requests.
+	Accounting []ResourceAccounting `json:"accounting,omitempty"`
+
+	// Transformations lists the resources whose requests are accounted as
+	// quantities of other resources, for example the partitions of a device
+	// accounted as fractions of the device, so that a single quota governs
+	// the workloads requesting either of them.
+	// The transformations are applied after the accounting policies.
+	Transformations []ResourceTransformation `json:"transformations,omitempty"`
+
+	// InitContainers configures how the requests of the init containers
+	// account for the quota usage of the pods.
+	InitContainers *InitContainersAccounting `json:"initContainers,omitempty"`
+}
+
+type ResourceAccounting struct {
+	// Name is the name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// Policy indicates the values of the containers that account for the
+	// usage of the resource. The possible values are:
+	//
+	// - `Requests`: the requests of the containers.
+	// - `Limits`: the limits of the containers, or their requests when they
+	//   don't have limits.
+	// - `MaxOfRequestsAndLimits`: the greater of the requests and the limits of
+	//   the containers.
+	Policy ResourceAccountingPolicy `json:"policy"`
+}
+
+type ResourceAccountingPolicy string
+
+const (
+	ResourceAccountingRequests               ResourceAccountingPolicy = "Requests"
+	ResourceAccountingLimits                 ResourceAccountingPolicy = "Limits"
+	ResourceAccountingMaxOfRequestsAndLimits ResourceAccountingPolicy = "MaxOfRequestsAndLimits"
+)
+
+type InitContainersAccounting struct {
+	// Policy indicates how the requests of the init containers account for
+	// the usage of the pods. The possible values are:
+	//
+	// - `Max`: the greater of the requests of every init container and the
+	//   sum of the requests of the containers, the way the pods are
+	//   scheduled.
+	// - `Sum`: the sum of the requests of the init containers and the
+	//   containers.
+	//
+	// Defaults to Max.
+	Policy *InitContainersAccountingPolicy `json:"policy,omitempty"`
+
+	// Frameworks overrides the policy for the workloads of some integrations.
+	Frameworks []FrameworkInitContainersAccounting `json:"frameworks,omitempty"`
+}
+
+type FrameworkInitContainersAccounting struct {
+	// Name is the name of the integration, as in integrations.frameworks.
+	Name string `json:"name"`
+
+	// Policy indicates how the requests of the init containers account for
+	// the usage of the pods of the integration.
+	Policy InitContainersAccountingPolicy `json:"policy"`
+}
+
+type InitContainersAccountingPolicy string
+
+const (
+	InitContainersAccountingMax InitContainersAccountingPolicy = "Max"
+	InitContainersAccountingSum InitContainersAccountingPolicy = "Sum"
+)
+
+type ResourceTransformation struct {
+	// Input is the name of the resource requested by the containers.
+	Input corev1.ResourceName `json:"input"`
+
+	// Outputs are the quantities of the resources accounted for every unit
+	// of the input, for example `nvidia.com/gpu: 250m` for a partition
+	// holding a quarter of a GPU.
+	Outputs corev1.ResourceList `json:"outputs"`
+
+	// Strategy indicates how the input is accounted. The possible values are:
+	//
+	// - `Retain`: the input is accounted along the outputs.
+	// - `Replace`: only the outputs are accounted.
+	//
+	// Defaults to Retain.
+	Strategy *ResourceTransformationStrategy `json:"strategy,omitempty"`
+}
+
+type ResourceTransformationStrategy string
+
+const (
+	RetainResourceTransformation  ResourceTransformationStrategy = "Retain"
+	ReplaceResourceTransformation ResourceTransformationStrategy = "Replace"
+)
@@ -41,6 +41,18 @@ const (
 	defaultPodsReadyTimeout                             = 5 * time.Minute
 	DefaultQueueVisibilityUpdateIntervalSeconds int32   = 5
 	DefaultClusterQueuesMaxCount                int32   = 10
+	DefaultLocalQueuesMaxCount                  int32   = 10
+	DefaultEventExporterSource                          = "kueue"
+	DefaultEventExporterTimeout                         = 10 * time.Second
+	DefaultEventExporterMaxRetries              int32   = 3
+	DefaultEventExporterBufferSize              int32   = 1000
+	DefaultAuditLogPath                                 = "/var/log/kueue/audit.log"
+	DefaultAuditLogMaxSizeMB                    int32   = 100
+	DefaultAuditLogMaxBackups                   int32   = 5
+	DefaultUsageAccountingInterval                      = time.Hour
+	DefaultUsageAccountingPath                          = "/var/log/kueue/usage.log"
+	DefaultUsageAccountingMaxSizeMB             int32   = 100
+	DefaultUsageAccountingMaxBackups            int32   = 5
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -129,6 +141,64 @@ func SetDefaults_Configuration(cfg *Configuration) {
 			MaxCount: DefaultClusterQueuesMaxCount,
 		}
 	}
+	if cfg.QueueVisibility.LocalQueues == nil {
+		cfg.QueueVisibility.LocalQueues = &LocalQueueVisibility{
+			MaxCount: DefaultLocalQueuesMaxCount,
+		}
+	}
+
+	if cfg.EventExporter != nil {
+		if cfg.EventExporter.Source == nil {
+			cfg.EventExporter.Source = ptr.To(DefaultEventExporterSource)
+		}
+		if cfg.EventExporter.Timeout == nil {
+			cfg.EventExporter.Timeout = &metav1.Duration{Duration: DefaultEventExporterTimeout}
+		}
+		if cfg.EventExporter.MaxRetries == nil {
+			cfg.EventExporter.MaxRetries = ptr.To(DefaultEventExporterMaxRetries)
+		}
+		if cfg.EventExporter.BufferSize == nil {
+			cfg.EventExporter.BufferSize = ptr.To(DefaultEventExporterBufferSize)
+		}
+	}
+
+	if cfg.AuditLog != nil {
+		if len(cfg.AuditLog.Path) == 0 {
+			cfg.AuditLog.Path = DefaultAuditLogPath
+		}
+		if cfg.AuditLog.MaxSizeMB == nil {
+			cfg.AuditLog.MaxSizeMB = ptr.To(DefaultAuditLogMaxSizeMB)
+		}
+		if cfg.AuditLog.MaxBackups == nil {
+			cfg.AuditLog.MaxBackups = ptr.To(DefaultAuditLogMaxBackups)
+		}
+	}
+
+	if cfg.UsageAccounting != nil {
+		if cfg.UsageAccounting.Interval == nil {
+			cfg.UsageAccounting.Interval = &metav1.Duration{Duration: DefaultUsageAccountingInterval}
+		}
+		if len(cfg.UsageAccounting.Path) == 0 {
+			cfg.UsageAccounting.Path = DefaultUsageAccountingPath
+		}
+		if cfg.UsageAccounting.MaxSizeMB == nil {
+			cfg.UsageAccounting.MaxSizeMB = ptr.To(DefaultUsageAccountingMaxSizeMB)
+		}
+		if cfg.UsageAccounting.MaxBackups == nil {
+			cfg.UsageAccounting.MaxBackups = ptr.To(DefaultUsageAccountingMaxBackups)
+		}
+	}
+
+	if cfg.Resources != nil {
+		for i := range cfg.Resources.Transformations {
+			if cfg.Resources.Transformations[i].Strategy == nil {
+				cfg.Resources.Transformations[i].Strategy = ptr.To(RetainResourceTransformation)
+			}
+		}
+		if cfg.Resources.InitContainers != nil && cfg.Resources.InitContainers.Policy == nil {
+			cfg.Resources.InitContainers.Policy = ptr.To(InitContainersAccountingMax)
+		}
+	}
 
 	if cfg.Integrations.PodOptions == nil {
 		cfg.Integrations.PodOptions = &PodIntegrationOptions{}
@@ -151,4 +221,8 @@ func SetDefaults_Configuration(cfg *Configuration) {
 	if cfg.Integrations.PodOptions.PodSelector == nil {
 		cfg.Integrations.PodOptions.PodSelector = &metav1.LabelSelector{}
 	}
+
+	if cfg.Integrations.PodOptions.GroupEvents == "" {
+		cfg.Integrations.PodOptions.GroupEvents = PodGroupEventsAggregated
+	}
 }
@@ -65,6 +65,7 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				},
 			},
 			PodSelector: &metav1.LabelSelector{},
+			GroupEvents: PodGroupEventsAggregated,
 		},
 	}
 	defaultQueueVisibility := &QueueVisibility{
@@ -72,6 +73,9 @@ func TestSetDefaults_Configuration(t *testing.T) {
 		ClusterQueues: &ClusterQueueVisibility{
 			MaxCount: 10,
 		},
+		LocalQueues: &LocalQueueVisibility{
+			MaxCount: 10,
+		},
 	}
 
 	overwriteNamespaceIntegrations := &Integrations{
@@ -87,6 +91,7 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				},
 			},
 			PodSelector: &metav1.LabelSelector{},
+			GroupEvents: PodGroupEventsAggregated,
 		},
 	}
 
@@ -421,6 +426,10 @@ func TestSetDefaults_Configuration(t *testing.T) {
 					ClusterQueues: &ClusterQueueVisibility{
 						MaxCount: 0,
 					},
+					LocalQueues: &LocalQueueVisibility{
+						MaxCount: 0,
+					},
+					IncludePositions: true,
 				},
 			},
 			want: &Configuration{
@@ -436,6 +445,127 @@ func TestSetDefaults_Configuration(t *testing.T) {
 					ClusterQueues: &ClusterQueueVisibility{
 						MaxCount: 0,
 					},
+					LocalQueues: &LocalQueueVisibility{
+						MaxCount: 0,
+					},
+					IncludePositions: true,
+				},
+			},
+		},
+		"event exporter": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				EventExporter: &EventExporter{
+					Enable: true,
+					Sink:   "http://sink.default.svc",
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				EventExporter: &EventExporter{
+					Enable:     true,
+					Sink:       "http://sink.default.svc",
+					Source:     ptr.To(DefaultEventExporterSource),
+					Timeout:    &metav1.Duration{Duration: DefaultEventExporterTimeout},
+					MaxRetries: ptr.To(DefaultEventExporterMaxRetries),
+					BufferSize: ptr.To(DefaultEventExporterBufferSize),
+				},
+			},
+		},
+		"audit log": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				AuditLog: &AuditLog{
+					Enable:     true,
+					MaxBackups: ptr.To[int32](0),
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				AuditLog: &AuditLog{
+					Enable:     true,
+					Path:       DefaultAuditLogPath,
+					MaxSizeMB:  ptr.To(DefaultAuditLogMaxSizeMB),
+					MaxBackups: ptr.To[int32](0),
+				},
+			},
+		},
+		"usage accounting": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				UsageAccounting: &UsageAccounting{
+					Enable:   true,
+					Interval: &metav1.Duration{Duration: 15 * time.Minute},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				UsageAccounting: &UsageAccounting{
+					Enable:     true,
+					Interval:   &metav1.Duration{Duration: 15 * time.Minute},
+					Path:       DefaultUsageAccountingPath,
+					MaxSizeMB:  ptr.To(DefaultUsageAccountingMaxSizeMB),
+					MaxBackups: ptr.To(DefaultUsageAccountingMaxBackups),
+				},
+			},
+		},
+		"resources": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				Resources: &Resources{
+					Transformations: []ResourceTransformation{
+						{Input: "example.com/gpu-slice"},
+						{Input: "example.com/gpu-half", Strategy: ptr.To(ReplaceResourceTransformation)},
+					},
+					InitContainers: &InitContainersAccounting{},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+				Resources: &Resources{
+					Transformations: []ResourceTransformation{
+						{Input: "example.com/gpu-slice", Strategy: ptr.To(RetainResourceTransformation)},
+						{Input: "example.com/gpu-half", Strategy: ptr.To(ReplaceResourceTransformation)},
+					},
+					InitContainers: &InitContainersAccounting{
+						Policy: ptr.To(InitContainersAccountingMax),
+					},
 				},
 			},
 		},
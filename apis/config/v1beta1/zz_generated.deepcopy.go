@@ -22,12 +22,58 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/component-base/config/v1alpha1"
 	timex "time"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLog) DeepCopyInto(out *AuditLog) {
+	*out = *in
+	if in.MaxSizeMB != nil {
+		in, out := &in.MaxSizeMB, &out.MaxSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackups != nil {
+		in, out := &in.MaxBackups, &out.MaxBackups
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLog.
+func (in *AuditLog) DeepCopy() *AuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheByObject) DeepCopyInto(out *CacheByObject) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheByObject.
+func (in *CacheByObject) DeepCopy() *CacheByObject {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheByObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 	*out = *in
@@ -103,6 +149,26 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(QueueVisibility)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EventExporter != nil {
+		in, out := &in.EventExporter, &out.EventExporter
+		*out = new(EventExporter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageAccounting != nil {
+		in, out := &in.UsageAccounting, &out.UsageAccounting
+		*out = new(UsageAccounting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(Resources)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -123,6 +189,28 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerCache) DeepCopyInto(out *ControllerCache) {
+	*out = *in
+	if in.ByObject != nil {
+		in, out := &in.ByObject, &out.ByObject
+		*out = make([]CacheByObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerCache.
+func (in *ControllerCache) DeepCopy() *ControllerCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfigurationSpec) DeepCopyInto(out *ControllerConfigurationSpec) {
 	*out = *in
@@ -181,6 +269,11 @@ func (in *ControllerManager) DeepCopyInto(out *ControllerManager) {
 		*out = new(ControllerConfigurationSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ControllerCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManager.
@@ -228,6 +321,88 @@ func (in *ControllerWebhook) DeepCopy() *ControllerWebhook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventExporter) DeepCopyInto(out *EventExporter) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventExporter.
+func (in *EventExporter) DeepCopy() *EventExporter {
+	if in == nil {
+		return nil
+	}
+	out := new(EventExporter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkInitContainersAccounting) DeepCopyInto(out *FrameworkInitContainersAccounting) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkInitContainersAccounting.
+func (in *FrameworkInitContainersAccounting) DeepCopy() *FrameworkInitContainersAccounting {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkInitContainersAccounting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InitContainersAccounting) DeepCopyInto(out *InitContainersAccounting) {
+	*out = *in
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(InitContainersAccountingPolicy)
+		**out = **in
+	}
+	if in.Frameworks != nil {
+		in, out := &in.Frameworks, &out.Frameworks
+		*out = make([]FrameworkInitContainersAccounting, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainersAccounting.
+func (in *InitContainersAccounting) DeepCopy() *InitContainersAccounting {
+	if in == nil {
+		return nil
+	}
+	out := new(InitContainersAccounting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Integrations) DeepCopyInto(out *Integrations) {
 	*out = *in
@@ -241,6 +416,16 @@ func (in *Integrations) DeepCopyInto(out *Integrations) {
 		*out = new(PodIntegrationOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LabelKeyPrefixesToCopy != nil {
+		in, out := &in.LabelKeyPrefixesToCopy, &out.LabelKeyPrefixesToCopy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationKeyPrefixesToCopy != nil {
+		in, out := &in.AnnotationKeyPrefixesToCopy, &out.AnnotationKeyPrefixesToCopy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Integrations.
@@ -283,6 +468,21 @@ func (in *InternalCertManagement) DeepCopy() *InternalCertManagement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueueVisibility) DeepCopyInto(out *LocalQueueVisibility) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueVisibility.
+func (in *LocalQueueVisibility) DeepCopy() *LocalQueueVisibility {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueueVisibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodIntegrationOptions) DeepCopyInto(out *PodIntegrationOptions) {
 	*out = *in
@@ -296,6 +496,11 @@ func (in *PodIntegrationOptions) DeepCopyInto(out *PodIntegrationOptions) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ReclaimablePodsUpdateWindow != nil {
+		in, out := &in.ReclaimablePodsUpdateWindow, &out.ReclaimablePodsUpdateWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodIntegrationOptions.
@@ -316,6 +521,11 @@ func (in *QueueVisibility) DeepCopyInto(out *QueueVisibility) {
 		*out = new(ClusterQueueVisibility)
 		**out = **in
 	}
+	if in.LocalQueues != nil {
+		in, out := &in.LocalQueues, &out.LocalQueues
+		*out = new(LocalQueueVisibility)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueVisibility.
@@ -328,6 +538,110 @@ func (in *QueueVisibility) DeepCopy() *QueueVisibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceAccounting) DeepCopyInto(out *ResourceAccounting) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceAccounting.
+func (in *ResourceAccounting) DeepCopy() *ResourceAccounting {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceAccounting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTransformation) DeepCopyInto(out *ResourceTransformation) {
+	*out = *in
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(ResourceTransformationStrategy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTransformation.
+func (in *ResourceTransformation) DeepCopy() *ResourceTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Resources) DeepCopyInto(out *Resources) {
+	*out = *in
+	if in.Accounting != nil {
+		in, out := &in.Accounting, &out.Accounting
+		*out = make([]ResourceAccounting, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transformations != nil {
+		in, out := &in.Transformations, &out.Transformations
+		*out = make([]ResourceTransformation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = new(InitContainersAccounting)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
+func (in *Resources) DeepCopy() *Resources {
+	if in == nil {
+		return nil
+	}
+	out := new(Resources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageAccounting) DeepCopyInto(out *UsageAccounting) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxSizeMB != nil {
+		in, out := &in.MaxSizeMB, &out.MaxSizeMB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxBackups != nil {
+		in, out := &in.MaxBackups, &out.MaxBackups
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageAccounting.
+func (in *UsageAccounting) DeepCopy() *UsageAccounting {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageAccounting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WaitForPodsReady) DeepCopyInto(out *WaitForPodsReady) {
 	*out = *in
@@ -72,6 +72,24 @@ type ClusterQueueSpec struct {
 	// If set to an empty selector `{}`, then all namespaces are eligible.
 	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
 
+	// namespaceSelectorTerms are additional selectors of the namespaces that
+	// are allowed to submit workloads to this clusterQueue. A namespace is
+	// allowed if it matches the namespaceSelector or any of the terms. Within
+	// a term, matchExpressions with the NotIn and DoesNotExist operators deny
+	// the namespaces that otherwise match the term.
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=8
+	NamespaceSelectorTerms []metav1.LabelSelector `json:"namespaceSelectorTerms,omitempty"`
+
+	// excludedNamespaces lists the namespaces that are not allowed to submit
+	// workloads to this clusterQueue, even if they match the namespaceSelector
+	// or any of the namespaceSelectorTerms. An item ending with `*` excludes
+	// all the namespaces whose names start with the rest of the item, e.g.
+	// `sandbox-*`.
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
 	// flavorFungibility defines whether a workload should try the next flavor
 	// before borrowing or preempting in the flavor being evaluated.
 	FlavorFungibility *FlavorFungibility `json:"flavorFungibility,omitempty"`
@@ -110,6 +128,37 @@ type ClusterQueueSpec struct {
 	// +kubebuilder:validation:Enum=None;Hold;HoldAndDrain
 	// +kubebuilder:default="None"
 	StopPolicy *StopPolicy `json:"stopPolicy,omitempty"`
+
+	// quotaTiers reserve parts of the nominal quota of the ClusterQueue to the
+	// workloads with higher priorities. The workloads with a priority lower
+	// than the minPriority of a tier can't use the quota reserved by the tier,
+	// nor by the tiers with a higher minPriority, even when it is unused.
+	// The quota which is not reserved is available to all the workloads.
+	// The limits include the quota that the workloads borrow from the cohort,
+	// and preemption is not considered to fit a workload in the quota not
+	// reserved to higher priorities.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	QuotaTiers []QuotaTier `json:"quotaTiers,omitempty"`
+}
+
+type QuotaTier struct {
+	// name of the tier.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	Name string `json:"name"`
+
+	// minPriority is the lowest priority of the workloads that can use the
+	// quota reserved by the tier.
+	MinPriority int32 `json:"minPriority"`
+
+	// reservedQuotaPercent is the percentage of the nominal quota of every
+	// flavor and resource that is reserved to the workloads of the tier.
+	// The reserved percentages of all the tiers can't exceed 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ReservedQuotaPercent int32 `json:"reservedQuotaPercent"`
 }
 
 type QueueingStrategy string
@@ -155,6 +204,33 @@ type ResourceGroup struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
 	Flavors []FlavorQuotas `json:"flavors"`
+
+	// flavorFungibility overrides the flavorFungibility of the ClusterQueue
+	// for the resources of this group. The fields that are not set take their
+	// default values, not the ones of the ClusterQueue.
+	// +optional
+	FlavorFungibility *FlavorFungibility `json:"flavorFungibility,omitempty"`
+
+	// resourceFlavorFungibility overrides the flavorFungibility of the group,
+	// or of the ClusterQueue, for individual resources of this group.
+	// When a flavor requires borrowing, or preemption, for more than one
+	// resource, the next flavor is tried if the policy of any of them is
+	// `TryNextFlavor`.
+	// The list can contain up to 16 resources.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	ResourceFlavorFungibility []ResourceFlavorFungibility `json:"resourceFlavorFungibility,omitempty"`
+}
+
+// ResourceFlavorFungibility is the flavorFungibility of a resource.
+type ResourceFlavorFungibility struct {
+	// name of the resource. It must be one of the coveredResources of the
+	// group.
+	Name corev1.ResourceName `json:"name"`
+
+	FlavorFungibility `json:",inline"`
 }
 
 type FlavorQuotas struct {
@@ -201,6 +277,23 @@ type ResourceQuota struct {
 	// borrowingLimit must be null if spec.cohort is empty.
 	// +optional
 	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
+
+	// nominalQuotaPercent expresses the nominalQuota as a percentage of the
+	// cohort capacity for the [flavor, resource] combination, which is the sum
+	// of the nominalQuota of the ClusterQueues in the cohort that don't set
+	// nominalQuotaPercent. The nominal quotas of those ClusterQueues are
+	// reduced by the sum of the percentages, so that the capacity of the
+	// cohort doesn't change. If the percentages of the cohort add up to more
+	// than 100, they are scaled down proportionally.
+	// The resulting nominal quotas of the ClusterQueues in the cohort are
+	// reported in status.flavorsNominalQuota.
+	// When set, nominalQuota must be zero and spec.cohort must not be empty.
+	//
+	// This field requires the CohortQuotaShares feature gate.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	NominalQuotaPercent *int32 `json:"nominalQuotaPercent,omitempty"`
 }
 
 // ResourceFlavorReference is the name of the ResourceFlavor.
@@ -224,6 +317,16 @@ type ClusterQueueStatus struct {
 	// +optional
 	FlavorsUsage []FlavorUsage `json:"flavorsUsage"`
 
+	// flavorsNominalQuota are the nominal quotas, by flavor, that the
+	// ClusterQueue uses for the resources whose nominal quota differs from the
+	// spec, because the ClusterQueue or other ClusterQueues in its cohort set
+	// nominalQuotaPercent.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	FlavorsNominalQuota []FlavorNominalQuota `json:"flavorsNominalQuota,omitempty"`
+
 	// pendingWorkloads is the number of workloads currently waiting to be
 	// admitted to this clusterQueue.
 	// +optional
@@ -272,6 +375,20 @@ type ClusterQueuePendingWorkload struct {
 
 	// Namespace indicates the name of the pending workload.
 	Namespace string `json:"namespace"`
+
+	// PositionInClusterQueue indicates the position of the workload among
+	// the pending workloads in the cluster queue, starting from 0.
+	// Only set when the positions are enabled in the queue visibility
+	// configuration.
+	// +optional
+	PositionInClusterQueue *int32 `json:"positionInClusterQueue,omitempty"`
+
+	// PositionInLocalQueue indicates the position of the workload among
+	// the pending workloads in its local queue, starting from 0.
+	// Only set when the positions are enabled in the queue visibility
+	// configuration.
+	// +optional
+	PositionInLocalQueue *int32 `json:"positionInLocalQueue,omitempty"`
 }
 
 type FlavorUsage struct {
@@ -298,6 +415,26 @@ type ResourceUsage struct {
 	Borrowed resource.Quantity `json:"borrowed,omitempty"`
 }
 
+type FlavorNominalQuota struct {
+	// name of the flavor.
+	Name ResourceFlavorReference `json:"name"`
+
+	// resources lists the nominal quotas of the resources in this flavor.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []ResourceNominalQuota `json:"resources"`
+}
+
+type ResourceNominalQuota struct {
+	// name of the resource
+	Name corev1.ResourceName `json:"name"`
+
+	// nominalQuota is the quantity of the resource that the ClusterQueue
+	// uses as its nominal quota.
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+}
+
 const (
 	// ClusterQueueActive indicates that the ClusterQueue can admit new workloads and its quota
 	// can be borrowed by other ClusterQueues in the same cohort.
@@ -380,6 +517,23 @@ type ClusterQueuePreemption struct {
 	// +kubebuilder:default=Never
 	// +kubebuilder:validation:Enum=Never;LowerPriority;LowerOrNewerEqualPriority
 	WithinClusterQueue PreemptionPolicy `json:"withinClusterQueue,omitempty"`
+
+	// minRuntime is the time, since their admission, during which the
+	// Workloads admitted by this ClusterQueue can't be preempted, neither by
+	// Workloads in this ClusterQueue nor by Workloads in the cohort.
+	// It protects the Workloads that need a long setup from being preempted
+	// before making progress.
+	// The Workloads are not protected when it's not set.
+	// +optional
+	MinRuntime *metav1.Duration `json:"minRuntime,omitempty"`
+
+	// gracePeriod is the time between the selection of a Workload admitted by
+	// this ClusterQueue as a preemption target and its eviction. During this
+	// time the Workload has the Preempting condition set to true, so that its
+	// job can checkpoint its progress.
+	// The Workloads are evicted right away when it's not set.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 //+genclient
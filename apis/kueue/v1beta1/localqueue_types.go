@@ -26,6 +26,36 @@ import (
 type LocalQueueSpec struct {
 	// clusterQueue is a reference to a clusterQueue that backs this localQueue.
 	ClusterQueue ClusterQueueReference `json:"clusterQueue,omitempty"`
+
+	// fallbackClusterQueues is an ordered list of clusterQueues where the
+	// workloads of this localQueue are queued when they stay pending for
+	// fallbackAfterSeconds in the clusterQueue, or in the previous fallback
+	// clusterQueue.
+	// The usage reported in the status of the localQueue only includes the
+	// workloads admitted by the clusterQueue.
+	//
+	// This field requires the LocalQueueFallback feature gate.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=8
+	FallbackClusterQueues []ClusterQueueReference `json:"fallbackClusterQueues,omitempty"`
+
+	// fallbackAfterSeconds is how long a workload stays pending in a
+	// clusterQueue before it's queued in the next fallback clusterQueue.
+	// Defaults to 300.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FallbackAfterSeconds *int32 `json:"fallbackAfterSeconds,omitempty"`
+
+	// defaultWorkloadPriorityClass is the name of the WorkloadPriorityClass
+	// of the workloads submitted to this localQueue by jobs that don't
+	// specify a workload priority class nor a priority class.
+	//
+	// This field requires the LocalQueueDefaultPriority feature gate.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	DefaultWorkloadPriorityClass string `json:"defaultWorkloadPriorityClass,omitempty"`
 }
 
 // ClusterQueueReference is the name of the ClusterQueue.
@@ -71,6 +101,42 @@ type LocalQueueStatus struct {
 	// +kubebuilder:validation:MaxItems=16
 	// +optional
 	FlavorUsage []LocalQueueFlavorUsage `json:"flavorUsage"`
+
+	// PendingWorkloadsStatus contains the information exposed about the current
+	// status of the pending workloads in the local queue.
+	// +optional
+	PendingWorkloadsStatus *LocalQueuePendingWorkloadsStatus `json:"pendingWorkloadsStatus,omitempty"`
+}
+
+type LocalQueuePendingWorkloadsStatus struct {
+	// Head contains the list of top pending workloads.
+	// +listType=atomic
+	// +optional
+	Head []LocalQueuePendingWorkload `json:"head"`
+
+	// LastChangeTime indicates the time of the last change of the structure.
+	LastChangeTime metav1.Time `json:"lastChangeTime"`
+}
+
+// LocalQueuePendingWorkload contains the information identifying a pending
+// workload in the local queue.
+type LocalQueuePendingWorkload struct {
+	// Name indicates the name of the pending workload.
+	Name string `json:"name"`
+
+	// PositionInClusterQueue indicates the position of the workload among
+	// the pending workloads in the cluster queue, starting from 0.
+	// Only set when the positions are enabled in the queue visibility
+	// configuration.
+	// +optional
+	PositionInClusterQueue *int32 `json:"positionInClusterQueue,omitempty"`
+
+	// PositionInLocalQueue indicates the position of the workload among
+	// the pending workloads in the local queue, starting from 0.
+	// Only set when the positions are enabled in the queue visibility
+	// configuration.
+	// +optional
+	PositionInLocalQueue *int32 `json:"positionInLocalQueue,omitempty"`
 }
 
 const (
@@ -51,6 +51,17 @@ type ProvisioningRequestConfigSpec struct {
 	// +listType=set
 	// +kubebuilder:validation:MaxItems=100
 	ManagedResources []corev1.ResourceName `json:"managedResources,omitempty"`
+
+	// flavorCooldownSeconds, when set, marks the ResourceFlavors assigned to the
+	// pod sets of a workload in its ProvisioningRequest as not provisionable for
+	// this number of seconds when the request fails. The workload is then
+	// requeued, instead of retrying the ProvisioningRequest, and the workloads
+	// don't get the marked ResourceFlavors assigned until the cooldown expires,
+	// so that they can get the next flavors of their ClusterQueues.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FlavorCooldownSeconds *int32 `json:"flavorCooldownSeconds,omitempty"`
 }
 
 // Parameter is limited to 255 characters.
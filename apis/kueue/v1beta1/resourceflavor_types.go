@@ -79,6 +79,22 @@ type ResourceFlavorSpec struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=8
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// stopPolicy - if set to a value different from None, the ResourceFlavor is
+	// cordoned, and no new workloads get assigned to it, for example during an
+	// upgrade of its nodes. The workloads that reserved quota in the
+	// ResourceFlavor but are not admitted yet release their quota.
+	//
+	// Depending on its value, its associated admitted workloads will:
+	//
+	// - None - Keep running and new workloads get assigned to the flavor.
+	// - Hold - Keep running.
+	// - HoldAndDrain - Get evicted.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=None;Hold;HoldAndDrain
+	// +kubebuilder:default="None"
+	StopPolicy *StopPolicy `json:"stopPolicy,omitempty"`
 }
 
 //+kubebuilder:object:root=true
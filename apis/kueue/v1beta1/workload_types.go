@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -70,6 +71,44 @@ type WorkloadSpec struct {
 	// Defaults to true
 	// +kubebuilder:default=true
 	Active *bool `json:"active,omitempty"`
+
+	// retryPolicy limits the number of times the workload is requeued after
+	// an eviction caused by the workload itself, that is, by exceeding the
+	// PodsReady timeout or by an admission check that is no longer satisfied.
+	// Evictions caused by preemption, by a stopped ClusterQueue or by the
+	// deactivation of the workload are not retries.
+	// Once the retries are exhausted, the workload is deactivated, by setting
+	// active to false, and it gets the Deactivated condition.
+	// If not set, the workload is requeued after every eviction without delay.
+	// +optional
+	RetryPolicy *RetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// RetryPolicy defines the requeueing of a workload after evictions.
+type RetryPolicy struct {
+	// maxRetries is the number of times the workload can be requeued after
+	// an eviction. The workload is deactivated when it's evicted once more.
+	// If not set, the workload is requeued indefinitely, with a backoff.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// backoffBaseSeconds is the delay, in seconds, before the workload is
+	// requeued after the first eviction. The delay doubles after every
+	// eviction, up to backoffMaxSeconds.
+	// Defaults to 10.
+	// +optional
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	BackoffBaseSeconds *int32 `json:"backoffBaseSeconds,omitempty"`
+
+	// backoffMaxSeconds is the maximum delay, in seconds, before the workload
+	// is requeued.
+	// Defaults to 3600.
+	// +optional
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=0
+	BackoffMaxSeconds *int32 `json:"backoffMaxSeconds,omitempty"`
 }
 
 type Admission struct {
@@ -160,6 +199,9 @@ type WorkloadStatus struct {
 	// - Finished: the associated workload finished running (failed or succeeded).
 	// - PodsReady: at least `.spec.podSets[*].count` Pods are ready or have
 	// succeeded.
+	// - Deactivated: the Workload was deactivated after exhausting the
+	// retries of its retryPolicy. The condition is set to false once the
+	// Workload is activated again.
 	//
 	// +optional
 	// +listType=map
@@ -182,8 +224,134 @@ type WorkloadStatus struct {
 	// +patchStrategy=merge
 	// +patchMergeKey=name
 	AdmissionChecks []AdmissionCheckState `json:"admissionChecks,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// inadmissibility holds, for each podSet that couldn't be assigned flavors
+	// in the last scheduling attempt, the reasons why each of the considered
+	// flavors didn't fit. It's cleared when the workload gets quota reserved.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	Inadmissibility []PodSetInadmissibility `json:"inadmissibility,omitempty"`
+
+	// requeueState holds the state of the requeueing of the workload after
+	// evictions, following its retryPolicy.
+	// +optional
+	RequeueState *RequeueState `json:"requeueState,omitempty"`
+
+	// lastAdmission holds the admission of the workload before its quota
+	// reservation was last removed, for example after an eviction. When the
+	// workload is admitted again by the same ClusterQueue, the flavors of the
+	// last admission are preferred when the podSets fit in them without
+	// preemption. It's only set when the FlavorStickiness feature is enabled.
+	// +optional
+	LastAdmission *Admission `json:"lastAdmission,omitempty"`
+}
+
+type RequeueState struct {
+	// count is the number of times the workload was requeued after an
+	// eviction.
+	// +optional
+	Count *int32 `json:"count,omitempty"`
+
+	// requeueAt is the time when the workload is requeued after its last
+	// eviction. It's cleared once the workload is requeued.
+	// +optional
+	RequeueAt *metav1.Time `json:"requeueAt,omitempty"`
+}
+
+type PodSetInadmissibility struct {
+	// name is the PodSet name.
+	Name string `json:"name"`
+
+	// flavors lists the flavors that were considered for the podSet and
+	// couldn't be assigned.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	Flavors []FlavorInadmissibility `json:"flavors,omitempty"`
 }
 
+type FlavorInadmissibility struct {
+	// name is the ResourceFlavor name.
+	Name ResourceFlavorReference `json:"name"`
+
+	// reason is set when the flavor couldn't be considered regardless of the
+	// requested quantities, one of FlavorNotFound, FlavorStopped,
+	// FlavorNotProvisionable, UntoleratedTaint or NodeAffinityMismatch.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// resources lists the requested resources which don't fit in the flavor.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Resources []ResourceInadmissibility `json:"resources,omitempty"`
+}
+
+type ResourceInadmissibility struct {
+	// name of the resource.
+	Name corev1.ResourceName `json:"name"`
+
+	// reason of the resource not fitting, one of InsufficientQuota,
+	// InsufficientUnusedQuota, BorrowingLimitExceeded or
+	// QuotaReservedToHigherPriorities.
+	Reason string `json:"reason"`
+
+	// missing is the quantity of the resource lacking in the ClusterQueue,
+	// or in the cohort when borrowing was considered.
+	// +optional
+	Missing *resource.Quantity `json:"missing,omitempty"`
+
+	// borrowingConsidered indicates that the unused quota in the cohort was
+	// considered.
+	BorrowingConsidered bool `json:"borrowingConsidered"`
+
+	// preemptionConsidered indicates that the request fits in the nominal
+	// quota of the ClusterQueue, so preempting other workloads was considered.
+	PreemptionConsidered bool `json:"preemptionConsidered"`
+}
+
+const (
+	// InadmissibilityReasonFlavorNotFound means that the ResourceFlavor doesn't exist.
+	InadmissibilityReasonFlavorNotFound = "FlavorNotFound"
+
+	// InadmissibilityReasonUntoleratedTaint means that the podSet doesn't tolerate
+	// a taint of the ResourceFlavor.
+	InadmissibilityReasonUntoleratedTaint = "UntoleratedTaint"
+
+	// InadmissibilityReasonNodeAffinityMismatch means that the node affinity of
+	// the podSet doesn't match the labels of the ResourceFlavor.
+	InadmissibilityReasonNodeAffinityMismatch = "NodeAffinityMismatch"
+
+	// InadmissibilityReasonFlavorStopped means that the ResourceFlavor is
+	// cordoned by its stopPolicy.
+	InadmissibilityReasonFlavorStopped = "FlavorStopped"
+
+	// InadmissibilityReasonFlavorNotProvisionable means that the ResourceFlavor
+	// is in the cooldown after a ProvisioningRequest failed to provision it.
+	InadmissibilityReasonFlavorNotProvisionable = "FlavorNotProvisionable"
+
+	// InadmissibilityReasonInsufficientQuota means that the request exceeds the
+	// quota of the ClusterQueue.
+	InadmissibilityReasonInsufficientQuota = "InsufficientQuota"
+
+	// InadmissibilityReasonInsufficientUnusedQuota means that the request fits in
+	// the quota, but not in the unused quota of the ClusterQueue or cohort.
+	InadmissibilityReasonInsufficientUnusedQuota = "InsufficientUnusedQuota"
+
+	// InadmissibilityReasonBorrowingLimitExceeded means that the request exceeds
+	// the borrowing limit of the ClusterQueue.
+	InadmissibilityReasonBorrowingLimitExceeded = "BorrowingLimitExceeded"
+
+	// InadmissibilityReasonQuotaReservedToHigherPriorities means that the
+	// request exceeds the quota of the ClusterQueue which is not reserved by
+	// its quotaTiers to higher priorities.
+	InadmissibilityReasonQuotaReservedToHigherPriorities = "QuotaReservedToHigherPriorities"
+)
+
 type AdmissionCheckState struct {
 	// name identifies the admission check.
 	// +required
@@ -264,6 +432,30 @@ const (
 
 	// WorkloadEvicted means that the Workload was evicted by a ClusterQueue
 	WorkloadEvicted = "Evicted"
+
+	// WorkloadDeactivated means that the Workload was deactivated by Kueue,
+	// because it exhausted the retries of its retryPolicy.
+	WorkloadDeactivated = "Deactivated"
+
+	// WorkloadPreempting means that the Workload was selected as a preemption
+	// target and will be evicted after the preemption gracePeriod of its
+	// ClusterQueue.
+	WorkloadPreempting = "Preempting"
+
+	// WorkloadSchedulingGated means that the Pods of the admitted Workload
+	// still carry scheduling gates which are not managed by Kueue, so that
+	// they can't be scheduled yet.
+	WorkloadSchedulingGated = "SchedulingGated"
+)
+
+const (
+	// WorkloadRetryLimitExceeded indicates that the workload was deactivated
+	// because it was evicted more times than the maxRetries of its retryPolicy.
+	WorkloadRetryLimitExceeded = "RetryLimitExceeded"
+
+	// WorkloadReactivated indicates that the workload, deactivated after
+	// exhausting its retries, was activated again and its retries were reset.
+	WorkloadReactivated = "Reactivated"
 )
 
 const (
@@ -283,6 +475,10 @@ const (
 	// because the ClusterQueue is Stopped.
 	WorkloadEvictedByClusterQueueStopped = "ClusterQueueStopped"
 
+	// WorkloadEvictedByResourceFlavorStopped indicates that the workload was
+	// evicted because a ResourceFlavor assigned to it is drained.
+	WorkloadEvictedByResourceFlavorStopped = "ResourceFlavorStopped"
+
 	// WorkloadEvictedByDeactivation indicates that the workload was evicted
 	// because spec.active is set to false.
 	WorkloadEvictedByDeactivation = "InactiveWorkload"
@@ -41,6 +41,25 @@ type WorkloadPriorityClass struct {
 	// when this workloadPriorityClass should be used.
 	// +optional
 	Description string `json:"description,omitempty"`
+
+	// preemptionPolicy defines whether the workloads of this workloadPriorityClass
+	// can preempt other workloads. The possible values are:
+	//
+	// - `LowerPriority` (default): the workloads can preempt workloads, as
+	//   allowed by the preemption policies of their ClusterQueue.
+	// - `Never`: the workloads never preempt other workloads.
+	//
+	// +optional
+	// +kubebuilder:default=LowerPriority
+	// +kubebuilder:validation:Enum=Never;LowerPriority
+	PreemptionPolicy PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+
+	// nonPreemptible indicates that the admitted workloads of this
+	// workloadPriorityClass are never preempted within their clusterQueue.
+	// They can still be preempted to reclaim the quota that their clusterQueue
+	// borrows from the cohort.
+	// +optional
+	NonPreemptible bool `json:"nonPreemptible,omitempty"`
 }
 
 //+kubebuilder:object:root=true
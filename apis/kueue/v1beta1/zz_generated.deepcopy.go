@@ -255,6 +255,16 @@ func (in *ClusterQueueList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueuePendingWorkload) DeepCopyInto(out *ClusterQueuePendingWorkload) {
 	*out = *in
+	if in.PositionInClusterQueue != nil {
+		in, out := &in.PositionInClusterQueue, &out.PositionInClusterQueue
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PositionInLocalQueue != nil {
+		in, out := &in.PositionInLocalQueue, &out.PositionInLocalQueue
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueuePendingWorkload.
@@ -273,7 +283,9 @@ func (in *ClusterQueuePendingWorkloadsStatus) DeepCopyInto(out *ClusterQueuePend
 	if in.Head != nil {
 		in, out := &in.Head, &out.Head
 		*out = make([]ClusterQueuePendingWorkload, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.LastChangeTime.DeepCopyInto(&out.LastChangeTime)
 }
@@ -291,6 +303,16 @@ func (in *ClusterQueuePendingWorkloadsStatus) DeepCopy() *ClusterQueuePendingWor
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterQueuePreemption) DeepCopyInto(out *ClusterQueuePreemption) {
 	*out = *in
+	if in.MinRuntime != nil {
+		in, out := &in.MinRuntime, &out.MinRuntime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueuePreemption.
@@ -318,6 +340,18 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelectorTerms != nil {
+		in, out := &in.NamespaceSelectorTerms, &out.NamespaceSelectorTerms
+		*out = make([]v1.LabelSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExcludedNamespaces != nil {
+		in, out := &in.ExcludedNamespaces, &out.ExcludedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.FlavorFungibility != nil {
 		in, out := &in.FlavorFungibility, &out.FlavorFungibility
 		*out = new(FlavorFungibility)
@@ -326,7 +360,7 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 	if in.Preemption != nil {
 		in, out := &in.Preemption, &out.Preemption
 		*out = new(ClusterQueuePreemption)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.AdmissionChecks != nil {
 		in, out := &in.AdmissionChecks, &out.AdmissionChecks
@@ -338,6 +372,11 @@ func (in *ClusterQueueSpec) DeepCopyInto(out *ClusterQueueSpec) {
 		*out = new(StopPolicy)
 		**out = **in
 	}
+	if in.QuotaTiers != nil {
+		in, out := &in.QuotaTiers, &out.QuotaTiers
+		*out = make([]QuotaTier, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterQueueSpec.
@@ -367,6 +406,13 @@ func (in *ClusterQueueStatus) DeepCopyInto(out *ClusterQueueStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FlavorsNominalQuota != nil {
+		in, out := &in.FlavorsNominalQuota, &out.FlavorsNominalQuota
+		*out = make([]FlavorNominalQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -406,6 +452,50 @@ func (in *FlavorFungibility) DeepCopy() *FlavorFungibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorInadmissibility) DeepCopyInto(out *FlavorInadmissibility) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceInadmissibility, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorInadmissibility.
+func (in *FlavorInadmissibility) DeepCopy() *FlavorInadmissibility {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorInadmissibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlavorNominalQuota) DeepCopyInto(out *FlavorNominalQuota) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceNominalQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlavorNominalQuota.
+func (in *FlavorNominalQuota) DeepCopy() *FlavorNominalQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FlavorNominalQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FlavorQuotas) DeepCopyInto(out *FlavorQuotas) {
 	*out = *in
@@ -455,7 +545,7 @@ func (in *LocalQueue) DeepCopyInto(out *LocalQueue) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -531,6 +621,54 @@ func (in *LocalQueueList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueuePendingWorkload) DeepCopyInto(out *LocalQueuePendingWorkload) {
+	*out = *in
+	if in.PositionInClusterQueue != nil {
+		in, out := &in.PositionInClusterQueue, &out.PositionInClusterQueue
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PositionInLocalQueue != nil {
+		in, out := &in.PositionInLocalQueue, &out.PositionInLocalQueue
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueuePendingWorkload.
+func (in *LocalQueuePendingWorkload) DeepCopy() *LocalQueuePendingWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueuePendingWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalQueuePendingWorkloadsStatus) DeepCopyInto(out *LocalQueuePendingWorkloadsStatus) {
+	*out = *in
+	if in.Head != nil {
+		in, out := &in.Head, &out.Head
+		*out = make([]LocalQueuePendingWorkload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastChangeTime.DeepCopyInto(&out.LastChangeTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueuePendingWorkloadsStatus.
+func (in *LocalQueuePendingWorkloadsStatus) DeepCopy() *LocalQueuePendingWorkloadsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalQueuePendingWorkloadsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueResourceUsage) DeepCopyInto(out *LocalQueueResourceUsage) {
 	*out = *in
@@ -550,6 +688,16 @@ func (in *LocalQueueResourceUsage) DeepCopy() *LocalQueueResourceUsage {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalQueueSpec) DeepCopyInto(out *LocalQueueSpec) {
 	*out = *in
+	if in.FallbackClusterQueues != nil {
+		in, out := &in.FallbackClusterQueues, &out.FallbackClusterQueues
+		*out = make([]ClusterQueueReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.FallbackAfterSeconds != nil {
+		in, out := &in.FallbackAfterSeconds, &out.FallbackAfterSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueSpec.
@@ -586,6 +734,11 @@ func (in *LocalQueueStatus) DeepCopyInto(out *LocalQueueStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PendingWorkloadsStatus != nil {
+		in, out := &in.PendingWorkloadsStatus, &out.PendingWorkloadsStatus
+		*out = new(LocalQueuePendingWorkloadsStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalQueueStatus.
@@ -653,6 +806,28 @@ func (in *PodSetAssignment) DeepCopy() *PodSetAssignment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSetInadmissibility) DeepCopyInto(out *PodSetInadmissibility) {
+	*out = *in
+	if in.Flavors != nil {
+		in, out := &in.Flavors, &out.Flavors
+		*out = make([]FlavorInadmissibility, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSetInadmissibility.
+func (in *PodSetInadmissibility) DeepCopy() *PodSetInadmissibility {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSetInadmissibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSetUpdate) DeepCopyInto(out *PodSetUpdate) {
 	*out = *in
@@ -769,6 +944,11 @@ func (in *ProvisioningRequestConfigSpec) DeepCopyInto(out *ProvisioningRequestCo
 		*out = make([]corev1.ResourceName, len(*in))
 		copy(*out, *in)
 	}
+	if in.FlavorCooldownSeconds != nil {
+		in, out := &in.FlavorCooldownSeconds, &out.FlavorCooldownSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProvisioningRequestConfigSpec.
@@ -781,6 +961,21 @@ func (in *ProvisioningRequestConfigSpec) DeepCopy() *ProvisioningRequestConfigSp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaTier) DeepCopyInto(out *QuotaTier) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaTier.
+func (in *QuotaTier) DeepCopy() *QuotaTier {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaTier)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReclaimablePod) DeepCopyInto(out *ReclaimablePod) {
 	*out = *in
@@ -796,6 +991,30 @@ func (in *ReclaimablePod) DeepCopy() *ReclaimablePod {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequeueState) DeepCopyInto(out *RequeueState) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequeueAt != nil {
+		in, out := &in.RequeueAt, &out.RequeueAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequeueState.
+func (in *RequeueState) DeepCopy() *RequeueState {
+	if in == nil {
+		return nil
+	}
+	out := new(RequeueState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceFlavor) DeepCopyInto(out *ResourceFlavor) {
 	*out = *in
@@ -822,6 +1041,22 @@ func (in *ResourceFlavor) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFlavorFungibility) DeepCopyInto(out *ResourceFlavorFungibility) {
+	*out = *in
+	out.FlavorFungibility = in.FlavorFungibility
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorFungibility.
+func (in *ResourceFlavorFungibility) DeepCopy() *ResourceFlavorFungibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFlavorFungibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceFlavorList) DeepCopyInto(out *ResourceFlavorList) {
 	*out = *in
@@ -878,6 +1113,11 @@ func (in *ResourceFlavorSpec) DeepCopyInto(out *ResourceFlavorSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StopPolicy != nil {
+		in, out := &in.StopPolicy, &out.StopPolicy
+		*out = new(StopPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorSpec.
@@ -905,6 +1145,16 @@ func (in *ResourceGroup) DeepCopyInto(out *ResourceGroup) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FlavorFungibility != nil {
+		in, out := &in.FlavorFungibility, &out.FlavorFungibility
+		*out = new(FlavorFungibility)
+		**out = **in
+	}
+	if in.ResourceFlavorFungibility != nil {
+		in, out := &in.ResourceFlavorFungibility, &out.ResourceFlavorFungibility
+		*out = make([]ResourceFlavorFungibility, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceGroup.
@@ -917,6 +1167,42 @@ func (in *ResourceGroup) DeepCopy() *ResourceGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceInadmissibility) DeepCopyInto(out *ResourceInadmissibility) {
+	*out = *in
+	if in.Missing != nil {
+		in, out := &in.Missing, &out.Missing
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceInadmissibility.
+func (in *ResourceInadmissibility) DeepCopy() *ResourceInadmissibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceInadmissibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceNominalQuota) DeepCopyInto(out *ResourceNominalQuota) {
+	*out = *in
+	out.NominalQuota = in.NominalQuota.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceNominalQuota.
+func (in *ResourceNominalQuota) DeepCopy() *ResourceNominalQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceNominalQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 	*out = *in
@@ -926,6 +1212,11 @@ func (in *ResourceQuota) DeepCopyInto(out *ResourceQuota) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.NominalQuotaPercent != nil {
+		in, out := &in.NominalQuotaPercent, &out.NominalQuotaPercent
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceQuota.
@@ -955,6 +1246,36 @@ func (in *ResourceUsage) DeepCopy() *ResourceUsage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffBaseSeconds != nil {
+		in, out := &in.BackoffBaseSeconds, &out.BackoffBaseSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffMaxSeconds != nil {
+		in, out := &in.BackoffMaxSeconds, &out.BackoffMaxSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Workload) DeepCopyInto(out *Workload) {
 	*out = *in
@@ -1091,6 +1412,11 @@ func (in *WorkloadSpec) DeepCopyInto(out *WorkloadSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSpec.
@@ -1130,6 +1456,23 @@ func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Inadmissibility != nil {
+		in, out := &in.Inadmissibility, &out.Inadmissibility
+		*out = make([]PodSetInadmissibility, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequeueState != nil {
+		in, out := &in.RequeueState, &out.RequeueState
+		*out = new(RequeueState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastAdmission != nil {
+		in, out := &in.LastAdmission, &out.LastAdmission
+		*out = new(Admission)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadStatus.
@@ -20,8 +20,10 @@ package v1beta1
 // ClusterQueuePendingWorkloadApplyConfiguration represents an declarative configuration of the ClusterQueuePendingWorkload type for use
 // with apply.
 type ClusterQueuePendingWorkloadApplyConfiguration struct {
-	Name      *string `json:"name,omitempty"`
-	Namespace *string `json:"namespace,omitempty"`
+	Name                   *string `json:"name,omitempty"`
+	Namespace              *string `json:"namespace,omitempty"`
+	PositionInClusterQueue *int32  `json:"positionInClusterQueue,omitempty"`
+	PositionInLocalQueue   *int32  `json:"positionInLocalQueue,omitempty"`
 }
 
 // ClusterQueuePendingWorkloadApplyConfiguration constructs an declarative configuration of the ClusterQueuePendingWorkload type for use with
@@ -45,3 +47,19 @@ func (b *ClusterQueuePendingWorkloadApplyConfiguration) WithNamespace(value stri
 	b.Namespace = &value
 	return b
 }
+
+// WithPositionInClusterQueue sets the PositionInClusterQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PositionInClusterQueue field is set to the value of the last call.
+func (b *ClusterQueuePendingWorkloadApplyConfiguration) WithPositionInClusterQueue(value int32) *ClusterQueuePendingWorkloadApplyConfiguration {
+	b.PositionInClusterQueue = &value
+	return b
+}
+
+// WithPositionInLocalQueue sets the PositionInLocalQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PositionInLocalQueue field is set to the value of the last call.
+func (b *ClusterQueuePendingWorkloadApplyConfiguration) WithPositionInLocalQueue(value int32) *ClusterQueuePendingWorkloadApplyConfiguration {
+	b.PositionInLocalQueue = &value
+	return b
+}
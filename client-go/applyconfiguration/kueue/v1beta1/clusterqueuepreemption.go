@@ -18,6 +18,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
@@ -26,6 +27,8 @@ import (
 type ClusterQueuePreemptionApplyConfiguration struct {
 	ReclaimWithinCohort *v1beta1.PreemptionPolicy `json:"reclaimWithinCohort,omitempty"`
 	WithinClusterQueue  *v1beta1.PreemptionPolicy `json:"withinClusterQueue,omitempty"`
+	MinRuntime          *v1.Duration              `json:"minRuntime,omitempty"`
+	GracePeriod         *v1.Duration              `json:"gracePeriod,omitempty"`
 }
 
 // ClusterQueuePreemptionApplyConfiguration constructs an declarative configuration of the ClusterQueuePreemption type for use with
@@ -49,3 +52,19 @@ func (b *ClusterQueuePreemptionApplyConfiguration) WithWithinClusterQueue(value
 	b.WithinClusterQueue = &value
 	return b
 }
+
+// WithMinRuntime sets the MinRuntime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinRuntime field is set to the value of the last call.
+func (b *ClusterQueuePreemptionApplyConfiguration) WithMinRuntime(value v1.Duration) *ClusterQueuePreemptionApplyConfiguration {
+	b.MinRuntime = &value
+	return b
+}
+
+// WithGracePeriod sets the GracePeriod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GracePeriod field is set to the value of the last call.
+func (b *ClusterQueuePreemptionApplyConfiguration) WithGracePeriod(value v1.Duration) *ClusterQueuePreemptionApplyConfiguration {
+	b.GracePeriod = &value
+	return b
+}
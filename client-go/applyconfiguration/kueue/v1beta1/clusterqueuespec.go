@@ -25,14 +25,17 @@ import (
 // ClusterQueueSpecApplyConfiguration represents an declarative configuration of the ClusterQueueSpec type for use
 // with apply.
 type ClusterQueueSpecApplyConfiguration struct {
-	ResourceGroups    []ResourceGroupApplyConfiguration         `json:"resourceGroups,omitempty"`
-	Cohort            *string                                   `json:"cohort,omitempty"`
-	QueueingStrategy  *kueuev1beta1.QueueingStrategy            `json:"queueingStrategy,omitempty"`
-	NamespaceSelector *v1.LabelSelector                         `json:"namespaceSelector,omitempty"`
-	FlavorFungibility *FlavorFungibilityApplyConfiguration      `json:"flavorFungibility,omitempty"`
-	Preemption        *ClusterQueuePreemptionApplyConfiguration `json:"preemption,omitempty"`
-	AdmissionChecks   []string                                  `json:"admissionChecks,omitempty"`
-	StopPolicy        *kueuev1beta1.StopPolicy                  `json:"stopPolicy,omitempty"`
+	ResourceGroups         []ResourceGroupApplyConfiguration         `json:"resourceGroups,omitempty"`
+	Cohort                 *string                                   `json:"cohort,omitempty"`
+	QueueingStrategy       *kueuev1beta1.QueueingStrategy            `json:"queueingStrategy,omitempty"`
+	NamespaceSelector      *v1.LabelSelector                         `json:"namespaceSelector,omitempty"`
+	NamespaceSelectorTerms []v1.LabelSelector                        `json:"namespaceSelectorTerms,omitempty"`
+	ExcludedNamespaces     []string                                  `json:"excludedNamespaces,omitempty"`
+	FlavorFungibility      *FlavorFungibilityApplyConfiguration      `json:"flavorFungibility,omitempty"`
+	Preemption             *ClusterQueuePreemptionApplyConfiguration `json:"preemption,omitempty"`
+	AdmissionChecks        []string                                  `json:"admissionChecks,omitempty"`
+	StopPolicy             *kueuev1beta1.StopPolicy                  `json:"stopPolicy,omitempty"`
+	QuotaTiers             []QuotaTierApplyConfiguration             `json:"quotaTiers,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs an declarative configuration of the ClusterQueueSpec type for use with
@@ -78,6 +81,26 @@ func (b *ClusterQueueSpecApplyConfiguration) WithNamespaceSelector(value v1.Labe
 	return b
 }
 
+// WithNamespaceSelectorTerms adds the given value to the NamespaceSelectorTerms field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NamespaceSelectorTerms field.
+func (b *ClusterQueueSpecApplyConfiguration) WithNamespaceSelectorTerms(values ...v1.LabelSelector) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		b.NamespaceSelectorTerms = append(b.NamespaceSelectorTerms, values[i])
+	}
+	return b
+}
+
+// WithExcludedNamespaces adds the given value to the ExcludedNamespaces field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExcludedNamespaces field.
+func (b *ClusterQueueSpecApplyConfiguration) WithExcludedNamespaces(values ...string) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		b.ExcludedNamespaces = append(b.ExcludedNamespaces, values[i])
+	}
+	return b
+}
+
 // WithFlavorFungibility sets the FlavorFungibility field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the FlavorFungibility field is set to the value of the last call.
@@ -111,3 +134,16 @@ func (b *ClusterQueueSpecApplyConfiguration) WithStopPolicy(value kueuev1beta1.S
 	b.StopPolicy = &value
 	return b
 }
+
+// WithQuotaTiers adds the given value to the QuotaTiers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the QuotaTiers field.
+func (b *ClusterQueueSpecApplyConfiguration) WithQuotaTiers(values ...*QuotaTierApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithQuotaTiers")
+		}
+		b.QuotaTiers = append(b.QuotaTiers, *values[i])
+	}
+	return b
+}
@@ -26,6 +26,7 @@ import (
 type ClusterQueueStatusApplyConfiguration struct {
 	FlavorsReservation     []FlavorUsageApplyConfiguration                       `json:"flavorsReservation,omitempty"`
 	FlavorsUsage           []FlavorUsageApplyConfiguration                       `json:"flavorsUsage,omitempty"`
+	FlavorsNominalQuota    []FlavorNominalQuotaApplyConfiguration                `json:"flavorsNominalQuota,omitempty"`
 	PendingWorkloads       *int32                                                `json:"pendingWorkloads,omitempty"`
 	ReservingWorkloads     *int32                                                `json:"reservingWorkloads,omitempty"`
 	AdmittedWorkloads      *int32                                                `json:"admittedWorkloads,omitempty"`
@@ -65,6 +66,19 @@ func (b *ClusterQueueStatusApplyConfiguration) WithFlavorsUsage(values ...*Flavo
 	return b
 }
 
+// WithFlavorsNominalQuota adds the given value to the FlavorsNominalQuota field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FlavorsNominalQuota field.
+func (b *ClusterQueueStatusApplyConfiguration) WithFlavorsNominalQuota(values ...*FlavorNominalQuotaApplyConfiguration) *ClusterQueueStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFlavorsNominalQuota")
+		}
+		b.FlavorsNominalQuota = append(b.FlavorsNominalQuota, *values[i])
+	}
+	return b
+}
+
 // WithPendingWorkloads sets the PendingWorkloads field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PendingWorkloads field is set to the value of the last call.
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// LocalQueuePendingWorkloadApplyConfiguration represents an declarative configuration of the LocalQueuePendingWorkload type for use
+// with apply.
+type LocalQueuePendingWorkloadApplyConfiguration struct {
+	Name                   *string `json:"name,omitempty"`
+	PositionInClusterQueue *int32  `json:"positionInClusterQueue,omitempty"`
+	PositionInLocalQueue   *int32  `json:"positionInLocalQueue,omitempty"`
+}
+
+// LocalQueuePendingWorkloadApplyConfiguration constructs an declarative configuration of the LocalQueuePendingWorkload type for use with
+// apply.
+func LocalQueuePendingWorkload() *LocalQueuePendingWorkloadApplyConfiguration {
+	return &LocalQueuePendingWorkloadApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *LocalQueuePendingWorkloadApplyConfiguration) WithName(value string) *LocalQueuePendingWorkloadApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithPositionInClusterQueue sets the PositionInClusterQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PositionInClusterQueue field is set to the value of the last call.
+func (b *LocalQueuePendingWorkloadApplyConfiguration) WithPositionInClusterQueue(value int32) *LocalQueuePendingWorkloadApplyConfiguration {
+	b.PositionInClusterQueue = &value
+	return b
+}
+
+// WithPositionInLocalQueue sets the PositionInLocalQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PositionInLocalQueue field is set to the value of the last call.
+func (b *LocalQueuePendingWorkloadApplyConfiguration) WithPositionInLocalQueue(value int32) *LocalQueuePendingWorkloadApplyConfiguration {
+	b.PositionInLocalQueue = &value
+	return b
+}
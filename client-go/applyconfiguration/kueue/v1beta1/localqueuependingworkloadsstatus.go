@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LocalQueuePendingWorkloadsStatusApplyConfiguration represents an declarative configuration of the LocalQueuePendingWorkloadsStatus type for use
+// with apply.
+type LocalQueuePendingWorkloadsStatusApplyConfiguration struct {
+	Head           []LocalQueuePendingWorkloadApplyConfiguration `json:"head,omitempty"`
+	LastChangeTime *v1.Time                                      `json:"lastChangeTime,omitempty"`
+}
+
+// LocalQueuePendingWorkloadsStatusApplyConfiguration constructs an declarative configuration of the LocalQueuePendingWorkloadsStatus type for use with
+// apply.
+func LocalQueuePendingWorkloadsStatus() *LocalQueuePendingWorkloadsStatusApplyConfiguration {
+	return &LocalQueuePendingWorkloadsStatusApplyConfiguration{}
+}
+
+// WithHead adds the given value to the Head field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Head field.
+func (b *LocalQueuePendingWorkloadsStatusApplyConfiguration) WithHead(values ...*LocalQueuePendingWorkloadApplyConfiguration) *LocalQueuePendingWorkloadsStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHead")
+		}
+		b.Head = append(b.Head, *values[i])
+	}
+	return b
+}
+
+// WithLastChangeTime sets the LastChangeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastChangeTime field is set to the value of the last call.
+func (b *LocalQueuePendingWorkloadsStatusApplyConfiguration) WithLastChangeTime(value v1.Time) *LocalQueuePendingWorkloadsStatusApplyConfiguration {
+	b.LastChangeTime = &value
+	return b
+}
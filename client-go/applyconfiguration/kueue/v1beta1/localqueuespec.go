@@ -24,7 +24,10 @@ import (
 // LocalQueueSpecApplyConfiguration represents an declarative configuration of the LocalQueueSpec type for use
 // with apply.
 type LocalQueueSpecApplyConfiguration struct {
-	ClusterQueue *v1beta1.ClusterQueueReference `json:"clusterQueue,omitempty"`
+	ClusterQueue                 *v1beta1.ClusterQueueReference  `json:"clusterQueue,omitempty"`
+	FallbackClusterQueues        []v1beta1.ClusterQueueReference `json:"fallbackClusterQueues,omitempty"`
+	FallbackAfterSeconds         *int32                          `json:"fallbackAfterSeconds,omitempty"`
+	DefaultWorkloadPriorityClass *string                         `json:"defaultWorkloadPriorityClass,omitempty"`
 }
 
 // LocalQueueSpecApplyConfiguration constructs an declarative configuration of the LocalQueueSpec type for use with
@@ -40,3 +43,29 @@ func (b *LocalQueueSpecApplyConfiguration) WithClusterQueue(value v1beta1.Cluste
 	b.ClusterQueue = &value
 	return b
 }
+
+// WithFallbackClusterQueues adds the given value to the FallbackClusterQueues field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FallbackClusterQueues field.
+func (b *LocalQueueSpecApplyConfiguration) WithFallbackClusterQueues(values ...v1beta1.ClusterQueueReference) *LocalQueueSpecApplyConfiguration {
+	for i := range values {
+		b.FallbackClusterQueues = append(b.FallbackClusterQueues, values[i])
+	}
+	return b
+}
+
+// WithFallbackAfterSeconds sets the FallbackAfterSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FallbackAfterSeconds field is set to the value of the last call.
+func (b *LocalQueueSpecApplyConfiguration) WithFallbackAfterSeconds(value int32) *LocalQueueSpecApplyConfiguration {
+	b.FallbackAfterSeconds = &value
+	return b
+}
+
+// WithDefaultWorkloadPriorityClass sets the DefaultWorkloadPriorityClass field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultWorkloadPriorityClass field is set to the value of the last call.
+func (b *LocalQueueSpecApplyConfiguration) WithDefaultWorkloadPriorityClass(value string) *LocalQueueSpecApplyConfiguration {
+	b.DefaultWorkloadPriorityClass = &value
+	return b
+}
@@ -24,12 +24,13 @@ import (
 // LocalQueueStatusApplyConfiguration represents an declarative configuration of the LocalQueueStatus type for use
 // with apply.
 type LocalQueueStatusApplyConfiguration struct {
-	PendingWorkloads   *int32                                    `json:"pendingWorkloads,omitempty"`
-	ReservingWorkloads *int32                                    `json:"reservingWorkloads,omitempty"`
-	AdmittedWorkloads  *int32                                    `json:"admittedWorkloads,omitempty"`
-	Conditions         []v1.Condition                            `json:"conditions,omitempty"`
-	FlavorsReservation []LocalQueueFlavorUsageApplyConfiguration `json:"flavorsReservation,omitempty"`
-	FlavorUsage        []LocalQueueFlavorUsageApplyConfiguration `json:"flavorUsage,omitempty"`
+	PendingWorkloads       *int32                                              `json:"pendingWorkloads,omitempty"`
+	ReservingWorkloads     *int32                                              `json:"reservingWorkloads,omitempty"`
+	AdmittedWorkloads      *int32                                              `json:"admittedWorkloads,omitempty"`
+	Conditions             []v1.Condition                                      `json:"conditions,omitempty"`
+	FlavorsReservation     []LocalQueueFlavorUsageApplyConfiguration           `json:"flavorsReservation,omitempty"`
+	FlavorUsage            []LocalQueueFlavorUsageApplyConfiguration           `json:"flavorUsage,omitempty"`
+	PendingWorkloadsStatus *LocalQueuePendingWorkloadsStatusApplyConfiguration `json:"pendingWorkloadsStatus,omitempty"`
 }
 
 // LocalQueueStatusApplyConfiguration constructs an declarative configuration of the LocalQueueStatus type for use with
@@ -97,3 +98,11 @@ func (b *LocalQueueStatusApplyConfiguration) WithFlavorUsage(values ...*LocalQue
 	}
 	return b
 }
+
+// WithPendingWorkloadsStatus sets the PendingWorkloadsStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PendingWorkloadsStatus field is set to the value of the last call.
+func (b *LocalQueueStatusApplyConfiguration) WithPendingWorkloadsStatus(value *LocalQueuePendingWorkloadsStatusApplyConfiguration) *LocalQueueStatusApplyConfiguration {
+	b.PendingWorkloadsStatus = value
+	return b
+}
@@ -28,6 +28,7 @@ type ProvisioningRequestConfigSpecApplyConfiguration struct {
 	ProvisioningClassName *string                      `json:"provisioningClassName,omitempty"`
 	Parameters            map[string]v1beta1.Parameter `json:"parameters,omitempty"`
 	ManagedResources      []v1.ResourceName            `json:"managedResources,omitempty"`
+	FlavorCooldownSeconds *int32                       `json:"flavorCooldownSeconds,omitempty"`
 }
 
 // ProvisioningRequestConfigSpecApplyConfiguration constructs an declarative configuration of the ProvisioningRequestConfigSpec type for use with
@@ -67,3 +68,11 @@ func (b *ProvisioningRequestConfigSpecApplyConfiguration) WithManagedResources(v
 	}
 	return b
 }
+
+// WithFlavorCooldownSeconds sets the FlavorCooldownSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlavorCooldownSeconds field is set to the value of the last call.
+func (b *ProvisioningRequestConfigSpecApplyConfiguration) WithFlavorCooldownSeconds(value int32) *ProvisioningRequestConfigSpecApplyConfiguration {
+	b.FlavorCooldownSeconds = &value
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// QuotaTierApplyConfiguration represents an declarative configuration of the QuotaTier type for use
+// with apply.
+type QuotaTierApplyConfiguration struct {
+	Name                 *string `json:"name,omitempty"`
+	MinPriority          *int32  `json:"minPriority,omitempty"`
+	ReservedQuotaPercent *int32  `json:"reservedQuotaPercent,omitempty"`
+}
+
+// QuotaTierApplyConfiguration constructs an declarative configuration of the QuotaTier type for use with
+// apply.
+func QuotaTier() *QuotaTierApplyConfiguration {
+	return &QuotaTierApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *QuotaTierApplyConfiguration) WithName(value string) *QuotaTierApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithMinPriority sets the MinPriority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinPriority field is set to the value of the last call.
+func (b *QuotaTierApplyConfiguration) WithMinPriority(value int32) *QuotaTierApplyConfiguration {
+	b.MinPriority = &value
+	return b
+}
+
+// WithReservedQuotaPercent sets the ReservedQuotaPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReservedQuotaPercent field is set to the value of the last call.
+func (b *QuotaTierApplyConfiguration) WithReservedQuotaPercent(value int32) *QuotaTierApplyConfiguration {
+	b.ReservedQuotaPercent = &value
+	return b
+}
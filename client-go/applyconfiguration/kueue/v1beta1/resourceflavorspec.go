@@ -19,14 +19,16 @@ package v1beta1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
 // ResourceFlavorSpecApplyConfiguration represents an declarative configuration of the ResourceFlavorSpec type for use
 // with apply.
 type ResourceFlavorSpecApplyConfiguration struct {
-	NodeLabels  map[string]string `json:"nodeLabels,omitempty"`
-	NodeTaints  []v1.Taint        `json:"nodeTaints,omitempty"`
-	Tolerations []v1.Toleration   `json:"tolerations,omitempty"`
+	NodeLabels  map[string]string   `json:"nodeLabels,omitempty"`
+	NodeTaints  []v1.Taint          `json:"nodeTaints,omitempty"`
+	Tolerations []v1.Toleration     `json:"tolerations,omitempty"`
+	StopPolicy  *v1beta1.StopPolicy `json:"stopPolicy,omitempty"`
 }
 
 // ResourceFlavorSpecApplyConfiguration constructs an declarative configuration of the ResourceFlavorSpec type for use with
@@ -68,3 +70,11 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTolerations(values ...v1.Tole
 	}
 	return b
 }
+
+// WithStopPolicy sets the StopPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StopPolicy field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithStopPolicy(value v1beta1.StopPolicy) *ResourceFlavorSpecApplyConfiguration {
+	b.StopPolicy = &value
+	return b
+}
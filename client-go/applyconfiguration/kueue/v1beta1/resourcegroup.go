@@ -24,8 +24,10 @@ import (
 // ResourceGroupApplyConfiguration represents an declarative configuration of the ResourceGroup type for use
 // with apply.
 type ResourceGroupApplyConfiguration struct {
-	CoveredResources []v1.ResourceName                `json:"coveredResources,omitempty"`
-	Flavors          []FlavorQuotasApplyConfiguration `json:"flavors,omitempty"`
+	CoveredResources          []v1.ResourceName                             `json:"coveredResources,omitempty"`
+	Flavors                   []FlavorQuotasApplyConfiguration              `json:"flavors,omitempty"`
+	FlavorFungibility         *FlavorFungibilityApplyConfiguration          `json:"flavorFungibility,omitempty"`
+	ResourceFlavorFungibility []ResourceFlavorFungibilityApplyConfiguration `json:"resourceFlavorFungibility,omitempty"`
 }
 
 // ResourceGroupApplyConfiguration constructs an declarative configuration of the ResourceGroup type for use with
@@ -56,3 +58,24 @@ func (b *ResourceGroupApplyConfiguration) WithFlavors(values ...*FlavorQuotasApp
 	}
 	return b
 }
+
+// WithFlavorFungibility sets the FlavorFungibility field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlavorFungibility field is set to the value of the last call.
+func (b *ResourceGroupApplyConfiguration) WithFlavorFungibility(value *FlavorFungibilityApplyConfiguration) *ResourceGroupApplyConfiguration {
+	b.FlavorFungibility = value
+	return b
+}
+
+// WithResourceFlavorFungibility adds the given value to the ResourceFlavorFungibility field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceFlavorFungibility field.
+func (b *ResourceGroupApplyConfiguration) WithResourceFlavorFungibility(values ...*ResourceFlavorFungibilityApplyConfiguration) *ResourceGroupApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceFlavorFungibility")
+		}
+		b.ResourceFlavorFungibility = append(b.ResourceFlavorFungibility, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResourceInadmissibilityApplyConfiguration represents an declarative configuration of the ResourceInadmissibility type for use
+// with apply.
+type ResourceInadmissibilityApplyConfiguration struct {
+	Name                 *v1.ResourceName   `json:"name,omitempty"`
+	Reason               *string            `json:"reason,omitempty"`
+	Missing              *resource.Quantity `json:"missing,omitempty"`
+	BorrowingConsidered  *bool              `json:"borrowingConsidered,omitempty"`
+	PreemptionConsidered *bool              `json:"preemptionConsidered,omitempty"`
+}
+
+// ResourceInadmissibilityApplyConfiguration constructs an declarative configuration of the ResourceInadmissibility type for use with
+// apply.
+func ResourceInadmissibility() *ResourceInadmissibilityApplyConfiguration {
+	return &ResourceInadmissibilityApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ResourceInadmissibilityApplyConfiguration) WithName(value v1.ResourceName) *ResourceInadmissibilityApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *ResourceInadmissibilityApplyConfiguration) WithReason(value string) *ResourceInadmissibilityApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithMissing sets the Missing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Missing field is set to the value of the last call.
+func (b *ResourceInadmissibilityApplyConfiguration) WithMissing(value resource.Quantity) *ResourceInadmissibilityApplyConfiguration {
+	b.Missing = &value
+	return b
+}
+
+// WithBorrowingConsidered sets the BorrowingConsidered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BorrowingConsidered field is set to the value of the last call.
+func (b *ResourceInadmissibilityApplyConfiguration) WithBorrowingConsidered(value bool) *ResourceInadmissibilityApplyConfiguration {
+	b.BorrowingConsidered = &value
+	return b
+}
+
+// WithPreemptionConsidered sets the PreemptionConsidered field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreemptionConsidered field is set to the value of the last call.
+func (b *ResourceInadmissibilityApplyConfiguration) WithPreemptionConsidered(value bool) *ResourceInadmissibilityApplyConfiguration {
+	b.PreemptionConsidered = &value
+	return b
+}
@@ -25,9 +25,10 @@ import (
 // ResourceQuotaApplyConfiguration represents an declarative configuration of the ResourceQuota type for use
 // with apply.
 type ResourceQuotaApplyConfiguration struct {
-	Name           *v1.ResourceName   `json:"name,omitempty"`
-	NominalQuota   *resource.Quantity `json:"nominalQuota,omitempty"`
-	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
+	Name                *v1.ResourceName   `json:"name,omitempty"`
+	NominalQuota        *resource.Quantity `json:"nominalQuota,omitempty"`
+	BorrowingLimit      *resource.Quantity `json:"borrowingLimit,omitempty"`
+	NominalQuotaPercent *int32             `json:"nominalQuotaPercent,omitempty"`
 }
 
 // ResourceQuotaApplyConfiguration constructs an declarative configuration of the ResourceQuota type for use with
@@ -59,3 +60,11 @@ func (b *ResourceQuotaApplyConfiguration) WithBorrowingLimit(value resource.Quan
 	b.BorrowingLimit = &value
 	return b
 }
+
+// WithNominalQuotaPercent sets the NominalQuotaPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NominalQuotaPercent field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithNominalQuotaPercent(value int32) *ResourceQuotaApplyConfiguration {
+	b.NominalQuotaPercent = &value
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// RetryPolicyApplyConfiguration represents an declarative configuration of the RetryPolicy type for use
+// with apply.
+type RetryPolicyApplyConfiguration struct {
+	MaxRetries         *int32 `json:"maxRetries,omitempty"`
+	BackoffBaseSeconds *int32 `json:"backoffBaseSeconds,omitempty"`
+	BackoffMaxSeconds  *int32 `json:"backoffMaxSeconds,omitempty"`
+}
+
+// RetryPolicyApplyConfiguration constructs an declarative configuration of the RetryPolicy type for use with
+// apply.
+func RetryPolicy() *RetryPolicyApplyConfiguration {
+	return &RetryPolicyApplyConfiguration{}
+}
+
+// WithMaxRetries sets the MaxRetries field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxRetries field is set to the value of the last call.
+func (b *RetryPolicyApplyConfiguration) WithMaxRetries(value int32) *RetryPolicyApplyConfiguration {
+	b.MaxRetries = &value
+	return b
+}
+
+// WithBackoffBaseSeconds sets the BackoffBaseSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackoffBaseSeconds field is set to the value of the last call.
+func (b *RetryPolicyApplyConfiguration) WithBackoffBaseSeconds(value int32) *RetryPolicyApplyConfiguration {
+	b.BackoffBaseSeconds = &value
+	return b
+}
+
+// WithBackoffMaxSeconds sets the BackoffMaxSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackoffMaxSeconds field is set to the value of the last call.
+func (b *RetryPolicyApplyConfiguration) WithBackoffMaxSeconds(value int32) *RetryPolicyApplyConfiguration {
+	b.BackoffMaxSeconds = &value
+	return b
+}
@@ -21,6 +21,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 )
 
 // WorkloadPriorityClassApplyConfiguration represents an declarative configuration of the WorkloadPriorityClass type for use
@@ -28,8 +29,10 @@ import (
 type WorkloadPriorityClassApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Value                            *int32  `json:"value,omitempty"`
-	Description                      *string `json:"description,omitempty"`
+	Value                            *int32                    `json:"value,omitempty"`
+	Description                      *string                   `json:"description,omitempty"`
+	PreemptionPolicy                 *v1beta1.PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+	NonPreemptible                   *bool                     `json:"nonPreemptible,omitempty"`
 }
 
 // WorkloadPriorityClass constructs an declarative configuration of the WorkloadPriorityClass type for use with
@@ -215,3 +218,19 @@ func (b *WorkloadPriorityClassApplyConfiguration) WithDescription(value string)
 	b.Description = &value
 	return b
 }
+
+// WithPreemptionPolicy sets the PreemptionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreemptionPolicy field is set to the value of the last call.
+func (b *WorkloadPriorityClassApplyConfiguration) WithPreemptionPolicy(value v1beta1.PreemptionPolicy) *WorkloadPriorityClassApplyConfiguration {
+	b.PreemptionPolicy = &value
+	return b
+}
+
+// WithNonPreemptible sets the NonPreemptible field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NonPreemptible field is set to the value of the last call.
+func (b *WorkloadPriorityClassApplyConfiguration) WithNonPreemptible(value bool) *WorkloadPriorityClassApplyConfiguration {
+	b.NonPreemptible = &value
+	return b
+}
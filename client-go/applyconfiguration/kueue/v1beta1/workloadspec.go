@@ -20,12 +20,13 @@ package v1beta1
 // WorkloadSpecApplyConfiguration represents an declarative configuration of the WorkloadSpec type for use
 // with apply.
 type WorkloadSpecApplyConfiguration struct {
-	PodSets             []PodSetApplyConfiguration `json:"podSets,omitempty"`
-	QueueName           *string                    `json:"queueName,omitempty"`
-	PriorityClassName   *string                    `json:"priorityClassName,omitempty"`
-	Priority            *int32                     `json:"priority,omitempty"`
-	PriorityClassSource *string                    `json:"priorityClassSource,omitempty"`
-	Active              *bool                      `json:"active,omitempty"`
+	PodSets             []PodSetApplyConfiguration     `json:"podSets,omitempty"`
+	QueueName           *string                        `json:"queueName,omitempty"`
+	PriorityClassName   *string                        `json:"priorityClassName,omitempty"`
+	Priority            *int32                         `json:"priority,omitempty"`
+	PriorityClassSource *string                        `json:"priorityClassSource,omitempty"`
+	Active              *bool                          `json:"active,omitempty"`
+	RetryPolicy         *RetryPolicyApplyConfiguration `json:"retryPolicy,omitempty"`
 }
 
 // WorkloadSpecApplyConfiguration constructs an declarative configuration of the WorkloadSpec type for use with
@@ -86,3 +87,11 @@ func (b *WorkloadSpecApplyConfiguration) WithActive(value bool) *WorkloadSpecApp
 	b.Active = &value
 	return b
 }
+
+// WithRetryPolicy sets the RetryPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryPolicy field is set to the value of the last call.
+func (b *WorkloadSpecApplyConfiguration) WithRetryPolicy(value *RetryPolicyApplyConfiguration) *WorkloadSpecApplyConfiguration {
+	b.RetryPolicy = value
+	return b
+}
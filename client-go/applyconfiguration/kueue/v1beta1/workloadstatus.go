@@ -24,10 +24,13 @@ import (
 // WorkloadStatusApplyConfiguration represents an declarative configuration of the WorkloadStatus type for use
 // with apply.
 type WorkloadStatusApplyConfiguration struct {
-	Admission       *AdmissionApplyConfiguration            `json:"admission,omitempty"`
-	Conditions      []v1.Condition                          `json:"conditions,omitempty"`
-	ReclaimablePods []ReclaimablePodApplyConfiguration      `json:"reclaimablePods,omitempty"`
-	AdmissionChecks []AdmissionCheckStateApplyConfiguration `json:"admissionChecks,omitempty"`
+	Admission       *AdmissionApplyConfiguration              `json:"admission,omitempty"`
+	Conditions      []v1.Condition                            `json:"conditions,omitempty"`
+	ReclaimablePods []ReclaimablePodApplyConfiguration        `json:"reclaimablePods,omitempty"`
+	AdmissionChecks []AdmissionCheckStateApplyConfiguration   `json:"admissionChecks,omitempty"`
+	Inadmissibility []PodSetInadmissibilityApplyConfiguration `json:"inadmissibility,omitempty"`
+	RequeueState    *RequeueStateApplyConfiguration           `json:"requeueState,omitempty"`
+	LastAdmission   *AdmissionApplyConfiguration              `json:"lastAdmission,omitempty"`
 }
 
 // WorkloadStatusApplyConfiguration constructs an declarative configuration of the WorkloadStatus type for use with
@@ -79,3 +82,32 @@ func (b *WorkloadStatusApplyConfiguration) WithAdmissionChecks(values ...*Admiss
 	}
 	return b
 }
+
+// WithInadmissibility adds the given value to the Inadmissibility field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Inadmissibility field.
+func (b *WorkloadStatusApplyConfiguration) WithInadmissibility(values ...*PodSetInadmissibilityApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithInadmissibility")
+		}
+		b.Inadmissibility = append(b.Inadmissibility, *values[i])
+	}
+	return b
+}
+
+// WithRequeueState sets the RequeueState field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequeueState field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithRequeueState(value *RequeueStateApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.RequeueState = value
+	return b
+}
+
+// WithLastAdmission sets the LastAdmission field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastAdmission field is set to the value of the last call.
+func (b *WorkloadStatusApplyConfiguration) WithLastAdmission(value *AdmissionApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	b.LastAdmission = value
+	return b
+}
@@ -56,6 +56,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ClusterQueueStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorFungibility"):
 		return &kueuev1beta1.FlavorFungibilityApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FlavorInadmissibility"):
+		return &kueuev1beta1.FlavorInadmissibilityApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("FlavorNominalQuota"):
+		return &kueuev1beta1.FlavorNominalQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorQuotas"):
 		return &kueuev1beta1.FlavorQuotasApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorUsage"):
@@ -64,6 +68,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.LocalQueueApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueFlavorUsage"):
 		return &kueuev1beta1.LocalQueueFlavorUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueuePendingWorkload"):
+		return &kueuev1beta1.LocalQueuePendingWorkloadApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("LocalQueuePendingWorkloadsStatus"):
+		return &kueuev1beta1.LocalQueuePendingWorkloadsStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueResourceUsage"):
 		return &kueuev1beta1.LocalQueueResourceUsageApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LocalQueueSpec"):
@@ -74,24 +82,38 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.PodSetApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetAssignment"):
 		return &kueuev1beta1.PodSetAssignmentApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodSetInadmissibility"):
+		return &kueuev1beta1.PodSetInadmissibilityApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetUpdate"):
 		return &kueuev1beta1.PodSetUpdateApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfig"):
 		return &kueuev1beta1.ProvisioningRequestConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfigSpec"):
 		return &kueuev1beta1.ProvisioningRequestConfigSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("QuotaTier"):
+		return &kueuev1beta1.QuotaTierApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ReclaimablePod"):
 		return &kueuev1beta1.ReclaimablePodApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RequeueState"):
+		return &kueuev1beta1.RequeueStateApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavor"):
 		return &kueuev1beta1.ResourceFlavorApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavorFungibility"):
+		return &kueuev1beta1.ResourceFlavorFungibilityApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavorSpec"):
 		return &kueuev1beta1.ResourceFlavorSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceGroup"):
 		return &kueuev1beta1.ResourceGroupApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceInadmissibility"):
+		return &kueuev1beta1.ResourceInadmissibilityApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceNominalQuota"):
+		return &kueuev1beta1.ResourceNominalQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceQuota"):
 		return &kueuev1beta1.ResourceQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceUsage"):
 		return &kueuev1beta1.ResourceUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RetryPolicy"):
+		return &kueuev1beta1.RetryPolicyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Workload"):
 		return &kueuev1beta1.WorkloadApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadPriorityClass"):
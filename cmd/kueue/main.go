@@ -21,7 +21,10 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"slices"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -32,6 +35,7 @@ import (
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
@@ -39,13 +43,16 @@ import (
 	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/audit"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/config"
 	"sigs.k8s.io/kueue/pkg/constants"
@@ -54,16 +61,19 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/controller/jobs/noop"
+	"sigs.k8s.io/kueue/pkg/debugger"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/scheduler"
 	"sigs.k8s.io/kueue/pkg/util/cert"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
+	"sigs.k8s.io/kueue/pkg/util/ratelimiter"
 	"sigs.k8s.io/kueue/pkg/util/useragent"
 	"sigs.k8s.io/kueue/pkg/version"
 	"sigs.k8s.io/kueue/pkg/visibility"
 	"sigs.k8s.io/kueue/pkg/webhooks"
+	"sigs.k8s.io/kueue/pkg/workload"
 
 	// Ensure linking of the job controllers.
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
@@ -126,6 +136,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	var reloader *config.Reloader
+	if features.Enabled(features.ConfigHotReload) && configFile != "" {
+		reloader = config.NewReloader(scheme, configFile, cfg)
+	}
+
 	metrics.Register()
 
 	kubeConfig := ctrl.GetConfigOrDie()
@@ -135,11 +150,39 @@ func main() {
 	kubeConfig.QPS = *cfg.ClientConnection.QPS
 	kubeConfig.Burst = int(*cfg.ClientConnection.Burst)
 	setupLog.V(2).Info("K8S Client", "qps", kubeConfig.QPS, "burst", kubeConfig.Burst)
+	if reloader != nil {
+		limiter := ratelimiter.NewAdjustable(kubeConfig.QPS, kubeConfig.Burst)
+		kubeConfig.RateLimiter = limiter
+		reloader.AddHandler(func(cfg *configapi.Configuration) {
+			limiter.SetLimits(*cfg.ClientConnection.QPS, int(*cfg.ClientConnection.Burst))
+		})
+	}
+	snapshotHandler := &debugger.SnapshotHandler{}
+	if cfg.Metrics.EnableSnapshotEndpoint {
+		// The snapshot is authorized on its own, since the metrics server
+		// might be reachable without going through the kube-rbac-proxy.
+		handler, err := authorizedHandler(kubeConfig, snapshotHandler)
+		if err != nil {
+			setupLog.Error(err, "Unable to set up the authorization of the snapshot endpoint")
+			os.Exit(1)
+		}
+		if options.Metrics.ExtraHandlers == nil {
+			options.Metrics.ExtraHandlers = make(map[string]http.Handler, 1)
+		}
+		options.Metrics.ExtraHandlers[debugger.SnapshotPath] = handler
+	}
+
 	mgr, err := ctrl.NewManager(kubeConfig, options)
 	if err != nil {
 		setupLog.Error(err, "Unable to start manager")
 		os.Exit(1)
 	}
+	if reloader != nil {
+		if err := mgr.Add(reloader); err != nil {
+			setupLog.Error(err, "Unable to add the configuration reloader to manager")
+			os.Exit(1)
+		}
+	}
 
 	certsReady := make(chan struct{})
 
@@ -153,7 +196,19 @@ func main() {
 	}
 
 	cCache := cache.New(mgr.GetClient(), cache.WithPodsReadyTracking(blockForPodsReady(&cfg)))
-	queues := queue.NewManager(mgr.GetClient(), cCache)
+	reloader.AddHandler(func(cfg *configapi.Configuration) {
+		cCache.SetPodsReadyTracking(blockForPodsReady(cfg))
+	})
+	initContainers, ownersInitContainers, err := initContainersAccounting(&cfg)
+	if err != nil {
+		setupLog.Error(err, "Unable to resolve the init containers accounting")
+		os.Exit(1)
+	}
+	queues := queue.NewManager(mgr.GetClient(), cCache, queue.WithAccountingPolicy(accountingPolicy(&cfg)),
+		queue.WithResourceTransformations(resourceTransformations(&cfg)),
+		queue.WithInitContainersAccounting(initContainers, ownersInitContainers))
+	snapshotHandler.Cache = cCache
+	snapshotHandler.Queues = queues
 
 	ctx := ctrl.SetupSignalHandler()
 	if err := setupIndexes(ctx, mgr, &cfg); err != nil {
@@ -167,7 +222,7 @@ func main() {
 	// Cert won't be ready until manager starts, so start a goroutine here which
 	// will block until the cert is ready before setting up the controllers.
 	// Controllers who register after manager starts will start directly.
-	go setupControllers(mgr, cCache, queues, certsReady, &cfg, serverVersionFetcher)
+	go setupControllers(mgr, cCache, queues, certsReady, &cfg, serverVersionFetcher, reloader)
 
 	go func() {
 		queues.CleanUpOnContext(ctx)
@@ -180,7 +235,7 @@ func main() {
 		go visibility.CreateAndStartVisibilityServer(queues, ctx)
 	}
 
-	setupScheduler(mgr, cCache, queues)
+	setupScheduler(mgr, cCache, queues, &cfg)
 
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -216,14 +271,14 @@ func setupIndexes(ctx context.Context, mgr ctrl.Manager, cfg *configapi.Configur
 	return err
 }
 
-func setupControllers(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, certsReady chan struct{}, cfg *configapi.Configuration, serverVersionFetcher *kubeversion.ServerVersionFetcher) {
+func setupControllers(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, certsReady chan struct{}, cfg *configapi.Configuration, serverVersionFetcher *kubeversion.ServerVersionFetcher, reloader *config.Reloader) {
 	// The controllers won't work until the webhooks are operating, and the webhook won't work until the
 	// certs are all in place.
 	setupLog.Info("Waiting for certificate generation to complete")
 	<-certsReady
 	setupLog.Info("Certs ready")
 
-	if failedCtrl, err := core.SetupControllers(mgr, queues, cCache, cfg); err != nil {
+	if failedCtrl, err := core.SetupControllers(mgr, queues, cCache, cfg, core.WithConfigReloader(reloader)); err != nil {
 		setupLog.Error(err, "Unable to create controller", "controller", failedCtrl)
 		os.Exit(1)
 	}
@@ -250,10 +305,16 @@ func setupControllers(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manag
 		os.Exit(1)
 	}
 
+	dynamicOpts := jobframework.NewDynamicOptions(dynamicJobOptions(cfg)...)
+	reloader.AddHandler(func(cfg *configapi.Configuration) {
+		dynamicOpts.Set(dynamicJobOptions(cfg)...)
+	})
 	opts := []jobframework.Option{
 		jobframework.WithManageJobsWithoutQueueName(manageJobsWithoutQueueName),
-		jobframework.WithWaitForPodsReady(waitForPodsReady(cfg)),
+		jobframework.WithDynamicOptions(dynamicOpts),
 		jobframework.WithKubeServerVersion(serverVersionFetcher),
+		jobframework.WithAggregateGroupEvents(aggregateGroupEvents(cfg)),
+		jobframework.WithKeyPrefixesToCopy(cfg.Integrations.LabelKeyPrefixesToCopy, cfg.Integrations.AnnotationKeyPrefixesToCopy),
 	}
 	err := jobframework.ForEachIntegration(func(name string, cb jobframework.IntegrationCallbacks) error {
 		log := setupLog.WithValues("jobFrameworkName", name)
@@ -268,10 +329,14 @@ func setupControllers(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manag
 				}
 				log.Info("No matching API in the server for job framework, skipped setup of controller and webhook")
 			} else {
+				reconcilerOpts := opts
+				if name == "pod" {
+					reconcilerOpts = append(slices.Clip(opts), jobframework.WithReclaimablePodsUpdateWindow(reclaimablePodsUpdateWindow(cfg)))
+				}
 				if err = cb.NewReconciler(
 					mgr.GetClient(),
 					mgr.GetEventRecorderFor(fmt.Sprintf("%s-%s-controller", name, constants.KueueName)),
-					opts...,
+					reconcilerOpts...,
 				).SetupWithManager(mgr); err != nil {
 					log.Error(err, "Unable to create controller")
 					return err
@@ -284,12 +349,6 @@ func setupControllers(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manag
 							"kubernetesVersion", v)
 						os.Exit(1)
 					}
-
-					opts = append(
-						opts,
-						jobframework.WithPodNamespaceSelector(cfg.Integrations.PodOptions.NamespaceSelector),
-						jobframework.WithPodSelector(cfg.Integrations.PodOptions.PodSelector),
-					)
 				}
 				if err = cb.SetupWebhook(mgr, opts...); err != nil {
 					log.Error(err, "Unable to create webhook")
@@ -325,12 +384,42 @@ func setupProbeEndpoints(mgr ctrl.Manager) {
 	}
 }
 
-func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager) {
+// authorizedHandler wraps the handler so that the requests are authenticated
+// with a TokenReview and authorized with a SubjectAccessReview for the
+// non-resource URL of the request.
+func authorizedHandler(kubeConfig *rest.Config, handler http.Handler) (http.Handler, error) {
+	httpClient, err := rest.HTTPClientFor(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := filters.WithAuthenticationAndAuthorization(kubeConfig, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return filter(ctrl.Log.WithName("debugger"), handler)
+}
+
+func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration) {
+	var opts []scheduler.Option
+	if cfg.AuditLog != nil && cfg.AuditLog.Enable {
+		backend, err := audit.NewFileBackend(cfg.AuditLog.Path, int64(*cfg.AuditLog.MaxSizeMB)*1024*1024, int(*cfg.AuditLog.MaxBackups))
+		if err != nil {
+			setupLog.Error(err, "Unable to open the audit log")
+			os.Exit(1)
+		}
+		asyncBackend := audit.NewAsyncBackend(backend, audit.DefaultBufferSize)
+		if err := mgr.Add(asyncBackend); err != nil {
+			setupLog.Error(err, "Unable to add the audit log to manager")
+			os.Exit(1)
+		}
+		opts = append(opts, scheduler.WithAuditBackend(asyncBackend))
+	}
 	sched := scheduler.New(
 		queues,
 		cCache,
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(constants.AdmissionName),
+		opts...,
 	)
 	if err := mgr.Add(sched); err != nil {
 		setupLog.Error(err, "Unable to add scheduler to manager")
@@ -360,14 +449,90 @@ func setupServerVersionFetcher(mgr ctrl.Manager, kubeConfig *rest.Config) *kubev
 	return serverVersionFetcher
 }
 
+// dynamicJobOptions returns the options of the integrations that are set again
+// when the configuration is reloaded.
+func dynamicJobOptions(cfg *configapi.Configuration) []jobframework.Option {
+	opts := []jobframework.Option{jobframework.WithWaitForPodsReady(waitForPodsReady(cfg))}
+	if cfg.Integrations != nil && cfg.Integrations.PodOptions != nil {
+		opts = append(opts,
+			jobframework.WithPodNamespaceSelector(cfg.Integrations.PodOptions.NamespaceSelector),
+			jobframework.WithPodSelector(cfg.Integrations.PodOptions.PodSelector),
+		)
+	}
+	return opts
+}
+
 func blockForPodsReady(cfg *configapi.Configuration) bool {
 	return waitForPodsReady(cfg) && cfg.WaitForPodsReady.BlockAdmission != nil && *cfg.WaitForPodsReady.BlockAdmission
 }
 
+func accountingPolicy(cfg *configapi.Configuration) workload.AccountingPolicy {
+	if cfg.Resources == nil || len(cfg.Resources.Accounting) == 0 {
+		return nil
+	}
+	policy := make(workload.AccountingPolicy, len(cfg.Resources.Accounting))
+	for _, ra := range cfg.Resources.Accounting {
+		policy[ra.Name] = workload.ResourceAccounting(ra.Policy)
+	}
+	return policy
+}
+
+func resourceTransformations(cfg *configapi.Configuration) workload.ResourceTransformations {
+	if cfg.Resources == nil || len(cfg.Resources.Transformations) == 0 {
+		return nil
+	}
+	transformations := make(workload.ResourceTransformations, len(cfg.Resources.Transformations))
+	for _, rt := range cfg.Resources.Transformations {
+		transformations[rt.Input] = workload.ResourceTransformation{
+			Outputs:     rt.Outputs,
+			RetainInput: ptr.Deref(rt.Strategy, configapi.RetainResourceTransformation) == configapi.RetainResourceTransformation,
+		}
+	}
+	return transformations
+}
+
+// initContainersAccounting returns the default accounting of the init
+// containers and the accounting for the kinds of the integrations that
+// override it.
+func initContainersAccounting(cfg *configapi.Configuration) (workload.InitContainersAccounting, map[schema.GroupKind]workload.InitContainersAccounting, error) {
+	if cfg.Resources == nil || cfg.Resources.InitContainers == nil {
+		return workload.InitContainersMax, nil, nil
+	}
+	ic := cfg.Resources.InitContainers
+	defaultAccounting := workload.InitContainersAccounting(ptr.Deref(ic.Policy, configapi.InitContainersAccountingMax))
+	if len(ic.Frameworks) == 0 {
+		return defaultAccounting, nil, nil
+	}
+	byOwner := make(map[schema.GroupKind]workload.InitContainersAccounting, len(ic.Frameworks))
+	for _, fw := range ic.Frameworks {
+		cb, found := jobframework.GetIntegration(fw.Name)
+		if !found {
+			return "", nil, fmt.Errorf("integration %q is not registered", fw.Name)
+		}
+		gvk, err := apiutil.GVKForObject(cb.JobType, scheme)
+		if err != nil {
+			return "", nil, fmt.Errorf("resolving the kind of integration %q: %w", fw.Name, err)
+		}
+		byOwner[gvk.GroupKind()] = workload.InitContainersAccounting(fw.Policy)
+	}
+	return defaultAccounting, byOwner, nil
+}
+
 func waitForPodsReady(cfg *configapi.Configuration) bool {
 	return cfg.WaitForPodsReady != nil && cfg.WaitForPodsReady.Enable
 }
 
+func aggregateGroupEvents(cfg *configapi.Configuration) bool {
+	return cfg.Integrations.PodOptions == nil || cfg.Integrations.PodOptions.GroupEvents != configapi.PodGroupEventsPerPod
+}
+
+func reclaimablePodsUpdateWindow(cfg *configapi.Configuration) time.Duration {
+	if cfg.Integrations.PodOptions == nil || cfg.Integrations.PodOptions.ReclaimablePodsUpdateWindow == nil {
+		return 0
+	}
+	return cfg.Integrations.PodOptions.ReclaimablePodsUpdateWindow.Duration
+}
+
 func apply(configFile string) (ctrl.Options, configapi.Configuration, error) {
 	options, cfg, err := config.Load(scheme, configFile)
 	if err != nil {
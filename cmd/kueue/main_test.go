@@ -109,6 +109,7 @@ integrations:
 							},
 						},
 						PodSelector: &metav1.LabelSelector{},
+						GroupEvents: config.PodGroupEventsAggregated,
 					},
 				},
 				QueueVisibility: &config.QueueVisibility{
@@ -116,6 +117,9 @@ integrations:
 					ClusterQueues: &config.ClusterQueueVisibility{
 						MaxCount: config.DefaultClusterQueuesMaxCount,
 					},
+					LocalQueues: &config.LocalQueueVisibility{
+						MaxCount: config.DefaultLocalQueuesMaxCount,
+					},
 				},
 			},
 		},
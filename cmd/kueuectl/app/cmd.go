@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kueue.AddToScheme(scheme))
+}
+
+// clientGetter builds the client and resolves the namespace from the
+// kubeconfig flags.
+type clientGetter struct {
+	kubeconfig string
+	context    string
+	namespace  string
+}
+
+func (g *clientGetter) config() clientcmd.ClientConfig {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = g.kubeconfig
+	overrides := &clientcmd.ConfigOverrides{
+		CurrentContext: g.context,
+	}
+	overrides.Context.Namespace = g.namespace
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides)
+}
+
+// Client returns a client for the cluster selected by the flags.
+func (g *clientGetter) Client() (client.Client, error) {
+	restConfig, err := g.config().ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
+// Namespace returns the namespace selected by the flags or the kubeconfig.
+func (g *clientGetter) Namespace() (string, error) {
+	ns, _, err := g.config().Namespace()
+	return ns, err
+}
+
+// NewKueuectlCmd returns the root command of kueuectl.
+func NewKueuectlCmd(out, errOut io.Writer) *cobra.Command {
+	getter := &clientGetter{}
+	cmd := &cobra.Command{
+		Use:          "kueuectl",
+		Short:        "Controls Kueue workloads and queues",
+		SilenceUsage: true,
+	}
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	cmd.PersistentFlags().StringVar(&getter.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use.")
+	cmd.PersistentFlags().StringVar(&getter.context, "context", "", "The name of the kubeconfig context to use.")
+	cmd.PersistentFlags().StringVarP(&getter.namespace, "namespace", "n", "", "The namespace of the objects.")
+
+	cmd.AddCommand(NewMigrateCmd(getter, out))
+	cmd.AddCommand(NewExplainCmd(getter, out))
+	cmd.AddCommand(NewReactivateCmd(getter, out))
+	return cmd
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const explainWorkloadLong = `Explain why a pending workload is not admitted.
+
+The command reads the ResourceFlavors, AdmissionChecks, ClusterQueues,
+LocalQueues and Workloads from the cluster and runs the flavor assignment of the
+scheduler against them, without changing anything. It prints the flavors tried
+for every pod set with the quota missing in each of them, the workloads that
+would need to be preempted, and the pending workloads ahead in the ClusterQueue.
+
+The evaluation is done against the current usage; the result might differ from
+the next scheduling attempt if the usage changes in the meantime.`
+
+// ExplainOptions holds the parameters of the explain workload command.
+type ExplainOptions struct {
+	Namespace string
+	Name      string
+
+	Client client.Client
+	Out    io.Writer
+}
+
+// NewExplainCmd returns the command explaining the admission of objects.
+func NewExplainCmd(getter *clientGetter, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain the admission of Kueue objects",
+	}
+	cmd.AddCommand(newExplainWorkloadCmd(getter, out))
+	cmd.AddCommand(newExplainPreemptionCmd(getter, out))
+	return cmd
+}
+
+func newExplainWorkloadCmd(getter *clientGetter, out io.Writer) *cobra.Command {
+	o := &ExplainOptions{Out: out}
+	return &cobra.Command{
+		Use:   "workload NAME",
+		Short: "Explain why a pending workload is not admitted",
+		Long:  explainWorkloadLong,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			o.Name = args[0]
+			if o.Namespace, err = getter.Namespace(); err != nil {
+				return err
+			}
+			if o.Client, err = getter.Client(); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+}
+
+// clusterObjects lists the LocalQueues and Workloads read from the cluster,
+// filtering them by the fields the cache matches, since the field indexes
+// are only available in the controller manager.
+type clusterObjects struct {
+	localQueues []kueue.LocalQueue
+	workloads   []kueue.Workload
+}
+
+var _ cache.Lister = (*clusterObjects)(nil)
+
+func (o *clusterObjects) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	lo := (&client.ListOptions{}).ApplyOptions(opts)
+	switch l := list.(type) {
+	case *kueue.LocalQueueList:
+		l.Items = nil
+		for i := range o.localQueues {
+			if ok, err := matchesFields(lo, indexer.QueueClusterQueueKey, indexer.IndexQueueClusterQueue(&o.localQueues[i])); err != nil {
+				return err
+			} else if ok {
+				l.Items = append(l.Items, o.localQueues[i])
+			}
+		}
+	case *kueue.WorkloadList:
+		l.Items = nil
+		for i := range o.workloads {
+			if ok, err := matchesFields(lo, indexer.WorkloadClusterQueueKey, indexer.IndexWorkloadClusterQueue(&o.workloads[i])); err != nil {
+				return err
+			} else if ok {
+				l.Items = append(l.Items, o.workloads[i])
+			}
+		}
+	default:
+		return fmt.Errorf("listing %T is not supported", list)
+	}
+	return nil
+}
+
+// matchesFields returns whether the indexed values of the field match the
+// field selector of the list options.
+func matchesFields(lo *client.ListOptions, field string, values []string) (bool, error) {
+	if lo.FieldSelector == nil || lo.FieldSelector.Empty() {
+		return true, nil
+	}
+	want, found := lo.FieldSelector.RequiresExactMatch(field)
+	if !found {
+		return false, fmt.Errorf("field selector %q is not supported", lo.FieldSelector)
+	}
+	return slices.Contains(values, want), nil
+}
+
+// Run evaluates the admission of the workload and prints the result.
+func (o *ExplainOptions) Run(ctx context.Context) error {
+	wi, snap, cqObj, err := o.load(ctx)
+	if err != nil || wi == nil {
+		return err
+	}
+	cq := snap.ClusterQueues[wi.ClusterQueue]
+	wl := wi.Obj
+	assignment, targets := evaluate(wi, snap)
+	switch assignment.RepresentativeMode() {
+	case flavorassigner.Fit:
+		fmt.Fprintln(o.Out, "Result: the workload fits and can be admitted in the next scheduling attempt")
+	case flavorassigner.Preempt:
+		if len(targets) > 0 {
+			fmt.Fprintln(o.Out, "Result: the workload can be admitted after preempting other workloads")
+		} else {
+			fmt.Fprintln(o.Out, "Result: the workload doesn't fit and preempting other workloads is not enough")
+		}
+	default:
+		fmt.Fprintln(o.Out, "Result: the workload doesn't fit")
+	}
+	o.printPodSets(&assignment)
+	if len(targets) > 0 {
+		fmt.Fprintln(o.Out, "Workloads to preempt:")
+		for _, t := range targets {
+			fmt.Fprintf(o.Out, "  %s (ClusterQueue %s)\n", workload.Key(t.Obj), t.ClusterQueue)
+		}
+	}
+	o.printQueuePosition(wl, cqObj, len(cq.Workloads))
+	return nil
+}
+
+// load reads the workload and builds a snapshot of the cache to evaluate its
+// admission in its ClusterQueue. It returns a nil workload, after printing
+// the result, when the workload can't be evaluated.
+func (o *ExplainOptions) load(ctx context.Context) (*workload.Info, *cache.Snapshot, *kueue.ClusterQueue, error) {
+	var wl kueue.Workload
+	if err := o.Client.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, &wl); err != nil {
+		return nil, nil, nil, fmt.Errorf("getting the workload: %w", err)
+	}
+	fmt.Fprintf(o.Out, "Workload: %s\n", workload.Key(&wl))
+	if workload.HasQuotaReservation(&wl) {
+		fmt.Fprintf(o.Out, "The workload already reserves quota in ClusterQueue %s\n", wl.Status.Admission.ClusterQueue)
+		return nil, nil, nil, nil
+	}
+	if workload.HasRetryOrRejectedChecks(&wl) {
+		fmt.Fprintln(o.Out, "The workload has admission checks in Retry or Rejected state")
+		return nil, nil, nil, nil
+	}
+
+	var lq kueue.LocalQueue
+	if err := o.Client.Get(ctx, client.ObjectKey{Namespace: wl.Namespace, Name: wl.Spec.QueueName}, &lq); err != nil {
+		return nil, nil, nil, fmt.Errorf("getting LocalQueue %q: %w", wl.Spec.QueueName, err)
+	}
+	cqName := string(lq.Spec.ClusterQueue)
+	fmt.Fprintf(o.Out, "LocalQueue: %s\nClusterQueue: %s\n", lq.Name, cqName)
+
+	snap, cqObj, err := o.snapshot(ctx, cqName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if snap.InactiveClusterQueueSets.Has(cqName) {
+		fmt.Fprintf(o.Out, "Result: ClusterQueue %s is inactive\n", cqName)
+		return nil, nil, nil, nil
+	}
+	cq := snap.ClusterQueues[cqName]
+	var ns corev1.Namespace
+	if err := o.Client.Get(ctx, client.ObjectKey{Name: wl.Namespace}, &ns); err != nil {
+		return nil, nil, nil, fmt.Errorf("getting the namespace: %w", err)
+	}
+	if !cq.NamespaceSelector.Matches(labels.Set(ns.Labels)) {
+		fmt.Fprintln(o.Out, "Result: the workload namespace doesn't match the ClusterQueue selector")
+		return nil, nil, nil, nil
+	}
+
+	wi := workload.NewInfo(&wl)
+	wi.ClusterQueue = cqName
+	return wi, &snap, cqObj, nil
+}
+
+// snapshot builds a snapshot of the cache from the objects in the cluster.
+func (o *ExplainOptions) snapshot(ctx context.Context, cqName string) (cache.Snapshot, *kueue.ClusterQueue, error) {
+	var lqs kueue.LocalQueueList
+	if err := o.Client.List(ctx, &lqs); err != nil {
+		return cache.Snapshot{}, nil, fmt.Errorf("listing LocalQueues: %w", err)
+	}
+	var wls kueue.WorkloadList
+	if err := o.Client.List(ctx, &wls); err != nil {
+		return cache.Snapshot{}, nil, fmt.Errorf("listing Workloads: %w", err)
+	}
+	// The cache lists the LocalQueues and the Workloads of every ClusterQueue
+	// added to it.
+	c := cache.New(&clusterObjects{localQueues: lqs.Items, workloads: wls.Items})
+	var flavors kueue.ResourceFlavorList
+	if err := o.Client.List(ctx, &flavors); err != nil {
+		return cache.Snapshot{}, nil, fmt.Errorf("listing ResourceFlavors: %w", err)
+	}
+	for i := range flavors.Items {
+		c.AddOrUpdateResourceFlavor(&flavors.Items[i])
+	}
+	var checks kueue.AdmissionCheckList
+	if err := o.Client.List(ctx, &checks); err != nil {
+		return cache.Snapshot{}, nil, fmt.Errorf("listing AdmissionChecks: %w", err)
+	}
+	for i := range checks.Items {
+		c.AddOrUpdateAdmissionCheck(&checks.Items[i])
+	}
+	var cqs kueue.ClusterQueueList
+	if err := o.Client.List(ctx, &cqs); err != nil {
+		return cache.Snapshot{}, nil, fmt.Errorf("listing ClusterQueues: %w", err)
+	}
+	var cqObj *kueue.ClusterQueue
+	for i := range cqs.Items {
+		if err := c.AddClusterQueue(ctx, &cqs.Items[i]); err != nil {
+			return cache.Snapshot{}, nil, fmt.Errorf("adding ClusterQueue %s: %w", cqs.Items[i].Name, err)
+		}
+		if cqs.Items[i].Name == cqName {
+			cqObj = &cqs.Items[i]
+		}
+	}
+	if cqObj == nil {
+		return cache.Snapshot{}, nil, fmt.Errorf("ClusterQueue %s not found", cqName)
+	}
+	return c.Snapshot(), cqObj, nil
+}
+
+// evaluate runs the flavor assignment and the search of preemption targets,
+// trying a partial admission if the full admission isn't possible, like the
+// scheduler does.
+func evaluate(wi *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*workload.Info) {
+	cq := snap.ClusterQueues[wi.ClusterQueue]
+	preemptor := preemption.New(nil, nil)
+	full := flavorassigner.AssignFlavors(logr.Discard(), wi, snap.ResourceFlavors, cq, nil)
+	var fullTargets []*workload.Info
+	switch full.RepresentativeMode() {
+	case flavorassigner.Fit:
+		return full, nil
+	case flavorassigner.Preempt:
+		fullTargets = preemptor.GetTargets(*wi, full, snap)
+	}
+	if !features.Enabled(features.PartialAdmission) || len(fullTargets) > 0 || !wi.CanBePartiallyAdmitted() {
+		return full, fullTargets
+	}
+	type partial struct {
+		assignment flavorassigner.Assignment
+		targets    []*workload.Info
+	}
+	reducer := flavorassigner.NewPodSetReducer(wi.Obj.Spec.PodSets, func(counts []int32) (*partial, bool) {
+		assignment := flavorassigner.AssignFlavors(logr.Discard(), wi, snap.ResourceFlavors, cq, counts)
+		if assignment.RepresentativeMode() == flavorassigner.Fit {
+			return &partial{assignment: assignment}, true
+		}
+		if targets := preemptor.GetTargets(*wi, assignment, snap); len(targets) > 0 {
+			return &partial{assignment: assignment, targets: targets}, true
+		}
+		return nil, false
+	})
+	if p, found := reducer.Search(); found {
+		return p.assignment, p.targets
+	}
+	return full, nil
+}
+
+func (o *ExplainOptions) printPodSets(assignment *flavorassigner.Assignment) {
+	inadmissibility := make(map[string][]kueue.FlavorInadmissibility)
+	for _, ps := range assignment.Inadmissibility() {
+		inadmissibility[ps.Name] = ps.Flavors
+	}
+	for _, ps := range assignment.PodSets {
+		fmt.Fprintf(o.Out, "Pod set %s (count %d):\n", ps.Name, ps.Count)
+		resources := make([]string, 0, len(ps.Flavors))
+		for r := range ps.Flavors {
+			resources = append(resources, string(r))
+		}
+		sort.Strings(resources)
+		for _, r := range resources {
+			fa := ps.Flavors[corev1.ResourceName(r)]
+			fmt.Fprintf(o.Out, "  %s: flavor %s (%s)\n", r, fa.Name, fa.Mode)
+		}
+		for _, f := range inadmissibility[ps.Name] {
+			fmt.Fprintf(o.Out, "  tried flavor %s: %s\n", f.Name, describeFlavorInadmissibility(&f))
+		}
+	}
+}
+
+func describeFlavorInadmissibility(f *kueue.FlavorInadmissibility) string {
+	if f.Reason != "" {
+		return f.Reason
+	}
+	parts := make([]string, 0, len(f.Resources))
+	for _, r := range f.Resources {
+		part := fmt.Sprintf("%s %s", r.Name, r.Reason)
+		if r.Missing != nil {
+			part += fmt.Sprintf(", missing %s", r.Missing.String())
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// printQueuePosition prints the number of pending workloads ahead of the
+// workload, when the ClusterQueue publishes its pending workloads in status.
+func (o *ExplainOptions) printQueuePosition(wl *kueue.Workload, cq *kueue.ClusterQueue, reserving int) {
+	fmt.Fprintf(o.Out, "Workloads reserving quota in the ClusterQueue: %d\n", reserving)
+	status := cq.Status.PendingWorkloadsStatus
+	if status == nil || len(status.Head) == 0 {
+		fmt.Fprintf(o.Out, "Pending workloads in the ClusterQueue: %d, the position of the workload is unknown\n", cq.Status.PendingWorkloads)
+		return
+	}
+	for i, pw := range status.Head {
+		if pw.Namespace == wl.Namespace && pw.Name == wl.Name {
+			fmt.Fprintf(o.Out, "Pending workloads ahead in the ClusterQueue: %d\n", i)
+			return
+		}
+	}
+	fmt.Fprintf(o.Out, "Pending workloads ahead in the ClusterQueue: at least %d\n", len(status.Head))
+}
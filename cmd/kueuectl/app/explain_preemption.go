@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const explainPreemptionLong = `List the workloads that a pending workload would preempt if it were admitted now.
+
+The command evaluates the admission of the workload like the explain workload
+command and prints the running workloads that the scheduler would preempt to
+admit it, in the order in which the scheduler chooses them: first the workloads
+of other ClusterQueues in the cohort, then the workloads with lower priorities,
+and then the workloads that reserved quota more recently.
+
+Use --priority to evaluate the workload as if it had another priority, to
+assess the impact of raising its priority before doing so.`
+
+// PreemptionOptions holds the parameters of the explain preemption command.
+type PreemptionOptions struct {
+	ExplainOptions
+
+	// Priority, when set, replaces the priority of the workload in the
+	// evaluation.
+	Priority *int32
+}
+
+func newExplainPreemptionCmd(getter *clientGetter, out io.Writer) *cobra.Command {
+	o := &PreemptionOptions{ExplainOptions: ExplainOptions{Out: out}}
+	var prio int32
+	cmd := &cobra.Command{
+		Use:   "preemption NAME",
+		Short: "List the workloads that a pending workload would preempt",
+		Long:  explainPreemptionLong,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			o.Name = args[0]
+			if o.Namespace, err = getter.Namespace(); err != nil {
+				return err
+			}
+			if o.Client, err = getter.Client(); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("priority") {
+				o.Priority = ptr.To(prio)
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+	cmd.Flags().Int32Var(&prio, "priority", 0, "The priority to evaluate the workload with, instead of its own.")
+	return cmd
+}
+
+// Run evaluates the admission of the workload and prints the workloads that
+// would be preempted.
+func (o *PreemptionOptions) Run(ctx context.Context) error {
+	wi, snap, _, err := o.load(ctx)
+	if err != nil || wi == nil {
+		return err
+	}
+	if o.Priority != nil {
+		fmt.Fprintf(o.Out, "Priority: %d (instead of %d)\n", *o.Priority, priority.Priority(wi.Obj))
+		wi.Obj.Spec.Priority = o.Priority
+	} else {
+		fmt.Fprintf(o.Out, "Priority: %d\n", priority.Priority(wi.Obj))
+	}
+	assignment, targets := evaluate(wi, snap)
+	switch mode := assignment.RepresentativeMode(); {
+	case mode == flavorassigner.Fit:
+		fmt.Fprintln(o.Out, "Result: the workload fits without preempting other workloads")
+		return nil
+	case mode == flavorassigner.Preempt && len(targets) > 0:
+		fmt.Fprintf(o.Out, "Result: admitting the workload now would preempt %d workloads\n", len(targets))
+	case mode == flavorassigner.Preempt:
+		fmt.Fprintln(o.Out, "Result: preempting other workloads is not enough to admit the workload")
+		return nil
+	default:
+		fmt.Fprintln(o.Out, "Result: the workload doesn't fit, even after preempting other workloads")
+		return nil
+	}
+	fmt.Fprintln(o.Out, "Preemption order:")
+	for i, t := range targets {
+		origin := "within the ClusterQueue"
+		if t.ClusterQueue != wi.ClusterQueue {
+			origin = "reclaiming the quota borrowed in the cohort"
+		}
+		fmt.Fprintf(o.Out, "  %d. %s: ClusterQueue %s, priority %d, %s\n", i+1, workload.Key(t.Obj), t.ClusterQueue, priority.Priority(t.Obj), origin)
+	}
+	return nil
+}
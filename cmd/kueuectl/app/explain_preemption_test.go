@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestExplainPreemption(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+	cq := utiltesting.MakeClusterQueue("cq").
+		Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	admitted := func(name string, prio int32) client.Object {
+		return utiltesting.MakeWorkload(name, "ns").
+			Queue("lq").
+			Priority(prio).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj()
+	}
+	running := []client.Object{admitted("mid", -1), admitted("low", -2)}
+
+	cases := map[string]struct {
+		objs     []client.Object
+		wl       *kueue.Workload
+		priority *int32
+		want     []string
+		wantNot  []string
+	}{
+		"fits": {
+			wl:   utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "2").Obj(),
+			want: []string{"Result: the workload fits without preempting other workloads"},
+		},
+		"preempts in order": {
+			objs: running,
+			wl:   utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "4").Obj(),
+			want: []string{
+				"Priority: 0\n",
+				"Result: admitting the workload now would preempt 2 workloads",
+				"  1. ns/low: ClusterQueue cq, priority -2, within the ClusterQueue\n  2. ns/mid: ClusterQueue cq, priority -1, within the ClusterQueue\n",
+			},
+		},
+		"preempts the minimal set": {
+			objs: running,
+			wl:   utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "2").Obj(),
+			want: []string{
+				"Result: admitting the workload now would preempt 1 workloads",
+				"  1. ns/low: ClusterQueue cq, priority -2, within the ClusterQueue\n",
+			},
+			wantNot: []string{"ns/mid"},
+		},
+		"lower priority than the running workloads": {
+			objs: running,
+			wl:   utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(-3).Request(corev1.ResourceCPU, "2").Obj(),
+			want: []string{"Result: preempting other workloads is not enough to admit the workload"},
+		},
+		"raised priority": {
+			objs:     running,
+			wl:       utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(-3).Request(corev1.ResourceCPU, "2").Obj(),
+			priority: ptr.To[int32](0),
+			want: []string{
+				"Priority: 0 (instead of -3)",
+				"  1. ns/low: ClusterQueue cq, priority -2, within the ClusterQueue\n",
+			},
+		},
+		"doesn't fit": {
+			wl:   utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "5").Obj(),
+			want: []string{"Result: the workload doesn't fit, even after preempting other workloads"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := []client.Object{ns.DeepCopy(), lq.DeepCopy(), cq.DeepCopy(), tc.wl, utiltesting.MakeResourceFlavor("default").Obj()}
+			for _, o := range tc.objs {
+				objs = append(objs, o.DeepCopyObject().(client.Object))
+			}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			var out bytes.Buffer
+			o := &PreemptionOptions{
+				ExplainOptions: ExplainOptions{
+					Namespace: "ns",
+					Name:      tc.wl.Name,
+					Client:    cl,
+					Out:       &out,
+				},
+				Priority: tc.priority,
+			}
+			if err := o.Run(context.Background()); err != nil {
+				t.Fatalf("Run() returned error: %v", err)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(out.String(), w) {
+					t.Errorf("Output doesn't contain %q:\n%s", w, out.String())
+				}
+			}
+			for _, w := range tc.wantNot {
+				if strings.Contains(out.String(), w) {
+					t.Errorf("Output contains %q:\n%s", w, out.String())
+				}
+			}
+		})
+	}
+}
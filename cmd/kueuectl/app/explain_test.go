@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestExplain(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+	cq := utiltesting.MakeClusterQueue("cq").
+		Preemption(kueue.ClusterQueuePreemption{WithinClusterQueue: kueue.PreemptionPolicyLowerPriority}).
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+			*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "2").Obj(),
+		).
+		Obj()
+	flavors := []client.Object{
+		utiltesting.MakeResourceFlavor("on-demand").Obj(),
+		utiltesting.MakeResourceFlavor("spot").Obj(),
+	}
+	lowPriority := utiltesting.MakeWorkload("low", "ns").
+		Queue("lq").
+		Priority(-1).
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "on-demand", "3").Obj()).
+		Obj()
+
+	cases := map[string]struct {
+		objs    []client.Object
+		wl      *kueue.Workload
+		want    []string
+		wantErr bool
+	}{
+		"fits": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "2").Obj(),
+			want: []string{
+				"Result: the workload fits",
+				"cpu: flavor on-demand (Fit)",
+			},
+		},
+		"needs preemption": {
+			objs: []client.Object{lowPriority},
+			wl:   utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "3").Obj(),
+			want: []string{
+				"Result: the workload can be admitted after preempting other workloads",
+				"cpu: flavor on-demand (Preempt)",
+				"tried flavor on-demand: cpu InsufficientUnusedQuota, missing 2",
+				"tried flavor spot: cpu InsufficientQuota, missing 1",
+				"ns/low (ClusterQueue cq)",
+				"Workloads reserving quota in the ClusterQueue: 1",
+			},
+		},
+		"doesn't fit": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request(corev1.ResourceCPU, "5").Obj(),
+			want: []string{
+				"Result: the workload doesn't fit",
+				"tried flavor on-demand: cpu InsufficientQuota, missing 1",
+				"tried flavor spot: cpu InsufficientQuota, missing 3",
+			},
+		},
+		"already admitted": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Request(corev1.ResourceCPU, "1").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Obj(),
+			want: []string{"already reserves quota in ClusterQueue cq"},
+		},
+		"missing local queue": {
+			wl:      utiltesting.MakeWorkload("wl", "ns").Queue("other").Request(corev1.ResourceCPU, "1").Obj(),
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := []client.Object{ns.DeepCopy(), lq.DeepCopy(), cq.DeepCopy(), tc.wl}
+			for _, o := range append(flavors, tc.objs...) {
+				objs = append(objs, o.DeepCopyObject().(client.Object))
+			}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			var out bytes.Buffer
+			o := &ExplainOptions{
+				Namespace: "ns",
+				Name:      tc.wl.Name,
+				Client:    cl,
+				Out:       &out,
+			}
+			err := o.Run(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Run() returned error %v, want error: %t", err, tc.wantErr)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(out.String(), w) {
+					t.Errorf("Output doesn't contain %q:\n%s", w, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestClusterObjectsList(t *testing.T) {
+	objs := &clusterObjects{
+		localQueues: []kueue.LocalQueue{
+			*utiltesting.MakeLocalQueue("a", "ns").ClusterQueue("cq").Obj(),
+			*utiltesting.MakeLocalQueue("b", "ns").ClusterQueue("other").Obj(),
+		},
+		workloads: []kueue.Workload{
+			*utiltesting.MakeWorkload("admitted", "ns").ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Obj(),
+			*utiltesting.MakeWorkload("pending", "ns").Obj(),
+		},
+	}
+	ctx := context.Background()
+
+	var lqs kueue.LocalQueueList
+	if err := objs.List(ctx, &lqs, client.MatchingFields{indexer.QueueClusterQueueKey: "cq"}); err != nil {
+		t.Fatalf("Listing LocalQueues: %v", err)
+	}
+	if len(lqs.Items) != 1 || lqs.Items[0].Name != "a" {
+		t.Errorf("Unexpected LocalQueues of the ClusterQueue: %v", lqs.Items)
+	}
+	var wls kueue.WorkloadList
+	if err := objs.List(ctx, &wls, client.MatchingFields{indexer.WorkloadClusterQueueKey: "cq"}); err != nil {
+		t.Fatalf("Listing Workloads: %v", err)
+	}
+	if len(wls.Items) != 1 || wls.Items[0].Name != "admitted" {
+		t.Errorf("Unexpected Workloads of the ClusterQueue: %v", wls.Items)
+	}
+	if err := objs.List(ctx, &wls, client.MatchingFields{indexer.WorkloadQueueKey: "lq"}); err == nil {
+		t.Errorf("Expected an error listing with an unsupported field")
+	}
+}
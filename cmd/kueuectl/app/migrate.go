@@ -0,0 +1,224 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const migrateLong = `Move the pending workloads from one LocalQueue to another LocalQueue in the same namespace.
+
+The queue name of the jobs owning the workloads is updated, so that Kueue moves
+their workloads to the destination. Workloads without an owner are updated
+directly. Workloads that already reserve quota or are finished are not moved.
+
+The command fails without moving any workload if the ClusterQueue of the
+destination LocalQueue doesn't cover all the resources requested by the
+pending workloads, or if it doesn't accept workloads from the namespace.`
+
+// MigrateOptions holds the parameters of the migrate command.
+type MigrateOptions struct {
+	Namespace string
+	From      string
+	To        string
+	DryRun    bool
+
+	Client client.Client
+	Out    io.Writer
+}
+
+// NewMigrateCmd returns the command moving pending workloads between
+// LocalQueues.
+func NewMigrateCmd(getter *clientGetter, out io.Writer) *cobra.Command {
+	o := &MigrateOptions{Out: out}
+	cmd := &cobra.Command{
+		Use:   "migrate --from SOURCE --to DESTINATION",
+		Short: "Move the pending workloads from one LocalQueue to another",
+		Long:  migrateLong,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var err error
+			if o.Namespace, err = getter.Namespace(); err != nil {
+				return err
+			}
+			if o.Client, err = getter.Client(); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+	cmd.Flags().StringVar(&o.From, "from", "", "The LocalQueue the workloads are moved from.")
+	cmd.Flags().StringVar(&o.To, "to", "", "The LocalQueue the workloads are moved to.")
+	cmd.Flags().BoolVar(&o.DryRun, "dry-run", false, "Only print the workloads that would be moved.")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+	return cmd
+}
+
+// Run validates the destination and moves the pending workloads.
+func (o *MigrateOptions) Run(ctx context.Context) error {
+	if o.From == o.To {
+		return errors.New("the source and destination LocalQueues must be different")
+	}
+	var dst kueue.LocalQueue
+	if err := o.Client.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.To}, &dst); err != nil {
+		return fmt.Errorf("getting the destination LocalQueue: %w", err)
+	}
+	var cq kueue.ClusterQueue
+	if err := o.Client.Get(ctx, client.ObjectKey{Name: string(dst.Spec.ClusterQueue)}, &cq); err != nil {
+		return fmt.Errorf("getting the ClusterQueue of the destination LocalQueue: %w", err)
+	}
+	if err := o.validateNamespace(ctx, &cq); err != nil {
+		return err
+	}
+
+	pending, err := o.pendingWorkloads(ctx)
+	if err != nil {
+		return err
+	}
+	covered := coveredResources(&cq)
+	var errs []error
+	for _, wl := range pending {
+		if missing := uncoveredResources(wl, covered); len(missing) > 0 {
+			errs = append(errs, fmt.Errorf("workload %s requests %v, not covered by ClusterQueue %s", workload.Key(wl), missing, cq.Name))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, wl := range pending {
+		if o.DryRun {
+			fmt.Fprintf(o.Out, "workload %s would be moved to %s (dry run)\n", workload.Key(wl), o.To)
+			continue
+		}
+		if err := o.retarget(ctx, wl); err != nil {
+			return fmt.Errorf("moving workload %s: %w", workload.Key(wl), err)
+		}
+		fmt.Fprintf(o.Out, "workload %s moved to %s\n", workload.Key(wl), o.To)
+	}
+	if len(pending) == 0 {
+		fmt.Fprintf(o.Out, "No pending workloads in LocalQueue %s\n", o.From)
+	}
+	return nil
+}
+
+func (o *MigrateOptions) validateNamespace(ctx context.Context, cq *kueue.ClusterQueue) error {
+	var ns corev1.Namespace
+	if err := o.Client.Get(ctx, client.ObjectKey{Name: o.Namespace}, &ns); err != nil {
+		return fmt.Errorf("getting the namespace: %w", err)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(cq.Spec.NamespaceSelector)
+	if err != nil {
+		return fmt.Errorf("parsing the namespace selector of ClusterQueue %s: %w", cq.Name, err)
+	}
+	if !selector.Matches(labels.Set(ns.Labels)) {
+		return fmt.Errorf("ClusterQueue %s doesn't accept workloads from namespace %s", cq.Name, o.Namespace)
+	}
+	return nil
+}
+
+// pendingWorkloads returns the workloads in the source LocalQueue that don't
+// reserve quota and are not finished.
+func (o *MigrateOptions) pendingWorkloads(ctx context.Context) ([]*kueue.Workload, error) {
+	var list kueue.WorkloadList
+	if err := o.Client.List(ctx, &list, client.InNamespace(o.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing workloads: %w", err)
+	}
+	var pending []*kueue.Workload
+	for i := range list.Items {
+		wl := &list.Items[i]
+		if wl.Spec.QueueName != o.From || workload.HasQuotaReservation(wl) ||
+			apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
+			continue
+		}
+		pending = append(pending, wl)
+	}
+	return pending, nil
+}
+
+// retarget updates the queue name of the owners of the workload, or of the
+// workload itself when it has no owners.
+func (o *MigrateOptions) retarget(ctx context.Context, wl *kueue.Workload) error {
+	if len(wl.OwnerReferences) == 0 {
+		patch := client.MergeFrom(wl.DeepCopy())
+		wl.Spec.QueueName = o.To
+		return o.Client.Patch(ctx, wl, patch)
+	}
+	for _, ref := range wl.OwnerReferences {
+		owner := &unstructured.Unstructured{}
+		owner.SetAPIVersion(ref.APIVersion)
+		owner.SetKind(ref.Kind)
+		if err := o.Client.Get(ctx, client.ObjectKey{Namespace: wl.Namespace, Name: ref.Name}, owner); err != nil {
+			return fmt.Errorf("getting owner %s %s: %w", ref.Kind, ref.Name, err)
+		}
+		patch := client.MergeFrom(owner.DeepCopy())
+		ownerLabels := owner.GetLabels()
+		if ownerLabels == nil {
+			ownerLabels = make(map[string]string, 1)
+		}
+		ownerLabels[constants.QueueLabel] = o.To
+		owner.SetLabels(ownerLabels)
+		if annotations := owner.GetAnnotations(); annotations[constants.QueueAnnotation] != "" {
+			annotations[constants.QueueAnnotation] = o.To
+			owner.SetAnnotations(annotations)
+		}
+		if err := o.Client.Patch(ctx, owner, patch); err != nil {
+			return fmt.Errorf("updating owner %s %s: %w", ref.Kind, ref.Name, err)
+		}
+	}
+	return nil
+}
+
+func coveredResources(cq *kueue.ClusterQueue) sets.Set[corev1.ResourceName] {
+	covered := sets.New[corev1.ResourceName]()
+	for _, rg := range cq.Spec.ResourceGroups {
+		covered.Insert(rg.CoveredResources...)
+	}
+	return covered
+}
+
+func uncoveredResources(wl *kueue.Workload, covered sets.Set[corev1.ResourceName]) []corev1.ResourceName {
+	missing := sets.New[corev1.ResourceName]()
+	for _, ps := range workload.NewInfo(wl).TotalRequests {
+		for name := range ps.Requests {
+			if !covered.Has(name) {
+				missing.Insert(name)
+			}
+		}
+	}
+	ret := missing.UnsortedList()
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
+)
+
+func TestMigrate(t *testing.T) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "ns",
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+	srcLQ := utiltesting.MakeLocalQueue("src", "ns").ClusterQueue("src-cq").Obj()
+	dstLQ := utiltesting.MakeLocalQueue("dst", "ns").ClusterQueue("dst-cq").Obj()
+	cpuCQ := utiltesting.MakeClusterQueue("dst-cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	job := testingjob.MakeJob("job", "ns").Queue("src").Obj()
+	ownedWl := utiltesting.MakeWorkload("job-wl", "ns").
+		Queue("src").
+		Request(corev1.ResourceCPU, "1").
+		OwnerReference("batch/v1", "Job", "job", "job-uid", true, true).
+		Obj()
+	bareWl := utiltesting.MakeWorkload("bare-wl", "ns").
+		Queue("src").
+		Request(corev1.ResourceCPU, "1").
+		Obj()
+	admittedWl := utiltesting.MakeWorkload("admitted-wl", "ns").
+		Queue("src").
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("src-cq").Obj()).
+		Obj()
+	gpuWl := utiltesting.MakeWorkload("gpu-wl", "ns").
+		Queue("src").
+		Request("example.com/gpu", "1").
+		Obj()
+
+	cases := map[string]struct {
+		objs         []client.Object
+		dryRun       bool
+		wantErr      bool
+		wantQueues   map[string]string
+		wantJobQueue string
+	}{
+		"moves pending workloads": {
+			objs: []client.Object{cpuCQ, job, ownedWl, bareWl, admittedWl},
+			wantQueues: map[string]string{
+				"job-wl":      "src",
+				"bare-wl":     "dst",
+				"admitted-wl": "src",
+			},
+			wantJobQueue: "dst",
+		},
+		"dry run": {
+			objs:   []client.Object{cpuCQ, job, ownedWl, bareWl},
+			dryRun: true,
+			wantQueues: map[string]string{
+				"job-wl":  "src",
+				"bare-wl": "src",
+			},
+			wantJobQueue: "src",
+		},
+		"uncovered resources": {
+			objs:    []client.Object{cpuCQ, job, bareWl, gpuWl},
+			wantErr: true,
+			wantQueues: map[string]string{
+				"bare-wl": "src",
+				"gpu-wl":  "src",
+			},
+			wantJobQueue: "src",
+		},
+		"namespace not selected": {
+			objs: []client.Object{
+				utiltesting.MakeClusterQueue("dst-cq").
+					NamespaceSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}}).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+					Obj(),
+				job, bareWl,
+			},
+			wantErr: true,
+			wantQueues: map[string]string{
+				"bare-wl": "src",
+			},
+			wantJobQueue: "src",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs := []client.Object{ns.DeepCopy(), srcLQ.DeepCopy(), dstLQ.DeepCopy()}
+			for _, o := range tc.objs {
+				objs = append(objs, o.DeepCopyObject().(client.Object))
+			}
+			cl := utiltesting.NewClientBuilder().WithObjects(objs...).Build()
+			var out bytes.Buffer
+			o := &MigrateOptions{
+				Namespace: "ns",
+				From:      "src",
+				To:        "dst",
+				DryRun:    tc.dryRun,
+				Client:    cl,
+				Out:       &out,
+			}
+			ctx := context.Background()
+			err := o.Run(ctx)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Run() returned error %v, want error: %t", err, tc.wantErr)
+			}
+
+			var wls kueue.WorkloadList
+			if err := cl.List(ctx, &wls); err != nil {
+				t.Fatalf("Listing workloads: %v", err)
+			}
+			gotQueues := make(map[string]string, len(wls.Items))
+			for _, wl := range wls.Items {
+				gotQueues[wl.Name] = wl.Spec.QueueName
+			}
+			if diff := cmp.Diff(tc.wantQueues, gotQueues); diff != "" {
+				t.Errorf("Unexpected workload queues (-want,+got):\n%s", diff)
+			}
+
+			var gotJob batchv1.Job
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(job), &gotJob); err != nil {
+				t.Fatalf("Getting job: %v", err)
+			}
+			if got := gotJob.Labels[constants.QueueLabel]; got != tc.wantJobQueue {
+				t.Errorf("Unexpected job queue %q, want %q", got, tc.wantJobQueue)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const reactivateWorkloadLong = `Reactivate a workload that is not active.
+
+The command sets the active field of the workload to true. When the workload
+was deactivated after exhausting the retries of its retryPolicy, Kueue resets
+its retries, records the user that reactivated it in the
+kueue.x-k8s.io/reactivated-by annotation and sets its Deactivated condition to
+false. The workload is then queued again, without deleting and resubmitting
+its job.`
+
+// ReactivateOptions holds the parameters of the reactivate workload command.
+type ReactivateOptions struct {
+	Namespace string
+	Name      string
+
+	Client client.Client
+	Out    io.Writer
+}
+
+// NewReactivateCmd returns the command reactivating objects.
+func NewReactivateCmd(getter *clientGetter, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reactivate",
+		Short: "Reactivate Kueue objects",
+	}
+	cmd.AddCommand(newReactivateWorkloadCmd(getter, out))
+	return cmd
+}
+
+func newReactivateWorkloadCmd(getter *clientGetter, out io.Writer) *cobra.Command {
+	o := &ReactivateOptions{Out: out}
+	return &cobra.Command{
+		Use:   "workload NAME",
+		Short: "Reactivate a workload that is not active",
+		Long:  reactivateWorkloadLong,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			o.Name = args[0]
+			if o.Namespace, err = getter.Namespace(); err != nil {
+				return err
+			}
+			if o.Client, err = getter.Client(); err != nil {
+				return err
+			}
+			return o.Run(cmd.Context())
+		},
+	}
+}
+
+// Run activates the workload.
+func (o *ReactivateOptions) Run(ctx context.Context) error {
+	var wl kueue.Workload
+	if err := o.Client.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, &wl); err != nil {
+		return fmt.Errorf("getting the workload: %w", err)
+	}
+	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
+		return errors.New("the workload is finished")
+	}
+	if ptr.Deref(wl.Spec.Active, true) {
+		fmt.Fprintf(o.Out, "Workload %s is already active\n", workload.Key(&wl))
+		return nil
+	}
+	patch := client.MergeFromWithOptions(wl.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	wl.Spec.Active = ptr.To(true)
+	if err := o.Client.Patch(ctx, &wl, patch); err != nil {
+		return fmt.Errorf("activating the workload: %w", err)
+	}
+	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadDeactivated) {
+		fmt.Fprintf(o.Out, "Workload %s reactivated, its retries will be reset\n", workload.Key(&wl))
+	} else {
+		fmt.Fprintf(o.Out, "Workload %s reactivated\n", workload.Key(&wl))
+	}
+	return nil
+}
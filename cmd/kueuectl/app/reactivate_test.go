@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestReactivate(t *testing.T) {
+	deactivated := metav1.Condition{
+		Type:   kueue.WorkloadDeactivated,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadRetryLimitExceeded,
+	}
+	cases := map[string]struct {
+		wl         *kueue.Workload
+		want       string
+		wantActive bool
+		wantErr    bool
+	}{
+		"deactivated after exhausting the retries": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				SetOrReplaceCondition(deactivated).
+				Obj(),
+			want:       "Workload ns/wl reactivated, its retries will be reset",
+			wantActive: true,
+		},
+		"inactive": {
+			wl:         utiltesting.MakeWorkload("wl", "ns").Active(false).Obj(),
+			want:       "Workload ns/wl reactivated",
+			wantActive: true,
+		},
+		"already active": {
+			wl:         utiltesting.MakeWorkload("wl", "ns").Obj(),
+			want:       "Workload ns/wl is already active",
+			wantActive: true,
+		},
+		"finished": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				Active(false).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadFinished,
+					Status: metav1.ConditionTrue,
+					Reason: "ByTest",
+				}).
+				Obj(),
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.wl).Build()
+			var out bytes.Buffer
+			o := &ReactivateOptions{
+				Namespace: "ns",
+				Name:      tc.wl.Name,
+				Client:    cl,
+				Out:       &out,
+			}
+			err := o.Run(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Run() returned error %v, want error: %t", err, tc.wantErr)
+			}
+			if !strings.Contains(out.String(), tc.want) {
+				t.Errorf("Output doesn't contain %q:\n%s", tc.want, out.String())
+			}
+			if tc.wantErr {
+				return
+			}
+			var got kueue.Workload
+			if err := cl.Get(context.Background(), client.ObjectKeyFromObject(tc.wl), &got); err != nil {
+				t.Fatalf("Could not get the workload: %v", err)
+			}
+			if active := ptr.Deref(got.Spec.Active, true); active != tc.wantActive {
+				t.Errorf("Unexpected active %t, want %t", active, tc.wantActive)
+			}
+		})
+	}
+}
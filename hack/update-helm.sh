@@ -118,3 +118,39 @@ for output_file in ${DEST_VISIBILITY_DIR}/*.yaml; do
     $YQ -N -i '.subjects.[].namespace = "{{ .Release.Namespace }}"' $output_file
   fi
 done
+
+# Let cert-manager inject the CA of the visibility server in the APIService
+search_apiservice_name_line="  name: v1alpha1.visibility.kueue.x-k8s.io"
+replace_apiservice_name_line=$(
+  cat <<'EOF'
+  {{- if .Values.enableCertManager }}
+  annotations:
+    cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include "kueue.fullname" . }}-visibility-server-cert
+  {{- end }}
+EOF
+)
+
+search_apiservice_tls_line="  insecureSkipTLSVerify: true"
+replace_apiservice_tls_line=$(
+  cat <<'EOF'
+  {{- if not .Values.enableCertManager }}
+  insecureSkipTLSVerify: true
+  {{- end }}
+EOF
+)
+
+output_file=${DEST_VISIBILITY_DIR}/apiservice.yaml
+input_file="${output_file%.yaml}.yaml.test"
+mv "$output_file" "$input_file"
+: >$output_file
+while IFS= read -r line; do
+  if [[ $line == "$search_apiservice_tls_line" ]]; then
+    echo "$replace_apiservice_tls_line" >>"$output_file"
+    continue
+  fi
+  echo "$line" >>"$output_file"
+  if [[ $line == "$search_apiservice_name_line" ]]; then
+    echo "$replace_apiservice_name_line" >>"$output_file"
+  fi
+done <"$input_file"
+rm $input_file
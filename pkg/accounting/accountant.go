@@ -0,0 +1,319 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounting
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+var realClock = clock.RealClock{}
+
+// Record is the consumption of the workloads of a LocalQueue, admitted in a
+// ClusterQueue, over a period.
+type Record struct {
+	PeriodStart  time.Time `json:"periodStart"`
+	PeriodEnd    time.Time `json:"periodEnd"`
+	Namespace    string    `json:"namespace"`
+	LocalQueue   string    `json:"localQueue"`
+	ClusterQueue string    `json:"clusterQueue"`
+	// Workloads is the number of workloads which held a quota reservation
+	// during the period.
+	Workloads int `json:"workloads"`
+	// ResourceHours is the consumption per flavor and resource, in hours of
+	// the base unit of the resource, e.g. core-hours for cpu and byte-hours
+	// for memory.
+	ResourceHours map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64 `json:"resourceHours"`
+}
+
+// Backend persists the records of a period.
+type Backend interface {
+	Write([]Record) error
+}
+
+type options struct {
+	interval time.Duration
+	clock    clock.WithTicker
+}
+
+// Option configures the accountant.
+type Option func(*options)
+
+// WithInterval sets the period covered by every record.
+func WithInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.interval = interval
+	}
+}
+
+// WithClock sets the clock used to measure the consumption.
+func WithClock(c clock.WithTicker) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+var defaultOptions = options{
+	interval: time.Hour,
+	clock:    realClock,
+}
+
+type queueKey struct {
+	namespace    string
+	localQueue   string
+	clusterQueue string
+}
+
+type runningWorkload struct {
+	queue     queueKey
+	admission *kueue.Admission
+	// rates is the usage per flavor and resource of the workload.
+	rates map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64
+	// accountedUntil is the time until which the consumption of the workload
+	// is part of the totals.
+	accountedUntil time.Time
+}
+
+type queueUsage struct {
+	workloads     sets.Set[string]
+	resourceHours map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64
+}
+
+// Accountant measures the consumption of the workloads holding a quota
+// reservation and, at the end of every period, writes a record per LocalQueue
+// and ClusterQueue to the backend. It observes the workloads as a workload
+// update watcher.
+type Accountant struct {
+	log      logr.Logger
+	backend  Backend
+	interval time.Duration
+	clock    clock.WithTicker
+
+	sync.Mutex
+	started     bool
+	periodStart time.Time
+	running     map[string]*runningWorkload
+	usage       map[queueKey]*queueUsage
+}
+
+// New creates an accountant writing the records to the backend.
+func New(backend Backend, opts ...Option) *Accountant {
+	options := defaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Accountant{
+		log:      ctrl.Log.WithName("usage-accounting"),
+		backend:  backend,
+		interval: options.interval,
+		clock:    options.clock,
+		running:  make(map[string]*runningWorkload),
+		usage:    make(map[queueKey]*queueUsage),
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that
+// only the leader replica writes the records.
+func (a *Accountant) NeedLeaderElection() bool {
+	return true
+}
+
+// Start writes the records at the end of every period, until the context is
+// done. The consumption is accounted from the time the replica becomes the
+// leader, and the records of the period in progress are written when the
+// context is done.
+func (a *Accountant) Start(ctx context.Context) error {
+	a.begin()
+	ticker := a.clock.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			a.write(a.flush())
+			return nil
+		case <-ticker.C():
+			a.write(a.flush())
+		}
+	}
+}
+
+// begin starts the first period. The consumption before it was measured by
+// the previous leader.
+func (a *Accountant) begin() {
+	a.Lock()
+	defer a.Unlock()
+	now := a.clock.Now()
+	a.started = true
+	a.periodStart = now
+	a.usage = make(map[queueKey]*queueUsage)
+	for _, w := range a.running {
+		if w.accountedUntil.Before(now) {
+			w.accountedUntil = now
+		}
+	}
+}
+
+// NotifyWorkloadUpdate starts measuring the consumption of the workload when it
+// gets a quota reservation, and adds it to the totals of its LocalQueue when
+// it loses the reservation, finishes or is deleted.
+func (a *Accountant) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	a.Lock()
+	defer a.Unlock()
+	now := a.clock.Now()
+	if newWl == nil {
+		if oldWl != nil {
+			a.stop(workload.Key(oldWl), now)
+		}
+		return
+	}
+	key := workload.Key(newWl)
+	if !workload.HasQuotaReservation(newWl) || apimeta.IsStatusConditionTrue(newWl.Status.Conditions, kueue.WorkloadFinished) {
+		a.stop(key, now)
+		return
+	}
+	w, found := a.running[key]
+	if found && equality.Semantic.DeepEqual(w.admission, newWl.Status.Admission) {
+		return
+	}
+	since := now
+	if found {
+		a.accumulate(key, w, now)
+	} else if cond := apimeta.FindStatusCondition(newWl.Status.Conditions, kueue.WorkloadQuotaReserved); cond != nil && cond.LastTransitionTime.Time.Before(now) {
+		since = cond.LastTransitionTime.Time
+	}
+	if a.started && since.Before(a.periodStart) {
+		since = a.periodStart
+	}
+	a.running[key] = newRunningWorkload(newWl, since)
+}
+
+func newRunningWorkload(wl *kueue.Workload, since time.Time) *runningWorkload {
+	admission := wl.Status.Admission
+	rates := make(map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64)
+	for _, psa := range admission.PodSetAssignments {
+		for res, q := range psa.ResourceUsage {
+			flv := psa.Flavors[res]
+			if rates[flv] == nil {
+				rates[flv] = make(map[corev1.ResourceName]float64)
+			}
+			rates[flv][res] += q.AsApproximateFloat64()
+		}
+	}
+	return &runningWorkload{
+		queue: queueKey{
+			namespace:    wl.Namespace,
+			localQueue:   wl.Spec.QueueName,
+			clusterQueue: string(admission.ClusterQueue),
+		},
+		admission:      admission.DeepCopy(),
+		rates:          rates,
+		accountedUntil: since,
+	}
+}
+
+func (a *Accountant) stop(key string, now time.Time) {
+	if w, found := a.running[key]; found {
+		a.accumulate(key, w, now)
+		delete(a.running, key)
+	}
+}
+
+// accumulate adds the consumption of the workload until the given time to the
+// totals of its queue.
+func (a *Accountant) accumulate(key string, w *runningWorkload, until time.Time) {
+	hours := until.Sub(w.accountedUntil).Hours()
+	if hours <= 0 {
+		return
+	}
+	w.accountedUntil = until
+	if !a.started {
+		return
+	}
+	u := a.usage[w.queue]
+	if u == nil {
+		u = &queueUsage{
+			workloads:     sets.New[string](),
+			resourceHours: make(map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64),
+		}
+		a.usage[w.queue] = u
+	}
+	u.workloads.Insert(key)
+	for flv, resRates := range w.rates {
+		if u.resourceHours[flv] == nil {
+			u.resourceHours[flv] = make(map[corev1.ResourceName]float64)
+		}
+		for res, rate := range resRates {
+			u.resourceHours[flv][res] += rate * hours
+		}
+	}
+}
+
+// flush returns the records of the period ending now, and starts a new period.
+func (a *Accountant) flush() []Record {
+	a.Lock()
+	defer a.Unlock()
+	now := a.clock.Now()
+	for key, w := range a.running {
+		a.accumulate(key, w, now)
+	}
+	records := make([]Record, 0, len(a.usage))
+	for q, u := range a.usage {
+		records = append(records, Record{
+			PeriodStart:   a.periodStart.UTC(),
+			PeriodEnd:     now.UTC(),
+			Namespace:     q.namespace,
+			LocalQueue:    q.localQueue,
+			ClusterQueue:  q.clusterQueue,
+			Workloads:     u.workloads.Len(),
+			ResourceHours: u.resourceHours,
+		})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Namespace != records[j].Namespace {
+			return records[i].Namespace < records[j].Namespace
+		}
+		if records[i].LocalQueue != records[j].LocalQueue {
+			return records[i].LocalQueue < records[j].LocalQueue
+		}
+		return records[i].ClusterQueue < records[j].ClusterQueue
+	})
+	a.usage = make(map[queueKey]*queueUsage)
+	a.periodStart = now
+	return records
+}
+
+func (a *Accountant) write(records []Record) {
+	if len(records) == 0 {
+		return
+	}
+	if err := a.backend.Write(records); err != nil {
+		a.log.Error(err, "Dropping usage records", "count", len(records))
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingclock "k8s.io/utils/clock/testing"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+type recordingBackend struct {
+	batches [][]Record
+}
+
+func (b *recordingBackend) Write(records []Record) error {
+	b.batches = append(b.batches, records)
+	return nil
+}
+
+func TestAccountant(t *testing.T) {
+	wlA := utiltesting.MakeWorkload("a", "team-a").Queue("lq").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "on-demand", "2").Obj()).
+		Obj()
+	wlB := utiltesting.MakeWorkload("b", "team-a").Queue("lq").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "spot", "1").Obj()).
+		Obj()
+	wlC := utiltesting.MakeWorkload("c", "team-b").Queue("lq").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("example.com/gpu", "a100", "4").Obj()).
+		Obj()
+	wlPending := utiltesting.MakeWorkload("d", "team-b").Queue("lq").Obj()
+	finishedB := wlB.DeepCopy()
+	apimeta.SetStatusCondition(&finishedB.Status.Conditions, metav1.Condition{
+		Type:   kueue.WorkloadFinished,
+		Status: metav1.ConditionTrue,
+		Reason: "JobFinished",
+	})
+
+	t0 := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(t0)
+	backend := &recordingBackend{}
+	a := New(backend, WithClock(fakeClock))
+
+	// The consumption before the replica becomes the leader is not accounted.
+	a.NotifyWorkloadUpdate(nil, wlA)
+	fakeClock.Step(10 * time.Minute)
+	t1 := fakeClock.Now()
+	a.begin()
+
+	a.NotifyWorkloadUpdate(nil, wlB)
+	a.NotifyWorkloadUpdate(nil, wlPending)
+	fakeClock.Step(30 * time.Minute)
+	a.NotifyWorkloadUpdate(wlB, finishedB)
+	// The consumption is accounted from the quota reservation.
+	wlC.Status.Conditions[0].LastTransitionTime = metav1.NewTime(fakeClock.Now())
+	a.NotifyWorkloadUpdate(nil, wlC)
+	fakeClock.Step(30 * time.Minute)
+	t2 := fakeClock.Now()
+	a.write(a.flush())
+
+	fakeClock.Step(15 * time.Minute)
+	a.NotifyWorkloadUpdate(wlA, nil)
+	fakeClock.Step(15 * time.Minute)
+	t3 := fakeClock.Now()
+	a.write(a.flush())
+
+	wantBatches := [][]Record{
+		{
+			{
+				PeriodStart:  t1.UTC(),
+				PeriodEnd:    t2.UTC(),
+				Namespace:    "team-a",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Workloads:    2,
+				ResourceHours: map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64{
+					"on-demand": {corev1.ResourceCPU: 2},
+					"spot":      {corev1.ResourceCPU: 0.5},
+				},
+			},
+			{
+				PeriodStart:  t1.UTC(),
+				PeriodEnd:    t2.UTC(),
+				Namespace:    "team-b",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Workloads:    1,
+				ResourceHours: map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64{
+					"a100": {"example.com/gpu": 2},
+				},
+			},
+		},
+		{
+			{
+				PeriodStart:  t2.UTC(),
+				PeriodEnd:    t3.UTC(),
+				Namespace:    "team-a",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Workloads:    1,
+				ResourceHours: map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64{
+					"on-demand": {corev1.ResourceCPU: 0.5},
+				},
+			},
+			{
+				PeriodStart:  t2.UTC(),
+				PeriodEnd:    t3.UTC(),
+				Namespace:    "team-b",
+				LocalQueue:   "lq",
+				ClusterQueue: "cq",
+				Workloads:    1,
+				ResourceHours: map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64{
+					"a100": {"example.com/gpu": 2},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(wantBatches, backend.batches); diff != "" {
+		t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+	}
+}
+
+func TestAccountantAdmissionChange(t *testing.T) {
+	wl := utiltesting.MakeWorkload("a", "ns").Queue("lq").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+		Obj()
+	shrunk := wl.DeepCopy()
+	shrunk.Status.Admission = utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()
+
+	t0 := time.Now().Truncate(time.Second)
+	fakeClock := testingclock.NewFakeClock(t0)
+	backend := &recordingBackend{}
+	a := New(backend, WithClock(fakeClock))
+	a.begin()
+	a.NotifyWorkloadUpdate(nil, wl)
+	fakeClock.Step(30 * time.Minute)
+	a.NotifyWorkloadUpdate(wl, shrunk)
+	fakeClock.Step(30 * time.Minute)
+	a.write(a.flush())
+
+	want := [][]Record{{{
+		PeriodStart:  t0.UTC(),
+		PeriodEnd:    fakeClock.Now().UTC(),
+		Namespace:    "ns",
+		LocalQueue:   "lq",
+		ClusterQueue: "cq",
+		Workloads:    1,
+		ResourceHours: map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64{
+			"default": {corev1.ResourceCPU: 2.5},
+		},
+	}}}
+	if diff := cmp.Diff(want, backend.batches); diff != "" {
+		t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+	}
+}
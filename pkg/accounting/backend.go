@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/kueue/pkg/audit"
+)
+
+// FileBackend appends the records to a file, one JSON object per line. The
+// file is rotated like the audit log.
+type FileBackend struct {
+	file *audit.FileBackend
+}
+
+var _ Backend = (*FileBackend)(nil)
+
+// NewFileBackend opens, or creates, the file at path.
+func NewFileBackend(path string, maxSize int64, maxBackups int) (*FileBackend, error) {
+	f, err := audit.NewFileBackend(path, maxSize, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	return &FileBackend{file: f}, nil
+}
+
+// Write appends the records to the file.
+func (b *FileBackend) Write(records []Record) error {
+	for i := range records {
+		if err := b.file.Append(&records[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the file.
+func (b *FileBackend) Close() error {
+	return b.file.Close()
+}
+
+// HTTPBackend sends the records of every period to an HTTP(S) endpoint, as a
+// JSON array.
+type HTTPBackend struct {
+	sink   string
+	client *http.Client
+}
+
+var _ Backend = (*HTTPBackend)(nil)
+
+// NewHTTPBackend creates a backend sending the records to the sink URL.
+func NewHTTPBackend(sink string, timeout time.Duration) *HTTPBackend {
+	return &HTTPBackend{
+		sink:   sink,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Write sends the records to the sink.
+func (b *HTTPBackend) Write(records []Record) error {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, b.sink, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accounting
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+var testRecords = []Record{
+	{
+		PeriodStart:  time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:    time.Date(2023, time.November, 1, 1, 0, 0, 0, time.UTC),
+		Namespace:    "team-a",
+		LocalQueue:   "lq",
+		ClusterQueue: "cq",
+		Workloads:    3,
+		ResourceHours: map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64{
+			"default": {corev1.ResourceCPU: 1.5},
+		},
+	},
+	{
+		PeriodStart:  time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:    time.Date(2023, time.November, 1, 1, 0, 0, 0, time.UTC),
+		Namespace:    "team-b",
+		LocalQueue:   "lq",
+		ClusterQueue: "cq",
+		Workloads:    1,
+		ResourceHours: map[kueue.ResourceFlavorReference]map[corev1.ResourceName]float64{
+			"a100": {"example.com/gpu": 8},
+		},
+	},
+}
+
+func TestFileBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.log")
+	b, err := NewFileBackend(path, 1024*1024, 1)
+	if err != nil {
+		t.Fatalf("Creating the backend: %v", err)
+	}
+	if err := b.Write(testRecords); err != nil {
+		t.Fatalf("Writing the records: %v", err)
+	}
+	if err := b.Close(); err != nil {
+		t.Fatalf("Closing the backend: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Opening %s: %v", path, err)
+	}
+	defer f.Close()
+	var got []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("Decoding record: %v", err)
+		}
+		got = append(got, r)
+	}
+	if diff := cmp.Diff(testRecords, got); diff != "" {
+		t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+	}
+}
+
+func TestHTTPBackend(t *testing.T) {
+	cases := map[string]struct {
+		status  int
+		wantErr bool
+	}{
+		"accepted": {
+			status: http.StatusAccepted,
+		},
+		"rejected": {
+			status:  http.StatusServiceUnavailable,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []Record
+			var contentType string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType = r.Header.Get("Content-Type")
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("Reading the request: %v", err)
+				}
+				if err := json.Unmarshal(body, &got); err != nil {
+					t.Errorf("Decoding the records: %v", err)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			err := NewHTTPBackend(srv.URL, time.Second).Write(testRecords)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Unexpected error: %v, want error %t", err, tc.wantErr)
+			}
+			if contentType != "application/json" {
+				t.Errorf("Unexpected content type %q", contentType)
+			}
+			if diff := cmp.Diff(testRecords, got); diff != "" {
+				t.Errorf("Unexpected records (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
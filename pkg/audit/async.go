@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultBufferSize is the number of records an AsyncBackend holds before
+// dropping the new ones.
+const DefaultBufferSize = 1000
+
+// ErrBufferFull is returned when a record is dropped because the buffer of
+// the AsyncBackend is full.
+var ErrBufferFull = errors.New("the audit record buffer is full")
+
+// AsyncBackend buffers the records and writes them to the wrapped backend
+// from its own goroutine, so that the scheduling cycle never waits on the
+// storage. It must be added to the manager to drain the buffer.
+type AsyncBackend struct {
+	log     logr.Logger
+	backend Backend
+	records chan *Record
+}
+
+var _ Backend = (*AsyncBackend)(nil)
+
+// NewAsyncBackend returns a backend writing to b asynchronously, holding up
+// to bufferSize pending records.
+func NewAsyncBackend(b Backend, bufferSize int) *AsyncBackend {
+	return &AsyncBackend{
+		log:     ctrl.Log.WithName("audit"),
+		backend: b,
+		records: make(chan *Record, bufferSize),
+	}
+}
+
+// Write enqueues the record, or returns ErrBufferFull when there is no room
+// for it.
+func (b *AsyncBackend) Write(r *Record) error {
+	select {
+	case b.records <- r:
+		return nil
+	default:
+		return ErrBufferFull
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface. The
+// buffer is drained on every replica, as only the replica running the
+// scheduler writes records.
+func (b *AsyncBackend) NeedLeaderElection() bool {
+	return false
+}
+
+// Start writes the buffered records until the context is done, and then
+// writes the records left in the buffer.
+func (b *AsyncBackend) Start(ctx context.Context) error {
+	for {
+		select {
+		case r := <-b.records:
+			b.write(r)
+		case <-ctx.Done():
+			for {
+				select {
+				case r := <-b.records:
+					b.write(r)
+				default:
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func (b *AsyncBackend) write(r *Record) {
+	if err := b.backend.Write(r); err != nil {
+		b.log.Error(err, "Could not write the audit record", "decision", r.Decision, "workload", r.Workload.Namespace+"/"+r.Workload.Name)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type recordingBackend struct {
+	sync.Mutex
+	names []string
+}
+
+func (b *recordingBackend) Write(r *Record) error {
+	b.Lock()
+	defer b.Unlock()
+	b.names = append(b.names, r.Workload.Name)
+	return nil
+}
+
+func TestAsyncBackend(t *testing.T) {
+	recorder := &recordingBackend{}
+	b := NewAsyncBackend(recorder, 2)
+	for _, name := range []string{"a", "b"} {
+		if err := b.Write(&Record{Workload: WorkloadRef{Name: name}}); err != nil {
+			t.Fatalf("Writing record %s: %v", name, err)
+		}
+	}
+	if err := b.Write(&Record{Workload: WorkloadRef{Name: "c"}}); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("Unexpected error writing to a full buffer, want %v, got %v", ErrBufferFull, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("Starting the backend: %v", err)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, recorder.names); diff != "" {
+		t.Errorf("Unexpected written records (-want,+got):\n%s", diff)
+	}
+}
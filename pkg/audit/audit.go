@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// DecisionType is the kind of scheduling decision a record describes.
+type DecisionType string
+
+const (
+	// DecisionAdmission is recorded when a workload gets quota reserved in a
+	// ClusterQueue.
+	DecisionAdmission DecisionType = "Admission"
+	// DecisionPreemption is recorded when workloads are preempted to make room
+	// for a pending workload.
+	DecisionPreemption DecisionType = "Preemption"
+)
+
+// Backend persists the decision records.
+type Backend interface {
+	Write(*Record) error
+}
+
+// Record is a structured description of a scheduling decision, including the
+// resource usage the decision was based on.
+type Record struct {
+	Time         time.Time    `json:"time"`
+	Decision     DecisionType `json:"decision"`
+	Workload     WorkloadRef  `json:"workload"`
+	ClusterQueue string       `json:"clusterQueue"`
+	Cohort       string       `json:"cohort,omitempty"`
+	Message      string       `json:"message,omitempty"`
+	// PodSets holds the flavors assigned to the workload.
+	PodSets []PodSet `json:"podSets,omitempty"`
+	// ClusterQueueUsage is the usage of the ClusterQueue before the decision.
+	ClusterQueueUsage Usage `json:"clusterQueueUsage,omitempty"`
+	// CohortUsage is the usage of the cohort before the decision.
+	CohortUsage Usage `json:"cohortUsage,omitempty"`
+	// Targets are the workloads preempted by the decision.
+	Targets []WorkloadRef `json:"targets,omitempty"`
+}
+
+// WorkloadRef identifies a workload in a decision record.
+type WorkloadRef struct {
+	Namespace    string    `json:"namespace"`
+	Name         string    `json:"name"`
+	UID          types.UID `json:"uid,omitempty"`
+	LocalQueue   string    `json:"localQueue,omitempty"`
+	ClusterQueue string    `json:"clusterQueue,omitempty"`
+	Priority     *int32    `json:"priority,omitempty"`
+}
+
+// PodSet is the flavor assignment of a podSet.
+type PodSet struct {
+	Name          string                                                `json:"name"`
+	Count         int32                                                 `json:"count"`
+	Flavors       map[corev1.ResourceName]kueue.ResourceFlavorReference `json:"flavors,omitempty"`
+	ResourceUsage corev1.ResourceList                                   `json:"resourceUsage,omitempty"`
+}
+
+// Usage is the resource usage per flavor.
+type Usage map[kueue.ResourceFlavorReference]corev1.ResourceList
+
+// NewWorkloadRef returns the reference to the workload.
+func NewWorkloadRef(wl *kueue.Workload) WorkloadRef {
+	ref := WorkloadRef{
+		Namespace:  wl.Namespace,
+		Name:       wl.Name,
+		UID:        wl.UID,
+		LocalQueue: wl.Spec.QueueName,
+		Priority:   wl.Spec.Priority,
+	}
+	if wl.Status.Admission != nil {
+		ref.ClusterQueue = string(wl.Status.Admission.ClusterQueue)
+	}
+	return ref
+}
+
+// NewPodSets returns the podSets of the admission.
+func NewPodSets(admission *kueue.Admission) []PodSet {
+	if admission == nil {
+		return nil
+	}
+	podSets := make([]PodSet, 0, len(admission.PodSetAssignments))
+	for _, psa := range admission.PodSetAssignments {
+		ps := PodSet{
+			Name:          psa.Name,
+			Flavors:       psa.Flavors,
+			ResourceUsage: psa.ResourceUsage,
+		}
+		if psa.Count != nil {
+			ps.Count = *psa.Count
+		}
+		podSets = append(podSets, ps)
+	}
+	return podSets
+}
+
+// NewUsage converts the usage as tracked by the cache into quantities.
+func NewUsage(usage map[kueue.ResourceFlavorReference]map[corev1.ResourceName]int64) Usage {
+	if len(usage) == 0 {
+		return nil
+	}
+	ret := make(Usage, len(usage))
+	for flavor, resources := range usage {
+		rl := make(corev1.ResourceList, len(resources))
+		for name, v := range resources {
+			rl[name] = workload.ResourceQuantity(name, v)
+		}
+		ret[flavor] = rl
+	}
+	return ret
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileBackend appends the records to a file, one JSON object per line. The
+// file is rotated once it exceeds the maximum size, keeping up to maxBackups
+// rotated files, named <path>.1 (the most recent) to <path>.<maxBackups>.
+type FileBackend struct {
+	sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+var _ Backend = (*FileBackend)(nil)
+
+// NewFileBackend opens, or creates, the file at path.
+func NewFileBackend(path string, maxSize int64, maxBackups int) (*FileBackend, error) {
+	b := &FileBackend{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	if err := b.open(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *FileBackend) open() error {
+	f, err := os.OpenFile(b.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	b.file = f
+	b.size = info.Size()
+	return nil
+}
+
+// Write appends the record to the file.
+func (b *FileBackend) Write(r *Record) error {
+	return b.Append(r)
+}
+
+// Append appends the JSON encoding of v to the file, so that other kinds of
+// records can share the rotation of the file.
+func (b *FileBackend) Append(v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	b.Lock()
+	defer b.Unlock()
+	if b.size > 0 && b.size+int64(len(line)) > b.maxSize {
+		if err := b.rotate(); err != nil {
+			return fmt.Errorf("rotating %s: %w", b.path, err)
+		}
+	}
+	n, err := b.file.Write(line)
+	b.size += int64(n)
+	return err
+}
+
+func (b *FileBackend) rotate() error {
+	if err := b.file.Close(); err != nil {
+		return err
+	}
+	if b.maxBackups == 0 {
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return b.open()
+	}
+	for i := b.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(b.backupName(i), b.backupName(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(b.path, b.backupName(1)); err != nil {
+		return err
+	}
+	return b.open()
+}
+
+func (b *FileBackend) backupName(i int) string {
+	return fmt.Sprintf("%s.%d", b.path, i)
+}
+
+// Close closes the file.
+func (b *FileBackend) Close() error {
+	b.Lock()
+	defer b.Unlock()
+	return b.file.Close()
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func readNames(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatalf("Opening %s: %v", path, err)
+	}
+	defer f.Close()
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatalf("Decoding record: %v", err)
+		}
+		names = append(names, r.Workload.Name)
+	}
+	return names
+}
+
+func TestFileBackend(t *testing.T) {
+	record := func(name string) *Record {
+		return &Record{
+			Time:         time.Date(2023, time.November, 1, 0, 0, 0, 0, time.UTC),
+			Decision:     DecisionAdmission,
+			Workload:     WorkloadRef{Namespace: "ns", Name: name},
+			ClusterQueue: "cq",
+			ClusterQueueUsage: Usage{
+				"default": corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		}
+	}
+	oneRecord, err := json.Marshal(record("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Fits two records per file.
+	maxSize := int64(2*(len(oneRecord)+1) + 1)
+
+	cases := map[string]struct {
+		maxBackups int
+		names      []string
+		wantFiles  map[string][]string
+	}{
+		"no rotation": {
+			maxBackups: 1,
+			names:      []string{"a", "b"},
+			wantFiles: map[string][]string{
+				"audit.log": {"a", "b"},
+			},
+		},
+		"rotation keeps backups": {
+			maxBackups: 2,
+			names:      []string{"a", "b", "c", "d", "e", "f", "g"},
+			wantFiles: map[string][]string{
+				"audit.log":   {"g"},
+				"audit.log.1": {"e", "f"},
+				"audit.log.2": {"c", "d"},
+			},
+		},
+		"rotation without backups": {
+			maxBackups: 0,
+			names:      []string{"a", "b", "c"},
+			wantFiles: map[string][]string{
+				"audit.log": {"c"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "audit.log")
+			b, err := NewFileBackend(path, maxSize, tc.maxBackups)
+			if err != nil {
+				t.Fatalf("Creating backend: %v", err)
+			}
+			for _, n := range tc.names {
+				if err := b.Write(record(n)); err != nil {
+					t.Fatalf("Writing record: %v", err)
+				}
+			}
+			if err := b.Close(); err != nil {
+				t.Fatalf("Closing backend: %v", err)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotFiles := make(map[string][]string, len(entries))
+			for _, e := range entries {
+				gotFiles[e.Name()] = readNames(t, filepath.Join(dir, e.Name()))
+			}
+			if diff := cmp.Diff(tc.wantFiles, gotFiles); diff != "" {
+				t.Errorf("Unexpected files (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewUsage(t *testing.T) {
+	got := NewUsage(map[kueue.ResourceFlavorReference]map[corev1.ResourceName]int64{
+		"default": {
+			corev1.ResourceCPU:    1500,
+			corev1.ResourceMemory: 1024,
+		},
+	})
+	want := Usage{
+		"default": corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1500m"),
+			corev1.ResourceMemory: resource.MustParse("1Ki"),
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+	}
+}
@@ -20,10 +20,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -68,21 +71,29 @@ func WithPodsReadyTracking(f bool) Option {
 
 var defaultOptions = options{}
 
+// Lister lists the LocalQueues and the Workloads of a ClusterQueue when it is
+// added to the cache, matching the indexed fields
+// indexer.QueueClusterQueueKey and indexer.WorkloadClusterQueueKey.
+type Lister interface {
+	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
+}
+
 // Cache keeps track of the Workloads that got admitted through ClusterQueues.
 type Cache struct {
 	sync.RWMutex
 	podsReadyCond sync.Cond
 
-	client            client.Client
+	client            Lister
 	clusterQueues     map[string]*ClusterQueue
 	cohorts           map[string]*Cohort
 	assumedWorkloads  map[string]string
 	resourceFlavors   map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	podsReadyTracking bool
 	admissionChecks   map[string]AdmissionCheck
+	priorityClasses   map[string]WorkloadPriorityClass
 }
 
-func New(client client.Client, opts ...Option) *Cache {
+func New(client Lister, opts ...Option) *Cache {
 	options := defaultOptions
 	for _, opt := range opts {
 		opt(&options)
@@ -94,6 +105,7 @@ func New(client client.Client, opts ...Option) *Cache {
 		assumedWorkloads:  make(map[string]string),
 		resourceFlavors:   make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
 		admissionChecks:   make(map[string]AdmissionCheck),
+		priorityClasses:   make(map[string]WorkloadPriorityClass),
 		podsReadyTracking: options.podsReadyTracking,
 	}
 	c.podsReadyCond.L = &c.RWMutex
@@ -118,16 +130,12 @@ func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*ClusterQueue, error) {
 // WaitForPodsReady waits for all admitted workloads to be in the PodsReady condition
 // if podsReadyTracking is enabled, otherwise returns immediately.
 func (c *Cache) WaitForPodsReady(ctx context.Context) {
-	if !c.podsReadyTracking {
-		return
-	}
-
 	c.Lock()
 	defer c.Unlock()
 
 	log := ctrl.LoggerFrom(ctx)
 	for {
-		if c.podsReadyForAllAdmittedWorkloads(log) {
+		if !c.podsReadyTracking || c.podsReadyForAllAdmittedWorkloads(log) {
 			return
 		}
 		log.V(3).Info("Blocking admission as not all workloads are in the PodsReady condition")
@@ -143,12 +151,9 @@ func (c *Cache) WaitForPodsReady(ctx context.Context) {
 }
 
 func (c *Cache) PodsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
-	if !c.podsReadyTracking {
-		return true
-	}
 	c.Lock()
 	defer c.Unlock()
-	return c.podsReadyForAllAdmittedWorkloads(log)
+	return !c.podsReadyTracking || c.podsReadyForAllAdmittedWorkloads(log)
 }
 
 func (c *Cache) podsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
@@ -172,6 +177,23 @@ func (c *Cache) CleanUpOnContext(ctx context.Context) {
 	c.podsReadyCond.Broadcast()
 }
 
+// SetPodsReadyTracking changes whether the cache tracks the PodsReady
+// condition of the admitted workloads, for a reloaded configuration. The
+// routines waiting on the condition are woken up, so that they stop blocking
+// the admission if the tracking is disabled.
+func (c *Cache) SetPodsReadyTracking(f bool) {
+	c.Lock()
+	defer c.Unlock()
+	if c.podsReadyTracking == f {
+		return
+	}
+	c.podsReadyTracking = f
+	for _, cq := range c.clusterQueues {
+		cq.setPodsReadyTracking(f)
+	}
+	c.podsReadyCond.Broadcast()
+}
+
 func (c *Cache) updateClusterQueues() sets.Set[string] {
 	cqs := sets.New[string]()
 
@@ -204,6 +226,34 @@ func (c *Cache) DeleteResourceFlavor(rf *kueue.ResourceFlavor) sets.Set[string]
 	return c.updateClusterQueues()
 }
 
+// AddOrUpdateWorkloadPriorityClass updates the preemption semantics of the
+// WorkloadPriorityClass. It returns whether they changed.
+func (c *Cache) AddOrUpdateWorkloadPriorityClass(wpc *kueue.WorkloadPriorityClass) bool {
+	c.Lock()
+	defer c.Unlock()
+	newClass := newWorkloadPriorityClass(wpc)
+	oldClass, found := c.priorityClasses[wpc.Name]
+	c.priorityClasses[wpc.Name] = newClass
+	return !found || oldClass != newClass
+}
+
+func (c *Cache) DeleteWorkloadPriorityClass(wpc *kueue.WorkloadPriorityClass) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.priorityClasses, wpc.Name)
+}
+
+// ClusterQueueNames returns the names of all the ClusterQueues.
+func (c *Cache) ClusterQueueNames() sets.Set[string] {
+	c.RLock()
+	defer c.RUnlock()
+	names := sets.New[string]()
+	for name := range c.clusterQueues {
+		names.Insert(name)
+	}
+	return names
+}
+
 func (c *Cache) AddOrUpdateAdmissionCheck(ac *kueue.AdmissionCheck) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
@@ -243,6 +293,30 @@ func (c *Cache) ClusterQueueReadiness(name string) (metav1.ConditionStatus, stri
 	return metav1.ConditionFalse, reason, msg
 }
 
+// PreemptionMinRuntime returns the time since their admission during which the
+// workloads of the ClusterQueue can't be preempted.
+func (c *Cache) PreemptionMinRuntime(name string) time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.clusterQueues[name]
+	if cq == nil || cq.Preemption.MinRuntime == nil {
+		return 0
+	}
+	return cq.Preemption.MinRuntime.Duration
+}
+
+// PreemptionGracePeriod returns the time between the selection of a workload
+// of the ClusterQueue as a preemption target and its eviction.
+func (c *Cache) PreemptionGracePeriod(name string) time.Duration {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.clusterQueues[name]
+	if cq == nil || cq.Preemption.GracePeriod == nil {
+		return 0
+	}
+	return cq.Preemption.GracePeriod.Duration
+}
+
 func (c *Cache) clusterQueueInStatus(name string, status metrics.ClusterQueueStatus) bool {
 	c.RLock()
 	defer c.RUnlock()
@@ -353,7 +427,9 @@ func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 	if cqImpl.Cohort.Name != cq.Spec.Cohort {
 		c.deleteClusterQueueFromCohort(cqImpl)
 		c.addClusterQueueToCohort(cqImpl, cq.Spec.Cohort)
+		return nil
 	}
+	cqImpl.Cohort.resolveNominalQuotas()
 	return nil
 }
 
@@ -548,6 +624,7 @@ type ClusterQueueUsageStats struct {
 	ReservingWorkloads int
 	AdmittedResources  []kueue.FlavorUsage
 	AdmittedWorkloads  int
+	NominalQuotas      []kueue.FlavorNominalQuota
 }
 
 // Usage reports the reserved and admitted resources and number of workloads holding them in the ClusterQueue.
@@ -565,9 +642,124 @@ func (c *Cache) Usage(cqObj *kueue.ClusterQueue) (*ClusterQueueUsageStats, error
 		ReservingWorkloads: len(cq.Workloads),
 		AdmittedResources:  getUsage(cq.AdmittedUsage, cq.ResourceGroups, cq.Cohort),
 		AdmittedWorkloads:  cq.admittedWorkloadsCount,
+		NominalQuotas:      cq.resolvedNominalQuotas(),
 	}, nil
 }
 
+// CohortShareStats holds the share of the cohort quota of a ClusterQueue.
+type CohortShareStats struct {
+	// FairShare is the fraction of the nominal quota of the cohort owned by
+	// the ClusterQueue, per resource.
+	FairShare map[corev1.ResourceName]float64
+	// Utilization is the fraction of the nominal quota of the cohort
+	// reserved by the ClusterQueue, per resource.
+	Utilization map[corev1.ResourceName]float64
+	// WeightedShare is the utilization of the dominant resource relative to
+	// its fair share, that is, the maximum ratio between the utilization and
+	// the fair share across resources. It is +Inf when the ClusterQueue
+	// reserves a resource for which it has no nominal quota.
+	WeightedShare float64
+}
+
+// CohortShare reports the share of the cohort quota of the ClusterQueue. It
+// returns nil if the ClusterQueue doesn't belong to a cohort.
+func (c *Cache) CohortShare(cqObj *kueue.ClusterQueue) (*CohortShareStats, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.clusterQueues[cqObj.Name]
+	if cq == nil {
+		return nil, errCqNotFound
+	}
+	if cq.Cohort == nil {
+		return nil, nil
+	}
+	return cohortShare(cq, cohortNominalPerResource(cq.Cohort)), nil
+}
+
+// CohortShares reports the share of the cohort quota of every active
+// ClusterQueue in the cohort of the ClusterQueue, by name, as a change in the
+// usage or the quotas of a member can change the share of the others. It
+// returns nil if the ClusterQueue doesn't belong to a cohort.
+func (c *Cache) CohortShares(cqObj *kueue.ClusterQueue) (map[string]*CohortShareStats, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.clusterQueues[cqObj.Name]
+	if cq == nil {
+		return nil, errCqNotFound
+	}
+	if cq.Cohort == nil {
+		return nil, nil
+	}
+	cohortNominal := cohortNominalPerResource(cq.Cohort)
+	shares := make(map[string]*CohortShareStats, cq.Cohort.Members.Len())
+	for member := range cq.Cohort.Members {
+		if member.Active() {
+			shares[member.Name] = cohortShare(member, cohortNominal)
+		}
+	}
+	return shares, nil
+}
+
+func cohortNominalPerResource(cohort *Cohort) map[corev1.ResourceName]int64 {
+	cohortNominal := make(map[corev1.ResourceName]int64)
+	for member := range cohort.Members {
+		if !member.Active() {
+			continue
+		}
+		for r, v := range nominalPerResource(member.ResourceGroups) {
+			cohortNominal[r] += v
+		}
+	}
+	return cohortNominal
+}
+
+func cohortShare(cq *ClusterQueue, cohortNominal map[corev1.ResourceName]int64) *CohortShareStats {
+	cqNominal := nominalPerResource(cq.ResourceGroups)
+	cqUsage := make(map[corev1.ResourceName]int64)
+	for _, resources := range cq.Usage {
+		for r, v := range resources {
+			cqUsage[r] += v
+		}
+	}
+
+	stats := &CohortShareStats{
+		FairShare:   make(map[corev1.ResourceName]float64, len(cqNominal)),
+		Utilization: make(map[corev1.ResourceName]float64, len(cqNominal)),
+	}
+	for r, total := range cohortNominal {
+		if total == 0 {
+			continue
+		}
+		fairShare := float64(cqNominal[r]) / float64(total)
+		utilization := float64(cqUsage[r]) / float64(total)
+		stats.FairShare[r] = fairShare
+		stats.Utilization[r] = utilization
+		if utilization == 0 {
+			continue
+		}
+		weighted := math.Inf(1)
+		if fairShare > 0 {
+			weighted = utilization / fairShare
+		}
+		stats.WeightedShare = max(stats.WeightedShare, weighted)
+	}
+	return stats
+}
+
+func nominalPerResource(rgs []ResourceGroup) map[corev1.ResourceName]int64 {
+	nominal := make(map[corev1.ResourceName]int64)
+	for _, rg := range rgs {
+		for _, flvQuotas := range rg.Flavors {
+			for r, q := range flvQuotas.Resources {
+				nominal[r] += q.Nominal
+			}
+		}
+	}
+	return nominal
+}
+
 func getUsage(frq FlavorResourceQuantities, rgs []ResourceGroup, cohort *Cohort) []kueue.FlavorUsage {
 	usage := make([]kueue.FlavorUsage, 0, len(frq))
 	for _, rg := range rgs {
@@ -694,6 +886,7 @@ func (c *Cache) addClusterQueueToCohort(cq *ClusterQueue, cohortName string) {
 	}
 	cohort.Members.Insert(cq)
 	cq.Cohort = cohort
+	cohort.resolveNominalQuotas()
 }
 
 func (c *Cache) deleteClusterQueueFromCohort(cq *ClusterQueue) {
@@ -704,7 +897,9 @@ func (c *Cache) deleteClusterQueueFromCohort(cq *ClusterQueue) {
 	if cq.Cohort.Members.Len() == 0 {
 		delete(c.cohorts, cq.Cohort.Name)
 	}
+	cq.Cohort.resolveNominalQuotas()
 	cq.Cohort = nil
+	cq.resetNominalQuotas()
 }
 
 func (c *Cache) ClusterQueuesUsingFlavor(flavor string) []string {
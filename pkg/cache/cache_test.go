@@ -19,7 +19,9 @@ package cache
 import (
 	"context"
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -29,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -1904,6 +1907,140 @@ func TestClusterQueueUsage(t *testing.T) {
 	}
 }
 
+func TestClusterQueueCohortShare(t *testing.T) {
+	cqA := utiltesting.MakeClusterQueue("a").
+		Cohort("one").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "6").
+				Resource(corev1.ResourceMemory, "0").
+				Obj(),
+		).Obj()
+	cqB := utiltesting.MakeClusterQueue("b").
+		Cohort("one").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Resource(corev1.ResourceMemory, "10Gi").
+				Obj(),
+		).Obj()
+	cqAlone := utiltesting.MakeClusterQueue("alone").
+		ResourceGroup(
+			*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "4").
+				Obj(),
+		).Obj()
+	cases := map[string]struct {
+		clusterQueue *kueue.ClusterQueue
+		workloads    []*kueue.Workload
+		want         *CohortShareStats
+	}{
+		"no cohort": {
+			clusterQueue: cqAlone,
+		},
+		"within fair share": {
+			clusterQueue: cqA,
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("one", "").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("a").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+					Obj(),
+			},
+			want: &CohortShareStats{
+				FairShare: map[corev1.ResourceName]float64{
+					corev1.ResourceCPU:    0.6,
+					corev1.ResourceMemory: 0,
+				},
+				Utilization: map[corev1.ResourceName]float64{
+					corev1.ResourceCPU:    0.3,
+					corev1.ResourceMemory: 0,
+				},
+				WeightedShare: 0.5,
+			},
+		},
+		"borrowing": {
+			clusterQueue: cqB,
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("one", "").
+					Request(corev1.ResourceCPU, "8").
+					ReserveQuota(utiltesting.MakeAdmission("b").Assignment(corev1.ResourceCPU, "default", "8").Obj()).
+					Obj(),
+			},
+			want: &CohortShareStats{
+				FairShare: map[corev1.ResourceName]float64{
+					corev1.ResourceCPU:    0.4,
+					corev1.ResourceMemory: 1,
+				},
+				Utilization: map[corev1.ResourceName]float64{
+					corev1.ResourceCPU:    0.8,
+					corev1.ResourceMemory: 0,
+				},
+				WeightedShare: 2,
+			},
+		},
+		"using a resource without nominal quota": {
+			clusterQueue: cqA,
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("one", "").
+					Request(corev1.ResourceMemory, "1Gi").
+					ReserveQuota(utiltesting.MakeAdmission("a").Assignment(corev1.ResourceMemory, "default", "1Gi").Obj()).
+					Obj(),
+			},
+			want: &CohortShareStats{
+				FairShare: map[corev1.ResourceName]float64{
+					corev1.ResourceCPU:    0.6,
+					corev1.ResourceMemory: 0,
+				},
+				Utilization: map[corev1.ResourceName]float64{
+					corev1.ResourceCPU:    0,
+					corev1.ResourceMemory: 0.1,
+				},
+				WeightedShare: math.Inf(1),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			ctx := context.Background()
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range []*kueue.ClusterQueue{cqA, cqB, cqAlone} {
+				if err := cache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Adding ClusterQueue: %v", err)
+				}
+			}
+			for _, w := range tc.workloads {
+				if added := cache.AddOrUpdateWorkload(w); !added {
+					t.Fatalf("Workload %s was not added", workload.Key(w))
+				}
+			}
+			got, err := cache.CohortShare(tc.clusterQueue)
+			if err != nil {
+				t.Fatalf("Couldn't get the cohort share: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Unexpected cohort share (-want,+got):\n%s", diff)
+			}
+			shares, err := cache.CohortShares(tc.clusterQueue)
+			if err != nil {
+				t.Fatalf("Couldn't get the cohort shares: %v", err)
+			}
+			if tc.want == nil {
+				if shares != nil {
+					t.Errorf("Unexpected cohort shares for a ClusterQueue without cohort: %v", shares)
+				}
+				return
+			}
+			if diff := cmp.Diff([]string{"a", "b"}, sets.List(sets.KeySet(shares))); diff != "" {
+				t.Errorf("Unexpected ClusterQueues in the cohort shares (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, shares[tc.clusterQueue.Name], cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("Unexpected cohort share in the cohort shares (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestLocalQueueUsage(t *testing.T) {
 	cq := *utiltesting.MakeClusterQueue("foo").
 		ResourceGroup(
@@ -2650,6 +2787,53 @@ func TestWaitForPodsReadyCancelled(t *testing.T) {
 	cache.WaitForPodsReady(ctx)
 }
 
+func TestSetPodsReadyTracking(t *testing.T) {
+	cache := New(utiltesting.NewFakeClient())
+	ctx := context.Background()
+	log := ctrl.LoggerFrom(ctx)
+
+	cq := utiltesting.MakeClusterQueue("one").Obj()
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue: %v", err)
+	}
+	cache.AddOrUpdateWorkload(utiltesting.MakeWorkload("a", "").ReserveQuota(&kueue.Admission{
+		ClusterQueue: "one",
+	}).Obj())
+	cache.AddOrUpdateWorkload(utiltesting.MakeWorkload("b", "").ReserveQuota(&kueue.Admission{
+		ClusterQueue: "one",
+	}).Condition(metav1.Condition{
+		Type:   kueue.WorkloadPodsReady,
+		Status: metav1.ConditionTrue,
+	}).Obj())
+
+	if !cache.PodsReadyForAllAdmittedWorkloads(log) {
+		t.Errorf("Unexpected workloads not in the PodsReady condition before enabling the tracking")
+	}
+
+	cache.SetPodsReadyTracking(true)
+	if cache.PodsReadyForAllAdmittedWorkloads(log) {
+		t.Errorf("Unexpected that all admitted workloads are in PodsReady condition after enabling the tracking")
+	}
+	if diff := cmp.Diff(sets.New("/a"), cache.clusterQueues["one"].WorkloadsNotReady); diff != "" {
+		t.Errorf("Unexpected workloads not ready (-want,+got):\n%s", diff)
+	}
+
+	waited := make(chan struct{})
+	go func() {
+		cache.WaitForPodsReady(ctx)
+		close(waited)
+	}()
+	cache.SetPodsReadyTracking(false)
+	select {
+	case <-waited:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("WaitForPodsReady didn't return after disabling the tracking")
+	}
+	if !cache.PodsReadyForAllAdmittedWorkloads(log) {
+		t.Errorf("Unexpected workloads not in the PodsReady condition after disabling the tracking")
+	}
+}
+
 // TestCachePodsReadyForAllAdmittedWorkloads verifies the condition used to determine whether to wait
 func TestCachePodsReadyForAllAdmittedWorkloads(t *testing.T) {
 	clusterQueues := []kueue.ClusterQueue{
@@ -3118,7 +3302,7 @@ func TestClusterQueueReadiness(t *testing.T) {
 			clusterQueueName: "queue1",
 			wantStatus:       metav1.ConditionFalse,
 			wantReason:       "FlavorNotFound",
-			wantMessage:      "Can't admit new workloads: FlavorNotFound",
+			wantMessage:      "Can't admit new workloads: FlavorNotFound; flavors not found: flavor1 (resourceGroups[0])",
 		},
 		"check not found": {
 			clusterQueues:    []*kueue.ClusterQueue{baseQueue},
@@ -3126,7 +3310,7 @@ func TestClusterQueueReadiness(t *testing.T) {
 			clusterQueueName: "queue1",
 			wantStatus:       metav1.ConditionFalse,
 			wantReason:       "CheckNotFoundOrInactive",
-			wantMessage:      "Can't admit new workloads: CheckNotFoundOrInactive",
+			wantMessage:      "Can't admit new workloads: CheckNotFoundOrInactive; admission checks not found: check1",
 		},
 		"check inactive": {
 			clusterQueues:    []*kueue.ClusterQueue{baseQueue},
@@ -3135,14 +3319,37 @@ func TestClusterQueueReadiness(t *testing.T) {
 			clusterQueueName: "queue1",
 			wantStatus:       metav1.ConditionFalse,
 			wantReason:       "CheckNotFoundOrInactive",
-			wantMessage:      "Can't admit new workloads: CheckNotFoundOrInactive",
+			wantMessage:      "Can't admit new workloads: CheckNotFoundOrInactive; admission checks inactive: check1",
 		},
 		"flavor and check not found": {
 			clusterQueues:    []*kueue.ClusterQueue{baseQueue},
 			clusterQueueName: "queue1",
 			wantStatus:       metav1.ConditionFalse,
 			wantReason:       "FlavorNotFound",
-			wantMessage:      "Can't admit new workloads: FlavorNotFound, CheckNotFoundOrInactive",
+			wantMessage:      "Can't admit new workloads: FlavorNotFound, CheckNotFoundOrInactive; flavors not found: flavor1 (resourceGroups[0]); admission checks not found: check1",
+		},
+		"flavors in several resource groups and checks not found or inactive": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("queue1").
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas(baseFalvor.Name).Resource(corev1.ResourceCPU, "10").Obj(),
+						*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "10").Obj(),
+					).
+					ResourceGroup(
+						*utiltesting.MakeFlavorQuotas("spot").Resource("example.com/gpu", "2").Obj(),
+						*utiltesting.MakeFlavorQuotas("a100").Resource("example.com/gpu", "2").Obj(),
+					).
+					AdmissionChecks(baseCheck.Name, "check2", "check3").
+					Obj(),
+			},
+			resourceFlavors:  []*kueue.ResourceFlavor{baseFalvor},
+			admissionChecks:  []*kueue.AdmissionCheck{baseCheck, utiltesting.MakeAdmissionCheck("check2").Obj()},
+			clusterQueueName: "queue1",
+			wantStatus:       metav1.ConditionFalse,
+			wantReason:       "FlavorNotFound",
+			wantMessage: "Can't admit new workloads: FlavorNotFound, CheckNotFoundOrInactive; " +
+				"flavors not found: a100 (resourceGroups[1]), spot (resourceGroups[0], resourceGroups[1]); " +
+				"admission checks not found: check3; admission checks inactive: check2",
 		},
 		"terminating": {
 			clusterQueues:    []*kueue.ClusterQueue{baseQueue},
@@ -1,20 +1,23 @@
 package cache
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/util/namespaceselector"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -33,11 +36,13 @@ type ClusterQueue struct {
 	AdmittedUsage     FlavorResourceQuantities
 	Workloads         map[string]*workload.Info
 	WorkloadsNotReady sets.Set[string]
-	NamespaceSelector labels.Selector
+	NamespaceSelector namespaceselector.Selector
 	Preemption        kueue.ClusterQueuePreemption
 	FlavorFungibility kueue.FlavorFungibility
 	AdmissionChecks   sets.Set[string]
 	Status            metrics.ClusterQueueStatus
+	// QuotaTiers are sorted by descending minPriority.
+	QuotaTiers []kueue.QuotaTier
 	// AllocatableResourceGeneration will be increased when some admitted workloads are
 	// deleted, or the resource groups are changed.
 	AllocatableResourceGeneration int64
@@ -49,8 +54,26 @@ type ClusterQueue struct {
 	podsReadyTracking                   bool
 	hasMissingFlavors                   bool
 	hasMissingOrInactiveAdmissionChecks bool
-	admittedWorkloadsCount              int
-	isStopped                           bool
+	// missingFlavors holds the indexes of the resource groups referencing
+	// each flavor that is not found.
+	missingFlavors          map[kueue.ResourceFlavorReference][]int
+	missingAdmissionChecks  sets.Set[string]
+	inactiveAdmissionChecks sets.Set[string]
+	admittedWorkloadsCount  int
+	isStopped               bool
+	// workloadsGeneration is increased when Workloads or Usage change. In a
+	// snapshot, it holds the generation of origin that was copied.
+	workloadsGeneration int64
+	// origin is the ClusterQueue that a snapshot was copied from.
+	origin *ClusterQueue
+	// configuredNominal holds the nominal quotas in the spec, and
+	// nominalQuotaPercents the ones expressed as a percentage of the cohort
+	// capacity.
+	configuredNominal    FlavorResourceQuantities
+	nominalQuotaPercents FlavorResourceQuantities
+	// tierUsage holds, for each of the QuotaTiers, the usage by the workloads
+	// with a priority lower than the minPriority of the tier.
+	tierUsage []FlavorResourceQuantities
 }
 
 // Cohort is a set of ClusterQueues that can borrow resources from each other.
@@ -73,6 +96,24 @@ type ResourceGroup struct {
 	// Those keys define the affinity terms of a workload
 	// that can be matched against the flavors.
 	LabelKeys sets.Set[string]
+	// FlavorFungibility overrides the flavorFungibility of the ClusterQueue,
+	// if not nil.
+	FlavorFungibility *kueue.FlavorFungibility
+	// ResourceFlavorFungibility overrides the flavorFungibility of the group
+	// for individual resources.
+	ResourceFlavorFungibility map[corev1.ResourceName]kueue.FlavorFungibility
+}
+
+// FlavorFungibilityFor returns the flavorFungibility that applies to the
+// resource, given the one of the ClusterQueue.
+func (rg *ResourceGroup) FlavorFungibilityFor(rName corev1.ResourceName, cqFungibility kueue.FlavorFungibility) kueue.FlavorFungibility {
+	if f, found := rg.ResourceFlavorFungibility[rName]; found {
+		return f
+	}
+	if rg.FlavorFungibility != nil {
+		return *rg.FlavorFungibility
+	}
+	return cqFungibility
 }
 
 // FlavorQuotas holds a processed ClusterQueue flavor quota.
@@ -153,7 +194,7 @@ var defaultFlavorFungibility = kueue.FlavorFungibility{WhenCanBorrow: kueue.Borr
 
 func (c *ClusterQueue) update(in *kueue.ClusterQueue, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, admissionChecks map[string]AdmissionCheck) error {
 	c.updateResourceGroups(in.Spec.ResourceGroups)
-	nsSelector, err := metav1.LabelSelectorAsSelector(in.Spec.NamespaceSelector)
+	nsSelector, err := namespaceselector.ForClusterQueue(&in.Spec)
 	if err != nil {
 		return err
 	}
@@ -164,6 +205,7 @@ func (c *ClusterQueue) update(in *kueue.ClusterQueue, resourceFlavors map[kueue.
 	c.AdmissionChecks = sets.New(in.Spec.AdmissionChecks...)
 
 	c.Usage = filterQuantities(c.Usage, in.Spec.ResourceGroups)
+	c.workloadsGeneration++
 	c.AdmittedUsage = filterQuantities(c.AdmittedUsage, in.Spec.ResourceGroups)
 	c.UpdateWithFlavors(resourceFlavors)
 	c.updateWithAdmissionChecks(admissionChecks)
@@ -175,20 +217,84 @@ func (c *ClusterQueue) update(in *kueue.ClusterQueue, resourceFlavors map[kueue.
 	}
 
 	if in.Spec.FlavorFungibility != nil {
-		c.FlavorFungibility = *in.Spec.FlavorFungibility
-		if c.FlavorFungibility.WhenCanBorrow == "" {
-			c.FlavorFungibility.WhenCanBorrow = defaultFlavorFungibility.WhenCanBorrow
-		}
-		if c.FlavorFungibility.WhenCanPreempt == "" {
-			c.FlavorFungibility.WhenCanPreempt = defaultFlavorFungibility.WhenCanPreempt
-		}
+		c.FlavorFungibility = withFlavorFungibilityDefaults(*in.Spec.FlavorFungibility)
 	} else {
 		c.FlavorFungibility = defaultFlavorFungibility
 	}
 
+	c.QuotaTiers = nil
+	if len(in.Spec.QuotaTiers) > 0 {
+		c.QuotaTiers = slices.Clone(in.Spec.QuotaTiers)
+		slices.SortFunc(c.QuotaTiers, func(a, b kueue.QuotaTier) int {
+			return cmp.Compare(b.MinPriority, a.MinPriority)
+		})
+	}
+	c.resetTierUsage(in.Spec.ResourceGroups)
+
 	return nil
 }
 
+// resetTierUsage recomputes the usage of the quota tiers from the workloads
+// of the ClusterQueue.
+func (c *ClusterQueue) resetTierUsage(resourceGroups []kueue.ResourceGroup) {
+	c.tierUsage = nil
+	if len(c.QuotaTiers) == 0 {
+		return
+	}
+	c.tierUsage = make([]FlavorResourceQuantities, len(c.QuotaTiers))
+	for i := range c.tierUsage {
+		c.tierUsage[i] = filterQuantities(nil, resourceGroups)
+	}
+	for _, wi := range c.Workloads {
+		c.updateTierUsage(wi, 1)
+	}
+}
+
+// updateTierUsage updates the usage of the quota tiers whose minPriority is
+// higher than the priority of the workload.
+func (c *ClusterQueue) updateTierUsage(wi *workload.Info, m int64) {
+	if len(c.tierUsage) == 0 {
+		return
+	}
+	wlPriority := priority.Priority(wi.Obj)
+	for i, tier := range c.QuotaTiers {
+		if tier.MinPriority <= wlPriority {
+			break
+		}
+		updateUsage(wi, c.tierUsage[i], m)
+	}
+}
+
+// ExceededQuotaTier returns the name of the first quota tier whose reserved
+// quota a workload with the priority would use, if val of the resource in the
+// flavor were added to the usage of the workloads with lower priorities than
+// the tier. It also returns the quota that remains for those workloads.
+func (c *ClusterQueue) ExceededQuotaTier(fName kueue.ResourceFlavorReference, rName corev1.ResourceName, nominal, val int64, wlPriority int32) (string, int64, bool) {
+	var reservedPercent int64
+	for i, tier := range c.QuotaTiers {
+		reservedPercent += int64(tier.ReservedQuotaPercent)
+		if tier.MinPriority <= wlPriority {
+			break
+		}
+		limit := nominal * (100 - reservedPercent) / 100
+		used := c.tierUsage[i][fName][rName]
+		if used+val > limit {
+			return tier.Name, max(limit-used, 0), true
+		}
+	}
+	return "", 0, false
+}
+
+func withFlavorFungibilityDefaults(f kueue.FlavorFungibility) kueue.FlavorFungibility {
+	if f.WhenCanBorrow == "" {
+		f.WhenCanBorrow = defaultFlavorFungibility.WhenCanBorrow
+	}
+	if f.WhenCanPreempt == "" {
+		f.WhenCanPreempt = defaultFlavorFungibility.WhenCanPreempt
+	}
+	return f
+}
+
 func filterQuantities(orig FlavorResourceQuantities, resourceGroups []kueue.ResourceGroup) FlavorResourceQuantities {
 	ret := make(FlavorResourceQuantities)
 	for _, rg := range resourceGroups {
@@ -207,12 +313,23 @@ func filterQuantities(orig FlavorResourceQuantities, resourceGroups []kueue.Reso
 func (c *ClusterQueue) updateResourceGroups(in []kueue.ResourceGroup) {
 	oldRG := c.ResourceGroups
 	c.ResourceGroups = make([]ResourceGroup, len(in))
+	c.configuredNominal = make(FlavorResourceQuantities)
+	c.nominalQuotaPercents = make(FlavorResourceQuantities)
 	for i, rgIn := range in {
 		rg := &c.ResourceGroups[i]
 		*rg = ResourceGroup{
 			CoveredResources: sets.New(rgIn.CoveredResources...),
 			Flavors:          make([]FlavorQuotas, 0, len(rgIn.Flavors)),
 		}
+		if rgIn.FlavorFungibility != nil {
+			rg.FlavorFungibility = ptr.To(withFlavorFungibilityDefaults(*rgIn.FlavorFungibility))
+		}
+		if len(rgIn.ResourceFlavorFungibility) > 0 {
+			rg.ResourceFlavorFungibility = make(map[corev1.ResourceName]kueue.FlavorFungibility, len(rgIn.ResourceFlavorFungibility))
+			for _, rf := range rgIn.ResourceFlavorFungibility {
+				rg.ResourceFlavorFungibility[rf.Name] = withFlavorFungibilityDefaults(rf.FlavorFungibility)
+			}
+		}
 		for i := range rgIn.Flavors {
 			fIn := &rgIn.Flavors[i]
 			fQuotas := FlavorQuotas{
@@ -226,6 +343,10 @@ func (c *ClusterQueue) updateResourceGroups(in []kueue.ResourceGroup) {
 				if rIn.BorrowingLimit != nil {
 					rQuota.BorrowingLimit = ptr.To(workload.ResourceValue(rIn.Name, *rIn.BorrowingLimit))
 				}
+				addQuantity(c.configuredNominal, fIn.Name, rIn.Name, rQuota.Nominal)
+				if rIn.NominalQuotaPercent != nil && features.Enabled(features.CohortQuotaShares) {
+					addQuantity(c.nominalQuotaPercents, fIn.Name, rIn.Name, int64(*rIn.NominalQuotaPercent))
+				}
 				fQuotas.Resources[rIn.Name] = &rQuota
 			}
 			rg.Flavors = append(rg.Flavors, fQuotas)
@@ -282,20 +403,52 @@ func (c *ClusterQueue) inactiveReason() (string, string) {
 			return "Unknown", "Can't admit new workloads."
 		}
 
-		return reasons[0], strings.Join([]string{"Can't admit new workloads:", strings.Join(reasons, ", ")}, " ")
+		msg := strings.Join([]string{"Can't admit new workloads:", strings.Join(reasons, ", ")}, " ")
+		if details := c.inactiveDetails(); len(details) > 0 {
+			msg += "; " + strings.Join(details, "; ")
+		}
+		return reasons[0], msg
 	}
 	return "Ready", "Can admit new flavors"
 }
 
+// inactiveDetails enumerates the missing flavors, with the resource groups
+// referencing them, and the missing or inactive admission checks.
+func (c *ClusterQueue) inactiveDetails() []string {
+	var details []string
+	if len(c.missingFlavors) > 0 {
+		flavors := make([]string, 0, len(c.missingFlavors))
+		for _, name := range sets.List(sets.KeySet(c.missingFlavors)) {
+			groups := make([]string, 0, len(c.missingFlavors[name]))
+			for _, i := range c.missingFlavors[name] {
+				groups = append(groups, fmt.Sprintf("resourceGroups[%d]", i))
+			}
+			flavors = append(flavors, fmt.Sprintf("%s (%s)", name, strings.Join(groups, ", ")))
+		}
+		details = append(details, "flavors not found: "+strings.Join(flavors, ", "))
+	}
+	if c.missingAdmissionChecks.Len() > 0 {
+		details = append(details, "admission checks not found: "+strings.Join(sets.List(c.missingAdmissionChecks), ", "))
+	}
+	if c.inactiveAdmissionChecks.Len() > 0 {
+		details = append(details, "admission checks inactive: "+strings.Join(sets.List(c.inactiveAdmissionChecks), ", "))
+	}
+	return details
+}
+
 // UpdateWithFlavors updates a ClusterQueue based on the passed ResourceFlavors set.
 // Exported only for testing.
 func (c *ClusterQueue) UpdateWithFlavors(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) {
-	c.hasMissingFlavors = c.updateLabelKeys(flavors)
+	c.missingFlavors = c.updateLabelKeys(flavors)
+	c.hasMissingFlavors = len(c.missingFlavors) > 0
 	c.updateQueueStatus()
 }
 
-func (c *ClusterQueue) updateLabelKeys(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) bool {
-	var flavorNotFound bool
+// updateLabelKeys updates the label keys of the resource groups and returns
+// the flavors that are not found, with the indexes of the resource groups
+// referencing them.
+func (c *ClusterQueue) updateLabelKeys(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) map[kueue.ResourceFlavorReference][]int {
+	var notFound map[kueue.ResourceFlavorReference][]int
 	for i := range c.ResourceGroups {
 		rg := &c.ResourceGroups[i]
 		if len(rg.Flavors) == 0 {
@@ -309,7 +462,10 @@ func (c *ClusterQueue) updateLabelKeys(flavors map[kueue.ResourceFlavorReference
 					keys.Insert(k)
 				}
 			} else {
-				flavorNotFound = true
+				if notFound == nil {
+					notFound = make(map[kueue.ResourceFlavorReference][]int)
+				}
+				notFound[rf.Name] = append(notFound[rf.Name], i)
 			}
 		}
 
@@ -318,19 +474,22 @@ func (c *ClusterQueue) updateLabelKeys(flavors map[kueue.ResourceFlavorReference
 		}
 	}
 
-	return flavorNotFound
+	return notFound
 }
 
 // updateWithAdmissionChecks updates a ClusterQueue based on the passed AdmissionChecks set.
 func (c *ClusterQueue) updateWithAdmissionChecks(checks map[string]AdmissionCheck) {
-	hasMissing := false
+	c.missingAdmissionChecks = sets.New[string]()
+	c.inactiveAdmissionChecks = sets.New[string]()
 	for acName := range c.AdmissionChecks {
-		if ac, found := checks[acName]; !found || !ac.Active {
-			hasMissing = true
-			break
+		if ac, found := checks[acName]; !found {
+			c.missingAdmissionChecks.Insert(acName)
+		} else if !ac.Active {
+			c.inactiveAdmissionChecks.Insert(acName)
 		}
 	}
 
+	hasMissing := c.missingAdmissionChecks.Len() > 0 || c.inactiveAdmissionChecks.Len() > 0
 	if hasMissing != c.hasMissingOrInactiveAdmissionChecks {
 		c.hasMissingOrInactiveAdmissionChecks = hasMissing
 		c.updateQueueStatus()
@@ -342,9 +501,10 @@ func (c *ClusterQueue) addWorkload(w *kueue.Workload) error {
 	if _, exist := c.Workloads[k]; exist {
 		return fmt.Errorf("workload already exists in ClusterQueue")
 	}
-	wi := workload.NewInfo(w)
+	wi := workload.NewCompactInfo(w)
 	c.Workloads[k] = wi
 	c.updateWorkloadUsage(wi, 1)
+	c.workloadsGeneration++
 	if c.podsReadyTracking && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
 		c.WorkloadsNotReady.Insert(k)
 	}
@@ -352,6 +512,21 @@ func (c *ClusterQueue) addWorkload(w *kueue.Workload) error {
 	return nil
 }
 
+// setPodsReadyTracking changes whether the workloads without the PodsReady
+// condition are tracked, recomputing them from the admitted workloads.
+func (c *ClusterQueue) setPodsReadyTracking(f bool) {
+	c.podsReadyTracking = f
+	c.WorkloadsNotReady = sets.New[string]()
+	if !f {
+		return
+	}
+	for k, wi := range c.Workloads {
+		if !apimeta.IsStatusConditionTrue(wi.Obj.Status.Conditions, kueue.WorkloadPodsReady) {
+			c.WorkloadsNotReady.Insert(k)
+		}
+	}
+}
+
 func (c *ClusterQueue) deleteWorkload(w *kueue.Workload) {
 	k := workload.Key(w)
 	wi, exist := c.Workloads[k]
@@ -367,6 +542,7 @@ func (c *ClusterQueue) deleteWorkload(w *kueue.Workload) {
 	c.AllocatableResourceGeneration++
 
 	delete(c.Workloads, k)
+	c.workloadsGeneration++
 	c.reportActiveWorkloads()
 }
 
@@ -380,6 +556,7 @@ func (c *ClusterQueue) reportActiveWorkloads() {
 func (c *ClusterQueue) updateWorkloadUsage(wi *workload.Info, m int64) {
 	admitted := workload.IsAdmitted(wi.Obj)
 	updateUsage(wi, c.Usage, m)
+	c.updateTierUsage(wi, m)
 	if admitted {
 		updateUsage(wi, c.AdmittedUsage, m)
 		c.admittedWorkloadsCount += int(m)
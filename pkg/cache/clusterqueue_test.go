@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -10,6 +11,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestClusterQueueUpdateWithFlavors(t *testing.T) {
@@ -351,3 +353,113 @@ func TestClusterQueueUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestExceededQuotaTier(t *testing.T) {
+	cqSpec := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "10").Obj()).
+		QuotaTiers(
+			kueue.QuotaTier{Name: "research", MinPriority: 100, ReservedQuotaPercent: 20},
+			kueue.QuotaTier{Name: "production", MinPriority: 1000, ReservedQuotaPercent: 50},
+		).Obj()
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("low", "ns").
+			Priority(0).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "one", "2").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("medium", "ns").
+			Priority(100).
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "one", "1").Obj()).
+			Obj(),
+		utiltesting.MakeWorkload("high", "ns").
+			Priority(1000).
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "one", "4").Obj()).
+			Obj(),
+	}
+
+	cases := map[string]struct {
+		deleted       []string
+		updated       bool
+		val           int64
+		priority      int32
+		wantTier      string
+		wantAvailable int64
+		wantExceeded  bool
+	}{
+		"top tier": {
+			val:      10_000,
+			priority: 1000,
+		},
+		"exceeds the quota not reserved to the top tier": {
+			val:           3_000,
+			priority:      100,
+			wantTier:      "production",
+			wantAvailable: 2_000,
+			wantExceeded:  true,
+		},
+		"fits in the quota not reserved to the top tier": {
+			val:      2_000,
+			priority: 100,
+		},
+		"exceeds the quota not reserved to any tier": {
+			val:           1_500,
+			priority:      10,
+			wantTier:      "research",
+			wantAvailable: 1_000,
+			wantExceeded:  true,
+		},
+		"fits in the quota not reserved to any tier": {
+			val:      1_000,
+			priority: 10,
+		},
+		"fits after the workloads with lower priorities are deleted": {
+			deleted:  []string{"low", "medium"},
+			val:      3_000,
+			priority: 100,
+		},
+		"the workloads of the tier don't use the quota not reserved to it": {
+			deleted:  []string{"high"},
+			val:      2_000,
+			priority: 100,
+		},
+		"exceeds after the ClusterQueue is updated": {
+			updated:       true,
+			val:           1_500,
+			priority:      10,
+			wantTier:      "research",
+			wantAvailable: 1_000,
+			wantExceeded:  true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cq := ClusterQueue{
+				Workloads: make(map[string]*workload.Info),
+			}
+			if err := cq.update(cqSpec, nil, nil); err != nil {
+				t.Fatalf("Updating the ClusterQueue: %v", err)
+			}
+			for _, wl := range workloads {
+				if err := cq.addWorkload(wl); err != nil {
+					t.Fatalf("Adding workload %s: %v", wl.Name, err)
+				}
+			}
+			for _, wl := range workloads {
+				if slices.Contains(tc.deleted, wl.Name) {
+					cq.deleteWorkload(wl)
+				}
+			}
+			if tc.updated {
+				if err := cq.update(cqSpec, nil, nil); err != nil {
+					t.Fatalf("Updating the ClusterQueue: %v", err)
+				}
+			}
+			tier, available, exceeded := cq.ExceededQuotaTier("one", corev1.ResourceCPU, 10_000, tc.val, tc.priority)
+			if tier != tc.wantTier || available != tc.wantAvailable || exceeded != tc.wantExceeded {
+				t.Errorf("ExceededQuotaTier() = (%q, %d, %t), want (%q, %d, %t)", tier, available, exceeded, tc.wantTier, tc.wantAvailable, tc.wantExceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// resolveNominalQuotas updates the nominal quotas of the members of the
+// cohort expressed as a percentage of the cohort capacity, and reduces the
+// nominal quotas of the other members accordingly.
+func (c *Cohort) resolveNominalQuotas() {
+	if c == nil {
+		return
+	}
+	capacity := make(FlavorResourceQuantities)
+	percents := make(FlavorResourceQuantities)
+	for cq := range c.Members {
+		for fName, resources := range cq.configuredNominal {
+			for rName, v := range resources {
+				if p, isShare := cq.nominalQuotaPercents[fName][rName]; isShare {
+					addQuantity(percents, fName, rName, p)
+				} else {
+					addQuantity(capacity, fName, rName, v)
+				}
+			}
+		}
+	}
+	for cq := range c.Members {
+		cq.setNominalQuotas(func(fName kueue.ResourceFlavorReference, rName corev1.ResourceName) int64 {
+			totalPercent := percents[fName][rName]
+			if p, isShare := cq.nominalQuotaPercents[fName][rName]; isShare {
+				return capacity[fName][rName] * p / max(totalPercent, 100)
+			}
+			return cq.configuredNominal[fName][rName] * (100 - min(totalPercent, 100)) / 100
+		})
+	}
+}
+
+// resetNominalQuotas restores the configured nominal quotas of a ClusterQueue
+// that doesn't belong to a cohort.
+func (c *ClusterQueue) resetNominalQuotas() {
+	c.setNominalQuotas(func(fName kueue.ResourceFlavorReference, rName corev1.ResourceName) int64 {
+		return c.configuredNominal[fName][rName]
+	})
+}
+
+// setNominalQuotas replaces the resource groups if any of the nominal quotas
+// changes. The resource groups are shared with the snapshots, so they are not
+// modified in place.
+func (c *ClusterQueue) setNominalQuotas(nominal func(kueue.ResourceFlavorReference, corev1.ResourceName) int64) {
+	changed := false
+	for _, rg := range c.ResourceGroups {
+		for _, fQuotas := range rg.Flavors {
+			for rName, rQuota := range fQuotas.Resources {
+				if rQuota.Nominal != nominal(fQuotas.Name, rName) {
+					changed = true
+				}
+			}
+		}
+	}
+	if !changed {
+		return
+	}
+	resourceGroups := make([]ResourceGroup, len(c.ResourceGroups))
+	for i, rg := range c.ResourceGroups {
+		resourceGroups[i] = rg
+		resourceGroups[i].Flavors = make([]FlavorQuotas, len(rg.Flavors))
+		for j, fQuotas := range rg.Flavors {
+			resources := make(map[corev1.ResourceName]*ResourceQuota, len(fQuotas.Resources))
+			for rName, rQuota := range fQuotas.Resources {
+				resources[rName] = &ResourceQuota{
+					Nominal:        nominal(fQuotas.Name, rName),
+					BorrowingLimit: rQuota.BorrowingLimit,
+				}
+			}
+			resourceGroups[i].Flavors[j] = FlavorQuotas{Name: fQuotas.Name, Resources: resources}
+		}
+	}
+	c.ResourceGroups = resourceGroups
+	c.AllocatableResourceGeneration++
+	c.UpdateRGByResource()
+}
+
+// resolvedNominalQuotas returns the nominal quotas of the ClusterQueue that
+// differ from the configured ones, or are expressed as a percentage of the
+// cohort capacity.
+func (c *ClusterQueue) resolvedNominalQuotas() []kueue.FlavorNominalQuota {
+	var quotas []kueue.FlavorNominalQuota
+	for _, rg := range c.ResourceGroups {
+		for _, fQuotas := range rg.Flavors {
+			var resources []kueue.ResourceNominalQuota
+			for rName, rQuota := range fQuotas.Resources {
+				_, isShare := c.nominalQuotaPercents[fQuotas.Name][rName]
+				if !isShare && rQuota.Nominal == c.configuredNominal[fQuotas.Name][rName] {
+					continue
+				}
+				resources = append(resources, kueue.ResourceNominalQuota{
+					Name:         rName,
+					NominalQuota: workload.ResourceQuantity(rName, rQuota.Nominal),
+				})
+			}
+			if len(resources) == 0 {
+				continue
+			}
+			// The resources should be in a stable order to avoid endless creation of update events.
+			sort.Slice(resources, func(i, j int) bool {
+				return resources[i].Name < resources[j].Name
+			})
+			quotas = append(quotas, kueue.FlavorNominalQuota{Name: fQuotas.Name, Resources: resources})
+		}
+	}
+	return quotas
+}
+
+func addQuantity(q FlavorResourceQuantities, fName kueue.ResourceFlavorReference, rName corev1.ResourceName, v int64) {
+	if q[fName] == nil {
+		q[fName] = make(map[corev1.ResourceName]int64)
+	}
+	q[fName][rName] += v
+}
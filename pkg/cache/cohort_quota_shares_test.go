@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestResolveNominalQuotas(t *testing.T) {
+	pool := utiltesting.MakeClusterQueue("pool").
+		Cohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	teamA := utiltesting.MakeClusterQueue("team-a").
+		Cohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").ResourcePercent(corev1.ResourceCPU, 30).Obj()).
+		Obj()
+	teamB := utiltesting.MakeClusterQueue("team-b").
+		Cohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").ResourcePercent(corev1.ResourceCPU, 20).Obj()).
+		Obj()
+
+	cases := map[string]struct {
+		enableShares bool
+		// update is applied to the ClusterQueues after they were added.
+		update      func(*Cache) error
+		wantNominal map[string]int64
+		// wantReported are the ClusterQueues reporting their nominal quotas.
+		wantReported sets.Set[string]
+	}{
+		"shares of the cohort capacity": {
+			enableShares: true,
+			wantNominal: map[string]int64{
+				"pool":   5_000,
+				"team-a": 3_000,
+				"team-b": 2_000,
+			},
+			wantReported: sets.New("pool", "team-a", "team-b"),
+		},
+		"the shares grow with the capacity": {
+			enableShares: true,
+			update: func(c *Cache) error {
+				grown := pool.DeepCopy()
+				grown.Spec.ResourceGroups[0].Flavors[0].Resources[0].NominalQuota.Set(20)
+				return c.UpdateClusterQueue(grown)
+			},
+			wantNominal: map[string]int64{
+				"pool":   10_000,
+				"team-a": 6_000,
+				"team-b": 4_000,
+			},
+			wantReported: sets.New("pool", "team-a", "team-b"),
+		},
+		"the shares are restored when a member leaves the cohort": {
+			enableShares: true,
+			update: func(c *Cache) error {
+				c.DeleteClusterQueue(teamB)
+				standalone := pool.DeepCopy()
+				standalone.Spec.Cohort = ""
+				return c.UpdateClusterQueue(standalone)
+			},
+			wantNominal: map[string]int64{
+				"pool":   10_000,
+				"team-a": 0,
+			},
+			wantReported: sets.New("team-a"),
+		},
+		"percentages over 100 are scaled down": {
+			enableShares: true,
+			update: func(c *Cache) error {
+				big := teamB.DeepCopy()
+				big.Spec.ResourceGroups[0].Flavors[0].Resources[0].NominalQuotaPercent = ptr.To[int32](80)
+				return c.UpdateClusterQueue(big)
+			},
+			wantNominal: map[string]int64{
+				"pool":   0,
+				"team-a": 2_727,
+				"team-b": 7_272,
+			},
+			wantReported: sets.New("pool", "team-a", "team-b"),
+		},
+		"feature disabled": {
+			wantNominal: map[string]int64{
+				"pool":   10_000,
+				"team-a": 0,
+				"team-b": 0,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer features.SetFeatureGateDuringTest(t, features.CohortQuotaShares, tc.enableShares)()
+			ctx := context.Background()
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Label("instance", "on-demand").Obj())
+			for _, cq := range []*kueue.ClusterQueue{pool, teamA, teamB} {
+				if err := cache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Failed adding ClusterQueue %s: %v", cq.Name, err)
+				}
+			}
+			if tc.update != nil {
+				if err := tc.update(cache); err != nil {
+					t.Fatalf("Failed updating the ClusterQueues: %v", err)
+				}
+			}
+			gotNominal := make(map[string]int64)
+			gotReported := sets.New[string]()
+			for name, cq := range cache.clusterQueues {
+				nominal := cq.ResourceGroups[0].Flavors[0].Resources[corev1.ResourceCPU].Nominal
+				gotNominal[name] = nominal
+				if !cq.ResourceGroups[0].LabelKeys.Has("instance") {
+					t.Errorf("The label keys of the flavors of ClusterQueue %s were lost", name)
+				}
+				stats, err := cache.Usage(&kueue.ClusterQueue{ObjectMeta: metav1.ObjectMeta{Name: name}})
+				if err != nil {
+					t.Fatalf("Failed getting the usage of ClusterQueue %s: %v", name, err)
+				}
+				if len(stats.NominalQuotas) == 0 {
+					continue
+				}
+				gotReported.Insert(name)
+				wantQuotas := []kueue.FlavorNominalQuota{{
+					Name: "default",
+					Resources: []kueue.ResourceNominalQuota{{
+						Name:         corev1.ResourceCPU,
+						NominalQuota: *resource.NewMilliQuantity(nominal, resource.DecimalSI),
+					}},
+				}}
+				if diff := cmp.Diff(wantQuotas, stats.NominalQuotas); diff != "" {
+					t.Errorf("Unexpected reported nominal quotas of ClusterQueue %s (-want,+got):\n%s", name, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.wantNominal, gotNominal); diff != "" {
+				t.Errorf("Unexpected nominal quotas (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantReported, gotReported, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected ClusterQueues reporting their nominal quotas (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ type Snapshot struct {
 	ClusterQueues            map[string]*ClusterQueue
 	ResourceFlavors          map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
 	InactiveClusterQueueSets sets.Set[string]
+	WorkloadPriorityClasses  map[string]WorkloadPriorityClass
 }
 
 // RemoveWorkload removes a workload from its corresponding ClusterQueue and
@@ -36,6 +37,7 @@ func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
 	delete(cq.Workloads, workload.Key(wl.Obj))
 	updateUsage(wl, cq.Usage, -1)
+	cq.updateTierUsage(wl, -1)
 	if cq.Cohort != nil {
 		updateUsage(wl, cq.Cohort.Usage, -1)
 	}
@@ -47,31 +49,46 @@ func (s *Snapshot) AddWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
 	cq.Workloads[workload.Key(wl.Obj)] = wl
 	updateUsage(wl, cq.Usage, 1)
+	cq.updateTierUsage(wl, 1)
 	if cq.Cohort != nil {
 		updateUsage(wl, cq.Cohort.Usage, 1)
 	}
 }
 
 func (c *Cache) Snapshot() Snapshot {
+	var snap Snapshot
+	c.UpdateSnapshot(&snap)
+	return snap
+}
+
+// UpdateSnapshot brings snap up to date with the cache. The workloads and the
+// usage of the ClusterQueues that didn't change since snap was last updated
+// are reused instead of copied again.
+// Changes to snap after it was updated, like the ones done while searching
+// for preemption targets, must be reverted before the next update.
+func (c *Cache) UpdateSnapshot(snap *Snapshot) {
 	c.RLock()
 	defer c.RUnlock()
 
-	snap := Snapshot{
-		ClusterQueues:            make(map[string]*ClusterQueue, len(c.clusterQueues)),
-		ResourceFlavors:          make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, len(c.resourceFlavors)),
-		InactiveClusterQueueSets: sets.New[string](),
-	}
+	prev := snap.ClusterQueues
+	snap.ClusterQueues = make(map[string]*ClusterQueue, len(c.clusterQueues))
+	snap.ResourceFlavors = make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, len(c.resourceFlavors))
+	snap.InactiveClusterQueueSets = sets.New[string]()
 	for _, cq := range c.clusterQueues {
 		if !cq.Active() {
 			snap.InactiveClusterQueueSets.Insert(cq.Name)
 			continue
 		}
-		snap.ClusterQueues[cq.Name] = cq.snapshot()
+		snap.ClusterQueues[cq.Name] = cq.snapshot(prev[cq.Name])
 	}
 	for name, rf := range c.resourceFlavors {
 		// Shallow copy is enough
 		snap.ResourceFlavors[name] = rf
 	}
+	snap.WorkloadPriorityClasses = make(map[string]WorkloadPriorityClass, len(c.priorityClasses))
+	for name, wpc := range c.priorityClasses {
+		snap.WorkloadPriorityClasses[name] = wpc
+	}
 	for _, cohort := range c.cohorts {
 		cohortCopy := newCohort(cohort.Name, cohort.Members.Len())
 		cohortCopy.AllocatableResourceGeneration = 0
@@ -85,32 +102,41 @@ func (c *Cache) Snapshot() Snapshot {
 			}
 		}
 	}
-	return snap
 }
 
 // snapshot creates a copy of ClusterQueue that includes references to immutable
 // objects and deep copies of changing ones. A reference to the cohort is not included.
-func (c *ClusterQueue) snapshot() *ClusterQueue {
+// The workloads and the usage of prev are reused if prev was copied from the
+// ClusterQueue and they didn't change since.
+func (c *ClusterQueue) snapshot(prev *ClusterQueue) *ClusterQueue {
 	cc := &ClusterQueue{
 		Name:                          c.Name,
 		ResourceGroups:                c.ResourceGroups, // Shallow copy is enough.
 		RGByResource:                  c.RGByResource,   // Shallow copy is enough.
 		FlavorFungibility:             c.FlavorFungibility,
 		AllocatableResourceGeneration: c.AllocatableResourceGeneration,
-		Usage:                         make(FlavorResourceQuantities, len(c.Usage)),
-		Workloads:                     make(map[string]*workload.Info, len(c.Workloads)),
 		Preemption:                    c.Preemption,
+		QuotaTiers:                    c.QuotaTiers,
 		NamespaceSelector:             c.NamespaceSelector,
 		Status:                        c.Status,
 		AdmissionChecks:               c.AdmissionChecks.Clone(),
+		workloadsGeneration:           c.workloadsGeneration,
+		origin:                        c,
 	}
-	for fName, rUsage := range c.Usage {
-		rUsageCopy := make(map[corev1.ResourceName]int64, len(rUsage))
-		for k, v := range rUsage {
-			rUsageCopy[k] = v
+	if prev != nil && prev.origin == c && prev.workloadsGeneration == c.workloadsGeneration {
+		cc.Usage = prev.Usage
+		cc.tierUsage = prev.tierUsage
+		cc.Workloads = prev.Workloads
+		return cc
+	}
+	cc.Usage = copyQuantities(c.Usage)
+	if c.tierUsage != nil {
+		cc.tierUsage = make([]FlavorResourceQuantities, len(c.tierUsage))
+		for i := range c.tierUsage {
+			cc.tierUsage[i] = copyQuantities(c.tierUsage[i])
 		}
-		cc.Usage[fName] = rUsageCopy
 	}
+	cc.Workloads = make(map[string]*workload.Info, len(c.Workloads))
 	for k, v := range c.Workloads {
 		// Shallow copy is enough.
 		cc.Workloads[k] = v
@@ -118,6 +144,18 @@ func (c *ClusterQueue) snapshot() *ClusterQueue {
 	return cc
 }
 
+func copyQuantities(q FlavorResourceQuantities) FlavorResourceQuantities {
+	ret := make(FlavorResourceQuantities, len(q))
+	for fName, rQuantities := range q {
+		rQuantitiesCopy := make(map[corev1.ResourceName]int64, len(rQuantities))
+		for k, v := range rQuantities {
+			rQuantitiesCopy[k] = v
+		}
+		ret[fName] = rQuantitiesCopy
+	}
+	return ret
+}
+
 func (c *ClusterQueue) accumulateResources(cohort *Cohort) {
 	if cohort.RequestableResources == nil {
 		cohort.RequestableResources = make(FlavorResourceQuantities, len(c.ResourceGroups))
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -68,7 +69,7 @@ func TestSnapshot(t *testing.T) {
 						FlavorFungibility:             defaultFlavorFungibility,
 						AllocatableResourceGeneration: 1,
 						Workloads: map[string]*workload.Info{
-							"/alpha": workload.NewInfo(
+							"/alpha": workload.NewCompactInfo(
 								utiltesting.MakeWorkload("alpha", "").
 									ReserveQuota(&kueue.Admission{ClusterQueue: "a"}).Obj()),
 						},
@@ -81,7 +82,7 @@ func TestSnapshot(t *testing.T) {
 						FlavorFungibility:             defaultFlavorFungibility,
 						AllocatableResourceGeneration: 1,
 						Workloads: map[string]*workload.Info{
-							"/beta": workload.NewInfo(
+							"/beta": workload.NewCompactInfo(
 								utiltesting.MakeWorkload("beta", "").
 									ReserveQuota(&kueue.Admission{ClusterQueue: "b"}).Obj()),
 						},
@@ -253,7 +254,7 @@ func TestSnapshot(t *testing.T) {
 								"spot":   {corev1.ResourceCPU: 0},
 							},
 							Workloads: map[string]*workload.Info{
-								"/alpha": workload.NewInfo(utiltesting.MakeWorkload("alpha", "").
+								"/alpha": workload.NewCompactInfo(utiltesting.MakeWorkload("alpha", "").
 									PodSets(*utiltesting.MakePodSet("main", 5).
 										Request(corev1.ResourceCPU, "2").Obj()).
 									ReserveQuota(utiltesting.MakeAdmission("a", "main").
@@ -301,7 +302,7 @@ func TestSnapshot(t *testing.T) {
 								},
 							},
 							Workloads: map[string]*workload.Info{
-								"/beta": workload.NewInfo(utiltesting.MakeWorkload("beta", "").
+								"/beta": workload.NewCompactInfo(utiltesting.MakeWorkload("beta", "").
 									PodSets(*utiltesting.MakePodSet("main", 5).
 										Request(corev1.ResourceCPU, "1").
 										Request("example.com/gpu", "2").
@@ -312,7 +313,7 @@ func TestSnapshot(t *testing.T) {
 										AssignmentPodCount(5).
 										Obj()).
 									Obj()),
-								"/gamma": workload.NewInfo(utiltesting.MakeWorkload("gamma", "").
+								"/gamma": workload.NewCompactInfo(utiltesting.MakeWorkload("gamma", "").
 									PodSets(*utiltesting.MakePodSet("main", 5).
 										Request(corev1.ResourceCPU, "1").
 										Request("example.com/gpu", "1").
@@ -662,3 +663,124 @@ func TestSnapshotAddRemoveWorkload(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotAddRemoveWorkloadQuotaTiers(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		QuotaTiers(kueue.QuotaTier{Name: "production", MinPriority: 1000, ReservedQuotaPercent: 50}).
+		Obj()
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("low", "").
+			Priority(0).
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+		*utiltesting.MakeWorkload("high", "").
+			Priority(1000).
+			Request(corev1.ResourceCPU, "4").
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj(),
+	}
+	ctx := context.Background()
+	cl := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: workloads}).Build()
+	cqCache := New(cl)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	wlInfos := cqCache.clusterQueues["cq"].Workloads
+
+	cases := map[string]struct {
+		remove       []string
+		add          []string
+		wantExceeded bool
+	}{
+		"no changes": {
+			wantExceeded: true,
+		},
+		"remove the workload with a lower priority": {
+			remove: []string{"/low"},
+		},
+		"remove the workload of the tier": {
+			remove:       []string{"/high"},
+			wantExceeded: true,
+		},
+		"remove and add back the workload with a lower priority": {
+			remove:       []string{"/low"},
+			add:          []string{"/low"},
+			wantExceeded: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			snap := cqCache.Snapshot()
+			for _, name := range tc.remove {
+				snap.RemoveWorkload(wlInfos[name])
+			}
+			for _, name := range tc.add {
+				snap.AddWorkload(wlInfos[name])
+			}
+			_, _, exceeded := snap.ClusterQueues["cq"].ExceededQuotaTier("default", corev1.ResourceCPU, 10_000, 2_000, 0)
+			if exceeded != tc.wantExceeded {
+				t.Errorf("ExceededQuotaTier() exceeded = %t, want %t", exceeded, tc.wantExceeded)
+			}
+			// The ClusterQueue in the cache is not modified.
+			if _, _, exceeded := cqCache.clusterQueues["cq"].ExceededQuotaTier("default", corev1.ResourceCPU, 10_000, 2_000, 0); !exceeded {
+				t.Errorf("The quota tiers usage of the ClusterQueue in the cache was modified")
+			}
+		})
+	}
+}
+
+func TestUpdateSnapshot(t *testing.T) {
+	ctx := context.Background()
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("a").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("b").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj(),
+	} {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding ClusterQueue: %v", err)
+		}
+	}
+	admitted := func(name, cq string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "default", "1000m").Obj()).
+			Obj()
+	}
+	cache.AddOrUpdateWorkload(admitted("a1", "a"))
+	cache.AddOrUpdateWorkload(admitted("b1", "b"))
+
+	var snap Snapshot
+	cache.UpdateSnapshot(&snap)
+	prevA := snap.ClusterQueues["a"]
+	prevB := snap.ClusterQueues["b"]
+
+	cache.AddOrUpdateWorkload(admitted("b2", "b"))
+	cache.UpdateSnapshot(&snap)
+
+	if diff := cmp.Diff(cache.Snapshot(), snap, snapCmpOpts...); diff != "" {
+		t.Errorf("Unexpected updated snapshot (-want,+got):\n%s", diff)
+	}
+	if got := snap.ClusterQueues["a"]; got == prevA || !sameWorkloads(got.Workloads, prevA.Workloads) {
+		t.Errorf("The unchanged ClusterQueue should be a new copy reusing its previous workloads")
+	}
+	if got := snap.ClusterQueues["b"]; sameWorkloads(got.Workloads, prevB.Workloads) {
+		t.Errorf("The workloads of the changed ClusterQueue should be copied again")
+	}
+	if got := snap.ClusterQueues["a"].Cohort; got != snap.ClusterQueues["b"].Cohort || got.Usage["default"][corev1.ResourceCPU] != 3_000 {
+		t.Errorf("Unexpected cohort after the update: %+v", got)
+	}
+}
+
+func sameWorkloads(a, b map[string]*workload.Info) bool {
+	return reflect.ValueOf(a).Pointer() == reflect.ValueOf(b).Pointer()
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
+)
+
+// WorkloadPriorityClass holds the preemption semantics of a
+// WorkloadPriorityClass.
+type WorkloadPriorityClass struct {
+	// CanPreempt is false if the workloads of the class never preempt.
+	CanPreempt bool
+	// NonPreemptible is true if the workloads of the class are never preempted.
+	NonPreemptible bool
+}
+
+func newWorkloadPriorityClass(wpc *kueue.WorkloadPriorityClass) WorkloadPriorityClass {
+	return WorkloadPriorityClass{
+		CanPreempt:     wpc.PreemptionPolicy != kueue.PreemptionPolicyNever,
+		NonPreemptible: wpc.NonPreemptible,
+	}
+}
+
+// WorkloadPriorityClassOf returns the WorkloadPriorityClass of the workload.
+// Workloads without a WorkloadPriorityClass, or with one that is not in the
+// snapshot, can preempt and be preempted.
+func (s *Snapshot) WorkloadPriorityClassOf(wl *kueue.Workload) WorkloadPriorityClass {
+	if wl.Spec.PriorityClassSource == constants.WorkloadPriorityClassSource {
+		if wpc, found := s.WorkloadPriorityClasses[wl.Spec.PriorityClassName]; found {
+			return wpc
+		}
+	}
+	return WorkloadPriorityClass{CanPreempt: true}
+}
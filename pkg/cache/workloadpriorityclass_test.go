@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadPriorityClassOf(t *testing.T) {
+	cache := New(utiltesting.NewFakeClient())
+	cache.AddOrUpdateWorkloadPriorityClass(utiltesting.MakeWorkloadPriorityClass("batch").PreemptionPolicy(kueue.PreemptionPolicyNever).Obj())
+	cache.AddOrUpdateWorkloadPriorityClass(utiltesting.MakeWorkloadPriorityClass("critical").NonPreemptible().Obj())
+	snapshot := cache.Snapshot()
+
+	cases := map[string]struct {
+		workload *kueue.Workload
+		want     WorkloadPriorityClass
+	}{
+		"without priority class": {
+			workload: utiltesting.MakeWorkload("wl", "").Obj(),
+			want:     WorkloadPriorityClass{CanPreempt: true},
+		},
+		"never preempts": {
+			workload: utiltesting.MakeWorkload("wl", "").
+				PriorityClass("batch").
+				PriorityClassSource(constants.WorkloadPriorityClassSource).
+				Obj(),
+			want: WorkloadPriorityClass{},
+		},
+		"non-preemptible": {
+			workload: utiltesting.MakeWorkload("wl", "").
+				PriorityClass("critical").
+				PriorityClassSource(constants.WorkloadPriorityClassSource).
+				Obj(),
+			want: WorkloadPriorityClass{CanPreempt: true, NonPreemptible: true},
+		},
+		"pod priority class with the same name": {
+			workload: utiltesting.MakeWorkload("wl", "").
+				PriorityClass("critical").
+				PriorityClassSource(constants.PodPriorityClassSource).
+				Obj(),
+			want: WorkloadPriorityClass{CanPreempt: true},
+		},
+		"missing priority class": {
+			workload: utiltesting.MakeWorkload("wl", "").
+				PriorityClass("missing").
+				PriorityClassSource(constants.WorkloadPriorityClassSource).
+				Obj(),
+			want: WorkloadPriorityClass{CanPreempt: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, snapshot.WorkloadPriorityClassOf(tc.workload)); diff != "" {
+				t.Errorf("Unexpected WorkloadPriorityClass (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAddOrUpdateWorkloadPriorityClass(t *testing.T) {
+	cache := New(utiltesting.NewFakeClient())
+	wpc := utiltesting.MakeWorkloadPriorityClass("batch").PriorityValue(1).Obj()
+	if !cache.AddOrUpdateWorkloadPriorityClass(wpc) {
+		t.Error("Adding the WorkloadPriorityClass didn't report a change")
+	}
+	wpc.Value = 2
+	if cache.AddOrUpdateWorkloadPriorityClass(wpc) {
+		t.Error("Updating the value of the WorkloadPriorityClass reported a change")
+	}
+	wpc.NonPreemptible = true
+	if !cache.AddOrUpdateWorkloadPriorityClass(wpc) {
+		t.Error("Updating the WorkloadPriorityClass to non-preemptible didn't report a change")
+	}
+}
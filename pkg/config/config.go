@@ -24,8 +24,11 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
@@ -37,7 +40,10 @@ func fromFile(path string, scheme *runtime.Scheme, cfg *configapi.Configuration)
 	if err != nil {
 		return err
 	}
+	return decode(content, scheme, cfg)
+}
 
+func decode(content []byte, scheme *runtime.Scheme, cfg *configapi.Configuration) error {
 	codecs := serializer.NewCodecFactory(scheme)
 
 	// Regardless of if the bytes are of any external version,
@@ -94,6 +100,33 @@ func addTo(o *ctrl.Options, cfg *configapi.Configuration) {
 	}
 }
 
+// addCacheTo restricts the objects cached by the manager informers. It fails
+// if a kind is not registered in the scheme of the options.
+func addCacheTo(o *ctrl.Options, cfg *configapi.Configuration) error {
+	if cfg.Cache == nil || len(cfg.Cache.ByObject) == 0 || o.Cache.ByObject != nil {
+		return nil
+	}
+	o.Cache.ByObject = make(map[client.Object]cache.ByObject, len(cfg.Cache.ByObject))
+	for _, bo := range cfg.Cache.ByObject {
+		gvk := schema.FromAPIVersionAndKind(bo.APIVersion, bo.Kind)
+		obj, err := o.Scheme.New(gvk)
+		if err != nil {
+			return fmt.Errorf("cache.byObject: %w", err)
+		}
+		cObj, ok := obj.(client.Object)
+		if !ok {
+			return fmt.Errorf("cache.byObject: %s is not an object", gvk)
+		}
+		var byObject cache.ByObject
+		if bo.LabelSelector != nil {
+			// The selector is validated with the configuration.
+			byObject.Label, _ = metav1.LabelSelectorAsSelector(bo.LabelSelector)
+		}
+		o.Cache.ByObject[cObj] = byObject
+	}
+	return nil
+}
+
 func addLeaderElectionTo(o *ctrl.Options, cfg *configapi.Configuration) {
 	if cfg.LeaderElection == nil {
 		// The source does not have any configuration; noop
@@ -166,5 +199,8 @@ func Load(scheme *runtime.Scheme, configFile string) (ctrl.Options, configapi.Co
 		return options, cfg, err
 	}
 	addTo(&options, &cfg)
+	if err := addCacheTo(&options, &cfg); err != nil {
+		return options, cfg, err
+	}
 	return options, cfg, err
 }
@@ -18,6 +18,7 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -232,6 +234,9 @@ queueVisibility:
   updateIntervalSeconds: 10
   clusterQueues:
     maxCount: 0
+  localQueues:
+    maxCount: 5
+  includePositions: true
 `), os.FileMode(0600)); err != nil {
 		t.Fatal(err)
 	}
@@ -253,6 +258,7 @@ integrations:
       - key: kueue-job
         operator: In
         values: [ "true", "True", "yes" ]
+    groupEvents: PerPod
 `), os.FileMode(0600)); err != nil {
 		t.Fatal(err)
 	}
@@ -308,6 +314,7 @@ integrations:
 				},
 			},
 			PodSelector: &metav1.LabelSelector{},
+			GroupEvents: configapi.PodGroupEventsAggregated,
 		},
 	}
 
@@ -316,6 +323,9 @@ integrations:
 		ClusterQueues: &configapi.ClusterQueueVisibility{
 			MaxCount: 10,
 		},
+		LocalQueues: &configapi.LocalQueueVisibility{
+			MaxCount: 10,
+		},
 	}
 
 	testcases := []struct {
@@ -383,6 +393,7 @@ integrations:
 							},
 						},
 						PodSelector: &metav1.LabelSelector{},
+						GroupEvents: configapi.PodGroupEventsAggregated,
 					},
 				},
 				QueueVisibility: defaultQueueVisibility,
@@ -614,6 +625,7 @@ integrations:
 							},
 						},
 						PodSelector: &metav1.LabelSelector{},
+						GroupEvents: configapi.PodGroupEventsAggregated,
 					},
 				},
 				QueueVisibility: defaultQueueVisibility,
@@ -648,6 +660,10 @@ integrations:
 					ClusterQueues: &configapi.ClusterQueueVisibility{
 						MaxCount: 0,
 					},
+					LocalQueues: &configapi.LocalQueueVisibility{
+						MaxCount: 5,
+					},
+					IncludePositions: true,
 				},
 			},
 			wantOptions: ctrl.Options{
@@ -698,6 +714,7 @@ integrations:
 								},
 							},
 						},
+						GroupEvents: configapi.PodGroupEventsPerPod,
 					},
 				},
 			},
@@ -805,11 +822,13 @@ func TestEncode(t *testing.T) {
 							}},
 						},
 						"podSelector": map[string]any{},
+						"groupEvents": "Aggregated",
 					},
 				},
 				"queueVisibility": map[string]any{
 					"updateIntervalSeconds": int64(configapi.DefaultQueueVisibilityUpdateIntervalSeconds),
 					"clusterQueues":         map[string]any{"maxCount": int64(10)},
+					"localQueues":           map[string]any{"maxCount": int64(10)},
 				},
 			},
 		},
@@ -831,3 +850,64 @@ func TestEncode(t *testing.T) {
 		})
 	}
 }
+
+func TestAddCacheTo(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	managedPods := &metav1.LabelSelector{MatchLabels: map[string]string{"kueue.x-k8s.io/managed": "true"}}
+
+	cases := map[string]struct {
+		cache     *configapi.ControllerCache
+		wantLabel map[string]string
+		wantErr   bool
+	}{
+		"no restrictions": {},
+		"pods with a label": {
+			cache: &configapi.ControllerCache{
+				ByObject: []configapi.CacheByObject{{APIVersion: "v1", Kind: "Pod", LabelSelector: managedPods}},
+			},
+			wantLabel: map[string]string{"*v1.Pod": "kueue.x-k8s.io/managed=true"},
+		},
+		"kind without selector": {
+			cache: &configapi.ControllerCache{
+				ByObject: []configapi.CacheByObject{{APIVersion: "v1", Kind: "ConfigMap"}},
+			},
+			wantLabel: map[string]string{"*v1.ConfigMap": ""},
+		},
+		"kind not in the scheme": {
+			cache: &configapi.ControllerCache{
+				ByObject: []configapi.CacheByObject{{APIVersion: "batch/v1", Kind: "Job"}},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := ctrl.Options{Scheme: scheme}
+			cfg := &configapi.Configuration{ControllerManager: configapi.ControllerManager{Cache: tc.cache}}
+			err := addCacheTo(&o, cfg)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("addCacheTo() returned error %v, want error: %t", err, tc.wantErr)
+			}
+			var gotLabel map[string]string
+			for obj, byObject := range o.Cache.ByObject {
+				if gotLabel == nil {
+					gotLabel = make(map[string]string)
+				}
+				var label string
+				if byObject.Label != nil {
+					label = byObject.Label.String()
+				}
+				gotLabel[fmt.Sprintf("%T", obj)] = label
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.wantLabel, gotLabel); diff != "" {
+				t.Errorf("Unexpected cache restrictions (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+// reloadInterval is how often the configuration file is read. The kubelet
+// updates the files of a mounted ConfigMap with a delay of up to a minute,
+// so a shorter interval doesn't make the changes apply much sooner.
+const reloadInterval = 10 * time.Second
+
+// ReloadHandler applies a reloaded configuration to a component.
+type ReloadHandler func(cfg *configapi.Configuration)
+
+// Reloader reads the configuration file periodically and passes the changes of
+// the reloadable fields to the handlers, without restarting the manager:
+//   - waitForPodsReady
+//   - clientConnection
+//   - integrations.podOptions.namespaceSelector and podSelector
+//
+// The changes of the other fields require a restart, they are logged and
+// ignored.
+type Reloader struct {
+	scheme   *runtime.Scheme
+	file     string
+	interval time.Duration
+
+	mu       sync.Mutex
+	content  []byte
+	current  configapi.Configuration
+	handlers []ReloadHandler
+}
+
+var _ manager.LeaderElectionRunnable = (*Reloader)(nil)
+
+// NewReloader returns a reloader of the file, from which cfg was loaded.
+func NewReloader(scheme *runtime.Scheme, file string, cfg configapi.Configuration) *Reloader {
+	content, _ := os.ReadFile(file)
+	return &Reloader{
+		scheme:   scheme,
+		file:     file,
+		interval: reloadInterval,
+		content:  content,
+		current:  cfg,
+	}
+}
+
+// AddHandler registers a handler of the reloaded configurations. It's a no-op
+// for a nil reloader, so that the components can register their handlers
+// regardless of whether the configuration is reloaded.
+func (r *Reloader) AddHandler(h ReloadHandler) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, h)
+}
+
+// NeedLeaderElection returns false, as the configuration is applied in all
+// the replicas.
+func (r *Reloader) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reads the configuration file until the context is done.
+func (r *Reloader) Start(ctx context.Context) error {
+	ctx = ctrl.LoggerInto(ctx, ctrl.LoggerFrom(ctx).WithName("config-reloader"))
+	wait.UntilWithContext(ctx, r.reload, r.interval)
+	return nil
+}
+
+func (r *Reloader) reload(ctx context.Context) {
+	log := ctrl.LoggerFrom(ctx)
+	content, err := os.ReadFile(r.file)
+	if err != nil {
+		log.Error(err, "Unable to read the configuration file", "file", r.file)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if bytes.Equal(content, r.content) {
+		return
+	}
+	r.content = content
+
+	var cfg configapi.Configuration
+	if err := decode(content, r.scheme, &cfg); err != nil {
+		log.Error(err, "Unable to decode the configuration, keeping the current one")
+		return
+	}
+	if err := validate(&cfg).ToAggregate(); err != nil {
+		log.Error(err, "Invalid configuration, keeping the current one")
+		return
+	}
+
+	if fields := restartRequiredChanges(&r.current, &cfg); len(fields) > 0 {
+		log.Info("The configuration changes of some fields are not applied until the manager is restarted", "fields", fields)
+	}
+	next := r.current.DeepCopy()
+	copyReloadable(next, &cfg)
+	if equality.Semantic.DeepEqual(*next, r.current) {
+		return
+	}
+	r.current = *next
+	for _, h := range r.handlers {
+		h(next)
+	}
+	log.Info("Applied the reloaded configuration")
+}
+
+// copyReloadable copies the reloadable fields of src to dst.
+func copyReloadable(dst, src *configapi.Configuration) {
+	dst.WaitForPodsReady = src.WaitForPodsReady.DeepCopy()
+	dst.ClientConnection = src.ClientConnection.DeepCopy()
+
+	var srcPod configapi.PodIntegrationOptions
+	if src.Integrations != nil && src.Integrations.PodOptions != nil {
+		srcPod = *src.Integrations.PodOptions
+	}
+	if dst.Integrations == nil {
+		if srcPod.NamespaceSelector == nil && srcPod.PodSelector == nil {
+			return
+		}
+		dst.Integrations = &configapi.Integrations{}
+	}
+	if dst.Integrations.PodOptions == nil {
+		if srcPod.NamespaceSelector == nil && srcPod.PodSelector == nil {
+			return
+		}
+		dst.Integrations.PodOptions = &configapi.PodIntegrationOptions{}
+	}
+	dst.Integrations.PodOptions.NamespaceSelector = srcPod.NamespaceSelector.DeepCopy()
+	dst.Integrations.PodOptions.PodSelector = srcPod.PodSelector.DeepCopy()
+}
+
+// restartRequiredChanges returns the json names of the top-level fields of the
+// configuration with changes that can't be reloaded.
+func restartRequiredChanges(current, next *configapi.Configuration) []string {
+	a := current.DeepCopy()
+	b := next.DeepCopy()
+	copyReloadable(a, b)
+	return changedFields(reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem())
+}
+
+func changedFields(a, b reflect.Value) []string {
+	var fields []string
+	for i := 0; i < a.NumField(); i++ {
+		f := a.Type().Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, changedFields(a.Field(i), b.Field(i))...)
+			continue
+		}
+		if !equality.Semantic.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
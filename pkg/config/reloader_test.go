@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+const baseReloadConfig = `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+manageJobsWithoutQueueName: false
+`
+
+func TestReload(t *testing.T) {
+	testScheme := runtime.NewScheme()
+	if err := configapi.AddToScheme(testScheme); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		content          string
+		wantCalled       bool
+		wantPodsReady    *configapi.WaitForPodsReady
+		wantQPS          float32
+		wantManageNoName bool
+	}{
+		"unchanged": {
+			content: baseReloadConfig,
+			wantQPS: 20,
+		},
+		"reloadable fields": {
+			content: baseReloadConfig + `
+waitForPodsReady:
+  enable: true
+  timeout: 3m
+  blockAdmission: false
+clientConnection:
+  qps: 100
+  burst: 200
+`,
+			wantCalled: true,
+			wantPodsReady: &configapi.WaitForPodsReady{
+				Enable:         true,
+				Timeout:        &metav1.Duration{Duration: 3 * time.Minute},
+				BlockAdmission: ptr.To(false),
+			},
+			wantQPS: 100,
+		},
+		"fields requiring a restart are ignored": {
+			content: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+manageJobsWithoutQueueName: true
+`,
+			wantQPS: 20,
+		},
+		"reloadable fields are applied along fields requiring a restart": {
+			content: `
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+namespace: kueue-system
+manageJobsWithoutQueueName: true
+clientConnection:
+  qps: 50
+`,
+			wantCalled: true,
+			wantQPS:    50,
+		},
+		"invalid configuration": {
+			content: baseReloadConfig + `
+clientConnection:
+  qps: 50
+  burst: -1
+`,
+			wantQPS: 20,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(file, []byte(baseReloadConfig), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, cfg, err := Load(testScheme, file)
+			if err != nil {
+				t.Fatalf("Loading the initial configuration: %v", err)
+			}
+			r := NewReloader(testScheme, file, cfg)
+			var got *configapi.Configuration
+			r.AddHandler(func(cfg *configapi.Configuration) {
+				got = cfg
+			})
+
+			if err := os.WriteFile(file, []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			r.reload(context.Background())
+
+			if tc.wantCalled != (got != nil) {
+				t.Fatalf("Handler called: %t, want %t", got != nil, tc.wantCalled)
+			}
+			if diff := cmp.Diff(tc.wantPodsReady, r.current.WaitForPodsReady); diff != "" {
+				t.Errorf("Unexpected waitForPodsReady (-want,+got):\n%s", diff)
+			}
+			if qps := *r.current.ClientConnection.QPS; qps != tc.wantQPS {
+				t.Errorf("Unexpected qps %v, want %v", qps, tc.wantQPS)
+			}
+			if r.current.ManageJobsWithoutQueueName != tc.wantManageNoName {
+				t.Errorf("Unexpected manageJobsWithoutQueueName %t, want %t", r.current.ManageJobsWithoutQueueName, tc.wantManageNoName)
+			}
+		})
+	}
+}
+
+func TestRestartRequiredChanges(t *testing.T) {
+	current := configapi.Configuration{
+		Namespace: ptr.To("kueue-system"),
+		ControllerManager: configapi.ControllerManager{
+			Metrics: configapi.ControllerMetrics{BindAddress: ":8080"},
+		},
+		ClientConnection: &configapi.ClientConnection{QPS: ptr.To[float32](20)},
+	}
+	next := current.DeepCopy()
+	next.ManageJobsWithoutQueueName = true
+	next.Metrics.BindAddress = ":8443"
+	next.ClientConnection.QPS = ptr.To[float32](50)
+	next.WaitForPodsReady = &configapi.WaitForPodsReady{Enable: true}
+
+	want := []string{"metrics", "manageJobsWithoutQueueName"}
+	if diff := cmp.Diff(want, restartRequiredChanges(&current, next)); diff != "" {
+		t.Errorf("Unexpected fields (-want,+got):\n%s", diff)
+	}
+}
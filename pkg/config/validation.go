@@ -18,11 +18,15 @@ package config
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/strings/slices"
 
@@ -31,14 +35,28 @@ import (
 
 const (
 	queueVisibilityClusterQueuesMaxValue              = 4000
+	queueVisibilityLocalQueuesMaxValue                = 4000
 	queueVisibilityClusterQueuesUpdateIntervalSeconds = 1
 )
 
 var (
-	integrationsPath           = field.NewPath("integrations")
-	integrationsFrameworksPath = integrationsPath.Child("frameworks")
-	podOptionsPath             = integrationsPath.Child("podOptions")
-	namespaceSelectorPath      = podOptionsPath.Child("namespaceSelector")
+	integrationsPath             = field.NewPath("integrations")
+	integrationsFrameworksPath   = integrationsPath.Child("frameworks")
+	podOptionsPath               = integrationsPath.Child("podOptions")
+	labelKeyPrefixesPath         = integrationsPath.Child("labelKeyPrefixesToCopy")
+	annotationKeyPrefixesPath    = integrationsPath.Child("annotationKeyPrefixesToCopy")
+	namespaceSelectorPath        = podOptionsPath.Child("namespaceSelector")
+	groupEventsPath              = podOptionsPath.Child("groupEvents")
+	reclaimablePodsWindowPath    = podOptionsPath.Child("reclaimablePodsUpdateWindow")
+	eventExporterPath            = field.NewPath("eventExporter")
+	auditLogPath                 = field.NewPath("auditLog")
+	usageAccountingPath          = field.NewPath("usageAccounting")
+	cacheByObjectPath            = field.NewPath("cache", "byObject")
+	clientConnectionPath         = field.NewPath("clientConnection")
+	groupKindConcurrencyPath     = field.NewPath("controller", "groupKindConcurrency")
+	resourcesAccountingPath      = field.NewPath("resources", "accounting")
+	resourcesTransformationsPath = field.NewPath("resources", "transformations")
+	resourcesInitContainersPath  = field.NewPath("resources", "initContainers")
 )
 
 func validate(c *configapi.Configuration) field.ErrorList {
@@ -49,6 +67,47 @@ func validate(c *configapi.Configuration) field.ErrorList {
 	// Validate PodNamespaceSelector for the pod framework
 	allErrs = append(allErrs, validateIntegrations(c)...)
 
+	allErrs = append(allErrs, validateEventExporter(c)...)
+
+	allErrs = append(allErrs, validateAuditLog(c)...)
+
+	allErrs = append(allErrs, validateUsageAccounting(c)...)
+
+	allErrs = append(allErrs, validateCache(c)...)
+
+	allErrs = append(allErrs, validateClientConnection(c)...)
+
+	allErrs = append(allErrs, validateController(c)...)
+
+	allErrs = append(allErrs, validateResources(c)...)
+
+	return allErrs
+}
+
+func validateClientConnection(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.ClientConnection == nil || c.ClientConnection.Burst == nil {
+		return allErrs
+	}
+	if burst := *c.ClientConnection.Burst; burst < 0 {
+		allErrs = append(allErrs, field.Invalid(clientConnectionPath.Child("burst"), burst, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateController(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.Controller == nil {
+		return allErrs
+	}
+	for _, gk := range sets.List(sets.KeySet(c.Controller.GroupKindConcurrency)) {
+		if schema.ParseGroupKind(gk).Kind == "" {
+			allErrs = append(allErrs, field.Invalid(groupKindConcurrencyPath, gk, "must have a kind"))
+		}
+		if concurrency := c.Controller.GroupKindConcurrency[gk]; concurrency <= 0 {
+			allErrs = append(allErrs, field.Invalid(groupKindConcurrencyPath.Key(gk), concurrency, "must be greater than 0"))
+		}
+	}
 	return allErrs
 }
 
@@ -62,6 +121,12 @@ func validateQueueVisibility(cfg *configapi.Configuration) field.ErrorList {
 				allErrs = append(allErrs, field.Invalid(clusterQueues.Child("maxCount"), cfg.QueueVisibility.ClusterQueues.MaxCount, fmt.Sprintf("must be less than %d", queueVisibilityClusterQueuesMaxValue)))
 			}
 		}
+		if cfg.QueueVisibility.LocalQueues != nil {
+			localQueues := queueVisibilityPath.Child("localQueues")
+			if cfg.QueueVisibility.LocalQueues.MaxCount > queueVisibilityLocalQueuesMaxValue {
+				allErrs = append(allErrs, field.Invalid(localQueues.Child("maxCount"), cfg.QueueVisibility.LocalQueues.MaxCount, fmt.Sprintf("must be less than %d", queueVisibilityLocalQueuesMaxValue)))
+			}
+		}
 		if cfg.QueueVisibility.UpdateIntervalSeconds < queueVisibilityClusterQueuesUpdateIntervalSeconds {
 			allErrs = append(allErrs, field.Invalid(queueVisibilityPath.Child("updateIntervalSeconds"), cfg.QueueVisibility.UpdateIntervalSeconds, fmt.Sprintf("greater than or equal to %d", queueVisibilityClusterQueuesUpdateIntervalSeconds)))
 		}
@@ -81,7 +146,23 @@ func validateIntegrations(c *configapi.Configuration) field.ErrorList {
 	}
 
 	allErrs = append(allErrs, validatePodIntegrationOptions(c)...)
+	allErrs = append(allErrs, validateKeyPrefixes(c.Integrations.LabelKeyPrefixesToCopy, labelKeyPrefixesPath)...)
+	allErrs = append(allErrs, validateKeyPrefixes(c.Integrations.AnnotationKeyPrefixesToCopy, annotationKeyPrefixesPath)...)
+
+	return allErrs
+}
 
+func validateKeyPrefixes(prefixes []string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := sets.New[string]()
+	for i, prefix := range prefixes {
+		if prefix == "" {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), prefix, "must not be empty"))
+		} else if seen.Has(prefix) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), prefix))
+		}
+		seen.Insert(prefix)
+	}
 	return allErrs
 }
 
@@ -98,6 +179,15 @@ func validatePodIntegrationOptions(c *configapi.Configuration) field.ErrorList {
 	if c.Integrations.PodOptions.NamespaceSelector == nil {
 		return field.ErrorList{field.Required(namespaceSelectorPath, "a namespace selector is required")}
 	}
+	switch c.Integrations.PodOptions.GroupEvents {
+	case "", configapi.PodGroupEventsAggregated, configapi.PodGroupEventsPerPod:
+	default:
+		allErrs = append(allErrs, field.NotSupported(groupEventsPath, c.Integrations.PodOptions.GroupEvents,
+			[]string{string(configapi.PodGroupEventsAggregated), string(configapi.PodGroupEventsPerPod)}))
+	}
+	if w := c.Integrations.PodOptions.ReclaimablePodsUpdateWindow; w != nil && w.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(reclaimablePodsWindowPath, w.Duration.String(), "must be greater than or equal to 0"))
+	}
 
 	prohibitedNamespaces := []labels.Set{{corev1.LabelMetadataName: "kube-system"}}
 
@@ -121,3 +211,175 @@ func validatePodIntegrationOptions(c *configapi.Configuration) field.ErrorList {
 
 	return allErrs
 }
+
+func validateEventExporter(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.EventExporter == nil || !c.EventExporter.Enable {
+		return allErrs
+	}
+	sinkPath := eventExporterPath.Child("sink")
+	if c.EventExporter.Sink == "" {
+		allErrs = append(allErrs, field.Required(sinkPath, "cannot be empty when the event exporter is enabled"))
+	} else if u, err := url.Parse(c.EventExporter.Sink); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(sinkPath, c.EventExporter.Sink, "must be an absolute http or https URL"))
+	}
+	if c.EventExporter.Timeout != nil && c.EventExporter.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(eventExporterPath.Child("timeout"), c.EventExporter.Timeout.Duration.String(), "must be greater than 0"))
+	}
+	if c.EventExporter.MaxRetries != nil && *c.EventExporter.MaxRetries < 0 {
+		allErrs = append(allErrs, field.Invalid(eventExporterPath.Child("maxRetries"), *c.EventExporter.MaxRetries, "must be greater than or equal to 0"))
+	}
+	if c.EventExporter.BufferSize != nil && *c.EventExporter.BufferSize <= 0 {
+		allErrs = append(allErrs, field.Invalid(eventExporterPath.Child("bufferSize"), *c.EventExporter.BufferSize, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validateAuditLog(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.AuditLog == nil || !c.AuditLog.Enable {
+		return allErrs
+	}
+	if !filepath.IsAbs(c.AuditLog.Path) {
+		allErrs = append(allErrs, field.Invalid(auditLogPath.Child("path"), c.AuditLog.Path, "must be an absolute path"))
+	}
+	if c.AuditLog.MaxSizeMB != nil && *c.AuditLog.MaxSizeMB <= 0 {
+		allErrs = append(allErrs, field.Invalid(auditLogPath.Child("maxSizeMB"), *c.AuditLog.MaxSizeMB, "must be greater than 0"))
+	}
+	if c.AuditLog.MaxBackups != nil && *c.AuditLog.MaxBackups < 0 {
+		allErrs = append(allErrs, field.Invalid(auditLogPath.Child("maxBackups"), *c.AuditLog.MaxBackups, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateUsageAccounting(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.UsageAccounting == nil || !c.UsageAccounting.Enable {
+		return allErrs
+	}
+	if c.UsageAccounting.Interval != nil && c.UsageAccounting.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(usageAccountingPath.Child("interval"), c.UsageAccounting.Interval.Duration.String(), "must be greater than 0"))
+	}
+	if c.UsageAccounting.Sink != "" {
+		if u, err := url.Parse(c.UsageAccounting.Sink); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(usageAccountingPath.Child("sink"), c.UsageAccounting.Sink, "must be an absolute http or https URL"))
+		}
+		return allErrs
+	}
+	if !filepath.IsAbs(c.UsageAccounting.Path) {
+		allErrs = append(allErrs, field.Invalid(usageAccountingPath.Child("path"), c.UsageAccounting.Path, "must be an absolute path"))
+	}
+	if c.UsageAccounting.MaxSizeMB != nil && *c.UsageAccounting.MaxSizeMB <= 0 {
+		allErrs = append(allErrs, field.Invalid(usageAccountingPath.Child("maxSizeMB"), *c.UsageAccounting.MaxSizeMB, "must be greater than 0"))
+	}
+	if c.UsageAccounting.MaxBackups != nil && *c.UsageAccounting.MaxBackups < 0 {
+		allErrs = append(allErrs, field.Invalid(usageAccountingPath.Child("maxBackups"), *c.UsageAccounting.MaxBackups, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+func validateCache(c *configapi.Configuration) field.ErrorList {
+	if c.Cache == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	seen := sets.New[schema.GroupVersionKind]()
+	for i, bo := range c.Cache.ByObject {
+		path := cacheByObjectPath.Index(i)
+		gv, err := schema.ParseGroupVersion(bo.APIVersion)
+		if bo.APIVersion == "" {
+			allErrs = append(allErrs, field.Required(path.Child("apiVersion"), ""))
+		} else if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("apiVersion"), bo.APIVersion, err.Error()))
+		}
+		if bo.Kind == "" {
+			allErrs = append(allErrs, field.Required(path.Child("kind"), ""))
+		}
+		gvk := gv.WithKind(bo.Kind)
+		if seen.Has(gvk) {
+			allErrs = append(allErrs, field.Duplicate(path, gvk.String()))
+		}
+		seen.Insert(gvk)
+		allErrs = append(allErrs, validation.ValidateLabelSelector(bo.LabelSelector, validation.LabelSelectorValidationOptions{}, path.Child("labelSelector"))...)
+	}
+	return allErrs
+}
+
+func validateResources(c *configapi.Configuration) field.ErrorList {
+	if c.Resources == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	seen := sets.New[corev1.ResourceName]()
+	for i, ra := range c.Resources.Accounting {
+		path := resourcesAccountingPath.Index(i)
+		if ra.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+		} else if seen.Has(ra.Name) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), ra.Name))
+		}
+		seen.Insert(ra.Name)
+		switch ra.Policy {
+		case configapi.ResourceAccountingRequests, configapi.ResourceAccountingLimits, configapi.ResourceAccountingMaxOfRequestsAndLimits:
+		default:
+			allErrs = append(allErrs, field.NotSupported(path.Child("policy"), ra.Policy,
+				[]string{string(configapi.ResourceAccountingRequests), string(configapi.ResourceAccountingLimits), string(configapi.ResourceAccountingMaxOfRequestsAndLimits)}))
+		}
+	}
+	seen = sets.New[corev1.ResourceName]()
+	for i, rt := range c.Resources.Transformations {
+		path := resourcesTransformationsPath.Index(i)
+		if rt.Input == "" {
+			allErrs = append(allErrs, field.Required(path.Child("input"), ""))
+		} else if seen.Has(rt.Input) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("input"), rt.Input))
+		}
+		seen.Insert(rt.Input)
+		if len(rt.Outputs) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("outputs"), ""))
+		}
+		for name, q := range rt.Outputs {
+			if name == rt.Input {
+				allErrs = append(allErrs, field.Invalid(path.Child("outputs").Key(string(name)), q.String(), "must not be the input"))
+			} else if q.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("outputs").Key(string(name)), q.String(), "must be greater than or equal to 0"))
+			}
+		}
+		if rt.Strategy != nil {
+			switch *rt.Strategy {
+			case configapi.RetainResourceTransformation, configapi.ReplaceResourceTransformation:
+			default:
+				allErrs = append(allErrs, field.NotSupported(path.Child("strategy"), *rt.Strategy,
+					[]string{string(configapi.RetainResourceTransformation), string(configapi.ReplaceResourceTransformation)}))
+			}
+		}
+	}
+	if ic := c.Resources.InitContainers; ic != nil {
+		if ic.Policy != nil {
+			allErrs = append(allErrs, validateInitContainersAccountingPolicy(*ic.Policy, resourcesInitContainersPath.Child("policy"))...)
+		}
+		seenFrameworks := sets.New[string]()
+		for i, fw := range ic.Frameworks {
+			path := resourcesInitContainersPath.Child("frameworks").Index(i)
+			if fw.Name == "" {
+				allErrs = append(allErrs, field.Required(path.Child("name"), ""))
+			} else if seenFrameworks.Has(fw.Name) {
+				allErrs = append(allErrs, field.Duplicate(path.Child("name"), fw.Name))
+			} else if c.Integrations != nil && !slices.Contains(c.Integrations.Frameworks, fw.Name) {
+				allErrs = append(allErrs, field.Invalid(path.Child("name"), fw.Name, "must be one of integrations.frameworks"))
+			}
+			seenFrameworks.Insert(fw.Name)
+			allErrs = append(allErrs, validateInitContainersAccountingPolicy(fw.Policy, path.Child("policy"))...)
+		}
+	}
+	return allErrs
+}
+
+func validateInitContainersAccountingPolicy(policy configapi.InitContainersAccountingPolicy, path *field.Path) field.ErrorList {
+	switch policy {
+	case configapi.InitContainersAccountingMax, configapi.InitContainersAccountingSum:
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(path, policy,
+		[]string{string(configapi.InitContainersAccountingMax), string(configapi.InitContainersAccountingSum)})}
+}
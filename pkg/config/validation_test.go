@@ -19,9 +19,12 @@ package config
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
@@ -35,6 +38,9 @@ func TestValidate(t *testing.T) {
 		ClusterQueues: &configapi.ClusterQueueVisibility{
 			MaxCount: configapi.DefaultClusterQueuesMaxCount,
 		},
+		LocalQueues: &configapi.LocalQueueVisibility{
+			MaxCount: configapi.DefaultLocalQueuesMaxCount,
+		},
 	}
 
 	defaultPodIntegrationOptions := &configapi.PodIntegrationOptions{
@@ -93,6 +99,20 @@ func TestValidate(t *testing.T) {
 				field.Invalid(field.NewPath("queueVisibility").Child("clusterQueues").Child("maxCount"), 4001, fmt.Sprintf("must be less than %d", queueVisibilityClusterQueuesMaxValue)),
 			},
 		},
+		"invalid queue visibility local queue max count": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: &configapi.QueueVisibility{
+					LocalQueues: &configapi.LocalQueueVisibility{
+						MaxCount: 4001,
+					},
+					UpdateIntervalSeconds: 1,
+				},
+				Integrations: defaultIntegrations,
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("queueVisibility").Child("localQueues").Child("maxCount"), 4001, fmt.Sprintf("must be less than %d", queueVisibilityLocalQueuesMaxValue)),
+			},
+		},
 		"nil PodIntegrationOptions": {
 			cfg: &configapi.Configuration{
 				QueueVisibility: defaultQueueVisibility,
@@ -125,6 +145,62 @@ func TestValidate(t *testing.T) {
 				},
 			},
 		},
+		"invalid PodIntegrationOptions.GroupEvents": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks: []string{"pod"},
+					PodOptions: &configapi.PodIntegrationOptions{
+						NamespaceSelector: defaultPodIntegrationOptions.NamespaceSelector,
+						GroupEvents:       "Verbose",
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "integrations.podOptions.groupEvents",
+				},
+			},
+		},
+		"negative PodIntegrationOptions.ReclaimablePodsUpdateWindow": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks: []string{"pod"},
+					PodOptions: &configapi.PodIntegrationOptions{
+						NamespaceSelector:           defaultPodIntegrationOptions.NamespaceSelector,
+						ReclaimablePodsUpdateWindow: &metav1.Duration{Duration: -time.Second},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.podOptions.reclaimablePodsUpdateWindow",
+				},
+			},
+		},
+		"invalid key prefixes to copy": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks:                  []string{"batch/job"},
+					LabelKeyPrefixesToCopy:      []string{"example.com/", ""},
+					AnnotationKeyPrefixesToCopy: []string{"example.com/", "example.com/"},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.labelKeyPrefixesToCopy[1]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "integrations.annotationKeyPrefixesToCopy[1]",
+				},
+			},
+		},
 		"emptyLabelSelector": {
 			cfg: &configapi.Configuration{
 				Namespace:       ptr.To("kueue-system"),
@@ -213,6 +289,356 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		"event exporter without sink": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				EventExporter: &configapi.EventExporter{
+					Enable: true,
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "eventExporter.sink",
+				},
+			},
+		},
+		"event exporter with invalid values": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				EventExporter: &configapi.EventExporter{
+					Enable:     true,
+					Sink:       "kafka://broker:9092",
+					Timeout:    &metav1.Duration{},
+					MaxRetries: ptr.To[int32](-1),
+					BufferSize: ptr.To[int32](0),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "eventExporter.sink",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "eventExporter.timeout",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "eventExporter.maxRetries",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "eventExporter.bufferSize",
+				},
+			},
+		},
+		"disabled event exporter is not validated": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				EventExporter: &configapi.EventExporter{
+					Sink: "kafka://broker:9092",
+				},
+			},
+			wantErr: nil,
+		},
+		"audit log with invalid values": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				AuditLog: &configapi.AuditLog{
+					Enable:     true,
+					Path:       "audit.log",
+					MaxSizeMB:  ptr.To[int32](0),
+					MaxBackups: ptr.To[int32](-1),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "auditLog.path",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "auditLog.maxSizeMB",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "auditLog.maxBackups",
+				},
+			},
+		},
+		"usage accounting with invalid values": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				UsageAccounting: &configapi.UsageAccounting{
+					Enable:     true,
+					Interval:   &metav1.Duration{},
+					Path:       "usage.log",
+					MaxSizeMB:  ptr.To[int32](0),
+					MaxBackups: ptr.To[int32](-1),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "usageAccounting.interval",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "usageAccounting.path",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "usageAccounting.maxSizeMB",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "usageAccounting.maxBackups",
+				},
+			},
+		},
+		"usage accounting with an invalid sink": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				UsageAccounting: &configapi.UsageAccounting{
+					Enable: true,
+					Sink:   "ftp://billing.example.com",
+					Path:   "usage.log",
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "usageAccounting.sink",
+				},
+			},
+		},
+		"invalid cache restrictions": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ControllerManager: configapi.ControllerManager{
+					Cache: &configapi.ControllerCache{
+						ByObject: []configapi.CacheByObject{
+							{
+								APIVersion: "v1",
+								Kind:       "Pod",
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"kueue.x-k8s.io/managed": "true"},
+								},
+							},
+							{APIVersion: "v1", Kind: "Pod"},
+							{APIVersion: "a/b/c"},
+							{
+								APIVersion: "batch/v1",
+								Kind:       "Job",
+								LabelSelector: &metav1.LabelSelector{
+									MatchExpressions: []metav1.LabelSelectorRequirement{{
+										Key:      "kueue.x-k8s.io/queue-name",
+										Operator: metav1.LabelSelectorOpIn,
+									}},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "cache.byObject[1]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "cache.byObject[2].apiVersion",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "cache.byObject[2].kind",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "cache.byObject[3].labelSelector.matchExpressions[0].values",
+				},
+			},
+		},
+		"valid client connection and concurrency": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ClientConnection: &configapi.ClientConnection{
+					QPS:   ptr.To[float32](-1),
+					Burst: ptr.To[int32](0),
+				},
+				ControllerManager: configapi.ControllerManager{
+					Controller: &configapi.ControllerConfigurationSpec{
+						GroupKindConcurrency: map[string]int{
+							"Job.batch":               5,
+							"Pod":                     5,
+							"Workload.kueue.x-k8s.io": 5,
+						},
+					},
+				},
+			},
+		},
+		"invalid client connection and concurrency": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				ClientConnection: &configapi.ClientConnection{
+					Burst: ptr.To[int32](-1),
+				},
+				ControllerManager: configapi.ControllerManager{
+					Controller: &configapi.ControllerConfigurationSpec{
+						GroupKindConcurrency: map[string]int{
+							".batch":                  5,
+							"Pod":                     0,
+							"Workload.kueue.x-k8s.io": -1,
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "clientConnection.burst",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "controller.groupKindConcurrency",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "controller.groupKindConcurrency[Pod]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "controller.groupKindConcurrency[Workload.kueue.x-k8s.io]",
+				},
+			},
+		},
+		"invalid resources accounting": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					Accounting: []configapi.ResourceAccounting{
+						{Name: "nvidia.com/gpu", Policy: configapi.ResourceAccountingLimits},
+						{Name: "nvidia.com/gpu", Policy: configapi.ResourceAccountingRequests},
+						{Name: corev1.ResourceCPU, Policy: "Usage"},
+						{Policy: configapi.ResourceAccountingMaxOfRequestsAndLimits},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.accounting[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "resources.accounting[2].policy",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resources.accounting[3].name",
+				},
+			},
+		},
+		"invalid resources transformations": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					Transformations: []configapi.ResourceTransformation{
+						{
+							Input:   "example.com/gpu-slice",
+							Outputs: corev1.ResourceList{"example.com/gpu": resource.MustParse("250m")},
+						},
+						{
+							Input:   "example.com/gpu-slice",
+							Outputs: corev1.ResourceList{"example.com/gpu": resource.MustParse("-1")},
+						},
+						{
+							Input:   "example.com/gpu-half",
+							Outputs: corev1.ResourceList{"example.com/gpu-half": resource.MustParse("1")},
+						},
+						{
+							Input:    "example.com/gpu-full",
+							Strategy: ptr.To[configapi.ResourceTransformationStrategy]("Drop"),
+						},
+						{
+							Outputs: corev1.ResourceList{"example.com/gpu": resource.MustParse("1")},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.transformations[1].input",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.transformations[1].outputs[example.com/gpu]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.transformations[2].outputs[example.com/gpu-half]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resources.transformations[3].outputs",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "resources.transformations[3].strategy",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resources.transformations[4].input",
+				},
+			},
+		},
+		"invalid resources init containers accounting": {
+			cfg: &configapi.Configuration{
+				Integrations: defaultIntegrations,
+				Resources: &configapi.Resources{
+					InitContainers: &configapi.InitContainersAccounting{
+						Policy: ptr.To[configapi.InitContainersAccountingPolicy]("Min"),
+						Frameworks: []configapi.FrameworkInitContainersAccounting{
+							{Name: "batch/job", Policy: configapi.InitContainersAccountingSum},
+							{Name: "batch/job", Policy: configapi.InitContainersAccountingMax},
+							{Name: "ray.io/rayjob", Policy: configapi.InitContainersAccountingSum},
+							{Policy: "Min"},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "resources.initContainers.policy",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.initContainers.frameworks[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.initContainers.frameworks[2].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resources.initContainers.frameworks[3].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "resources.initContainers.frameworks[3].policy",
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
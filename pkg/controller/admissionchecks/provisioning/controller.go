@@ -34,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	autoscaling "k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/apis/autoscaling.x-k8s.io/v1beta1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
@@ -45,9 +46,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/podset"
 	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/util/resourceflavor"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -90,6 +93,7 @@ var _ reconcile.Reconciler = (*Controller)(nil)
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=admissionchecks,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=provisioningrequestconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=get;patch
 
 func NewController(client client.Client, record record.EventRecorder) (*Controller, error) {
 	helper, err := newProvisioningConfigHelper(client)
@@ -119,7 +123,13 @@ func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	if !workload.HasQuotaReservation(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
 		//1.2 workload has no reservation or is finished
 		log.V(5).Info("workload with no reservation, delete owned requests")
-		return reconcile.Result{}, c.deleteOwnedProvisionRequests(ctx, req.Namespace, req.Name)
+		if err := c.deleteOwnedProvisionRequests(ctx, req.Namespace, req.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		if workload.HasQuotaReservation(wl) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, c.resetRetryChecks(ctx, wl)
 	}
 
 	// get the lists of relevant checks
@@ -235,7 +245,8 @@ func (c *Controller) syncOwnedProvisionRequest(ctx context.Context, wl *kueue.Wo
 		if exists {
 			attempt = getAttempt(ctx, oldPr, wl.Name, checkName)
 			if apimeta.IsStatusConditionTrue(oldPr.Status.Conditions, autoscaling.Failed) {
-				if attempt <= MaxRetries {
+				// with a flavor cooldown, the workload gets other flavors instead
+				if prc.Spec.FlavorCooldownSeconds == nil && attempt <= MaxRetries {
 					prFailed := apimeta.FindStatusCondition(oldPr.Status.Conditions, autoscaling.Failed)
 					remainingTime := remainingTime(prc, attempt, prFailed.LastTransitionTime.Time)
 					if remainingTime <= 0 {
@@ -479,7 +490,19 @@ func (c *Controller) syncCheckStates(ctx context.Context, wl *kueue.Workload, ch
 
 			switch {
 			case prFailed:
-				if checkState.State != kueue.CheckStateRejected {
+				if prc.Spec.FlavorCooldownSeconds != nil {
+					if checkState.State != kueue.CheckStateRetry {
+						failed := apimeta.FindStatusCondition(pr.Status.Conditions, autoscaling.Failed)
+						until := failed.LastTransitionTime.Add(time.Duration(*prc.Spec.FlavorCooldownSeconds) * time.Second)
+						flavors, err := c.markFlavorsNotProvisionable(ctx, wl, pr, until)
+						if err != nil {
+							return err
+						}
+						updated = true
+						checkState.State = kueue.CheckStateRetry
+						checkState.Message = fmt.Sprintf("ResourceFlavors %v are not provisionable until %s: %s", flavors, until.UTC().Format(time.RFC3339), failed.Message)
+					}
+				} else if checkState.State != kueue.CheckStateRejected {
 					if attempt := getAttempt(ctx, pr, wl.Name, check); attempt <= MaxRetries {
 						// it is going to be retried
 						message := fmt.Sprintf("Retrying after failure: %s", apimeta.FindStatusCondition(pr.Status.Conditions, autoscaling.Failed).Message)
@@ -529,6 +552,75 @@ func (c *Controller) syncCheckStates(ctx context.Context, wl *kueue.Workload, ch
 	return nil
 }
 
+// markFlavorsNotProvisionable marks the flavors assigned to the pod sets of
+// the workload in the request as not provisionable until the time. It returns
+// the names of the flavors.
+func (c *Controller) markFlavorsNotProvisionable(ctx context.Context, wl *kueue.Workload, pr *autoscaling.ProvisioningRequest, until time.Time) ([]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	refMap := slices.ToMap(wl.Spec.PodSets, func(i int) (string, string) {
+		return getProvisioningRequestPodTemplateName(pr.Name, wl.Spec.PodSets[i].Name), wl.Spec.PodSets[i].Name
+	})
+	requestPodSets := sets.New[string]()
+	for i := range pr.Spec.PodSets {
+		requestPodSets.Insert(refMap[pr.Spec.PodSets[i].PodTemplateRef.Name])
+	}
+	names := sets.New[string]()
+	for i := range wl.Status.Admission.PodSetAssignments {
+		psa := &wl.Status.Admission.PodSetAssignments[i]
+		if !requestPodSets.Has(psa.Name) {
+			continue
+		}
+		for _, flavor := range psa.Flavors {
+			names.Insert(string(flavor))
+		}
+	}
+	flavors := sets.List(names)
+	for _, name := range flavors {
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			rf := &kueue.ResourceFlavor{}
+			if err := c.client.Get(ctx, types.NamespacedName{Name: name}, rf); err != nil {
+				return err
+			}
+			patch := client.MergeFromWithOptions(rf.DeepCopy(), client.MergeFromWithOptimisticLock{})
+			if !resourceflavor.MarkNotProvisionable(rf, until) {
+				return nil
+			}
+			log.V(3).Info("Marking the ResourceFlavor as not provisionable", "resourceFlavor", klog.KObj(rf), "until", until)
+			return c.client.Patch(ctx, rf, patch)
+		})
+		if client.IgnoreNotFound(err) != nil {
+			return nil, err
+		}
+	}
+	return flavors, nil
+}
+
+// resetRetryChecks sets the checks of the controller that are in the Retry
+// state back to Pending, once the workload released its quota reservation,
+// so that the workload can be admitted again.
+func (c *Controller) resetRetryChecks(ctx context.Context, wl *kueue.Workload) error {
+	relevantChecks, err := admissioncheck.FilterForController(ctx, c.client, wl.Status.AdmissionChecks, ControllerName)
+	if err != nil {
+		return err
+	}
+	wlPatch := workload.BaseSSAWorkload(wl)
+	updated := false
+	for _, check := range relevantChecks {
+		checkState := *workload.FindAdmissionCheck(wl.Status.AdmissionChecks, check)
+		if checkState.State == kueue.CheckStateRetry {
+			updated = true
+			checkState.State = kueue.CheckStatePending
+			checkState.Message = ""
+			checkState.PodSetUpdates = nil
+		}
+		workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, checkState)
+	}
+	if !updated {
+		return nil
+	}
+	return c.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(ControllerName), client.ForceOwnership)
+}
+
 func podSetUpdates(wl *kueue.Workload, pr *autoscaling.ProvisioningRequest) []kueue.PodSetUpdate {
 	podSets := wl.Spec.PodSets
 	refMap := slices.ToMap(podSets, func(i int) (string, string) {
@@ -597,7 +689,7 @@ func (a *acHandler) Generic(_ context.Context, _ event.GenericEvent, _ workqueue
 
 func (a *acHandler) reconcileWorkloadsUsing(ctx context.Context, check string, q workqueue.RateLimitingInterface) error {
 	list := &kueue.WorkloadList{}
-	if err := a.client.List(ctx, list, client.MatchingFields{WorkloadsWithAdmissionCheckKey: check}); client.IgnoreNotFound(err) != nil {
+	if err := a.client.List(ctx, list, client.MatchingFields{indexer.WorkloadAdmissionCheckKey: check}); client.IgnoreNotFound(err) != nil {
 		return err
 	}
 
@@ -17,7 +17,9 @@ limitations under the License.
 package provisioning
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -30,9 +32,11 @@ import (
 	autoscaling "k8s.io/autoscaler/cluster-autoscaler/provisioningrequest/apis/autoscaling.x-k8s.io/v1beta1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -235,19 +239,23 @@ func TestReconcile(t *testing.T) {
 		Obj()
 
 	cases := map[string]struct {
-		requests             []autoscaling.ProvisioningRequest
-		templates            []corev1.PodTemplate
-		checks               []kueue.AdmissionCheck
-		configs              []kueue.ProvisioningRequestConfig
-		flavors              []kueue.ResourceFlavor
-		workload             *kueue.Workload
-		maxRetries           int32
-		wantReconcileError   error
-		wantWorkloads        map[string]*kueue.Workload
-		wantRequests         map[string]*autoscaling.ProvisioningRequest
-		wantTemplates        map[string]*corev1.PodTemplate
-		wantRequestsNotFound []string
-		wantEvents           []utiltesting.EventRecord
+		requests              []autoscaling.ProvisioningRequest
+		templates             []corev1.PodTemplate
+		checks                []kueue.AdmissionCheck
+		configs               []kueue.ProvisioningRequestConfig
+		flavors               []kueue.ResourceFlavor
+		workload              *kueue.Workload
+		maxRetries            int32
+		wantReconcileError    error
+		wantWorkloads         map[string]*kueue.Workload
+		wantRequests          map[string]*autoscaling.ProvisioningRequest
+		wantTemplates         map[string]*corev1.PodTemplate
+		wantRequestsNotFound  []string
+		wantEvents            []utiltesting.EventRecord
+		wantFlavorAnnotations map[string]map[string]string
+		// concurrentFlavorUpdate updates the annotations of a flavor before
+		// the first patch of the controller to it.
+		concurrentFlavorUpdate bool
 	}{
 		"unrelated workload": {
 			workload: utiltesting.MakeWorkload("wl", "ns").Obj(),
@@ -439,6 +447,181 @@ func TestReconcile(t *testing.T) {
 					Obj(),
 			},
 		},
+		"when request fails with a flavor cooldown": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "config1",
+					},
+					Spec: kueue.ProvisioningRequestConfigSpec{
+						ProvisioningClassName: "class1",
+						Parameters: map[string]kueue.Parameter{
+							"p1": "v1",
+						},
+						FlavorCooldownSeconds: ptr.To[int32](600),
+					},
+				},
+			},
+			requests: func() []autoscaling.ProvisioningRequest {
+				r := baseRequest.DeepCopy()
+				r.Status.Conditions = []metav1.Condition{{
+					Type:               autoscaling.Failed,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)),
+					Message:            "capacity not available",
+				}}
+				return []autoscaling.ProvisioningRequest{*r}
+			}(),
+			maxRetries: 2,
+			templates:  []corev1.PodTemplate{*baseTemplate1.DeepCopy(), *baseTemplate2.DeepCopy()},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStateRetry,
+						Message: "ResourceFlavors [flv1 flv2] are not provisionable until 2023-11-01T10:10:00Z: capacity not available",
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequestsNotFound: []string{"wl-check1-2"},
+			wantFlavorAnnotations: map[string]map[string]string{
+				"flv1": {controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z"},
+				"flv2": {controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z"},
+			},
+		},
+		"when request fails with a flavor cooldown, marks the flavors of the requested pod sets": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "config1",
+					},
+					Spec: kueue.ProvisioningRequestConfigSpec{
+						ProvisioningClassName: "class1",
+						Parameters: map[string]kueue.Parameter{
+							"p1": "v1",
+						},
+						ManagedResources:      []corev1.ResourceName{corev1.ResourceCPU},
+						FlavorCooldownSeconds: ptr.To[int32](600),
+					},
+				},
+			},
+			requests: func() []autoscaling.ProvisioningRequest {
+				r := baseRequest.DeepCopy()
+				r.Spec.PodSets = r.Spec.PodSets[:1]
+				r.Status.Conditions = []metav1.Condition{{
+					Type:               autoscaling.Failed,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)),
+					Message:            "capacity not available",
+				}}
+				return []autoscaling.ProvisioningRequest{*r}
+			}(),
+			maxRetries: 2,
+			templates:  []corev1.PodTemplate{*baseTemplate1.DeepCopy()},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStateRetry,
+						Message: "ResourceFlavors [flv1] are not provisionable until 2023-11-01T10:10:00Z: capacity not available",
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequestsNotFound: []string{"wl-check1-2"},
+			wantFlavorAnnotations: map[string]map[string]string{
+				"flv1": {controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z"},
+				"flv2": nil,
+			},
+		},
+		"when request fails with a flavor cooldown, and the flavors are updated concurrently": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "config1",
+					},
+					Spec: kueue.ProvisioningRequestConfigSpec{
+						ProvisioningClassName: "class1",
+						Parameters: map[string]kueue.Parameter{
+							"p1": "v1",
+						},
+						FlavorCooldownSeconds: ptr.To[int32](600),
+					},
+				},
+			},
+			requests: func() []autoscaling.ProvisioningRequest {
+				r := baseRequest.DeepCopy()
+				r.Status.Conditions = []metav1.Condition{{
+					Type:               autoscaling.Failed,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)),
+					Message:            "capacity not available",
+				}}
+				return []autoscaling.ProvisioningRequest{*r}
+			}(),
+			maxRetries:             2,
+			templates:              []corev1.PodTemplate{*baseTemplate1.DeepCopy(), *baseTemplate2.DeepCopy()},
+			concurrentFlavorUpdate: true,
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStateRetry,
+						Message: "ResourceFlavors [flv1 flv2] are not provisionable until 2023-11-01T10:10:00Z: capacity not available",
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequestsNotFound: []string{"wl-check1-2"},
+			wantFlavorAnnotations: map[string]map[string]string{
+				"flv1": {
+					controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z",
+					"example.com/updated":                            "true",
+				},
+				"flv2": {controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z"},
+			},
+		},
+		"when the workload released the quota after a retry": {
+			workload: utiltesting.MakeWorkload("wl", TestNamespace).
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check1",
+					State:   kueue.CheckStateRetry,
+					Message: "ResourceFlavors [flv1] are not provisionable until 2023-11-01T10:10:00Z",
+				}, kueue.AdmissionCheckState{
+					Name:  "not-provisioning",
+					State: kueue.CheckStateRetry,
+				}).
+				Obj(),
+			checks:  []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			wantWorkloads: map[string]*kueue.Workload{
+				"wl": utiltesting.MakeWorkload("wl", TestNamespace).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:  "check1",
+						State: kueue.CheckStatePending,
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStateRetry,
+					}).
+					Obj(),
+			},
+		},
 		"when request fails, and there is no retry": {
 			workload: baseWorkload.DeepCopy(),
 			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
@@ -658,6 +841,25 @@ func TestReconcile(t *testing.T) {
 				&kueue.AdmissionCheckList{Items: tc.checks},
 				&kueue.ResourceFlavorList{Items: tc.flavors},
 			)
+			if tc.concurrentFlavorUpdate {
+				updated := false
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, client client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if _, isFlavor := obj.(*kueue.ResourceFlavor); isFlavor && !updated {
+							updated = true
+							rf := &kueue.ResourceFlavor{}
+							if err := client.Get(ctx, types.NamespacedName{Name: obj.GetName()}, rf); err != nil {
+								return err
+							}
+							rf.Annotations = map[string]string{"example.com/updated": "true"}
+							if err := client.Update(ctx, rf); err != nil {
+								return err
+							}
+						}
+						return client.Patch(ctx, obj, patch, opts...)
+					},
+				})
+			}
 
 			k8sclient := builder.Build()
 			recorder := &utiltesting.EventRecorder{}
@@ -717,6 +919,16 @@ func TestReconcile(t *testing.T) {
 				}
 			}
 
+			for name, wantAnnotations := range tc.wantFlavorAnnotations {
+				gotFlavor := &kueue.ResourceFlavor{}
+				if err := k8sclient.Get(ctx, types.NamespacedName{Name: name}, gotFlavor); err != nil {
+					t.Errorf("unexpected error getting flavor %q", name)
+				}
+				if diff := cmp.Diff(wantAnnotations, gotFlavor.Annotations); diff != "" {
+					t.Errorf("unexpected annotations of flavor %q (-want/+got):\n%s", name, diff)
+				}
+			}
+
 			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
 				t.Errorf("unexpected events (-want/+got):\n%s", diff)
 			}
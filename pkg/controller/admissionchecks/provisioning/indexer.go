@@ -32,9 +32,8 @@ import (
 )
 
 const (
-	RequestsOwnedByWorkloadKey     = "metadata.ownedByWorkload"
-	WorkloadsWithAdmissionCheckKey = "status.admissionChecks"
-	AdmissionCheckUsingConfigKey   = "spec.provisioningRequestConfig"
+	RequestsOwnedByWorkloadKey   = "metadata.ownedByWorkload"
+	AdmissionCheckUsingConfigKey = "spec.provisioningRequestConfig"
 )
 
 var (
@@ -49,22 +48,10 @@ func indexRequestsOwner(obj client.Object) []string {
 	return slices.Map(refs, func(r *metav1.OwnerReference) string { return r.Name })
 }
 
-func indexWorkloadsChecks(obj client.Object) []string {
-	wl, isWl := obj.(*kueue.Workload)
-	if !isWl || len(wl.Status.AdmissionChecks) == 0 {
-		return nil
-	}
-	return slices.Map(wl.Status.AdmissionChecks, func(c *kueue.AdmissionCheckState) string { return c.Name })
-}
-
 func SetupIndexer(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &autoscaling.ProvisioningRequest{}, RequestsOwnedByWorkloadKey, indexRequestsOwner); err != nil {
 		return fmt.Errorf("setting index on provisionRequest owner: %w", err)
 	}
-	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadsWithAdmissionCheckKey, indexWorkloadsChecks); err != nil {
-		return fmt.Errorf("setting index on workloads checks: %w", err)
-	}
-
 	if err := indexer.IndexField(ctx, &kueue.AdmissionCheck{}, AdmissionCheckUsingConfigKey, admissioncheck.IndexerByConfigFunction(ControllerName, configGVK)); err != nil {
 		return fmt.Errorf("setting index on admission checks config: %w", err)
 	}
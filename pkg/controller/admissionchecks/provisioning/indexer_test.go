@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
@@ -57,7 +58,7 @@ func getClientBuilder() (*fake.ClientBuilder, context.Context) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name: TestNamespace,
 		},
-	})
+	}).WithIndex(&kueue.Workload{}, indexer.WorkloadAdmissionCheckKey, indexer.IndexWorkloadAdmissionChecks)
 	_ = SetupIndexer(ctx, utiltesting.AsIndexer(builder))
 	return builder, ctx
 }
@@ -182,7 +183,7 @@ func TestIndexWorkload(t *testing.T) {
 					},
 				},
 			},
-			filter: client.MatchingFields{WorkloadsWithAdmissionCheckKey: "check"},
+			filter: client.MatchingFields{indexer.WorkloadAdmissionCheckKey: "check"},
 		},
 		"single check, single match": {
 			workloads: []*kueue.Workload{
@@ -206,7 +207,7 @@ func TestIndexWorkload(t *testing.T) {
 					},
 				},
 			},
-			filter:   client.MatchingFields{WorkloadsWithAdmissionCheckKey: "check"},
+			filter:   client.MatchingFields{indexer.WorkloadAdmissionCheckKey: "check"},
 			wantList: []string{"name2"},
 		},
 		"multiple checks, multiple matches": {
@@ -247,7 +248,7 @@ func TestIndexWorkload(t *testing.T) {
 					},
 				},
 			},
-			filter:   client.MatchingFields{WorkloadsWithAdmissionCheckKey: "check"},
+			filter:   client.MatchingFields{indexer.WorkloadAdmissionCheckKey: "check"},
 			wantList: []string{"name2", "name3"},
 		},
 	}
@@ -44,4 +44,44 @@ const (
 	// workloadPriorityClass name.
 	// This label is always mutable because it might be useful for the preemption.
 	WorkloadPriorityClassLabel = "kueue.x-k8s.io/priority-class"
+
+	// DefaultLocalQueueLabel is the label key marking the LocalQueue where the
+	// jobs submitted without a queue name in its namespace are queued, when the
+	// value is "true".
+	DefaultLocalQueueLabel = "kueue.x-k8s.io/default-queue"
+
+	// PodSetMinCountsAnnotation is the annotation key of the job holding the
+	// minimum counts of its pod sets for partial admission, as a comma
+	// separated list of <podSetName>=<minCount> pairs. It is only honored for
+	// the integrations that support partial admission.
+	PodSetMinCountsAnnotation = "kueue.x-k8s.io/podset-min-counts"
+
+	// ClusterQueueLabel is the label key of the admitted jobs and their pods
+	// holding the name of the ClusterQueue that admitted them.
+	ClusterQueueLabel = "kueue.x-k8s.io/cluster-queue"
+
+	// CohortLabel is the label key of the admitted jobs and their pods holding
+	// the cohort of the ClusterQueue that admitted them.
+	CohortLabel = "kueue.x-k8s.io/cohort"
+
+	// FlavorLabel is the label key of the admitted pods holding the flavors
+	// assigned to their pod set. The names of the flavors are sorted and
+	// separated by "_" when the pod set has more than one.
+	FlavorLabel = "kueue.x-k8s.io/flavor"
+
+	// PodSetFlavorLabelPrefix is the prefix of the label keys of the admitted
+	// jobs holding the flavors assigned to every pod set, as the FlavorLabel
+	// of its pods. The name of the label is the name of the pod set.
+	PodSetFlavorLabelPrefix = "flavor.kueue.x-k8s.io/"
+
+	// ReactivatedByAnnotation is the annotation key of the workloads holding
+	// the name of the user that activated them again after they were
+	// deactivated for exhausting their retries.
+	ReactivatedByAnnotation = "kueue.x-k8s.io/reactivated-by"
+
+	// NotProvisionableUntilAnnotation is the annotation key of the
+	// ResourceFlavors holding the time, in RFC 3339 format, until which they
+	// aren't assigned to workloads, after the ProvisioningRequest of a workload
+	// assigned to them failed.
+	NotProvisionableUntilAnnotation = "kueue.x-k8s.io/not-provisionable-until"
 )
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
@@ -214,13 +215,15 @@ func (h *acCqHandler) Generic(ctx context.Context, e event.GenericEvent, q workq
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *AdmissionCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *AdmissionCheckReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	handler := acCqHandler{
 		cache: r.cache,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.AdmissionCheck{}).
 		WatchesRawSource(&source.Channel{Source: r.cqUpdateCh}, &handler).
+		WatchesRawSource(requeueOnElection(mgr, cfg, &kueue.AdmissionCheckList{}), electionEventHandler).
 		WithEventFilter(r).
-		Complete(r)
+		WithOptions(controllerOptions()).
+		Complete(withLeadingManager(mgr, r, cfg))
 }
@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
@@ -54,6 +55,12 @@ type ClusterQueueUpdateWatcher interface {
 	NotifyClusterQueueUpdate(*kueue.ClusterQueue, *kueue.ClusterQueue)
 }
 
+// LocalQueueSnapshotWatcher is notified when the snapshot of the pending
+// workloads in a LocalQueue, identified by its key, changes.
+type LocalQueueSnapshotWatcher interface {
+	NotifyLocalQueueSnapshotUpdate(string)
+}
+
 // ClusterQueueReconciler reconciles a ClusterQueue object
 type ClusterQueueReconciler struct {
 	client                               client.Client
@@ -69,6 +76,9 @@ type ClusterQueueReconciler struct {
 	reportResourceMetrics                bool
 	queueVisibilityUpdateInterval        time.Duration
 	queueVisibilityClusterQueuesMaxCount int32
+	queueVisibilityLocalQueuesMaxCount   int32
+	queueVisibilityIncludePositions      bool
+	lqSnapshotWatchers                   []LocalQueueSnapshotWatcher
 }
 
 type ClusterQueueReconcilerOptions struct {
@@ -76,6 +86,9 @@ type ClusterQueueReconcilerOptions struct {
 	ReportResourceMetrics                bool
 	QueueVisibilityUpdateInterval        time.Duration
 	QueueVisibilityClusterQueuesMaxCount int32
+	QueueVisibilityLocalQueuesMaxCount   int32
+	QueueVisibilityIncludePositions      bool
+	LocalQueueSnapshotWatchers           []LocalQueueSnapshotWatcher
 }
 
 // ClusterQueueReconcilerOption configures the reconciler.
@@ -109,6 +122,30 @@ func WithQueueVisibilityClusterQueuesMaxCount(value int32) ClusterQueueReconcile
 	}
 }
 
+// WithQueueVisibilityLocalQueuesMaxCount indicates the maximal number of pending workloads exposed in the
+// local queue status
+func WithQueueVisibilityLocalQueuesMaxCount(value int32) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.QueueVisibilityLocalQueuesMaxCount = value
+	}
+}
+
+// WithQueueVisibilityIncludePositions indicates whether the positions of the pending workloads are exposed
+// in the cluster queue and local queue status
+func WithQueueVisibilityIncludePositions(include bool) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.QueueVisibilityIncludePositions = include
+	}
+}
+
+// WithLocalQueueSnapshotWatchers sets the watchers notified when the snapshot of pending workloads
+// of a local queue changes
+func WithLocalQueueSnapshotWatchers(watchers ...LocalQueueSnapshotWatcher) ClusterQueueReconcilerOption {
+	return func(o *ClusterQueueReconcilerOptions) {
+		o.LocalQueueSnapshotWatchers = watchers
+	}
+}
+
 var defaultCQOptions = ClusterQueueReconcilerOptions{}
 
 func NewClusterQueueReconciler(
@@ -135,6 +172,9 @@ func NewClusterQueueReconciler(
 		reportResourceMetrics:                options.ReportResourceMetrics,
 		queueVisibilityUpdateInterval:        options.QueueVisibilityUpdateInterval,
 		queueVisibilityClusterQueuesMaxCount: options.QueueVisibilityClusterQueuesMaxCount,
+		queueVisibilityLocalQueuesMaxCount:   options.QueueVisibilityLocalQueuesMaxCount,
+		queueVisibilityIncludePositions:      options.QueueVisibilityIncludePositions,
+		lqSnapshotWatchers:                   options.LocalQueueSnapshotWatchers,
 	}
 }
 
@@ -349,6 +389,26 @@ func recordResourceMetrics(cq *kueue.ClusterQueue) {
 	}
 }
 
+// recordCohortShareMetrics reports the share metrics of every ClusterQueue in
+// the cohort of cq, since they all depend on the quotas of the cohort.
+func (r *ClusterQueueReconciler) recordCohortShareMetrics(cq *kueue.ClusterQueue) {
+	shares, err := r.cache.CohortShares(cq)
+	if err != nil || shares[cq.Name] == nil {
+		metrics.ClearClusterQueueCohortShare(cq.Name)
+	}
+	for name, share := range shares {
+		fairShare := make(map[string]float64, len(share.FairShare))
+		for r, v := range share.FairShare {
+			fairShare[string(r)] = v
+		}
+		utilization := make(map[string]float64, len(share.Utilization))
+		for r, v := range share.Utilization {
+			utilization[string(r)] = v
+		}
+		metrics.ReportClusterQueueCohortShare(cq.Spec.Cohort, name, fairShare, utilization, share.WeightedShare)
+	}
+}
+
 func updateResourceMetrics(oldCq, newCq *kueue.ClusterQueue) {
 	// if the cohort changed, drop all the old metrics
 	if oldCq.Spec.Cohort != newCq.Spec.Cohort {
@@ -483,10 +543,8 @@ func (h *cqNamespaceHandler) Create(ctx context.Context, e event.CreateEvent, q
 }
 
 func (h *cqNamespaceHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
-	oldNs := e.ObjectOld.(*corev1.Namespace)
-	oldMatchingCqs := h.cache.MatchingClusterQueues(oldNs.Labels)
-	newNs := e.ObjectNew.(*corev1.Namespace)
-	newMatchingCqs := h.cache.MatchingClusterQueues(newNs.Labels)
+	oldMatchingCqs := h.cache.MatchingClusterQueues(e.ObjectOld.GetLabels())
+	newMatchingCqs := h.cache.MatchingClusterQueues(e.ObjectNew.GetLabels())
 	cqs := sets.New[string]()
 	for cq := range newMatchingCqs {
 		if !oldMatchingCqs.Has(cq) {
@@ -594,7 +652,7 @@ func (h *cqSnapshotHandler) Generic(_ context.Context, e event.GenericEvent, q w
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	wHandler := cqWorkloadHandler{
 		qManager: r.qManager,
 	}
@@ -613,13 +671,16 @@ func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.ClusterQueue{}).
-		Watches(&corev1.Namespace{}, &nsHandler).
+		// Only the labels of the namespaces are needed.
+		WatchesMetadata(&corev1.Namespace{}, &nsHandler).
 		WatchesRawSource(&source.Channel{Source: r.wlUpdateCh}, &wHandler).
 		WatchesRawSource(&source.Channel{Source: r.rfUpdateCh}, &rfHandler).
 		WatchesRawSource(&source.Channel{Source: r.acUpdateCh}, &acHandler).
 		WatchesRawSource(&source.Channel{Source: r.snapUpdateCh}, &snapHandler).
+		WatchesRawSource(requeueOnElection(mgr, cfg, &kueue.ClusterQueueList{}), electionEventHandler).
 		WithEventFilter(r).
-		Complete(r)
+		WithOptions(controllerOptions()).
+		Complete(withLeadingManager(mgr, r, cfg))
 }
 
 func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
@@ -639,10 +700,14 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	}
 	cq.Status.FlavorsReservation = stats.ReservedResources
 	cq.Status.FlavorsUsage = stats.AdmittedResources
+	cq.Status.FlavorsNominalQuota = stats.NominalQuotas
 	cq.Status.ReservingWorkloads = int32(stats.ReservingWorkloads)
 	cq.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
 	cq.Status.PendingWorkloads = int32(pendingWorkloads)
 	cq.Status.PendingWorkloadsStatus = r.getWorkloadsStatus(cq)
+	if r.reportResourceMetrics {
+		r.recordCohortShareMetrics(cq)
+	}
 	meta.SetStatusCondition(&cq.Status.Conditions, metav1.Condition{
 		Type:    kueue.ClusterQueueActive,
 		Status:  conditionStatus,
@@ -657,11 +722,12 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 
 // Taking snapshot of cluster queue is enabled when maxcount non-zero
 func (r *ClusterQueueReconciler) isVisibilityEnabled() bool {
-	return features.Enabled(features.QueueVisibility) && r.queueVisibilityClusterQueuesMaxCount > 0
+	return features.Enabled(features.QueueVisibility) &&
+		(r.queueVisibilityClusterQueuesMaxCount > 0 || r.queueVisibilityLocalQueuesMaxCount > 0)
 }
 
 func (r *ClusterQueueReconciler) getWorkloadsStatus(cq *kueue.ClusterQueue) *kueue.ClusterQueuePendingWorkloadsStatus {
-	if !r.isVisibilityEnabled() {
+	if !r.isVisibilityEnabled() || r.queueVisibilityClusterQueuesMaxCount == 0 {
 		return nil
 	}
 	pendingWorkloads := r.qManager.GetSnapshot(cq.Name)
@@ -721,7 +787,17 @@ func (r *ClusterQueueReconciler) processNextSnapshot(ctx context.Context) bool {
 	defer r.snapshotsQueue.Done(key)
 
 	cqName := key.(string)
-	if r.qManager.UpdateSnapshot(cqName, r.queueVisibilityClusterQueuesMaxCount) {
+	cqUpdated, lqsUpdated := r.qManager.UpdateSnapshot(cqName, queue.SnapshotOptions{
+		ClusterQueueMaxCount: r.queueVisibilityClusterQueuesMaxCount,
+		LocalQueueMaxCount:   r.queueVisibilityLocalQueuesMaxCount,
+		IncludePositions:     r.queueVisibilityIncludePositions,
+	})
+	for _, lqKey := range lqsUpdated {
+		for _, w := range r.lqSnapshotWatchers {
+			w.NotifyLocalQueueSnapshotUpdate(lqKey)
+		}
+	}
+	if cqUpdated {
 		log.V(5).Info("Triggering CQ update due to snapshot change", "clusterQueue", klog.KRef("", cqName))
 		r.snapUpdateCh <- event.GenericEvent{Object: &kueue.ClusterQueue{
 			ObjectMeta: metav1.ObjectMeta{
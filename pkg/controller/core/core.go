@@ -22,26 +22,56 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/accounting"
 	"sigs.k8s.io/kueue/pkg/cache"
+	kueueconfig "sigs.k8s.io/kueue/pkg/config"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/eventexporter"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
-const updateChBuffer = 10
+const (
+	updateChBuffer = 10
+
+	usageAccountingSinkTimeout = 30 * time.Second
+)
+
+type setupOptions struct {
+	configReloader *kueueconfig.Reloader
+}
+
+// SetupOption configures the core controllers.
+type SetupOption func(*setupOptions)
+
+// WithConfigReloader makes the controllers apply the reloaded configurations.
+func WithConfigReloader(r *kueueconfig.Reloader) SetupOption {
+	return func(o *setupOptions) {
+		o.configReloader = r
+	}
+}
 
 // SetupControllers sets up the core controllers. It returns the name of the
 // controller that failed to create and an error, if any.
-func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache, cfg *config.Configuration) (string, error) {
+func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache, cfg *config.Configuration, opts ...SetupOption) (string, error) {
+	var options setupOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	rfRec := NewResourceFlavorReconciler(mgr.GetClient(), qManager, cc)
-	if err := rfRec.SetupWithManager(mgr); err != nil {
+	if err := rfRec.SetupWithManager(mgr, cfg); err != nil {
 		return "ResourceFlavor", err
 	}
 	acRec := NewAdmissionCheckReconciler(mgr.GetClient(), qManager, cc)
-	if err := acRec.SetupWithManager(mgr); err != nil {
+	if err := acRec.SetupWithManager(mgr, cfg); err != nil {
 		return "AdmissionCheck", err
 	}
+	wpcRec := NewWorkloadPriorityClassReconciler(qManager, cc)
+	if err := wpcRec.SetupWithManager(mgr, cfg); err != nil {
+		return "WorkloadPriorityClass", err
+	}
 	qRec := NewLocalQueueReconciler(mgr.GetClient(), qManager, cc)
-	if err := qRec.SetupWithManager(mgr); err != nil {
+	if err := qRec.SetupWithManager(mgr, cfg); err != nil {
 		return "LocalQueue", err
 	}
 
@@ -51,6 +81,9 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 		cc,
 		WithQueueVisibilityUpdateInterval(queueVisibilityUpdateInterval(cfg)),
 		WithQueueVisibilityClusterQueuesMaxCount(queueVisibilityClusterQueuesMaxCount(cfg)),
+		WithQueueVisibilityLocalQueuesMaxCount(queueVisibilityLocalQueuesMaxCount(cfg)),
+		WithQueueVisibilityIncludePositions(queueVisibilityIncludePositions(cfg)),
+		WithLocalQueueSnapshotWatchers(qRec),
 		WithReportResourceMetrics(cfg.Metrics.EnableClusterQueueResources),
 		WithWatchers(rfRec, acRec),
 	)
@@ -59,15 +92,36 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 	}
 	rfRec.AddUpdateWatcher(cqRec)
 	acRec.AddUpdateWatchers(cqRec)
-	if err := cqRec.SetupWithManager(mgr); err != nil {
+	if err := cqRec.SetupWithManager(mgr, cfg); err != nil {
 		return "ClusterQueue", err
 	}
-	if err := NewWorkloadReconciler(mgr.GetClient(), qManager, cc,
+	wlWatchers := []WorkloadUpdateWatcher{qRec, cqRec}
+	if exporter := newEventExporter(cfg); exporter != nil {
+		if err := mgr.Add(exporter); err != nil {
+			return "Unable to add EventExporter to manager", err
+		}
+		wlWatchers = append(wlWatchers, exporter)
+	}
+	accountant, err := newUsageAccountant(cfg)
+	if err != nil {
+		return "Unable to open the usage records", err
+	}
+	if accountant != nil {
+		if err := mgr.Add(accountant); err != nil {
+			return "Unable to add UsageAccounting to manager", err
+		}
+		wlWatchers = append(wlWatchers, accountant)
+	}
+	wlRec := NewWorkloadReconciler(mgr.GetClient(), qManager, cc,
 		mgr.GetEventRecorderFor(constants.WorkloadControllerName),
-		WithWorkloadUpdateWatchers(qRec, cqRec),
-		WithPodsReadyTimeout(podsReadyTimeout(cfg))).SetupWithManager(mgr); err != nil {
+		WithWorkloadUpdateWatchers(wlWatchers...),
+		WithPodsReadyTimeout(podsReadyTimeout(cfg)))
+	if err := wlRec.SetupWithManager(mgr, cfg); err != nil {
 		return "Workload", err
 	}
+	options.configReloader.AddHandler(func(cfg *config.Configuration) {
+		wlRec.SetPodsReadyTimeout(podsReadyTimeout(cfg))
+	})
 	return "", nil
 }
 
@@ -91,3 +145,51 @@ func queueVisibilityClusterQueuesMaxCount(cfg *config.Configuration) int32 {
 	}
 	return 0
 }
+
+func queueVisibilityLocalQueuesMaxCount(cfg *config.Configuration) int32 {
+	if cfg.QueueVisibility != nil && cfg.QueueVisibility.LocalQueues != nil {
+		return cfg.QueueVisibility.LocalQueues.MaxCount
+	}
+	return 0
+}
+
+func queueVisibilityIncludePositions(cfg *config.Configuration) bool {
+	return cfg.QueueVisibility != nil && cfg.QueueVisibility.IncludePositions
+}
+
+func newEventExporter(cfg *config.Configuration) *eventexporter.Exporter {
+	if cfg.EventExporter == nil || !cfg.EventExporter.Enable {
+		return nil
+	}
+	opts := []eventexporter.Option{eventexporter.WithHeaders(cfg.EventExporter.Headers)}
+	if cfg.EventExporter.Source != nil {
+		opts = append(opts, eventexporter.WithSource(*cfg.EventExporter.Source))
+	}
+	if cfg.EventExporter.Timeout != nil {
+		opts = append(opts, eventexporter.WithTimeout(cfg.EventExporter.Timeout.Duration))
+	}
+	if cfg.EventExporter.MaxRetries != nil {
+		opts = append(opts, eventexporter.WithMaxRetries(*cfg.EventExporter.MaxRetries))
+	}
+	if cfg.EventExporter.BufferSize != nil {
+		opts = append(opts, eventexporter.WithBufferSize(*cfg.EventExporter.BufferSize))
+	}
+	return eventexporter.New(cfg.EventExporter.Sink, opts...)
+}
+
+func newUsageAccountant(cfg *config.Configuration) (*accounting.Accountant, error) {
+	if cfg.UsageAccounting == nil || !cfg.UsageAccounting.Enable {
+		return nil, nil
+	}
+	var backend accounting.Backend
+	if cfg.UsageAccounting.Sink != "" {
+		backend = accounting.NewHTTPBackend(cfg.UsageAccounting.Sink, usageAccountingSinkTimeout)
+	} else {
+		fileBackend, err := accounting.NewFileBackend(cfg.UsageAccounting.Path, int64(*cfg.UsageAccounting.MaxSizeMB)*1024*1024, int(*cfg.UsageAccounting.MaxBackups))
+		if err != nil {
+			return nil, err
+		}
+		backend = fileBackend
+	}
+	return accounting.New(backend, accounting.WithInterval(cfg.UsageAccounting.Interval.Duration)), nil
+}
@@ -36,6 +36,8 @@ const (
 	LimitRangeHasContainerType = "spec.hasContainerType"
 	WorkloadQuotaReservedKey   = "status.quotaReserved"
 	WorkloadRuntimeClassKey    = "spec.runtimeClass"
+	WorkloadAdmissionCheckKey  = "status.admissionChecks"
+	WorkloadFlavorKey          = "status.admission.flavors"
 )
 
 func IndexQueueClusterQueue(obj client.Object) []string {
@@ -110,6 +112,35 @@ func IndexWorkloadRuntimeClass(obj client.Object) []string {
 	return nil
 }
 
+func IndexWorkloadAdmissionChecks(obj client.Object) []string {
+	wl, ok := obj.(*kueue.Workload)
+	if !ok || len(wl.Status.AdmissionChecks) == 0 {
+		return nil
+	}
+	checks := make([]string, len(wl.Status.AdmissionChecks))
+	for i := range wl.Status.AdmissionChecks {
+		checks[i] = wl.Status.AdmissionChecks[i].Name
+	}
+	return checks
+}
+
+func IndexWorkloadFlavors(obj client.Object) []string {
+	wl, ok := obj.(*kueue.Workload)
+	if !ok || wl.Status.Admission == nil {
+		return nil
+	}
+	set := sets.New[string]()
+	for _, psa := range wl.Status.Admission.PodSetAssignments {
+		for _, flv := range psa.Flavors {
+			set.Insert(string(flv))
+		}
+	}
+	if set.Len() > 0 {
+		return set.UnsortedList()
+	}
+	return nil
+}
+
 // Setup sets the index with the given fields for core apis.
 func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadQueueKey, IndexWorkloadQueue); err != nil {
@@ -124,6 +155,12 @@ func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadRuntimeClassKey, IndexWorkloadRuntimeClass); err != nil {
 		return fmt.Errorf("setting index on runtimeClass for Workload: %w", err)
 	}
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadAdmissionCheckKey, IndexWorkloadAdmissionChecks); err != nil {
+		return fmt.Errorf("setting index on admissionChecks for Workload: %w", err)
+	}
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadFlavorKey, IndexWorkloadFlavors); err != nil {
+		return fmt.Errorf("setting index on flavors for Workload: %w", err)
+	}
 	if err := indexer.IndexField(ctx, &kueue.LocalQueue{}, QueueClusterQueueKey, IndexQueueClusterQueue); err != nil {
 		return fmt.Errorf("setting index on clusterQueue for localQueue: %w", err)
 	}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestIndexers(t *testing.T) {
+	cases := map[string]struct {
+		obj     client.Object
+		indexer client.IndexerFunc
+		want    []string
+	}{
+		"workload without admission checks": {
+			obj:     &kueue.Workload{},
+			indexer: IndexWorkloadAdmissionChecks,
+		},
+		"workload with admission checks": {
+			obj: &kueue.Workload{
+				Status: kueue.WorkloadStatus{
+					AdmissionChecks: []kueue.AdmissionCheckState{{Name: "check1"}, {Name: "check2"}},
+				},
+			},
+			indexer: IndexWorkloadAdmissionChecks,
+			want:    []string{"check1", "check2"},
+		},
+		"admission checks of a non-workload": {
+			obj:     &kueue.ClusterQueue{},
+			indexer: IndexWorkloadAdmissionChecks,
+		},
+		"workload without admission": {
+			obj:     &kueue.Workload{},
+			indexer: IndexWorkloadFlavors,
+		},
+		"workload with flavors": {
+			obj: &kueue.Workload{
+				Status: kueue.WorkloadStatus{
+					Admission: &kueue.Admission{
+						ClusterQueue: "cq",
+						PodSetAssignments: []kueue.PodSetAssignment{
+							{
+								Name: "main",
+								Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+									corev1.ResourceCPU:    "on-demand",
+									corev1.ResourceMemory: "on-demand",
+								},
+							},
+							{
+								Name: "workers",
+								Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+									corev1.ResourceCPU: "spot",
+								},
+							},
+						},
+					},
+				},
+			},
+			indexer: IndexWorkloadFlavors,
+			want:    []string{"on-demand", "spot"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.indexer(tc.obj)
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Unexpected index values (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+// leaderAwareReconciler delegates the requests to the decorated reconciler
+// only once the manager is elected. The controllers maintaining the cache and
+// the queues run in all the replicas, so that a standby replica is warm when
+// it's elected, but only the leader writes to the API server.
+type leaderAwareReconciler struct {
+	elected  <-chan struct{}
+	delegate reconcile.Reconciler
+}
+
+var _ reconcile.Reconciler = (*leaderAwareReconciler)(nil)
+
+// controllerOptions returns the options of the core controllers, which don't
+// need the leader election to keep the cache and the queues warm.
+func controllerOptions() controller.Options {
+	return controller.Options{NeedLeaderElection: ptr.To(false)}
+}
+
+func leaderElectionEnabled(cfg *config.Configuration) bool {
+	return cfg != nil && cfg.LeaderElection != nil && ptr.Deref(cfg.LeaderElection.LeaderElect, false)
+}
+
+// withLeadingManager decorates the reconciler so that the requests are
+// dropped in the replicas that are not the leader. The reconciler is not
+// decorated when the leader election is disabled.
+func withLeadingManager(mgr ctrl.Manager, r reconcile.Reconciler, cfg *config.Configuration) reconcile.Reconciler {
+	if !leaderElectionEnabled(cfg) {
+		return r
+	}
+	return &leaderAwareReconciler{
+		elected:  mgr.Elected(),
+		delegate: r,
+	}
+}
+
+func (r *leaderAwareReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	select {
+	case <-r.elected:
+		return r.delegate.Reconcile(ctx, req)
+	default:
+		// The request is dropped, every object is requeued once the
+		// replica is elected by requeueOnElection.
+		ctrl.LoggerFrom(ctx).V(5).Info("Not the leader, dropping the request")
+		return reconcile.Result{}, nil
+	}
+}
+
+// electionSource requeues every object of the list once the manager is
+// elected, so that the requests dropped before the election are reconciled.
+type electionSource struct {
+	elected     <-chan struct{}
+	reader      client.Reader
+	waitForSync func(context.Context) bool
+	list        client.ObjectList
+}
+
+var _ source.Source = (*electionSource)(nil)
+
+// electionEventHandler is the handler to watch an electionSource with. It is
+// never called, as the source adds the requests to the queue itself.
+var electionEventHandler handler.EventHandler = &handler.EnqueueRequestForObject{}
+
+// requeueOnElection returns the source requeueing the objects of the list,
+// which is of the kind reconciled by the controller, once the manager is
+// elected. The source doesn't produce any request when the leader election
+// is disabled.
+func requeueOnElection(mgr ctrl.Manager, cfg *config.Configuration, list client.ObjectList) source.Source {
+	s := &electionSource{
+		reader:      mgr.GetClient(),
+		waitForSync: mgr.GetCache().WaitForCacheSync,
+		list:        list,
+	}
+	if leaderElectionEnabled(cfg) {
+		s.elected = mgr.Elected()
+	}
+	return s
+}
+
+// Start requeues the objects from a goroutine, once elected. The handler and
+// the predicates are ignored, as the requests need to reach the reconciler
+// regardless of the event filters of the controller.
+func (s *electionSource) Start(ctx context.Context, _ handler.EventHandler, q workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+	if s.elected == nil {
+		return nil
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.elected:
+		}
+		if !s.waitForSync(ctx) {
+			return
+		}
+		list := s.list.DeepCopyObject().(client.ObjectList)
+		if err := s.reader.List(ctx, list); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Listing the objects to requeue after the election")
+			return
+		}
+		_ = meta.EachListItem(list, func(o runtime.Object) error {
+			if obj, ok := o.(client.Object); ok {
+				q.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+			}
+			return nil
+		})
+	}()
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+type countingReconciler struct {
+	requests []reconcile.Request
+}
+
+func (r *countingReconciler) Reconcile(_ context.Context, req reconcile.Request) (reconcile.Result, error) {
+	r.requests = append(r.requests, req)
+	return reconcile.Result{}, nil
+}
+
+func TestLeaderAwareReconciler(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	elected := make(chan struct{})
+	delegate := &countingReconciler{}
+	r := &leaderAwareReconciler{
+		elected:  elected,
+		delegate: delegate,
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "cq"}}
+
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if diff := cmp.Diff(reconcile.Result{}, result); diff != "" {
+		t.Errorf("Unexpected result before being elected (-want,+got):\n%s", diff)
+	}
+	if len(delegate.requests) != 0 {
+		t.Errorf("The request was delegated before being elected: %v", delegate.requests)
+	}
+
+	close(elected)
+	result, err = r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if diff := cmp.Diff(reconcile.Result{}, result); diff != "" {
+		t.Errorf("Unexpected result after being elected (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]reconcile.Request{req}, delegate.requests); diff != "" {
+		t.Errorf("Unexpected delegated requests (-want,+got):\n%s", diff)
+	}
+}
+
+func TestElectionSource(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	elected := make(chan struct{})
+	cl := utiltesting.NewFakeClient(
+		utiltesting.MakeLocalQueue("a", "ns").Obj(),
+		utiltesting.MakeLocalQueue("b", "ns").Obj(),
+	)
+	s := &electionSource{
+		elected:     elected,
+		reader:      cl,
+		waitForSync: func(context.Context) bool { return true },
+		list:        &kueue.LocalQueueList{},
+	}
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	if err := s.Start(ctx, electionEventHandler, q); err != nil {
+		t.Fatalf("Starting the source: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if q.Len() != 0 {
+		t.Errorf("Requests were queued before the election")
+	}
+
+	close(elected)
+	var got []reconcile.Request
+	for len(got) < 2 {
+		item, _ := q.Get()
+		got = append(got, item.(reconcile.Request))
+		q.Done(item)
+	}
+	want := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}},
+		{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "b"}},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b reconcile.Request) bool { return a.Name < b.Name })); diff != "" {
+		t.Errorf("Unexpected requests after the election (-want,+got):\n%s", diff)
+	}
+}
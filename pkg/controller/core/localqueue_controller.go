@@ -18,6 +18,8 @@ package core
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -30,13 +32,16 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 )
 
@@ -47,20 +52,22 @@ const (
 
 // LocalQueueReconciler reconciles a LocalQueue object
 type LocalQueueReconciler struct {
-	client     client.Client
-	log        logr.Logger
-	queues     *queue.Manager
-	cache      *cache.Cache
-	wlUpdateCh chan event.GenericEvent
+	client       client.Client
+	log          logr.Logger
+	queues       *queue.Manager
+	cache        *cache.Cache
+	wlUpdateCh   chan event.GenericEvent
+	snapUpdateCh chan event.GenericEvent
 }
 
 func NewLocalQueueReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache) *LocalQueueReconciler {
 	return &LocalQueueReconciler{
-		log:        ctrl.Log.WithName("localqueue-reconciler"),
-		queues:     queues,
-		cache:      cache,
-		client:     client,
-		wlUpdateCh: make(chan event.GenericEvent, updateChBuffer),
+		log:          ctrl.Log.WithName("localqueue-reconciler"),
+		queues:       queues,
+		cache:        cache,
+		client:       client,
+		wlUpdateCh:   make(chan event.GenericEvent, updateChBuffer),
+		snapUpdateCh: make(chan event.GenericEvent, updateChBuffer),
 	}
 }
 
@@ -77,6 +84,21 @@ func (r *LocalQueueReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload
 	}
 }
 
+// NotifyLocalQueueSnapshotUpdate triggers the update of the pending workloads
+// in the status of the LocalQueue identified by the key.
+func (r *LocalQueueReconciler) NotifyLocalQueueSnapshotUpdate(lqKey string) {
+	namespace, name, found := strings.Cut(lqKey, "/")
+	if !found {
+		return
+	}
+	r.snapUpdateCh <- event.GenericEvent{Object: &kueue.LocalQueue{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}}
+}
+
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=localqueues,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=localqueues/status,verbs=get;update;patch
@@ -248,16 +270,19 @@ func (h *qCQHandler) addLocalQueueToWorkQueue(ctx context.Context, cq *kueue.Clu
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *LocalQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *LocalQueueReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	queueCQHandler := qCQHandler{
 		client: r.client,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.LocalQueue{}).
 		WatchesRawSource(&source.Channel{Source: r.wlUpdateCh}, &qWorkloadHandler{}).
+		WatchesRawSource(&source.Channel{Source: r.snapUpdateCh}, &handler.EnqueueRequestForObject{}).
 		Watches(&kueue.ClusterQueue{}, &queueCQHandler).
+		WatchesRawSource(requeueOnElection(mgr, cfg, &kueue.LocalQueueList{}), electionEventHandler).
 		WithEventFilter(r).
-		Complete(r)
+		WithOptions(controllerOptions()).
+		Complete(withLeadingManager(mgr, r, cfg))
 }
 
 func (r *LocalQueueReconciler) UpdateStatusIfChanged(
@@ -282,6 +307,7 @@ func (r *LocalQueueReconciler) UpdateStatusIfChanged(
 	queue.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
 	queue.Status.FlavorsReservation = stats.ReservedResources
 	queue.Status.FlavorUsage = stats.AdmittedResources
+	queue.Status.PendingWorkloadsStatus = r.getPendingWorkloadsStatus(queue)
 	if len(conditionStatus) != 0 && len(reason) != 0 && len(msg) != 0 {
 		meta.SetStatusCondition(&queue.Status.Conditions, metav1.Condition{
 			Type:    kueue.LocalQueueActive,
@@ -295,3 +321,22 @@ func (r *LocalQueueReconciler) UpdateStatusIfChanged(
 	}
 	return nil
 }
+
+func (r *LocalQueueReconciler) getPendingWorkloadsStatus(lq *kueue.LocalQueue) *kueue.LocalQueuePendingWorkloadsStatus {
+	if !features.Enabled(features.QueueVisibility) {
+		return nil
+	}
+	pendingWorkloads, found := r.queues.GetLocalQueueSnapshot(queue.Key(lq))
+	if !found {
+		return nil
+	}
+	if lq.Status.PendingWorkloadsStatus == nil ||
+		lq.Status.PendingWorkloadsStatus.Head == nil ||
+		!equality.Semantic.DeepEqual(lq.Status.PendingWorkloadsStatus.Head, pendingWorkloads) {
+		return &kueue.LocalQueuePendingWorkloadsStatus{
+			Head:           pendingWorkloads,
+			LastChangeTime: metav1.Time{Time: time.Now()},
+		}
+	}
+	return lq.Status.PendingWorkloadsStatus
+}
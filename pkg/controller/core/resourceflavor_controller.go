@@ -18,6 +18,7 @@ package core
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/types"
@@ -31,9 +32,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/resourceflavor"
 )
 
 type ResourceFlavorUpdateWatcher interface {
@@ -86,6 +90,15 @@ func (r *ResourceFlavorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			}
 			log.V(5).Info("Added finalizer")
 		}
+		if until, marked := resourceflavor.NotProvisionableUntil(&flavor, time.Now()); marked {
+			return ctrl.Result{RequeueAfter: time.Until(until)}, nil
+		} else if _, found := flavor.Annotations[controllerconsts.NotProvisionableUntilAnnotation]; found {
+			delete(flavor.Annotations, controllerconsts.NotProvisionableUntilAnnotation)
+			if err := r.client.Update(ctx, &flavor); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+			log.V(3).Info("Removed the expired not provisionable mark")
+		}
 	} else {
 		if controllerutil.ContainsFinalizer(&flavor, kueue.ResourceInUseFinalizerName) {
 			if cqs := r.cache.ClusterQueuesUsingFlavor(flavor.Name); len(cqs) != 0 {
@@ -173,10 +186,17 @@ func (r *ResourceFlavorReconciler) Update(e event.UpdateEvent) bool {
 		return true
 	}
 
-	if cqNames := r.cache.AddOrUpdateResourceFlavor(newFlv.DeepCopy()); len(cqNames) > 0 {
+	cqNames := r.cache.AddOrUpdateResourceFlavor(newFlv.DeepCopy())
+	oldMark, newMark := oldFlv.Annotations[controllerconsts.NotProvisionableUntilAnnotation], newFlv.Annotations[controllerconsts.NotProvisionableUntilAnnotation]
+	if oldMark != newMark {
+		// The workloads that couldn't use the flavor can be admitted when the mark is removed.
+		cqNames.Insert(r.cache.ClusterQueuesUsingFlavor(newFlv.Name)...)
+	}
+	if len(cqNames) > 0 {
 		r.qManager.QueueInadmissibleWorkloads(context.Background(), cqNames)
 	}
-	return false
+	// Reconcile the flavor to remove the mark when it expires.
+	return newMark != "" && oldMark != newMark
 }
 
 func (r *ResourceFlavorReconciler) Generic(e event.GenericEvent) bool {
@@ -249,15 +269,17 @@ func (h *cqHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *ResourceFlavorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *ResourceFlavorReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	handler := cqHandler{
 		cache: r.cache,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.ResourceFlavor{}).
 		WatchesRawSource(&source.Channel{Source: r.cqUpdateCh}, &handler).
+		WatchesRawSource(requeueOnElection(mgr, cfg, &kueue.ResourceFlavorList{}), electionEventHandler).
 		WithEventFilter(r).
-		Complete(r)
+		WithOptions(controllerOptions()).
+		Complete(withLeadingManager(mgr, r, cfg))
 }
 
 func resourceFlavors(cq *kueue.ClusterQueue) sets.Set[kueue.ResourceFlavorReference] {
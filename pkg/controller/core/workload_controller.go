@@ -19,11 +19,14 @@ package core
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,11 +42,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/util/resourcequota"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -53,6 +61,11 @@ const (
 	pending  = "pending"
 	admitted = "admitted"
 	finished = "finished"
+
+	// minRuntimeExpiryWindow is the time, after the end of the preemption
+	// protection of an admitted workload, during which its reconciliation
+	// queues the inadmissible workloads of its ClusterQueue again.
+	minRuntimeExpiryWindow = time.Minute
 )
 
 var (
@@ -90,13 +103,15 @@ type WorkloadUpdateWatcher interface {
 
 // WorkloadReconciler reconciles a Workload object
 type WorkloadReconciler struct {
-	log              logr.Logger
-	queues           *queue.Manager
-	cache            *cache.Cache
-	client           client.Client
-	watchers         []WorkloadUpdateWatcher
-	podsReadyTimeout *time.Duration
-	recorder         record.EventRecorder
+	log      logr.Logger
+	queues   *queue.Manager
+	cache    *cache.Cache
+	client   client.Client
+	watchers []WorkloadUpdateWatcher
+	recorder record.EventRecorder
+
+	podsReadyTimeoutMu sync.RWMutex
+	podsReadyTimeout   *time.Duration
 }
 
 func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache, recorder record.EventRecorder, opts ...Option) *WorkloadReconciler {
@@ -116,8 +131,23 @@ func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *c
 	}
 }
 
+// SetPodsReadyTimeout changes the timeout to reach the PodsReady=True
+// condition, for a reloaded configuration. A nil value disables the timeout.
+func (r *WorkloadReconciler) SetPodsReadyTimeout(value *time.Duration) {
+	r.podsReadyTimeoutMu.Lock()
+	defer r.podsReadyTimeoutMu.Unlock()
+	r.podsReadyTimeout = value
+}
+
+func (r *WorkloadReconciler) getPodsReadyTimeout() *time.Duration {
+	r.podsReadyTimeoutMu.RLock()
+	defer r.podsReadyTimeoutMu.RUnlock()
+	return r.podsReadyTimeout
+}
+
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;watch;update;patch
 //+kubebuilder:rbac:groups="",resources=limitranges,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/finalizers,verbs=update
@@ -137,6 +167,10 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, nil
 	}
 
+	if reactivated, err := r.reconcileReactivation(ctx, &wl); reactivated || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if rejectedChecks := workload.GetRejectedChecks(&wl); len(rejectedChecks) > 0 {
 		// Finish the workload
 		log.V(3).Info("Workload has Rejected admission checks, Finish with failure")
@@ -172,11 +206,30 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			return ctrl.Result{}, err
 		}
 
+		if preempting, result, err := r.reconcilePreemptionGracePeriod(ctx, &wl); preempting || err != nil {
+			return result, err
+		}
+
 		if updated, err := r.reconcileOnClusterQueueActiveState(ctx, &wl, cqName); updated || err != nil {
 			return ctrl.Result{}, err
 		}
 
-		return r.reconcileNotReadyTimeout(ctx, req, &wl)
+		if updated, err := r.reconcileOnResourceFlavorStopPolicy(ctx, &wl); updated || err != nil {
+			return ctrl.Result{}, err
+		}
+
+		result, err := r.reconcileNotReadyTimeout(ctx, req, &wl)
+		if err != nil {
+			return result, err
+		}
+		if wait := r.reconcileMinRuntime(ctx, &wl); wait > 0 && (result.RequeueAfter == 0 || wait < result.RequeueAfter) {
+			result.RequeueAfter = wait
+		}
+		return result, nil
+	}
+
+	if handled, result, err := r.reconcileRequeueBackoff(ctx, &wl); handled || err != nil {
+		return result, err
 	}
 
 	if !r.queues.QueueForWorkloadExists(&wl) {
@@ -186,21 +239,27 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Route the workload before checking its ClusterQueue, so that it falls
+	// back to the next ClusterQueue of its LocalQueue even when the current
+	// one is missing or inactive, and retry when it should fall back again.
+	wait := r.queues.RouteWorkload(&wl)
+	cqName, cqOk = r.queues.ClusterQueueForWorkload(&wl)
+
 	if !cqOk {
 		log.V(3).Info("Workload is inadmissible because of missing ClusterQueue", "clusterQueue", klog.KRef("", cqName))
 		workload.UnsetQuotaReservationWithCondition(&wl, "Inadmissible", fmt.Sprintf("ClusterQueue %s doesn't exist", cqName))
 		err := workload.ApplyAdmissionStatus(ctx, r.client, &wl, true)
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return ctrl.Result{RequeueAfter: wait}, client.IgnoreNotFound(err)
 	}
 
 	if !r.cache.ClusterQueueActive(cqName) {
 		log.V(3).Info("Workload is inadmissible because ClusterQueue is inactive", "clusterQueue", klog.KRef("", cqName))
 		workload.UnsetQuotaReservationWithCondition(&wl, "Inadmissible", fmt.Sprintf("ClusterQueue %s is inactive", cqName))
 		err := workload.ApplyAdmissionStatus(ctx, r.client, &wl, true)
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return ctrl.Result{RequeueAfter: wait}, client.IgnoreNotFound(err)
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: wait}, nil
 }
 
 func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl *kueue.Workload) (bool, error) {
@@ -209,11 +268,103 @@ func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl
 	}
 	log := ctrl.LoggerFrom(ctx)
 	log.V(3).Info("Workload is evicted due to admission checks")
-	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByAdmissionCheck, "At least one admission check is false")
-	err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
+	err := r.evictForRetry(ctx, wl, kueue.WorkloadEvictedByAdmissionCheck, "At least one admission check is false", true)
 	return true, client.IgnoreNotFound(err)
 }
 
+// evictForRetry evicts the workload for a failure of its own, which counts as
+// a retry of its retryPolicy. The workload is deactivated, instead, once it
+// exhausted its retries.
+func (r *WorkloadReconciler) evictForRetry(ctx context.Context, wl *kueue.Workload, reason, message string, strict bool) error {
+	if workload.RetriesExhausted(wl) {
+		return r.deactivateAfterRetries(ctx, wl, message)
+	}
+	workload.SetEvictedCondition(wl, reason, message)
+	if wl.Spec.RetryPolicy != nil {
+		workload.UpdateRequeueState(wl, realClock.Now())
+	}
+	return workload.ApplyAdmissionStatus(ctx, r.client, wl, strict)
+}
+
+// deactivateAfterRetries evicts the workload and records it as deactivated in
+// a single status write, with the generation of the workload, before setting it
+// inactive. If the last write fails, the workload is set inactive in the next
+// reconcile, as its spec didn't change since it was recorded as deactivated.
+func (r *WorkloadReconciler) deactivateAfterRetries(ctx context.Context, wl *kueue.Workload, message string) error {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Deactivating the workload after exhausting the retries of its retryPolicy", "retries", workload.RequeueCount(wl))
+	msg := fmt.Sprintf("Deactivated after exhausting the %d retries of the retryPolicy, last failure: %s", workload.RequeueCount(wl), message)
+	patch := client.MergeFromWithOptions(wl.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByDeactivation, msg)
+	apimeta.SetStatusCondition(&wl.Status.Conditions, metav1.Condition{
+		Type:               kueue.WorkloadDeactivated,
+		Status:             metav1.ConditionTrue,
+		Reason:             kueue.WorkloadRetryLimitExceeded,
+		Message:            api.TruncateConditionMessage(msg),
+		ObservedGeneration: wl.Generation,
+	})
+	if err := r.client.Status().Patch(ctx, wl, patch); err != nil {
+		return err
+	}
+	r.recorder.Event(wl, corev1.EventTypeWarning, kueue.WorkloadRetryLimitExceeded, msg)
+	wl.Spec.Active = ptr.To(false)
+	return r.client.Update(ctx, wl)
+}
+
+// reconcileReactivation resets the retries of a workload that was deactivated
+// after exhausting them, once it's active again. A workload recorded as
+// deactivated in its current generation was not set inactive yet, so this is
+// done instead.
+func (r *WorkloadReconciler) reconcileReactivation(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	deactivated := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadDeactivated)
+	if !ptr.Deref(wl.Spec.Active, true) || deactivated == nil || deactivated.Status != metav1.ConditionTrue {
+		return false, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	if deactivated.ObservedGeneration == wl.Generation {
+		log.V(2).Info("Setting inactive the workload recorded as deactivated after exhausting the retries of its retryPolicy")
+		wl.Spec.Active = ptr.To(false)
+		return true, client.IgnoreNotFound(r.client.Update(ctx, wl))
+	}
+	msg := "Reactivated, the retries of the retryPolicy were reset"
+	if by := wl.Annotations[controllerconsts.ReactivatedByAnnotation]; len(by) > 0 {
+		msg = fmt.Sprintf("Reactivated by %s, the retries of the retryPolicy were reset", by)
+	}
+	log.V(2).Info("Resetting the retries of the reactivated workload", "retries", workload.RequeueCount(wl))
+	patch := client.MergeFromWithOptions(wl.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	wl.Status.RequeueState = nil
+	apimeta.SetStatusCondition(&wl.Status.Conditions, metav1.Condition{
+		Type:               kueue.WorkloadDeactivated,
+		Status:             metav1.ConditionFalse,
+		Reason:             kueue.WorkloadReactivated,
+		Message:            msg,
+		ObservedGeneration: wl.Generation,
+	})
+	if err := r.client.Status().Patch(ctx, wl, patch); err != nil {
+		return true, client.IgnoreNotFound(err)
+	}
+	r.recorder.Event(wl, corev1.EventTypeNormal, kueue.WorkloadReactivated, msg)
+	return true, nil
+}
+
+// reconcileRequeueBackoff holds the workload until the backoff of its
+// retryPolicy expires, and then clears its requeueAt so that it's queued again.
+func (r *WorkloadReconciler) reconcileRequeueBackoff(ctx context.Context, wl *kueue.Workload) (bool, ctrl.Result, error) {
+	if !workload.IsBackingOff(wl) {
+		return false, ctrl.Result{}, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	if wait := wl.Status.RequeueState.RequeueAt.Sub(realClock.Now()); wait > 0 {
+		log.V(3).Info("Workload waits for its backoff before being requeued", "requeueAt", wl.Status.RequeueState.RequeueAt)
+		return true, ctrl.Result{RequeueAfter: wait}, nil
+	}
+	log.V(2).Info("Requeueing the workload after its backoff")
+	patch := client.MergeFromWithOptions(wl.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	wl.Status.RequeueState.RequeueAt = nil
+	err := r.client.Status().Patch(ctx, wl, patch)
+	return true, ctrl.Result{}, client.IgnoreNotFound(err)
+}
+
 func (r *WorkloadReconciler) reconcileSyncAdmissionChecks(ctx context.Context, wl *kueue.Workload, cqName string) (bool, error) {
 	// because we need to react to API cluster queue events, the list of checks from a cache can lead to race conditions
 	queue := kueue.ClusterQueue{}
@@ -268,6 +419,40 @@ func (r *WorkloadReconciler) reconcileOnClusterQueueActiveState(ctx context.Cont
 	return false, nil
 }
 
+// reconcileOnResourceFlavorStopPolicy evicts the admitted workload when one of
+// its ResourceFlavors is drained, and releases the quota reserved by the
+// workload that is not admitted yet when one of its ResourceFlavors is stopped.
+func (r *WorkloadReconciler) reconcileOnResourceFlavorStopPolicy(ctx context.Context, wl *kueue.Workload) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	flavors := indexer.IndexWorkloadFlavors(wl)
+	sort.Strings(flavors)
+	for _, flavorName := range flavors {
+		var flavor kueue.ResourceFlavor
+		if err := r.client.Get(ctx, types.NamespacedName{Name: flavorName}, &flavor); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return false, err
+		}
+		stopPolicy := ptr.Deref(flavor.Spec.StopPolicy, kueue.None)
+		if workload.IsAdmitted(wl) {
+			if stopPolicy != kueue.HoldAndDrain {
+				continue
+			}
+			log.V(3).Info("Workload is evicted because the ResourceFlavor is stopped", "resourceFlavor", klog.KRef("", flavorName))
+			workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByResourceFlavorStopped, fmt.Sprintf("The ResourceFlavor %s is stopped", flavorName))
+			err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
+			return true, client.IgnoreNotFound(err)
+		}
+		if stopPolicy != kueue.None {
+			log.V(3).Info("Workload is inadmissible because the ResourceFlavor is stopped", "resourceFlavor", klog.KRef("", flavorName))
+			workload.UnsetQuotaReservationWithCondition(wl, "Inadmissible", fmt.Sprintf("ResourceFlavor %s is stopped", flavorName))
+			return true, workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
+		}
+	}
+	return false, nil
+}
+
 func syncAdmissionCheckConditions(conds []kueue.AdmissionCheckState, queueChecks []string) ([]kueue.AdmissionCheckState, bool) {
 	if len(queueChecks) == 0 {
 		return nil, len(conds) > 0
@@ -312,12 +497,64 @@ func (r *WorkloadReconciler) reconcileNotReadyTimeout(ctx context.Context, req c
 		return ctrl.Result{RequeueAfter: recheckAfter}, nil
 	} else {
 		log.V(2).Info("Start the eviction of the workload due to exceeding the PodsReady timeout")
-		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPodsReadyTimeout, fmt.Sprintf("Exceeded the PodsReady timeout %s", req.NamespacedName.String()))
-		err := workload.ApplyAdmissionStatus(ctx, r.client, wl, false)
+		err := r.evictForRetry(ctx, wl, kueue.WorkloadEvictedByPodsReadyTimeout, fmt.Sprintf("Exceeded the PodsReady timeout %s", req.NamespacedName.String()), false)
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 }
 
+// reconcilePreemptionGracePeriod evicts the workload selected as a preemption
+// target once the preemption gracePeriod of its ClusterQueue expires. It
+// returns true while the workload is preempting.
+func (r *WorkloadReconciler) reconcilePreemptionGracePeriod(ctx context.Context, wl *kueue.Workload) (bool, ctrl.Result, error) {
+	if !workload.IsPreempting(wl) {
+		return false, ctrl.Result{}, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadPreempting)
+	gracePeriod := r.cache.PreemptionGracePeriod(string(wl.Status.Admission.ClusterQueue))
+	if wait := cond.LastTransitionTime.Add(gracePeriod).Sub(realClock.Now()); wait > 0 {
+		log.V(3).Info("Workload waits for its preemption grace period before being evicted", "gracePeriod", gracePeriod)
+		return true, ctrl.Result{RequeueAfter: wait}, nil
+	}
+	log.V(2).Info("Evicting the workload after its preemption grace period")
+	workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPreemption, "Preempted to accommodate a higher priority Workload")
+	apimeta.SetStatusCondition(&wl.Status.Conditions, metav1.Condition{
+		Type:    kueue.WorkloadPreempting,
+		Status:  metav1.ConditionFalse,
+		Reason:  kueue.WorkloadEvictedByPreemption,
+		Message: "Evicted after the preemption grace period",
+	})
+	if err := workload.ApplyAdmissionStatus(ctx, r.client, wl, true); err != nil {
+		return true, ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	r.recorder.Eventf(wl, corev1.EventTypeNormal, "Preempted", "Preempted after the grace period of %s", gracePeriod)
+	return true, ctrl.Result{}, nil
+}
+
+// reconcileMinRuntime returns the time until the end of the preemption
+// protection of the admitted workload. Once the protection ends, the
+// inadmissible workloads of the ClusterQueue and its cohort are queued again,
+// as they might be able to preempt the workload.
+func (r *WorkloadReconciler) reconcileMinRuntime(ctx context.Context, wl *kueue.Workload) time.Duration {
+	if wl.Status.Admission == nil {
+		return 0
+	}
+	cqName := string(wl.Status.Admission.ClusterQueue)
+	until, protected := workload.PreemptionProtectedUntil(wl, r.cache.PreemptionMinRuntime(cqName))
+	if !protected {
+		return 0
+	}
+	now := realClock.Now()
+	if now.Before(until) {
+		return until.Sub(now)
+	}
+	if now.Sub(until) < minRuntimeExpiryWindow {
+		ctrl.LoggerFrom(ctx).V(3).Info("Workload no longer protected from preemption, queueing the inadmissible workloads", "clusterQueue", klog.KRef("", cqName))
+		r.queues.QueueInadmissibleWorkloads(ctx, sets.New(cqName))
+	}
+	return 0
+}
+
 func (r *WorkloadReconciler) Create(e event.CreateEvent) bool {
 	wl, isWorkload := e.Object.(*kueue.Workload)
 	if !isWorkload {
@@ -489,17 +726,23 @@ func (r *WorkloadReconciler) notifyWatchers(oldWl, newWl *kueue.Workload) {
 }
 
 // SetupWithManager sets up the controller with the Manager.
-func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
+func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
 	ruh := &resourceUpdatesHandler{
 		r: r,
 	}
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.Workload{}).
 		Watches(&corev1.LimitRange{}, ruh).
 		Watches(&nodev1.RuntimeClass{}, ruh).
 		Watches(&kueue.ClusterQueue{}, &workloadCqHandler{client: r.client}).
+		Watches(&kueue.ResourceFlavor{}, &workloadFlavorHandler{client: r.client})
+	if features.Enabled(features.NamespaceResourceQuota) {
+		b = b.Watches(&corev1.ResourceQuota{}, &resourceQuotaHandler{r: r})
+	}
+	return b.WatchesRawSource(requeueOnElection(mgr, cfg, &kueue.WorkloadList{}), electionEventHandler).
 		WithEventFilter(r).
-		Complete(r)
+		WithOptions(controllerOptions()).
+		Complete(withLeadingManager(mgr, r, cfg))
 }
 
 // admittedNotReadyWorkload returns as a pair of values. The first boolean determines
@@ -509,7 +752,8 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // specified timeout counted since max of the LastTransitionTime's for the
 // Admitted and PodsReady conditions.
 func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload, clock clock.Clock) (bool, time.Duration) {
-	if r.podsReadyTimeout == nil {
+	podsReadyTimeout := r.getPodsReadyTimeout()
+	if podsReadyTimeout == nil {
 		// the timeout is not configured for the workload controller
 		return false, 0
 	}
@@ -522,12 +766,22 @@ func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload, clock
 	if podsReadyCond != nil && podsReadyCond.Status == metav1.ConditionTrue {
 		return false, 0
 	}
+	// The pods gated by other controllers can't get ready, so the timeout
+	// only counts once the gates are removed.
+	gatedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadSchedulingGated)
+	if gatedCond != nil && gatedCond.Status == metav1.ConditionTrue {
+		return false, 0
+	}
 	admittedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted)
-	elapsedTime := clock.Since(admittedCond.LastTransitionTime.Time)
-	if podsReadyCond != nil && podsReadyCond.Status == metav1.ConditionFalse && podsReadyCond.LastTransitionTime.After(admittedCond.LastTransitionTime.Time) {
-		elapsedTime = clock.Since(podsReadyCond.LastTransitionTime.Time)
+	since := admittedCond.LastTransitionTime.Time
+	if podsReadyCond != nil && podsReadyCond.Status == metav1.ConditionFalse && podsReadyCond.LastTransitionTime.After(since) {
+		since = podsReadyCond.LastTransitionTime.Time
 	}
-	waitFor := *r.podsReadyTimeout - elapsedTime
+	if gatedCond != nil && gatedCond.LastTransitionTime.After(since) {
+		since = gatedCond.LastTransitionTime.Time
+	}
+	elapsedTime := clock.Since(since)
+	waitFor := *podsReadyTimeout - elapsedTime
 	if waitFor < 0 {
 		waitFor = 0
 	}
@@ -607,6 +861,56 @@ func (h *resourceUpdatesHandler) queueReconcileForPending(ctx context.Context, _
 	}
 }
 
+// resourceQuotaHandler queues the inadmissible workloads of a namespace again
+// when the headroom of one of its ResourceQuotas increases.
+type resourceQuotaHandler struct {
+	r *WorkloadReconciler
+}
+
+var _ handler.EventHandler = (*resourceQuotaHandler)(nil)
+
+func (h *resourceQuotaHandler) Create(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
+}
+
+func (h *resourceQuotaHandler) Update(ctx context.Context, e event.UpdateEvent, _ workqueue.RateLimitingInterface) {
+	oldQuota, isQuota := e.ObjectOld.(*corev1.ResourceQuota)
+	newQuota, isNewQuota := e.ObjectNew.(*corev1.ResourceQuota)
+	if !isQuota || !isNewQuota || !resourcequota.HeadroomIncreased(oldQuota, newQuota) {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("resourceQuota", klog.KObj(newQuota))
+	log.V(5).Info("ResourceQuota headroom increased")
+	h.queueInadmissibleWorkloads(ctrl.LoggerInto(ctx, log), newQuota.Namespace)
+}
+
+func (h *resourceQuotaHandler) Delete(ctx context.Context, e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	log := ctrl.LoggerFrom(ctx).WithValues("resourceQuota", klog.KObj(e.Object))
+	log.V(5).Info("ResourceQuota deleted")
+	h.queueInadmissibleWorkloads(ctrl.LoggerInto(ctx, log), e.Object.GetNamespace())
+}
+
+func (h *resourceQuotaHandler) Generic(context.Context, event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+func (h *resourceQuotaHandler) queueInadmissibleWorkloads(ctx context.Context, namespace string) {
+	log := ctrl.LoggerFrom(ctx)
+	lst := kueue.WorkloadList{}
+	err := h.r.client.List(ctx, &lst, client.InNamespace(namespace), client.MatchingFields{indexer.WorkloadQuotaReservedKey: string(metav1.ConditionFalse)})
+	if err != nil {
+		log.Error(err, "Could not list pending workloads")
+		return
+	}
+	cqNames := sets.New[string]()
+	for i := range lst.Items {
+		if cqName, found := h.r.queues.ClusterQueueForWorkload(&lst.Items[i]); found {
+			cqNames.Insert(cqName)
+		}
+	}
+	if len(cqNames) > 0 {
+		h.r.queues.QueueInadmissibleWorkloads(ctx, cqNames)
+	}
+}
+
 type workloadCqHandler struct {
 	client client.Client
 }
@@ -685,3 +989,39 @@ func (w *workloadCqHandler) queueReconcileForWorkloadsOfLocalQueue(ctx context.C
 		log.V(5).Info("Queued reconcile for workload")
 	}
 }
+
+// workloadFlavorHandler queues the reconciliation of the workloads that have a
+// ResourceFlavor assigned, when the stopPolicy of the ResourceFlavor changes.
+type workloadFlavorHandler struct {
+	client client.Client
+}
+
+var _ handler.EventHandler = (*workloadFlavorHandler)(nil)
+
+func (w *workloadFlavorHandler) Create(context.Context, event.CreateEvent, workqueue.RateLimitingInterface) {
+}
+
+func (w *workloadFlavorHandler) Update(ctx context.Context, ev event.UpdateEvent, wq workqueue.RateLimitingInterface) {
+	oldFlavor, isFlavor := ev.ObjectOld.(*kueue.ResourceFlavor)
+	newFlavor, isNewFlavor := ev.ObjectNew.(*kueue.ResourceFlavor)
+	if !isFlavor || !isNewFlavor || ptr.Deref(oldFlavor.Spec.StopPolicy, kueue.None) == ptr.Deref(newFlavor.Spec.StopPolicy, kueue.None) {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("resourceFlavor", klog.KObj(newFlavor))
+	log.V(5).Info("ResourceFlavor stopPolicy update event")
+	lst := kueue.WorkloadList{}
+	if err := w.client.List(ctx, &lst, client.MatchingFields{indexer.WorkloadFlavorKey: newFlavor.Name}); err != nil {
+		log.Error(err, "Could not list the workloads of the ResourceFlavor")
+		return
+	}
+	for _, wl := range lst.Items {
+		wq.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&wl)})
+		log.V(5).Info("Queued reconcile for workload", "workload", klog.KObj(&wl))
+	}
+}
+
+func (w *workloadFlavorHandler) Delete(context.Context, event.DeleteEvent, workqueue.RateLimitingInterface) {
+}
+
+func (w *workloadFlavorHandler) Generic(context.Context, event.GenericEvent, workqueue.RateLimitingInterface) {
+}
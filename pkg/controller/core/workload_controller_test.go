@@ -18,22 +18,28 @@ package core
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestAdmittedNotReadyWorkload(t *testing.T) {
@@ -81,6 +87,48 @@ func TestAdmittedNotReadyWorkload(t *testing.T) {
 				},
 			},
 		},
+		"workload with Admitted=True, SchedulingGated=True; not counting": {
+			workload: kueue.Workload{
+				Status: kueue.WorkloadStatus{
+					Admission: &kueue.Admission{},
+					Conditions: []metav1.Condition{
+						{
+							Type:               kueue.WorkloadAdmitted,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(now.Add(-7 * time.Minute)),
+						},
+						{
+							Type:               kueue.WorkloadSchedulingGated,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(now.Add(-7 * time.Minute)),
+						},
+					},
+				},
+			},
+			podsReadyTimeout: ptr.To(5 * time.Minute),
+		},
+		"workload with Admitted=True, SchedulingGated=False; counting since the gates were removed": {
+			workload: kueue.Workload{
+				Status: kueue.WorkloadStatus{
+					Admission: &kueue.Admission{},
+					Conditions: []metav1.Condition{
+						{
+							Type:               kueue.WorkloadAdmitted,
+							Status:             metav1.ConditionTrue,
+							LastTransitionTime: metav1.NewTime(now.Add(-7 * time.Minute)),
+						},
+						{
+							Type:               kueue.WorkloadSchedulingGated,
+							Status:             metav1.ConditionFalse,
+							LastTransitionTime: metav1.NewTime(minuteAgo),
+						},
+					},
+				},
+			},
+			podsReadyTimeout:           ptr.To(5 * time.Minute),
+			wantCountingTowardsTimeout: true,
+			wantRecheckAfter:           4 * time.Minute,
+		},
 		"workload with Admitted=True, no PodsReady; timeout exceeded": {
 			workload: kueue.Workload{
 				Status: kueue.WorkloadStatus{
@@ -299,10 +347,37 @@ func TestSyncCheckStates(t *testing.T) {
 }
 
 func TestReconcile(t *testing.T) {
+	podsReadyTimeout := 5 * time.Minute
+	admittedLongAgo := metav1.Condition{
+		Type:               kueue.WorkloadAdmitted,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+		Reason:             "ByTest",
+	}
+	preempting := metav1.Condition{
+		Type:               kueue.WorkloadPreempting,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+		Reason:             kueue.WorkloadEvictedByPreemption,
+	}
+	podsReady := metav1.Condition{
+		Type:   kueue.WorkloadPodsReady,
+		Status: metav1.ConditionTrue,
+		Reason: "ByTest",
+	}
+	errUpdate := errors.New("update failed")
 	cases := map[string]struct {
-		workload   *kueue.Workload
-		wantError  error
-		wantEvents []utiltesting.EventRecord
+		clusterQueue   *kueue.ClusterQueue
+		localQueue     *kueue.LocalQueue
+		resourceFlavor *kueue.ResourceFlavor
+		workload       *kueue.Workload
+		// updateError is returned by the updates of the workloads.
+		updateError    error
+		wantWorkload   *kueue.Workload
+		wantBackingOff bool
+		wantRequeue    bool
+		wantError      error
+		wantEvents     []utiltesting.EventRecord
 	}{
 		"admit": {
 			workload: utiltesting.MakeWorkload("wl", "ns").
@@ -330,30 +405,393 @@ func TestReconcile(t *testing.T) {
 				}).
 				Obj(),
 		},
+		"evicted for exceeding the PodsReady timeout, requeued with a backoff": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				RequeueState(1, nil).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByPodsReadyTimeout,
+				}).
+				RequeueState(2, nil).
+				Obj(),
+			wantBackingOff: true,
+		},
+		"deactivated after exhausting the retries": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				RequeueState(2, nil).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				Active(false).
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByDeactivation,
+				}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadDeactivated,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadRetryLimitExceeded,
+				}).
+				RequeueState(2, nil).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    kueue.WorkloadRetryLimitExceeded,
+				},
+			},
+		},
+		"recorded as deactivated when setting it inactive fails": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				RequeueState(2, nil).
+				Obj(),
+			updateError: errUpdate,
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByDeactivation,
+				}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadDeactivated,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadRetryLimitExceeded,
+				}).
+				RequeueState(2, nil).
+				Obj(),
+			wantError: errUpdate,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Warning",
+					Reason:    kueue.WorkloadRetryLimitExceeded,
+				},
+			},
+		},
+		"set inactive when recorded as deactivated in its generation": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Generation(1).
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByDeactivation,
+				}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:               kueue.WorkloadDeactivated,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadRetryLimitExceeded,
+					ObservedGeneration: 1,
+				}).
+				RequeueState(2, nil).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				Active(false).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByDeactivation,
+				}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadDeactivated,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadRetryLimitExceeded,
+				}).
+				RequeueState(2, nil).
+				Obj(),
+		},
+		"reactivated after exhausting the retries": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Generation(3).
+				Annotations(map[string]string{controllerconsts.ReactivatedByAnnotation: "admin"}).
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByDeactivation,
+				}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:               kueue.WorkloadDeactivated,
+					Status:             metav1.ConditionTrue,
+					Reason:             kueue.WorkloadRetryLimitExceeded,
+					ObservedGeneration: 1,
+				}).
+				RequeueState(2, nil).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByDeactivation,
+				}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadDeactivated,
+					Status: metav1.ConditionFalse,
+					Reason: kueue.WorkloadReactivated,
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    kueue.WorkloadReactivated,
+				},
+			},
+		},
+		"evicted without a retryPolicy": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByPodsReadyTimeout,
+				}).
+				Obj(),
+		},
+		"preempting during the grace period": {
+			clusterQueue: utiltesting.MakeClusterQueue("q1").
+				Preemption(kueue.ClusterQueuePreemption{
+					GracePeriod: &metav1.Duration{Duration: 5 * time.Minute},
+				}).
+				Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(preempting).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(preempting).
+				Obj(),
+		},
+		"evicted after the preemption grace period": {
+			clusterQueue: utiltesting.MakeClusterQueue("q1").
+				Preemption(kueue.ClusterQueuePreemption{
+					GracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+				}).
+				Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(preempting).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadPreempting,
+					Status: metav1.ConditionFalse,
+					Reason: kueue.WorkloadEvictedByPreemption,
+				}).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByPreemption,
+				}).
+				Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "ns", Name: "wl"},
+					EventType: "Normal",
+					Reason:    "Preempted",
+				},
+			},
+		},
+		"evicted when its ResourceFlavor is drained": {
+			resourceFlavor: utiltesting.MakeResourceFlavor("on-demand").StopPolicy(kueue.HoldAndDrain).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(podsReady).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(podsReady).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadEvicted,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByResourceFlavorStopped,
+				}).
+				Obj(),
+		},
+		"keeps running when its ResourceFlavor is on hold": {
+			resourceFlavor: utiltesting.MakeResourceFlavor("on-demand").StopPolicy(kueue.Hold).Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(podsReady).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("q1").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				SetOrReplaceCondition(admittedLongAgo).
+				SetOrReplaceCondition(podsReady).
+				Obj(),
+		},
+		"backing off": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				RequeueState(1, ptr.To(metav1.NewTime(time.Now().Add(time.Minute)))).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				RequeueState(1, nil).
+				Obj(),
+			wantBackingOff: true,
+		},
+		"falls back from a missing ClusterQueue": {
+			clusterQueue: utiltesting.MakeClusterQueue("q1").Obj(),
+			localQueue: utiltesting.MakeLocalQueue("lq", "ns").
+				ClusterQueue("missing").
+				FallbackClusterQueues("q1").
+				FallbackAfter(60).
+				Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Creation(time.Now().Add(-2 * time.Minute)).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Obj(),
+		},
+		"waits to fall back from a missing ClusterQueue": {
+			clusterQueue: utiltesting.MakeClusterQueue("q1").Obj(),
+			localQueue: utiltesting.MakeLocalQueue("lq", "ns").
+				ClusterQueue("missing").
+				FallbackClusterQueues("q1").
+				FallbackAfter(60).
+				Obj(),
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				Creation(time.Now()).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				Queue("lq").
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadQuotaReserved,
+					Status: metav1.ConditionFalse,
+					Reason: "Inadmissible",
+				}).
+				Obj(),
+			wantRequeue: true,
+		},
+		"requeued after the backoff": {
+			workload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				RequeueState(1, ptr.To(metav1.NewTime(time.Now().Add(-time.Minute)))).
+				Obj(),
+			wantWorkload: utiltesting.MakeWorkload("wl", "ns").
+				RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+				RequeueState(1, nil).
+				Obj(),
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			defer features.SetFeatureGateDuringTest(t, features.LocalQueueFallback, tc.localQueue != nil)()
 			objs := []client.Object{tc.workload}
+			if tc.resourceFlavor != nil {
+				objs = append(objs, tc.resourceFlavor)
+			}
+			if tc.clusterQueue != nil {
+				objs = append(objs, tc.clusterQueue)
+			}
 			clientBuilder := utiltesting.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(objs...)
+			if tc.updateError != nil {
+				clientBuilder = clientBuilder.WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, isWorkload := obj.(*kueue.Workload); isWorkload {
+							return tc.updateError
+						}
+						return client.Update(ctx, obj, opts...)
+					},
+				})
+			}
 			cl := clientBuilder.Build()
 			recorder := &utiltesting.EventRecorder{}
 
 			cqCache := cache.New(cl)
 			qManager := queue.NewManager(cl, cqCache)
-			reconciler := NewWorkloadReconciler(cl, qManager, cqCache, recorder)
+			reconciler := NewWorkloadReconciler(cl, qManager, cqCache, recorder, WithPodsReadyTimeout(&podsReadyTimeout))
 
 			ctx, ctxCancel := context.WithCancel(context.Background())
 			defer ctxCancel()
 
-			_, gotError := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.workload)})
+			if tc.clusterQueue != nil {
+				if err := cqCache.AddClusterQueue(ctx, tc.clusterQueue); err != nil {
+					t.Fatalf("Couldn't add the ClusterQueue to the cache: %v", err)
+				}
+				if err := qManager.AddClusterQueue(ctx, tc.clusterQueue); err != nil {
+					t.Fatalf("Couldn't add the ClusterQueue to the queue manager: %v", err)
+				}
+			}
+			if tc.localQueue != nil {
+				if err := qManager.AddLocalQueue(ctx, tc.localQueue); err != nil {
+					t.Fatalf("Couldn't add the LocalQueue to the queue manager: %v", err)
+				}
+			}
+
+			result, gotError := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.workload)})
 
-			if diff := cmp.Diff(tc.wantError, gotError); diff != "" {
+			if diff := cmp.Diff(tc.wantError, gotError, cmpopts.EquateErrors()); diff != "" {
 				t.Errorf("unexpected reconcile error (-want/+got):\n%s", diff)
 			}
+			if tc.wantRequeue && result.RequeueAfter == 0 {
+				t.Error("Reconcile didn't requeue the workload")
+			}
 
 			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents, cmpopts.IgnoreFields(utiltesting.EventRecord{}, "Message")); diff != "" {
 				t.Errorf("unexpected events (-want/+got):\n%s", diff)
 			}
+
+			if tc.wantWorkload != nil {
+				var gotWorkload kueue.Workload
+				if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.workload), &gotWorkload); err != nil {
+					t.Fatalf("Could not get the workload: %v", err)
+				}
+				if diff := cmp.Diff(tc.wantWorkload, &gotWorkload,
+					cmpopts.IgnoreFields(kueue.Workload{}, "TypeMeta", "ObjectMeta"),
+					cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime", "Message", "ObservedGeneration"),
+					cmpopts.IgnoreFields(kueue.RequeueState{}, "RequeueAt"),
+					cmpopts.SortSlices(func(a, b metav1.Condition) bool { return a.Type < b.Type }),
+					cmpopts.EquateEmpty(),
+				); diff != "" {
+					t.Errorf("Unexpected workload (-want,+got):\n%s", diff)
+				}
+				if backingOff := workload.IsBackingOff(&gotWorkload); backingOff != tc.wantBackingOff {
+					t.Errorf("Workload backing off: %t, want %t", backingOff, tc.wantBackingOff)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	config "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+// WorkloadPriorityClassReconciler keeps the preemption semantics of the
+// WorkloadPriorityClasses in the cache.
+type WorkloadPriorityClassReconciler struct {
+	log      logr.Logger
+	qManager *queue.Manager
+	cache    *cache.Cache
+}
+
+func NewWorkloadPriorityClassReconciler(qMgr *queue.Manager, cache *cache.Cache) *WorkloadPriorityClassReconciler {
+	return &WorkloadPriorityClassReconciler{
+		log:      ctrl.Log.WithName("workloadpriorityclass-reconciler"),
+		qManager: qMgr,
+		cache:    cache,
+	}
+}
+
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloadpriorityclasses,verbs=get;list;watch
+
+// Reconcile does nothing, the cache is updated by the event filter.
+func (r *WorkloadPriorityClassReconciler) Reconcile(context.Context, ctrl.Request) (ctrl.Result, error) {
+	return ctrl.Result{}, nil
+}
+
+func (r *WorkloadPriorityClassReconciler) Create(e event.CreateEvent) bool {
+	wpc, match := e.Object.(*kueue.WorkloadPriorityClass)
+	if !match {
+		return false
+	}
+	r.log.V(2).Info("WorkloadPriorityClass create event", "workloadPriorityClass", klog.KObj(wpc))
+	r.cache.AddOrUpdateWorkloadPriorityClass(wpc)
+	return false
+}
+
+func (r *WorkloadPriorityClassReconciler) Update(e event.UpdateEvent) bool {
+	wpc, match := e.ObjectNew.(*kueue.WorkloadPriorityClass)
+	if !match {
+		return false
+	}
+	r.log.V(2).Info("WorkloadPriorityClass update event", "workloadPriorityClass", klog.KObj(wpc))
+	if r.cache.AddOrUpdateWorkloadPriorityClass(wpc) {
+		// The workloads that couldn't preempt might be admissible now.
+		r.qManager.QueueInadmissibleWorkloads(context.Background(), r.cache.ClusterQueueNames())
+	}
+	return false
+}
+
+func (r *WorkloadPriorityClassReconciler) Delete(e event.DeleteEvent) bool {
+	wpc, match := e.Object.(*kueue.WorkloadPriorityClass)
+	if !match {
+		return false
+	}
+	r.log.V(2).Info("WorkloadPriorityClass delete event", "workloadPriorityClass", klog.KObj(wpc))
+	r.cache.DeleteWorkloadPriorityClass(wpc)
+	r.qManager.QueueInadmissibleWorkloads(context.Background(), r.cache.ClusterQueueNames())
+	return false
+}
+
+func (r *WorkloadPriorityClassReconciler) Generic(event.GenericEvent) bool {
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkloadPriorityClassReconciler) SetupWithManager(mgr ctrl.Manager, cfg *config.Configuration) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueue.WorkloadPriorityClass{}).
+		WatchesRawSource(requeueOnElection(mgr, cfg, &kueue.WorkloadPriorityClassList{}), electionEventHandler).
+		WithEventFilter(r).
+		WithOptions(controllerOptions()).
+		Complete(withLeadingManager(mgr, r, cfg))
+}
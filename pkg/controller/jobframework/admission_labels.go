@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/podset"
+)
+
+// addAdmissionLabels adds the labels with the ClusterQueue, the cohort and
+// the flavors assigned to the workload to the podSetsInfo and, unless it's
+// nil, to the job object.
+func (r *JobReconciler) addAdmissionLabels(ctx context.Context, object client.Object, wl *kueue.Workload, podSetsInfo []podset.PodSetInfo) error {
+	log := ctrl.LoggerFrom(ctx)
+	cqName := string(wl.Status.Admission.ClusterQueue)
+	labels := map[string]string{constants.ClusterQueueLabel: cqName}
+	var cq kueue.ClusterQueue
+	if err := r.client.Get(ctx, types.NamespacedName{Name: cqName}, &cq); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else if cq.Spec.Cohort != "" {
+		labels[constants.CohortLabel] = cq.Spec.Cohort
+	}
+	for i := range wl.Status.Admission.PodSetAssignments {
+		psa := &wl.Status.Admission.PodSetAssignments[i]
+		labels[constants.PodSetFlavorLabelPrefix+psa.Name] = flavorsLabelValue(psa)
+	}
+	for key, value := range labels {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			log.V(2).Info("Skipping an invalid admission label", "label", key, "value", value, "validationErrors", errs)
+			delete(labels, key)
+		}
+	}
+
+	for i := range podSetsInfo {
+		info := &podSetsInfo[i]
+		for _, key := range []string{constants.ClusterQueueLabel, constants.CohortLabel} {
+			if value, found := labels[key]; found {
+				info.AddOrUpdateLabel(key, value)
+			}
+		}
+		if value, found := labels[constants.PodSetFlavorLabelPrefix+info.Name]; found {
+			info.AddOrUpdateLabel(constants.FlavorLabel, value)
+		}
+	}
+	if object != nil {
+		objLabels := object.GetLabels()
+		if objLabels == nil {
+			objLabels = make(map[string]string, len(labels))
+		}
+		for key, value := range labels {
+			objLabels[key] = value
+		}
+		object.SetLabels(objLabels)
+	}
+	return nil
+}
+
+// removeAdmissionLabels removes the labels added by addAdmissionLabels from
+// the job object.
+func removeAdmissionLabels(object client.Object) {
+	labels := object.GetLabels()
+	for key := range labels {
+		if key == constants.ClusterQueueLabel || key == constants.CohortLabel || strings.HasPrefix(key, constants.PodSetFlavorLabelPrefix) {
+			delete(labels, key)
+		}
+	}
+}
+
+// flavorsLabelValue returns the sorted names of the flavors of the
+// assignment, separated by "_", which isn't allowed in the flavor names.
+func flavorsLabelValue(psa *kueue.PodSetAssignment) string {
+	names := sets.New[string]()
+	for _, flavor := range psa.Flavors {
+		names.Insert(string(flavor))
+	}
+	return strings.Join(sets.List(names), "_")
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"context"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+const kueueDomain = "kueue.x-k8s.io"
+
+// copyMetadata copies the labels and annotations of the job object with the
+// configured prefixes to the workload, and removes the ones with the
+// prefixes that the job object no longer has. It returns whether the
+// workload changed.
+func (r *JobReconciler) copyMetadata(object client.Object, wl *kueue.Workload) bool {
+	labels, labelsChanged := syncKeysWithPrefixes(wl.Labels, object.GetLabels(), r.labelKeyPrefixesToCopy)
+	annotations, annotationsChanged := syncKeysWithPrefixes(wl.Annotations, object.GetAnnotations(), r.annotationKeyPrefixesToCopy)
+	wl.Labels = labels
+	wl.Annotations = annotations
+	return labelsChanged || annotationsChanged
+}
+
+// syncCopiedMetadata updates the workload when the labels and annotations
+// copied from the job object changed.
+func (r *JobReconciler) syncCopiedMetadata(ctx context.Context, object client.Object, wl *kueue.Workload) error {
+	if len(r.labelKeyPrefixesToCopy) == 0 && len(r.annotationKeyPrefixesToCopy) == 0 {
+		return nil
+	}
+	updated := wl.DeepCopy()
+	if !r.copyMetadata(object, updated) {
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).V(3).Info("Updating the labels and annotations copied from the job to the workload")
+	if err := r.client.Update(ctx, updated); err != nil {
+		return err
+	}
+	*wl = *updated
+	return nil
+}
+
+// syncKeysWithPrefixes returns dst with the entries of src whose keys have one
+// of the prefixes, without the entries of dst with the prefixes that src
+// doesn't have, and whether it changed. The keys in the kueue.x-k8s.io
+// domain are left untouched.
+func syncKeysWithPrefixes(dst, src map[string]string, prefixes []string) (map[string]string, bool) {
+	changed := false
+	for key := range dst {
+		if _, found := src[key]; !found && hasAnyPrefix(key, prefixes) {
+			delete(dst, key)
+			changed = true
+		}
+	}
+	for key, value := range src {
+		if !hasAnyPrefix(key, prefixes) {
+			continue
+		}
+		if current, found := dst[key]; found && current == value {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]string)
+		}
+		dst[key] = value
+		changed = true
+	}
+	return dst, changed
+}
+
+func hasAnyPrefix(key string, prefixes []string) bool {
+	if domain, _, found := strings.Cut(key, "/"); found && (domain == kueueDomain || strings.HasSuffix(domain, "."+kueueDomain)) {
+		return false
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
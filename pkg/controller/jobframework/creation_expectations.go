@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// workloadCreationTimeout is how long the reconciler waits to observe a
+// created workload in the cache before it attempts to create it again.
+const workloadCreationTimeout = time.Minute
+
+// creationExpectations tracks the workloads that were created but not yet
+// observed in the cache, so that a stale cache doesn't lead to creating them
+// again.
+type creationExpectations struct {
+	timeout time.Duration
+	clock   clock.Clock
+
+	mu      sync.Mutex
+	pending map[string]time.Time
+}
+
+func newCreationExpectations(timeout time.Duration, clock clock.Clock) *creationExpectations {
+	return &creationExpectations{
+		timeout: timeout,
+		clock:   clock,
+		pending: make(map[string]time.Time),
+	}
+}
+
+// expect records that the workload was created.
+func (e *creationExpectations) expect(key string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	now := e.clock.Now()
+	// Drop the expired expectations, so that the workloads of jobs deleted
+	// before their workload was observed are not tracked forever.
+	for k, created := range e.pending {
+		if now.Sub(created) >= e.timeout {
+			delete(e.pending, k)
+		}
+	}
+	e.pending[key] = now
+}
+
+// observed records that the workload is in the cache.
+func (e *creationExpectations) observed(key string) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.pending, key)
+}
+
+// wait returns how long to wait for the created workload to be observed. It
+// returns 0 if the workload can be created.
+func (e *creationExpectations) wait(key string) time.Duration {
+	if e == nil {
+		return 0
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	created, found := e.pending[key]
+	if !found {
+		return 0
+	}
+	if remaining := e.timeout - e.clock.Since(created); remaining > 0 {
+		return remaining
+	}
+	delete(e.pending, key)
+	return 0
+}
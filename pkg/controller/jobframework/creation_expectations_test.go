@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"testing"
+	"time"
+
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestCreationExpectations(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	e := newCreationExpectations(time.Minute, fakeClock)
+
+	if got := e.wait("ns/a"); got != 0 {
+		t.Errorf("Unexpected wait before the workload is created: %v", got)
+	}
+	e.expect("ns/a")
+	e.expect("ns/b")
+	fakeClock.Step(20 * time.Second)
+	if got, want := e.wait("ns/a"), 40*time.Second; got != want {
+		t.Errorf("Unexpected wait for the created workload, got %v, want %v", got, want)
+	}
+
+	e.observed("ns/a")
+	if got := e.wait("ns/a"); got != 0 {
+		t.Errorf("Unexpected wait after the workload was observed: %v", got)
+	}
+
+	fakeClock.Step(40 * time.Second)
+	if got := e.wait("ns/b"); got != 0 {
+		t.Errorf("Unexpected wait after the timeout: %v", got)
+	}
+	if _, found := e.pending["ns/b"]; found {
+		t.Error("The expired expectation is still tracked")
+	}
+}
+
+func TestCreationExpectations_Nil(t *testing.T) {
+	var e *creationExpectations
+	e.expect("ns/a")
+	e.observed("ns/a")
+	if got := e.wait("ns/a"); got != 0 {
+		t.Errorf("Unexpected wait for nil expectations: %v", got)
+	}
+}
@@ -13,6 +13,20 @@ limitations under the License.
 
 package jobframework
 
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
+)
+
 func ApplyDefaultForSuspend(job GenericJob, manageJobsWithoutQueueName bool) {
 	if QueueName(job) != "" || manageJobsWithoutQueueName {
 		if !job.IsSuspended() {
@@ -20,3 +34,46 @@ func ApplyDefaultForSuspend(job GenericJob, manageJobsWithoutQueueName bool) {
 		}
 	}
 }
+
+// ApplyDefaultLocalQueue sets the queue name of a job submitted without one to
+// the default LocalQueue of its namespace, if there is one. The jobs owned by
+// a job managed by Kueue are left untouched.
+func ApplyDefaultLocalQueue(ctx context.Context, c client.Reader, job GenericJob) error {
+	if !features.Enabled(features.DefaultLocalQueue) || QueueName(job) != "" {
+		return nil
+	}
+	object := job.Object()
+	if owner := metav1.GetControllerOf(object); owner != nil && IsOwnerManagedByKueue(owner) {
+		return nil
+	}
+	name, err := DefaultLocalQueue(ctx, c, object.GetNamespace())
+	if err != nil || name == "" {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).V(5).Info("Using the default LocalQueue", "localQueue", name)
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string, 1)
+	}
+	labels[constants.QueueLabel] = name
+	object.SetLabels(labels)
+	return nil
+}
+
+// DefaultLocalQueue returns the name of the LocalQueue marked as default in
+// the namespace, or an empty string if there is none. If several LocalQueues
+// are marked as default, the first one by name is returned.
+func DefaultLocalQueue(ctx context.Context, c client.Reader, namespace string) (string, error) {
+	var queues kueue.LocalQueueList
+	if err := c.List(ctx, &queues, client.InNamespace(namespace), client.MatchingLabels{constants.DefaultLocalQueueLabel: "true"}); err != nil {
+		return "", fmt.Errorf("listing the default LocalQueues: %w", err)
+	}
+	if len(queues.Items) == 0 {
+		return "", nil
+	}
+	names := make([]string, 0, len(queues.Items))
+	for i := range queues.Items {
+		names = append(names, queues.Items[i].Name)
+	}
+	return slices.Min(names), nil
+}
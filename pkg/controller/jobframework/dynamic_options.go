@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"sync/atomic"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DynamicOptions holds the options that can change while the controllers and
+// webhooks run, to apply a reloaded configuration.
+type DynamicOptions struct {
+	waitForPodsReady     atomic.Bool
+	podNamespaceSelector atomic.Pointer[metav1.LabelSelector]
+	podSelector          atomic.Pointer[metav1.LabelSelector]
+}
+
+// NewDynamicOptions returns the dynamic options set by
+// WithWaitForPodsReady, WithPodNamespaceSelector and WithPodSelector.
+func NewDynamicOptions(opts ...Option) *DynamicOptions {
+	d := &DynamicOptions{}
+	d.Set(opts...)
+	return d
+}
+
+// Set replaces all the dynamic options. The ones not set by opts are reset to
+// their defaults.
+func (d *DynamicOptions) Set(opts ...Option) {
+	options := DefaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	d.waitForPodsReady.Store(options.WaitForPodsReady)
+	d.podNamespaceSelector.Store(options.PodNamespaceSelector)
+	d.podSelector.Store(options.PodSelector)
+}
+
+// WaitForPodsReady returns whether the controllers add the PodsReady
+// condition to the workloads.
+func (d *DynamicOptions) WaitForPodsReady() bool {
+	return d.waitForPodsReady.Load()
+}
+
+// PodNamespaceSelector returns the selector of the namespaces of the pods
+// managed by Kueue.
+func (d *DynamicOptions) PodNamespaceSelector() *metav1.LabelSelector {
+	return d.podNamespaceSelector.Load()
+}
+
+// PodSelector returns the selector of the pods managed by Kueue.
+func (d *DynamicOptions) PodSelector() *metav1.LabelSelector {
+	return d.podSelector.Load()
+}
+
+// DynamicOptions returns the options set by WithDynamicOptions or, if unset,
+// fixed ones from WithWaitForPodsReady, WithPodNamespaceSelector and
+// WithPodSelector.
+func (o *Options) DynamicOptions() *DynamicOptions {
+	if o.Dynamic != nil {
+		return o.Dynamic
+	}
+	return NewDynamicOptions(
+		WithWaitForPodsReady(o.WaitForPodsReady),
+		WithPodNamespaceSelector(o.PodNamespaceSelector),
+		WithPodSelector(o.PodSelector),
+	)
+}
@@ -78,6 +78,16 @@ type JobWithCustomStop interface {
 	Stop(ctx context.Context, c client.Client, podSetsInfo []podset.PodSetInfo, stopReason StopReason, eventMsg string) (bool, error)
 }
 
+// JobWithCustomRun interface should be implemented by generic jobs,
+// when starting the job requires writes to API objects other than the job.
+type JobWithCustomRun interface {
+	// Run implements a custom start procedure, persisting the podSetsInfo
+	// into all the objects composing the job.
+	// The function should only issue API calls for the objects that aren't started yet.
+	// Returns the objects started with this call or an error.
+	Run(ctx context.Context, c client.Client, podSetsInfo []podset.PodSetInfo) ([]client.Object, error)
+}
+
 // JobWithFinalize interface should be implemented by generic jobs,
 // when custom finalization logic is needed for a job, after it's finished.
 type JobWithFinalize interface {
@@ -90,11 +100,44 @@ type JobWithSkip interface {
 	Skip() bool
 }
 
+// JobWithPartialAdmission interface should be implemented by generic jobs
+// that can run with less pods than the counts of their pod sets. These jobs
+// run with the counts of the podSetsInfo passed to RunWithPodSetsInfo and
+// restore their original counts in RestorePodSetsInfo. The minimum counts of
+// their pod sets are set with the kueue.x-k8s.io/podset-min-counts annotation.
+type JobWithPartialAdmission interface {
+	// SupportsPartialAdmission returns whether the job can run with less pods
+	// than the counts of its pod sets.
+	SupportsPartialAdmission() bool
+}
+
 type JobWithPriorityClass interface {
 	// PriorityClass returns the job's priority class name.
 	PriorityClass() string
 }
 
+// JobWithForeignSchedulingGates interface should be implemented by generic
+// jobs whose pods can carry scheduling gates managed by other controllers,
+// which keep the pods from being scheduled once Kueue starts the job.
+type JobWithForeignSchedulingGates interface {
+	// ForeignSchedulingGates returns the names of the scheduling gates, not
+	// managed by Kueue, that remain on the active pods of the job.
+	ForeignSchedulingGates() []string
+}
+
+// JobWithGroupEvents interface should be implemented by composable jobs whose
+// members are started and stopped individually. When the group events are
+// aggregated, a single event is emitted on the workload for the whole group,
+// instead of an event for every member.
+type JobWithGroupEvents interface {
+	// GroupSize returns the number of members of the group, or 0 if the job
+	// is not part of a group.
+	GroupSize() int
+	// IsGroupLeader returns whether the start of the group is reported when
+	// this member is started.
+	IsGroupLeader() bool
+}
+
 // ComposableJob interface should be implemented by generic jobs that
 // are composed out of multiple API objects.
 type ComposableJob interface {
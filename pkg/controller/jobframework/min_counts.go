@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// PodSetMinCounts returns the minimum counts of the pod sets of the job, set
+// with the kueue.x-k8s.io/podset-min-counts annotation, or nil if the job
+// doesn't have the annotation. The value of the annotation is a comma
+// separated list of <podSetName>=<minCount> pairs.
+func PodSetMinCounts(job GenericJob) (map[string]int32, error) {
+	value, found := job.Object().GetAnnotations()[constants.PodSetMinCountsAnnotation]
+	if !found {
+		return nil, nil
+	}
+	minCounts := make(map[string]int32)
+	for _, pair := range strings.Split(value, ",") {
+		name, countStr, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("%q is not a <podSetName>=<minCount> pair", pair)
+		}
+		if _, duplicate := minCounts[name]; duplicate {
+			return nil, fmt.Errorf("duplicate pod set %q", name)
+		}
+		count, err := strconv.ParseInt(countStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum count for pod set %q: %w", name, err)
+		}
+		minCounts[name] = int32(count)
+	}
+	return minCounts, nil
+}
+
+// supportsPartialAdmission returns whether the job can run with less pods
+// than the counts of its pod sets.
+func supportsPartialAdmission(job GenericJob) bool {
+	jobWithPartialAdmission, implements := job.(JobWithPartialAdmission)
+	return implements && jobWithPartialAdmission.SupportsPartialAdmission()
+}
+
+// jobPodSets returns the pod sets of the job, with the minimum counts of the
+// kueue.x-k8s.io/podset-min-counts annotation when the job supports partial
+// admission.
+func jobPodSets(job GenericJob) []kueue.PodSet {
+	podSets := job.PodSets()
+	if supportsPartialAdmission(job) {
+		// The annotation is validated by the webhooks.
+		minCounts, _ := PodSetMinCounts(job)
+		for i := range podSets {
+			if minCount, found := minCounts[podSets[i].Name]; found {
+				podSets[i].MinCount = ptr.To(minCount)
+			}
+		}
+	}
+	return clearMinCountsIfFeatureDisabled(podSets)
+}
@@ -17,6 +17,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -26,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -60,18 +63,28 @@ var (
 
 // JobReconciler reconciles a GenericJob object
 type JobReconciler struct {
-	client                     client.Client
-	record                     record.EventRecorder
-	manageJobsWithoutQueueName bool
-	waitForPodsReady           bool
+	client                      client.Client
+	record                      record.EventRecorder
+	manageJobsWithoutQueueName  bool
+	dynamic                     *DynamicOptions
+	aggregateGroupEvents        bool
+	reclaimablePodsUpdates      *updateWindow
+	workloadCreations           *creationExpectations
+	labelKeyPrefixesToCopy      []string
+	annotationKeyPrefixesToCopy []string
 }
 
 type Options struct {
-	ManageJobsWithoutQueueName bool
-	WaitForPodsReady           bool
-	KubeServerVersion          *kubeversion.ServerVersionFetcher
-	PodNamespaceSelector       *metav1.LabelSelector
-	PodSelector                *metav1.LabelSelector
+	ManageJobsWithoutQueueName  bool
+	WaitForPodsReady            bool
+	KubeServerVersion           *kubeversion.ServerVersionFetcher
+	PodNamespaceSelector        *metav1.LabelSelector
+	PodSelector                 *metav1.LabelSelector
+	AggregateGroupEvents        bool
+	ReclaimablePodsUpdateWindow time.Duration
+	Dynamic                     *DynamicOptions
+	LabelKeyPrefixesToCopy      []string
+	AnnotationKeyPrefixesToCopy []string
 }
 
 // Option configures the reconciler.
@@ -116,6 +129,42 @@ func WithPodSelector(s *metav1.LabelSelector) Option {
 	}
 }
 
+// WithAggregateGroupEvents indicates if the controller should emit a single
+// event on the workload when a group of jobs is started or stopped, instead
+// of an event for every member of the group.
+func WithAggregateGroupEvents(f bool) Option {
+	return func(o *Options) {
+		o.AggregateGroupEvents = f
+	}
+}
+
+// WithReclaimablePodsUpdateWindow sets the minimum time between two updates
+// of the reclaimable pods of a workload. The changes within the window are
+// coalesced into a single update at the end of it.
+func WithReclaimablePodsUpdateWindow(d time.Duration) Option {
+	return func(o *Options) {
+		o.ReclaimablePodsUpdateWindow = d
+	}
+}
+
+// WithDynamicOptions makes the controllers and webhooks read waitForPodsReady
+// and the pod selectors from the dynamic options, instead of the ones set by
+// WithWaitForPodsReady, WithPodNamespaceSelector and WithPodSelector.
+func WithDynamicOptions(d *DynamicOptions) Option {
+	return func(o *Options) {
+		o.Dynamic = d
+	}
+}
+
+// WithKeyPrefixesToCopy sets the prefixes of the keys of the labels and the
+// annotations that are copied from the jobs to their workloads.
+func WithKeyPrefixesToCopy(labels, annotations []string) Option {
+	return func(o *Options) {
+		o.LabelKeyPrefixesToCopy = labels
+		o.AnnotationKeyPrefixesToCopy = annotations
+	}
+}
+
 var DefaultOptions = Options{}
 
 func NewReconciler(
@@ -128,10 +177,15 @@ func NewReconciler(
 	}
 
 	return &JobReconciler{
-		client:                     client,
-		record:                     record,
-		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
-		waitForPodsReady:           options.WaitForPodsReady,
+		client:                      client,
+		record:                      record,
+		manageJobsWithoutQueueName:  options.ManageJobsWithoutQueueName,
+		dynamic:                     options.DynamicOptions(),
+		aggregateGroupEvents:        options.AggregateGroupEvents,
+		reclaimablePodsUpdates:      newUpdateWindow(options.ReclaimablePodsUpdateWindow, clock.RealClock{}),
+		workloadCreations:           newCreationExpectations(workloadCreationTimeout, clock.RealClock{}),
+		labelKeyPrefixesToCopy:      options.LabelKeyPrefixesToCopy,
+		annotationKeyPrefixesToCopy: options.AnnotationKeyPrefixesToCopy,
 	}
 }
 
@@ -238,6 +292,9 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if wl != nil {
+		r.workloadCreations.observed(workload.Key(wl))
+	}
 
 	if wl != nil && apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
 		// Finalize the job if it's finished
@@ -265,6 +322,14 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// 1.2 keep the labels and annotations copied from the job in sync. The
+	// workloads of composable jobs only get them when they are created.
+	if _, isComposable := job.(ComposableJob); wl != nil && !isComposable {
+		if err := r.syncCopiedMetadata(ctx, object, wl); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
 	// 2. handle job is finished.
 	if condition, finished := job.Finished(); finished {
 		if wl != nil && !apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
@@ -284,11 +349,11 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 
 	// 3. handle workload is nil.
 	if wl == nil {
-		err := r.handleJobWithNoWorkload(ctx, job, object)
+		result, err := r.handleJobWithNoWorkload(ctx, job, object)
 		if err != nil {
 			log.Error(err, "Handling job with no workload")
 		}
-		return ctrl.Result{}, err
+		return result, err
 	}
 
 	// 4. update reclaimable counts if implemented by the job
@@ -300,18 +365,44 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		}
 
 		if !workload.ReclaimablePodsAreEqual(reclPods, wl.Status.ReclaimablePods) {
-			err = workload.UpdateReclaimablePods(ctx, r.client, wl, reclPods)
+			wlKey := workload.Key(wl)
+			if delay := r.reclaimablePodsUpdates.delay(wlKey); delay > 0 {
+				// Keep reconciling the job, and update the reclaimable pods when
+				// the window ends.
+				log.V(3).Info("Delaying the update of the reclaimable pods", "delay", delay)
+				defer func() {
+					if err == nil && !result.Requeue && (result.RequeueAfter == 0 || result.RequeueAfter > delay) {
+						result.RequeueAfter = delay
+					}
+				}()
+			} else {
+				err = workload.UpdateReclaimablePods(ctx, r.client, wl, reclPods)
+				if err != nil {
+					log.Error(err, "Updating reclaimable pods")
+					return ctrl.Result{}, err
+				}
+				r.reclaimablePodsUpdates.updated(wlKey)
+				return ctrl.Result{}, nil
+			}
+		}
+	}
+
+	// 5. surface the scheduling gates of other controllers that keep the
+	// started pods from being scheduled.
+	if jfg, implements := job.(JobWithForeignSchedulingGates); implements && workload.IsAdmitted(wl) && !job.IsSuspended() {
+		if condition, changed := schedulingGatedCondition(wl, jfg.ForeignSchedulingGates()); changed {
+			log.V(3).Info("Updating the SchedulingGated condition", "status", condition.Status)
+			apimeta.SetStatusCondition(&wl.Status.Conditions, condition)
+			err := workload.UpdateStatus(ctx, r.client, wl, condition.Type, condition.Status, condition.Reason, condition.Message, constants.JobControllerName)
 			if err != nil {
-				log.Error(err, "Updating reclaimable pods")
-				return ctrl.Result{}, err
+				log.Error(err, "Updating workload status")
 			}
-			return ctrl.Result{}, nil
 		}
 	}
 
-	// 5. handle WaitForPodsReady only for a standalone job.
+	// 6. handle WaitForPodsReady only for a standalone job.
 	// handle a job when waitForPodsReady is enabled, and it is the main job
-	if r.waitForPodsReady {
+	if r.dynamic.WaitForPodsReady() {
 		log.V(5).Info("Handling a job when waitForPodsReady is enabled")
 		condition := generatePodsReadyCondition(job, wl)
 		// optimization to avoid sending the update request if the status didn't change
@@ -325,7 +416,7 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		}
 	}
 
-	// 6. handle eviction
+	// 7. handle eviction
 	if evCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadEvicted); evCond != nil && evCond.Status == metav1.ConditionTrue {
 		if err := r.stopJob(ctx, job, wl, StopReasonWorkloadEvicted, evCond.Message); err != nil {
 			return ctrl.Result{}, err
@@ -344,7 +435,7 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	}
 
-	// 7. handle job is suspended.
+	// 8. handle job is suspended.
 	if job.IsSuspended() {
 		// start the job if the workload has been admitted, and the job is still suspended
 		if workload.IsAdmitted(wl) {
@@ -599,7 +690,7 @@ func equivalentToWorkload(job GenericJob, wl *kueue.Workload) bool {
 		return false
 	}
 
-	jobPodSets := clearMinCountsIfFeatureDisabled(job.PodSets())
+	jobPodSets := jobPodSets(job)
 
 	if !workload.CanBePartiallyAdmitted(wl) || !workload.HasQuotaReservation(wl) {
 		// the two sets should fully match.
@@ -655,31 +746,84 @@ func (r *JobReconciler) startJob(ctx context.Context, job GenericJob, object cli
 	if err != nil {
 		return err
 	}
-	if runErr := job.RunWithPodSetsInfo(info); runErr != nil {
-		return runErr
+	started := []client.Object{object}
+	jcr, customRun := job.(JobWithCustomRun)
+	if features.Enabled(features.AdmissionLabels) {
+		// The custom runs label the objects they start with the podSetsInfo.
+		labeled := object
+		if customRun {
+			labeled = nil
+		}
+		if err := r.addAdmissionLabels(ctx, labeled, wl, info); err != nil {
+			return err
+		}
 	}
+	if customRun {
+		var err error
+		if started, err = jcr.Run(ctx, r.client, info); err != nil {
+			return err
+		}
+		if len(started) == 0 {
+			return nil
+		}
+	} else {
+		if runErr := job.RunWithPodSetsInfo(info); runErr != nil {
+			return runErr
+		}
 
-	if err := r.client.Update(ctx, object); err != nil {
-		return err
+		if err := r.client.Update(ctx, object); err != nil {
+			return err
+		}
 	}
 
-	r.record.Eventf(object, corev1.EventTypeNormal, "Started",
-		"Admitted by clusterQueue %v", wl.Status.Admission.ClusterQueue)
+	if jge, groupSize := r.groupEvents(job); groupSize > 0 {
+		// A custom run starts all the members of the group at once, so the
+		// member doing it reports the start, even if it isn't the leader.
+		if customRun || jge.IsGroupLeader() {
+			r.record.Eventf(wl, corev1.EventTypeNormal, "Started",
+				"Admitted by clusterQueue %v, starting %d members of the group", wl.Status.Admission.ClusterQueue, groupSize)
+		}
+		return nil
+	}
+
+	for _, o := range started {
+		r.record.Eventf(o, corev1.EventTypeNormal, "Started",
+			"Admitted by clusterQueue %v", wl.Status.Admission.ClusterQueue)
+	}
 
 	return nil
 }
 
+// groupEvents returns the job as a JobWithGroupEvents and the size of its
+// group, if the events for the group should be aggregated.
+func (r *JobReconciler) groupEvents(job GenericJob) (JobWithGroupEvents, int) {
+	if !r.aggregateGroupEvents {
+		return nil, 0
+	}
+	jge, implements := job.(JobWithGroupEvents)
+	if !implements {
+		return nil, 0
+	}
+	return jge, jge.GroupSize()
+}
+
 // stopJob will suspend the job, and also restore node affinity, reset job status if needed.
 // Returns whether any operation was done to stop the job or an error.
 func (r *JobReconciler) stopJob(ctx context.Context, job GenericJob, wl *kueue.Workload, stopReason StopReason, eventMsg string) error {
 	object := job.Object()
+	// The labels are only persisted along the other changes to stop the job.
+	removeAdmissionLabels(object)
 
 	info := GetPodSetsInfoFromWorkload(wl)
 
 	if jws, implements := job.(JobWithCustomStop); implements {
 		stoppedNow, err := jws.Stop(ctx, r.client, info, stopReason, eventMsg)
 		if stoppedNow {
-			r.record.Eventf(object, corev1.EventTypeNormal, "Stopped", eventMsg)
+			if _, groupSize := r.groupEvents(job); groupSize > 0 && wl != nil {
+				r.record.Eventf(wl, corev1.EventTypeNormal, "Stopped", "%s, stopping %d members of the group", eventMsg, groupSize)
+			} else {
+				r.record.Eventf(object, corev1.EventTypeNormal, "Stopped", eventMsg)
+			}
 		}
 
 		return err
@@ -728,10 +872,11 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 			return nil, err
 		}
 
+		r.copyMetadata(object, wl)
 		return wl, nil
 	}
 
-	podSets := job.PodSets()
+	podSets := jobPodSets(job)
 
 	wl := &kueue.Workload{
 		ObjectMeta: metav1.ObjectMeta{
@@ -746,6 +891,8 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 		},
 	}
 
+	r.copyMetadata(object, wl)
+
 	jobUid := string(job.Object().GetUID())
 	if errs := validation.IsValidLabelValue(jobUid); len(errs) == 0 {
 		wl.Labels[controllerconsts.JobUIDLabel] = jobUid
@@ -783,12 +930,36 @@ func (r *JobReconciler) extractPriority(ctx context.Context, podSets []kueue.Pod
 	if workloadPriorityClass := workloadPriorityClassName(job); len(workloadPriorityClass) > 0 {
 		return utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, r.client, workloadPriorityClass)
 	}
+	var priorityClass string
 	if jobWithPriorityClass, isImplemented := job.(JobWithPriorityClass); isImplemented {
-		return utilpriority.GetPriorityFromPriorityClass(
-			ctx, r.client, jobWithPriorityClass.PriorityClass())
+		priorityClass = jobWithPriorityClass.PriorityClass()
+	} else {
+		priorityClass = extractPriorityFromPodSets(podSets)
+	}
+	if len(priorityClass) == 0 && features.Enabled(features.LocalQueueDefaultPriority) {
+		defaultClass, err := r.localQueueDefaultPriorityClass(ctx, job)
+		if err != nil {
+			return "", "", 0, err
+		}
+		if len(defaultClass) > 0 {
+			return utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, r.client, defaultClass)
+		}
 	}
-	return utilpriority.GetPriorityFromPriorityClass(
-		ctx, r.client, extractPriorityFromPodSets(podSets))
+	return utilpriority.GetPriorityFromPriorityClass(ctx, r.client, priorityClass)
+}
+
+// localQueueDefaultPriorityClass returns the default workload priority class of
+// the LocalQueue of the job, if the queue exists.
+func (r *JobReconciler) localQueueDefaultPriorityClass(ctx context.Context, job GenericJob) (string, error) {
+	queueName := QueueName(job)
+	if len(queueName) == 0 {
+		return "", nil
+	}
+	var lq kueue.LocalQueue
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: job.Object().GetNamespace(), Name: queueName}, &lq); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	return lq.Spec.DefaultWorkloadPriorityClass, nil
 }
 
 func extractPriorityFromPodSets(podSets []kueue.PodSet) string {
@@ -830,43 +1001,50 @@ func (r *JobReconciler) getPodSetsInfoFromStatus(ctx context.Context, w *kueue.W
 	return podSetsInfo, nil
 }
 
-func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job GenericJob, object client.Object) error {
+func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job GenericJob, object client.Object) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	_, usePrebuiltWorkload := prebuiltWorkload(job)
 	if usePrebuiltWorkload {
 		// Stop the job if not already suspended
 		if stopErr := r.stopJob(ctx, job, nil, StopReasonNoMatchingWorkload, "missing workload"); stopErr != nil {
-			return stopErr
+			return ctrl.Result{}, stopErr
 		}
 	}
 
 	// Wait until there are no active pods.
 	if job.IsActive() {
 		log.V(2).Info("Job is suspended but still has active pods, waiting")
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	if usePrebuiltWorkload {
 		log.V(2).Info("Skip workload creation for job with prebuilt workload")
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	// Create the corresponding workload.
 	wl, err := r.constructWorkload(ctx, job, object)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
+	}
+	// The workload created in a previous reconcile might not be in the cache yet.
+	wlKey := workload.Key(wl)
+	if wait := r.workloadCreations.wait(wlKey); wait > 0 {
+		log.V(3).Info("Waiting for the created workload to be observed", "workload", wlKey)
+		return ctrl.Result{RequeueAfter: wait}, nil
 	}
 	err = r.prepareWorkload(ctx, job, wl)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 	if err = r.client.Create(ctx, wl); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
+	r.workloadCreations.expect(wlKey)
 	r.record.Eventf(object, corev1.EventTypeNormal, "CreatedWorkload",
-		"Created Workload: %v", workload.Key(wl))
-	return nil
+		"Created Workload: %v", wlKey)
+	return ctrl.Result{}, nil
 }
 
 func (r *JobReconciler) ignoreUnretryableError(log logr.Logger, err error) error {
@@ -897,6 +1075,32 @@ func generatePodsReadyCondition(job GenericJob, wl *kueue.Workload) metav1.Condi
 	}
 }
 
+// schedulingGatedCondition returns the SchedulingGated condition for the
+// foreign scheduling gates of the job, and whether it differs from the
+// condition of the workload. The condition is only added while gates remain.
+func schedulingGatedCondition(wl *kueue.Workload, gates []string) (metav1.Condition, bool) {
+	current := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadSchedulingGated)
+	if len(gates) == 0 {
+		if current == nil || current.Status == metav1.ConditionFalse {
+			return metav1.Condition{}, false
+		}
+		return metav1.Condition{
+			Type:    kueue.WorkloadSchedulingGated,
+			Status:  metav1.ConditionFalse,
+			Reason:  "SchedulingGatesRemoved",
+			Message: "The pods are no longer gated by scheduling gates not managed by Kueue",
+		}, true
+	}
+	condition := metav1.Condition{
+		Type:    kueue.WorkloadSchedulingGated,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ForeignSchedulingGates",
+		Message: fmt.Sprintf("The pods are gated by scheduling gates not managed by Kueue: %s", strings.Join(gates, ", ")),
+	}
+	changed := current == nil || current.Status != condition.Status || current.Message != condition.Message
+	return condition, changed
+}
+
 // GetPodSetsInfoFromWorkload retrieve the podSetsInfo slice from the
 // provided workload's spec
 func GetPodSetsInfoFromWorkload(wl *kueue.Workload) []podset.PodSetInfo {
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+// updateWindow tracks the last time a status field of every workload was
+// updated, so that the changes within a window are coalesced into a single
+// update at the end of it.
+type updateWindow struct {
+	window time.Duration
+	clock  clock.Clock
+
+	mu         sync.Mutex
+	lastUpdate map[string]time.Time
+}
+
+func newUpdateWindow(window time.Duration, clock clock.Clock) *updateWindow {
+	return &updateWindow{
+		window:     window,
+		clock:      clock,
+		lastUpdate: make(map[string]time.Time),
+	}
+}
+
+// delay returns how long the update of the workload needs to wait for the
+// window to end. It returns 0 if the update can be done right away.
+func (w *updateWindow) delay(key string) time.Duration {
+	if w == nil || w.window <= 0 {
+		return 0
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	last, found := w.lastUpdate[key]
+	if !found {
+		return 0
+	}
+	if remaining := w.window - w.clock.Since(last); remaining > 0 {
+		return remaining
+	}
+	delete(w.lastUpdate, key)
+	return 0
+}
+
+// updated records that the workload was updated, starting a new window.
+func (w *updateWindow) updated(key string) {
+	if w == nil || w.window <= 0 {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.clock.Now()
+	// Drop the windows that ended, so that the workloads that are not updated
+	// anymore are not tracked forever.
+	for k, last := range w.lastUpdate {
+		if now.Sub(last) >= w.window {
+			delete(w.lastUpdate, k)
+		}
+	}
+	w.lastUpdate[key] = now
+}
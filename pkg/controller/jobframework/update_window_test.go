@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	"testing"
+	"time"
+
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+func TestUpdateWindow(t *testing.T) {
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	w := newUpdateWindow(10*time.Second, fakeClock)
+
+	if got := w.delay("ns/a"); got != 0 {
+		t.Errorf("Unexpected delay before the first update: %v", got)
+	}
+	w.updated("ns/a")
+	fakeClock.Step(4 * time.Second)
+	if got, want := w.delay("ns/a"), 6*time.Second; got != want {
+		t.Errorf("Unexpected delay within the window, got %v, want %v", got, want)
+	}
+	if got := w.delay("ns/b"); got != 0 {
+		t.Errorf("Unexpected delay for a workload that wasn't updated: %v", got)
+	}
+
+	w.updated("ns/b")
+	fakeClock.Step(6 * time.Second)
+	if got := w.delay("ns/a"); got != 0 {
+		t.Errorf("Unexpected delay after the window ended: %v", got)
+	}
+	if _, found := w.lastUpdate["ns/a"]; found {
+		t.Error("The window that ended is still tracked")
+	}
+
+	fakeClock.Step(4 * time.Second)
+	w.updated("ns/c")
+	if _, found := w.lastUpdate["ns/b"]; found {
+		t.Error("The window that ended is still tracked after another update")
+	}
+}
+
+func TestUpdateWindow_Disabled(t *testing.T) {
+	var nilWindow *updateWindow
+	nilWindow.updated("ns/a")
+	if got := nilWindow.delay("ns/a"); got != 0 {
+		t.Errorf("Unexpected delay for a nil window: %v", got)
+	}
+
+	w := newUpdateWindow(0, testingclock.NewFakeClock(time.Now()))
+	w.updated("ns/a")
+	if got := w.delay("ns/a"); got != 0 {
+		t.Errorf("Unexpected delay for an empty window: %v", got)
+	}
+}
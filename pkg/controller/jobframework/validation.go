@@ -15,6 +15,7 @@ package jobframework
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
@@ -29,6 +30,7 @@ var (
 	annotationsPath               = field.NewPath("metadata", "annotations")
 	labelsPath                    = field.NewPath("metadata", "labels")
 	parentWorkloadKeyPath         = annotationsPath.Key(constants.ParentWorkloadAnnotation)
+	podSetMinCountsPath           = annotationsPath.Key(constants.PodSetMinCountsAnnotation)
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
 	supportedPrebuiltWlJobGVKs    = sets.New("batch/v1, Kind=Job")
@@ -80,6 +82,53 @@ func ValidateCreateForParentWorkload(job GenericJob) field.ErrorList {
 	return allErrs
 }
 
+// ValidateCreateForPodSetMinCounts validates the minimum counts of the pod
+// sets of the job, set with the kueue.x-k8s.io/podset-min-counts annotation.
+// It should be called by the webhooks of the integrations supporting partial
+// admission.
+func ValidateCreateForPodSetMinCounts(job GenericJob) field.ErrorList {
+	value, found := job.Object().GetAnnotations()[constants.PodSetMinCountsAnnotation]
+	if !found {
+		return nil
+	}
+	if !supportsPartialAdmission(job) {
+		return field.ErrorList{field.Forbidden(podSetMinCountsPath, fmt.Sprintf("Is not supported for %q", job.GVK().String()))}
+	}
+	minCounts, err := PodSetMinCounts(job)
+	if err != nil {
+		return field.ErrorList{field.Invalid(podSetMinCountsPath, value, err.Error())}
+	}
+	var allErrs field.ErrorList
+	counts := make(map[string]int32, len(minCounts))
+	for _, ps := range job.PodSets() {
+		counts[ps.Name] = ps.Count
+	}
+	names := make([]string, 0, len(minCounts))
+	for name := range minCounts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		count, found := counts[name]
+		if !found {
+			allErrs = append(allErrs, field.Invalid(podSetMinCountsPath, value, fmt.Sprintf("pod set %q doesn't exist", name)))
+		} else if minCount := minCounts[name]; minCount <= 0 || minCount > count {
+			allErrs = append(allErrs, field.Invalid(podSetMinCountsPath, value, fmt.Sprintf("the minimum count of pod set %q should be between 1 and %d", name, count)))
+		}
+	}
+	return allErrs
+}
+
+func ValidateUpdateForPodSetMinCounts(oldJob, newJob GenericJob) field.ErrorList {
+	var allErrs field.ErrorList
+	if !newJob.IsSuspended() {
+		oldValue := oldJob.Object().GetAnnotations()[constants.PodSetMinCountsAnnotation]
+		newValue := newJob.Object().GetAnnotations()[constants.PodSetMinCountsAnnotation]
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newValue, oldValue, podSetMinCountsPath)...)
+	}
+	return allErrs
+}
+
 func ValidateUpdateForQueueName(oldJob, newJob GenericJob) field.ErrorList {
 	var allErrs field.ErrorList
 	if !newJob.IsSuspended() {
@@ -136,6 +136,7 @@ type Job batchv1.Job
 var _ jobframework.GenericJob = (*Job)(nil)
 var _ jobframework.JobWithReclaimablePods = (*Job)(nil)
 var _ jobframework.JobWithCustomStop = (*Job)(nil)
+var _ jobframework.JobWithPartialAdmission = (*Job)(nil)
 
 func (j *Job) Object() client.Object {
 	return (*batchv1.Job)(j)
@@ -318,9 +319,19 @@ func (j *Job) minPodsCount() *int32 {
 			return ptr.To[int32](int32(iVal))
 		}
 	}
+	if minCounts, err := jobframework.PodSetMinCounts(j); err == nil {
+		if minCount, found := minCounts[kueue.DefaultPodSetName]; found {
+			return &minCount
+		}
+	}
 	return nil
 }
 
+// SupportsPartialAdmission implements jobframework.JobWithPartialAdmission.
+func (j *Job) SupportsPartialAdmission() bool {
+	return true
+}
+
 func (j *Job) syncCompletionWithParallelism() bool {
 	if strVal, found := j.GetAnnotations()[JobCompletionsEqualParallelismAnnotation]; found {
 		if bVal, err := strconv.ParseBool(strVal); err == nil {
@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,6 +37,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/constants"
 	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/podset"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	utiltestingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
@@ -347,6 +349,17 @@ func TestPodSets(t *testing.T) {
 				},
 			},
 		},
+		"partial admission with the pod set min counts annotation": {
+			job: (*Job)(utiltestingjob.MakeJob("job", "ns").Parallelism(3).SetAnnotation(controllerconsts.PodSetMinCountsAnnotation, "main=1").Obj()),
+			wantPodSets: []kueue.PodSet{
+				{
+					Name:     kueue.DefaultPodSetName,
+					Template: *podTemplate.DeepCopy(),
+					Count:    3,
+					MinCount: ptr.To[int32](1),
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -413,6 +426,8 @@ func TestReconciler(t *testing.T) {
 		job               batchv1.Job
 		workloads         []kueue.Workload
 		priorityClasses   []client.Object
+		clusterObjects    []client.Object
+		enableGates       []featuregate.Feature
 		wantJob           batchv1.Job
 		wantWorkloads     []kueue.Workload
 		wantErr           error
@@ -1071,6 +1086,37 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"suspended job with matching admitted workload is unsuspended with the admission labels": {
+			enableGates: []featuregate.Feature{features.AdmissionLabels},
+			clusterObjects: []client.Object{
+				utiltesting.MakeResourceFlavor("on-demand").Obj(),
+				utiltesting.MakeClusterQueue("cq").Cohort("all").Obj(),
+			},
+			job: *baseJobWrapper.DeepCopy(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				Label(controllerconsts.ClusterQueueLabel, "cq").
+				Label(controllerconsts.CohortLabel, "all").
+				Label(controllerconsts.PodSetFlavorLabelPrefix+kueue.DefaultPodSetName, "on-demand").
+				PodLabel(controllerconsts.ClusterQueueLabel, "cq").
+				PodLabel(controllerconsts.CohortLabel, "all").
+				PodLabel(controllerconsts.FlavorLabel, "on-demand").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "on-demand", "10").AssignmentPodCount(10).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "on-demand", "10").AssignmentPodCount(10).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+		},
 		"non-matching admitted workload is deleted": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -1139,6 +1185,38 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"suspended job with the pod set min counts annotation and admitted workload is unsuspended": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithManageJobsWithoutQueueName(true),
+			},
+			job: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.PodSetMinCountsAnnotation, "main=5").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				SetAnnotation(controllerconsts.PodSetMinCountsAnnotation, "main=5").
+				Suspend(false).
+				Parallelism(8).
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).SetMinimumCount(5).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(8).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(
+						*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).
+							SetMinimumCount(5).
+							Request(corev1.ResourceCPU, "1").
+							Obj(),
+					).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(8).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+		},
 		"unsuspended job with partial admission and non-matching admitted workload is suspended and workload is deleted": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -1187,6 +1265,81 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"the workload is created with the labels and annotations to copy": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithKeyPrefixesToCopy([]string{"example.com/"}, []string{"cost.example.com/"}),
+			},
+			job: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				Label("example.com/team", "ml").
+				Label("other.com/project", "p1").
+				SetAnnotation("cost.example.com/center", "c1").
+				Obj(),
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				Label("example.com/team", "ml").
+				Label("other.com/project", "p1").
+				SetAnnotation("cost.example.com/center", "c1").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					Priority(0).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+						"example.com/team":           "ml",
+					}).
+					Annotations(map[string]string{
+						"cost.example.com/center": "c1",
+					}).
+					Obj(),
+			},
+		},
+		"the labels copied to the workload are kept in sync": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithKeyPrefixesToCopy([]string{"example.com/"}, nil),
+			},
+			job: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				Label("example.com/team", "research").
+				Obj(),
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				Label("example.com/team", "research").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					Priority(0).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+						"example.com/team":           "ml",
+						"example.com/project":        "p1",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					Priority(0).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+						"example.com/team":           "research",
+					}).
+					Obj(),
+			},
+		},
 		"the workload is updated when queue name has changed for suspended job": {
 			job: *baseJobWrapper.
 				Clone().
@@ -1460,6 +1613,42 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"when workload is evicted, suspend and remove the admission labels": {
+			enableGates: []featuregate.Feature{features.AdmissionLabels},
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				Label(controllerconsts.ClusterQueueLabel, "cq").
+				Label(controllerconsts.CohortLabel, "all").
+				Label(controllerconsts.PodSetFlavorLabelPrefix+kueue.DefaultPodSetName, "on-demand").
+				Active(10).
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet("main", 10).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(10).Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+					}).
+					Obj(),
+			},
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(true).
+				Active(10).
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet("main", 10).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(10).Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:   kueue.WorkloadEvicted,
+						Status: metav1.ConditionTrue,
+					}).
+					Obj(),
+			},
+		},
 		"when workload is evicted but suspended, reset startTime and restore node affinity": {
 			job: *baseJobWrapper.Clone().
 				Suspend(true).
@@ -1610,6 +1799,38 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"the workload is created when queue name is set, with the default workloadPriorityClass of the queue": {
+			enableGates: []featuregate.Feature{features.LocalQueueDefaultPriority},
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Queue("test-queue").
+				UID("test-uid").
+				Obj(),
+			priorityClasses: []client.Object{
+				baseWPCWrapper.Obj(),
+			},
+			clusterObjects: []client.Object{
+				utiltesting.MakeLocalQueue("test-queue", "ns").DefaultWorkloadPriorityClass("test-wpc").Obj(),
+			},
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+		},
 		"the workload is created when queue name is set, with PriorityClass": {
 			job: *baseJobWrapper.
 				Clone().
@@ -1807,12 +2028,16 @@ func TestReconciler(t *testing.T) {
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			for _, gate := range tc.enableGates {
+				defer features.SetFeatureGateDuringTest(t, gate, true)()
+			}
 			ctx, _ := utiltesting.ContextWithLog(t)
 			clientBuilder := utiltesting.NewClientBuilder()
 			if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
 				t.Fatalf("Could not setup indexes: %v", err)
 			}
-			objs := append(tc.priorityClasses, &tc.job)
+			objs := append(tc.priorityClasses, tc.clusterObjects...)
+			objs = append(objs, &tc.job)
 			kcBuilder := clientBuilder.
 				WithObjects(objs...)
 
@@ -29,6 +29,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -43,6 +44,7 @@ var (
 )
 
 type JobWebhook struct {
+	client                     client.Client
 	manageJobsWithoutQueueName bool
 	kubeServerVersion          *kubeversion.ServerVersionFetcher
 }
@@ -54,6 +56,7 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		opt(&options)
 	}
 	wh := &JobWebhook{
+		client:                     mgr.GetClient(),
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
 		kubeServerVersion:          options.KubeServerVersion,
 	}
@@ -85,6 +88,9 @@ func (w *JobWebhook) Default(ctx context.Context, obj runtime.Object) error {
 		}
 	}
 
+	if err := jobframework.ApplyDefaultLocalQueue(ctx, w.client, job); err != nil {
+		return err
+	}
 	jobframework.ApplyDefaultForSuspend(job, w.manageJobsWithoutQueueName)
 
 	return nil
@@ -107,6 +113,7 @@ func (w *JobWebhook) validateCreate(job *Job) field.ErrorList {
 	allErrs = append(allErrs, jobframework.ValidateAnnotationAsCRDName(job, constants.ParentWorkloadAnnotation)...)
 	allErrs = append(allErrs, jobframework.ValidateCreateForQueueName(job)...)
 	allErrs = append(allErrs, w.validatePartialAdmissionCreate(job)...)
+	allErrs = append(allErrs, jobframework.ValidateCreateForPodSetMinCounts(job)...)
 	allErrs = append(allErrs, jobframework.ValidateCreateForParentWorkload(job)...)
 	return allErrs
 }
@@ -160,13 +167,14 @@ func (w *JobWebhook) validateUpdate(oldJob, newJob *Job) field.ErrorList {
 	allErrs = append(allErrs, jobframework.ValidateUpdateForParentWorkload(oldJob, newJob)...)
 	allErrs = append(allErrs, jobframework.ValidateUpdateForQueueName(oldJob, newJob)...)
 	allErrs = append(allErrs, validatePartialAdmissionUpdate(oldJob, newJob)...)
+	allErrs = append(allErrs, jobframework.ValidateUpdateForPodSetMinCounts(oldJob, newJob)...)
 	allErrs = append(allErrs, jobframework.ValidateUpdateForWorkloadPriorityClassName(oldJob, newJob)...)
 	return allErrs
 }
 
 func validatePartialAdmissionUpdate(oldJob, newJob *Job) field.ErrorList {
 	var allErrs field.ErrorList
-	if _, found := oldJob.Annotations[JobMinParallelismAnnotation]; found {
+	if oldJob.minPodsCount() != nil {
 		if !oldJob.IsSuspended() && ptr.Deref(oldJob.Spec.Parallelism, 1) != ptr.Deref(newJob.Spec.Parallelism, 1) {
 			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "parallelism"), "cannot change when partial admission is enabled and the job is not suspended"))
 		}
@@ -31,10 +31,13 @@ import (
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
 
 	// without this only the job framework is registered
@@ -53,6 +56,7 @@ var (
 	prebuiltWlNameLabelPath       = labelsPath.Key(constants.PrebuiltWorkloadLabel)
 	queueNameAnnotationsPath      = annotationsPath.Key(constants.QueueAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
+	podSetMinCountsPath           = annotationsPath.Key(constants.PodSetMinCountsAnnotation)
 )
 
 func TestValidateCreate(t *testing.T) {
@@ -148,6 +152,36 @@ func TestValidateCreate(t *testing.T) {
 				Obj(),
 			wantErr: nil,
 		},
+		{
+			name: "valid pod set min counts annotation",
+			job: testingutil.MakeJob("job", "default").
+				Parallelism(4).
+				Completions(6).
+				SetAnnotation(constants.PodSetMinCountsAnnotation, "main=3").
+				Obj(),
+			wantErr: nil,
+		},
+		{
+			name: "invalid pod set min counts annotation (format)",
+			job: testingutil.MakeJob("job", "default").
+				Parallelism(4).
+				SetAnnotation(constants.PodSetMinCountsAnnotation, "main").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(podSetMinCountsPath, "main", `"main" is not a <podSetName>=<minCount> pair`),
+			},
+		},
+		{
+			name: "invalid pod set min counts annotation (badValue)",
+			job: testingutil.MakeJob("job", "default").
+				Parallelism(4).
+				SetAnnotation(constants.PodSetMinCountsAnnotation, "main=5,workers=1").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(podSetMinCountsPath, "main=5,workers=1", `the minimum count of pod set "main" should be between 1 and 4`),
+				field.Invalid(podSetMinCountsPath, "main=5,workers=1", `pod set "workers" doesn't exist`),
+			},
+		},
 		{
 			name: "invalid sync completions annotation (format)",
 			job: testingutil.MakeJob("job", "default").
@@ -376,6 +410,22 @@ func TestValidateUpdate(t *testing.T) {
 				field.Forbidden(field.NewPath("spec", "parallelism"), "cannot change when partial admission is enabled and the job is not suspended"),
 			},
 		},
+		{
+			name: "immutable pod set min counts annotation while unsuspended",
+			oldJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				Parallelism(4).
+				SetAnnotation(constants.PodSetMinCountsAnnotation, "main=3").
+				Obj(),
+			newJob: testingutil.MakeJob("job", "default").
+				Suspend(false).
+				Parallelism(4).
+				SetAnnotation(constants.PodSetMinCountsAnnotation, "main=2").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(podSetMinCountsPath, "main=2", apivalidation.FieldImmutableErrorMsg),
+			},
+		},
 		{
 			name: "mutable parallelism while suspended with partial admission enabled",
 			oldJob: testingutil.MakeJob("job", "default").
@@ -460,6 +510,8 @@ func TestDefault(t *testing.T) {
 	testcases := map[string]struct {
 		job                        *batchv1.Job
 		manageJobsWithoutQueueName bool
+		enableDefaultLocalQueue    bool
+		localQueues                []client.Object
 		want                       *batchv1.Job
 	}{
 		"add a parent job name to annotations": {
@@ -480,10 +532,60 @@ func TestDefault(t *testing.T) {
 			manageJobsWithoutQueueName: true,
 			want:                       testingutil.MakeJob("job", "default").Obj(),
 		},
+		"set the default LocalQueue of the namespace": {
+			job:                     testingutil.MakeJob("job", "default").Suspend(false).Obj(),
+			enableDefaultLocalQueue: true,
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("other", "default").Obj(),
+				utiltesting.MakeLocalQueue("main", "default").Label(constants.DefaultLocalQueueLabel, "true").Obj(),
+				utiltesting.MakeLocalQueue("main", "other").Label(constants.DefaultLocalQueueLabel, "true").Obj(),
+			},
+			want: testingutil.MakeJob("job", "default").Queue("main").Obj(),
+		},
+		"keep the queue name when there is a default LocalQueue": {
+			job:                     testingutil.MakeJob("job", "default").Queue("queue").Obj(),
+			enableDefaultLocalQueue: true,
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("main", "default").Label(constants.DefaultLocalQueueLabel, "true").Obj(),
+			},
+			want: testingutil.MakeJob("job", "default").Queue("queue").Obj(),
+		},
+		"no default LocalQueue in the namespace": {
+			job:                     testingutil.MakeJob("job", "default").Suspend(false).Obj(),
+			enableDefaultLocalQueue: true,
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("main", "default").Label(constants.DefaultLocalQueueLabel, "false").Obj(),
+			},
+			want: testingutil.MakeJob("job", "default").Suspend(false).Obj(),
+		},
+		"don't set the default LocalQueue to a child job": {
+			job: testingutil.MakeJob("child-job", "default").
+				OwnerReference("parent-job", kubeflow.SchemeGroupVersionKind).
+				Obj(),
+			enableDefaultLocalQueue: true,
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("main", "default").Label(constants.DefaultLocalQueueLabel, "true").Obj(),
+			},
+			want: testingutil.MakeJob("child-job", "default").
+				OwnerReference("parent-job", kubeflow.SchemeGroupVersionKind).
+				ParentWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("parent-job", kubeflow.SchemeGroupVersionKind)).
+				Obj(),
+		},
+		"default LocalQueue with the feature disabled": {
+			job: testingutil.MakeJob("job", "default").Suspend(false).Obj(),
+			localQueues: []client.Object{
+				utiltesting.MakeLocalQueue("main", "default").Label(constants.DefaultLocalQueueLabel, "true").Obj(),
+			},
+			want: testingutil.MakeJob("job", "default").Suspend(false).Obj(),
+		},
 	}
 	for name, tc := range testcases {
 		t.Run(name, func(t *testing.T) {
-			w := &JobWebhook{manageJobsWithoutQueueName: tc.manageJobsWithoutQueueName}
+			defer features.SetFeatureGateDuringTest(t, features.DefaultLocalQueue, tc.enableDefaultLocalQueue)()
+			w := &JobWebhook{
+				client:                     utiltesting.NewFakeClient(tc.localQueues...),
+				manageJobsWithoutQueueName: tc.manageJobsWithoutQueueName,
+			}
 			if err := w.Default(context.Background(), tc.job); err != nil {
 				t.Errorf("set defaults to a batch/job by a Defaulter")
 			}
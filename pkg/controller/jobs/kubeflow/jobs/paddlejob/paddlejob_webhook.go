@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -31,6 +32,7 @@ import (
 )
 
 type PaddleJobWebhook struct {
+	client                     client.Client
 	manageJobsWithoutQueueName bool
 }
 
@@ -41,6 +43,7 @@ func SetupPaddleJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error
 		opt(&options)
 	}
 	wh := &PaddleJobWebhook{
+		client:                     mgr.GetClient(),
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
@@ -59,6 +62,9 @@ func (w *PaddleJobWebhook) Default(ctx context.Context, obj runtime.Object) erro
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("paddlejob-webhook")
 	log.V(5).Info("Applying defaults", "paddlejob", klog.KObj(job.Object()))
+	if err := jobframework.ApplyDefaultLocalQueue(ctx, w.client, job); err != nil {
+		return err
+	}
 	jobframework.ApplyDefaultForSuspend(job, w.manageJobsWithoutQueueName)
 	return nil
 }
@@ -36,6 +36,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -49,6 +50,7 @@ import (
 	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/podset"
+	"sigs.k8s.io/kueue/pkg/util/parallelize"
 )
 
 const (
@@ -57,6 +59,10 @@ const (
 	gateNotFound                   = -1
 	ConditionTypeTerminationTarget = "TerminationTarget"
 	errMsgIncorrectGroupRoleCount  = "pod group can't include more than 8 roles"
+
+	// groupNameKey indexes the pods by the value of the GroupNameLabel, so that
+	// loading a group doesn't require filtering all the pods in the namespace.
+	groupNameKey = "metadata.groupName"
 )
 
 var (
@@ -95,10 +101,13 @@ type Pod struct {
 }
 
 var (
-	_ jobframework.GenericJob        = (*Pod)(nil)
-	_ jobframework.JobWithCustomStop = (*Pod)(nil)
-	_ jobframework.JobWithFinalize   = (*Pod)(nil)
-	_ jobframework.ComposableJob     = (*Pod)(nil)
+	_ jobframework.GenericJob                    = (*Pod)(nil)
+	_ jobframework.JobWithCustomStop             = (*Pod)(nil)
+	_ jobframework.JobWithCustomRun              = (*Pod)(nil)
+	_ jobframework.JobWithFinalize               = (*Pod)(nil)
+	_ jobframework.ComposableJob                 = (*Pod)(nil)
+	_ jobframework.JobWithGroupEvents            = (*Pod)(nil)
+	_ jobframework.JobWithForeignSchedulingGates = (*Pod)(nil)
 )
 
 func fromObject(o runtime.Object) *Pod {
@@ -123,6 +132,27 @@ func gateIndex(p *corev1.Pod) int {
 	return gateNotFound
 }
 
+// ForeignSchedulingGates returns the names of the scheduling gates, other than
+// the Kueue one, of the active pods.
+func (p *Pod) ForeignSchedulingGates() []string {
+	pods := []corev1.Pod{p.pod}
+	if p.isGroup {
+		pods = p.list.Items
+	}
+	gates := sets.New[string]()
+	for i := range pods {
+		if !podActive(&pods[i]) || !pods[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, gate := range pods[i].Spec.SchedulingGates {
+			if gate.Name != SchedulingGateName {
+				gates.Insert(gate.Name)
+			}
+		}
+	}
+	return sets.List(gates)
+}
+
 func podActive(p *corev1.Pod) bool {
 	return p.Status.Phase != corev1.PodFailed && p.Status.Phase != corev1.PodSucceeded
 }
@@ -168,11 +198,91 @@ func (p *Pod) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 	if p.groupName() == "" && len(podSetsInfo) != 1 {
 		return fmt.Errorf("%w: expecting 1 pod set got %d", podset.ErrInvalidPodsetInfo, len(podSetsInfo))
 	}
-	idx := gateIndex(&p.pod)
+	return ungate(&p.pod, podSetsInfo[0])
+}
+
+// ungate removes the Kueue scheduling gate from the pod and injects the podSetInfo.
+func ungate(pod *corev1.Pod, info podset.PodSetInfo) error {
+	idx := gateIndex(pod)
 	if idx != gateNotFound {
-		p.pod.Spec.SchedulingGates = append(p.pod.Spec.SchedulingGates[:idx], p.pod.Spec.SchedulingGates[idx+1:]...)
+		pod.Spec.SchedulingGates = append(pod.Spec.SchedulingGates[:idx], pod.Spec.SchedulingGates[idx+1:]...)
+	}
+	return podset.Merge(&pod.ObjectMeta, &pod.Spec, info)
+}
+
+// patchPod sends the difference between the original and the modified pod as a
+// strategic merge patch, so that the write doesn't conflict with the concurrent
+// changes to the pod, like the status updates done by the kubelet.
+func patchPod(ctx context.Context, c client.Client, original, pod *corev1.Pod) error {
+	return c.Patch(ctx, pod, client.StrategicMergeFrom(original))
+}
+
+// Run removes the scheduling gate of the pod, or of all the gated pods in the
+// group, injecting the podSetInfo of the pod set matching the role of each pod.
+// All the pods of a group are started by the first member reconciled after
+// the admission, so the rest of the members don't issue any writes.
+func (p *Pod) Run(ctx context.Context, c client.Client, podSetsInfo []podset.PodSetInfo) ([]client.Object, error) {
+	if !p.isGroup {
+		if len(podSetsInfo) != 1 {
+			return nil, fmt.Errorf("%w: expecting 1 pod set got %d", podset.ErrInvalidPodsetInfo, len(podSetsInfo))
+		}
+		original := p.pod.DeepCopy()
+		if err := ungate(&p.pod, podSetsInfo[0]); err != nil {
+			return nil, err
+		}
+		if err := patchPod(ctx, c, original, &p.pod); err != nil {
+			return nil, err
+		}
+		return []client.Object{&p.pod}, nil
+	}
+
+	var podsToUngate []*corev1.Pod
+	for i := range p.list.Items {
+		if gateIndex(&p.list.Items[i]) != gateNotFound {
+			podsToUngate = append(podsToUngate, &p.list.Items[i])
+		}
+	}
+
+	err := parallelize.Until(ctx, len(podsToUngate), func(i int) error {
+		pod := podsToUngate[i]
+		info, err := podSetInfoForPod(*pod, podSetsInfo)
+		if err != nil {
+			return err
+		}
+		original := pod.DeepCopy()
+		if err := ungate(pod, info); err != nil {
+			return err
+		}
+		return client.IgnoreNotFound(patchPod(ctx, c, original, pod))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	started := make([]client.Object, len(podsToUngate))
+	for i := range podsToUngate {
+		started[i] = podsToUngate[i]
+	}
+	return started, nil
+}
+
+// podSetInfoForPod returns the podSetInfo of the pod set matching the role of
+// the pod. A single podSetInfo applies to all the pods in the group.
+func podSetInfoForPod(pod corev1.Pod, podSetsInfo []podset.PodSetInfo) (podset.PodSetInfo, error) {
+	if len(podSetsInfo) == 1 {
+		return podSetsInfo[0], nil
+	}
+	roleHash, err := getRoleHash(pod)
+	if err != nil {
+		return podset.PodSetInfo{}, err
+	}
+	idx := slices.IndexFunc(podSetsInfo, func(info podset.PodSetInfo) bool {
+		return info.Name == roleHash
+	})
+	if idx == -1 {
+		return podset.PodSetInfo{}, fmt.Errorf("%w: podSetInfo with the name %q is not found", podset.ErrInvalidPodsetInfo, roleHash)
 	}
-	return podset.Merge(&p.pod.ObjectMeta, &p.pod.Spec, podSetsInfo[0])
+	return podSetsInfo[idx], nil
 }
 
 // RestorePodSetsInfo will restore the original node affinity and podSet counts of the job.
@@ -265,14 +375,20 @@ func hasPodReadyTrue(conds []corev1.PodCondition) bool {
 	return false
 }
 
+// podReady returns whether the pod is ready. A pod which still carries
+// scheduling gates, including the ones of other controllers, is not ready.
+func podReady(p *corev1.Pod) bool {
+	return len(p.Spec.SchedulingGates) == 0 && hasPodReadyTrue(p.Status.Conditions)
+}
+
 // PodsReady instructs whether job derived pods are all ready now.
 func (p *Pod) PodsReady() bool {
 	if !p.isGroup {
-		return hasPodReadyTrue(p.pod.Status.Conditions)
+		return podReady(&p.pod)
 	}
 
 	for i := range p.list.Items {
-		if !hasPodReadyTrue(p.list.Items[i].Status.Conditions) {
+		if !podReady(&p.list.Items[i]) {
 			return false
 		}
 	}
@@ -293,12 +409,17 @@ func (p *Pod) Stop(ctx context.Context, c client.Client, _ []podset.PodSetInfo,
 		podsInGroup = []corev1.Pod{p.pod}
 	}
 
+	var podsToStop []*corev1.Pod
 	for i := range podsInGroup {
 		// If the workload is being deleted, delete even finished Pods.
 		if !podsInGroup[i].DeletionTimestamp.IsZero() || (stopReason != jobframework.StopReasonWorkloadDeleted && podSuspended(&podsInGroup[i])) {
 			continue
 		}
-		podInGroup := fromObject(&podsInGroup[i])
+		podsToStop = append(podsToStop, &podsInGroup[i])
+	}
+
+	err := parallelize.Until(ctx, len(podsToStop), func(i int) error {
+		podInGroup := fromObject(podsToStop[i])
 
 		// The podset info is not relevant here, since this should mark the pod's end of life
 		pCopy := &corev1.Pod{
@@ -323,12 +444,17 @@ func (p *Pod) Stop(ctx context.Context, c client.Client, _ []podset.PodSetInfo,
 			},
 		}
 		if err := c.Status().Patch(ctx, pCopy, client.Apply, client.FieldOwner(constants.KueueName)); err != nil && !apierrors.IsNotFound(err) {
-			return false, err
+			return err
 		}
 		if err := c.Delete(ctx, podInGroup.Object()); err != nil && !apierrors.IsNotFound(err) {
-			return false, err
+			return err
 		}
+		return nil
+	})
+	if err != nil {
+		return false, err
 	}
+	stoppedNow := len(podsToStop) > 0
 
 	// If related workload is deleted, the generic reconciler will stop the pod group and finalize the workload.
 	// However, it won't finalize the pods. Since the Stop method for the pod group deletes all the pods in the
@@ -340,13 +466,62 @@ func (p *Pod) Stop(ctx context.Context, c client.Client, _ []podset.PodSetInfo,
 		}
 	}
 
-	return true, nil
+	return stoppedNow, nil
+}
+
+// GroupSize returns the number of pods in the group, or 0 if the pod is not
+// part of a group.
+func (p *Pod) GroupSize() int {
+	if !p.isGroup {
+		return 0
+	}
+	if count, err := p.groupTotalCount(); err == nil {
+		return count
+	}
+	return len(p.list.Items)
+}
+
+// IsGroupLeader returns whether the pod has the lowest name among the active
+// pods of the group, so that a finished, failed or deleted pod never holds the
+// leadership. If no pod is active, the lowest name in the group is used.
+func (p *Pod) IsGroupLeader() bool {
+	candidates := make([]*corev1.Pod, 0, len(p.list.Items))
+	for i := range p.list.Items {
+		if pod := &p.list.Items[i]; podActive(pod) && pod.DeletionTimestamp.IsZero() {
+			candidates = append(candidates, pod)
+		}
+	}
+	if len(candidates) == 0 {
+		for i := range p.list.Items {
+			candidates = append(candidates, &p.list.Items[i])
+		}
+	}
+	isCandidate := len(candidates) == 0
+	for _, pod := range candidates {
+		if pod.Name < p.pod.Name {
+			return false
+		}
+		if pod.Name == p.pod.Name {
+			isCandidate = true
+		}
+	}
+	return isCandidate
 }
 
 func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &corev1.Pod{}, groupNameKey, indexPodGroupName); err != nil {
+		return fmt.Errorf("setting index on group name for Pod: %w", err)
+	}
 	return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, gvk)
 }
 
+func indexPodGroupName(o client.Object) []string {
+	if groupName := o.GetLabels()[GroupNameLabel]; groupName != "" {
+		return []string{groupName}
+	}
+	return nil
+}
+
 func (p *Pod) Finalize(ctx context.Context, c client.Client) error {
 	groupName := p.groupName()
 
@@ -354,22 +529,32 @@ func (p *Pod) Finalize(ctx context.Context, c client.Client) error {
 	if groupName == "" {
 		podsInGroup.Items = append(podsInGroup.Items, *p.Object().(*corev1.Pod))
 	} else {
-		if err := c.List(ctx, &podsInGroup, client.MatchingLabels{
-			GroupNameLabel: groupName,
+		if err := c.List(ctx, &podsInGroup, client.MatchingFields{
+			groupNameKey: groupName,
 		}, client.InNamespace(p.pod.Namespace)); err != nil {
 			return err
 		}
 	}
 
-	for _, pod := range podsInGroup.Items {
-		if controllerutil.RemoveFinalizer(&pod, PodFinalizer) {
-			if err := c.Update(ctx, &pod); err != nil {
-				return err
-			}
+	var podsToFinalize []*corev1.Pod
+	for i := range podsInGroup.Items {
+		if controllerutil.ContainsFinalizer(&podsInGroup.Items[i], PodFinalizer) {
+			podsToFinalize = append(podsToFinalize, &podsInGroup.Items[i])
 		}
 	}
 
-	return nil
+	return parallelize.Until(ctx, len(podsToFinalize), func(i int) error {
+		return client.IgnoreNotFound(removePodFinalizer(ctx, c, podsToFinalize[i]))
+	})
+}
+
+// removePodFinalizer removes the Kueue finalizer from the pod, if present.
+func removePodFinalizer(ctx context.Context, c client.Client, pod *corev1.Pod) error {
+	original := pod.DeepCopy()
+	if !controllerutil.RemoveFinalizer(pod, PodFinalizer) {
+		return nil
+	}
+	return patchPod(ctx, c, original, pod)
 }
 
 func (p *Pod) Skip() bool {
@@ -457,8 +642,8 @@ func (p *Pod) Load(ctx context.Context, c client.Client, key types.NamespacedNam
 		return !p.pod.DeletionTimestamp.IsZero(), nil
 	}
 
-	if err := c.List(ctx, &p.list, client.MatchingLabels{
-		GroupNameLabel: groupName,
+	if err := c.List(ctx, &p.list, client.MatchingFields{
+		groupNameKey: groupName,
 	}, client.InNamespace(key.Namespace)); err != nil {
 		return false, err
 	}
@@ -580,19 +765,24 @@ func (p *Pod) cleanupExcessPods(ctx context.Context, c client.Client, totalCount
 	extraPods := activePods[len(activePods)-extraPodsCount:]
 
 	// Finalize and delete the active pods created last
-	for _, extraPod := range extraPods {
-		if controllerutil.RemoveFinalizer(&extraPod, PodFinalizer) {
-			log.V(3).Info("Finalizing excess pod in group", "excessPod", klog.KObj(&extraPod))
-			if err := c.Update(ctx, &extraPod); err != nil {
+	err := parallelize.Until(ctx, len(extraPods), func(i int) error {
+		extraPod := &extraPods[i]
+		if controllerutil.ContainsFinalizer(extraPod, PodFinalizer) {
+			log.V(3).Info("Finalizing excess pod in group", "excessPod", klog.KObj(extraPod))
+			if err := removePodFinalizer(ctx, c, extraPod); err != nil {
 				return err
 			}
 		}
 		if extraPod.ObjectMeta.DeletionTimestamp.IsZero() {
-			log.V(3).Info("Deleting excess pod in group", "excessPod", klog.KObj(&extraPod))
-			if err := c.Delete(ctx, &extraPod); err != nil {
+			log.V(3).Info("Deleting excess pod in group", "excessPod", klog.KObj(extraPod))
+			if err := c.Delete(ctx, extraPod); err != nil {
 				return err
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Remove excess pods from the group list
@@ -19,6 +19,7 @@ package pod
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -28,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -69,6 +71,19 @@ func TestPodsReady(t *testing.T) {
 				Obj(),
 			want: false,
 		},
+		"pod is gated by another controller": {
+			pod: testingpod.MakePod("test-pod", "test-ns").
+				Queue("test-queue").
+				SchedulingGate("example.com/gate").
+				StatusConditions(
+					corev1.PodCondition{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				).
+				Obj(),
+			want: false,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -137,6 +152,45 @@ var (
 	}
 )
 
+func TestIsGroupLeader(t *testing.T) {
+	pod := func(name string) *testingpod.PodWrapper {
+		return testingpod.MakePod(name, "ns").Group("group")
+	}
+	cases := map[string]struct {
+		group []corev1.Pod
+		want  map[string]bool
+	}{
+		"lowest name": {
+			group: []corev1.Pod{*pod("a").Obj(), *pod("b").Obj()},
+			want:  map[string]bool{"a": true, "b": false},
+		},
+		"lowest name finished": {
+			group: []corev1.Pod{*pod("a").StatusPhase(corev1.PodSucceeded).Obj(), *pod("b").Obj(), *pod("c").Obj()},
+			want:  map[string]bool{"a": false, "b": true, "c": false},
+		},
+		"lowest name failed and replaced": {
+			group: []corev1.Pod{*pod("a").StatusPhase(corev1.PodFailed).Obj(), *pod("b").Delete().Obj(), *pod("c").Obj()},
+			want:  map[string]bool{"a": false, "b": false, "c": true},
+		},
+		"no active pod": {
+			group: []corev1.Pod{*pod("a").StatusPhase(corev1.PodSucceeded).Obj(), *pod("b").StatusPhase(corev1.PodFailed).Obj()},
+			want:  map[string]bool{"a": true, "b": false},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := make(map[string]bool, len(tc.group))
+			for i := range tc.group {
+				p := &Pod{pod: tc.group[i], isGroup: true, list: corev1.PodList{Items: tc.group}}
+				got[tc.group[i].Name] = p.IsGroupLeader()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected group leaders (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestReconciler(t *testing.T) {
 	basePodWrapper := testingpod.MakePod("pod", "ns").
 		UID("test-uid").
@@ -202,6 +256,82 @@ func TestReconciler(t *testing.T) {
 			},
 			workloadCmpOpts: defaultWorkloadCmpOpts,
 		},
+		"the SchedulingGated condition is added while gates of other controllers remain": {
+			pods: []corev1.Pod{*basePodWrapper.
+				Clone().
+				Label("kueue.x-k8s.io/managed", "true").
+				KueueFinalizer().
+				SchedulingGate("example.com/quota").
+				SchedulingGate("example.com/data").
+				Obj()},
+			wantPods: []corev1.Pod{*basePodWrapper.
+				Clone().
+				Label("kueue.x-k8s.io/managed", "true").
+				KueueFinalizer().
+				SchedulingGate("example.com/quota").
+				SchedulingGate("example.com/data").
+				Obj()},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("unit-test", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("unit-test", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadSchedulingGated,
+						Status:  metav1.ConditionTrue,
+						Reason:  "ForeignSchedulingGates",
+						Message: "The pods are gated by scheduling gates not managed by Kueue: example.com/data, example.com/quota",
+					}).
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+		},
+		"the SchedulingGated condition is set to false once the gates of other controllers are removed": {
+			pods: []corev1.Pod{*basePodWrapper.
+				Clone().
+				Label("kueue.x-k8s.io/managed", "true").
+				KueueFinalizer().
+				Obj()},
+			wantPods: []corev1.Pod{*basePodWrapper.
+				Clone().
+				Label("kueue.x-k8s.io/managed", "true").
+				KueueFinalizer().
+				Obj()},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("unit-test", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadSchedulingGated,
+						Status:  metav1.ConditionTrue,
+						Reason:  "ForeignSchedulingGates",
+						Message: "The pods are gated by scheduling gates not managed by Kueue: example.com/quota",
+					}).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("unit-test", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 1).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(1).Obj()).
+					Admitted(true).
+					Condition(metav1.Condition{
+						Type:    kueue.WorkloadSchedulingGated,
+						Status:  metav1.ConditionFalse,
+						Reason:  "SchedulingGatesRemoved",
+						Message: "The pods are no longer gated by scheduling gates not managed by Kueue",
+					}).
+					Obj(),
+			},
+			workloadCmpOpts: defaultWorkloadCmpOpts,
+		},
 		"non-matching admitted workload is deleted and pod is finalized": {
 			pods: []corev1.Pod{*basePodWrapper.
 				Clone().
@@ -2176,20 +2306,15 @@ func TestReconciler_ErrorFinalizingPod(t *testing.T) {
 		WithObjects(&pod).
 		WithStatusSubresource(&wl).
 		WithInterceptorFuncs(interceptor.Funcs{
-			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
-				gvk := obj.GetObjectKind().GroupVersionKind()
-				if gvk.GroupVersion().String() == "v1" && gvk.Kind == "Pod" {
+			Patch: func(ctx context.Context, client client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if _, isPod := obj.(*corev1.Pod); isPod {
 					defer func() { reqcount++ }()
 					if reqcount == 0 {
-						// return a connection refused error for the first update request.
+						// return a connection refused error for the first patch request.
 						return errMock
 					}
-					if reqcount == 1 {
-						// Exec a regular update operation for the second request
-						return client.Update(ctx, obj, opts...)
-					}
 				}
-				return client.Update(ctx, obj, opts...)
+				return client.Patch(ctx, obj, patch, opts...)
 			},
 		})
 
@@ -2259,6 +2384,384 @@ func TestReconciler_ErrorFinalizingPod(t *testing.T) {
 	}
 }
 
+func TestReconciler_GroupEvents(t *testing.T) {
+	basePodWrapper := testingpod.MakePod("pod", "ns").
+		UID("test-uid").
+		Queue("user-queue").
+		Label("kueue.x-k8s.io/managed", "true").
+		KueueFinalizer().
+		Group("test-group").
+		GroupTotalCount("2").
+		Annotation("kueue.x-k8s.io/role-hash", "b990493b").
+		Request(corev1.ResourceCPU, "1").
+		Image("", nil)
+	gatedPods := []corev1.Pod{
+		*basePodWrapper.Clone().KueueSchedulingGate().Obj(),
+		*basePodWrapper.Clone().Name("pod2").KueueSchedulingGate().Obj(),
+	}
+	runningPods := []corev1.Pod{
+		*basePodWrapper.Clone().StatusPhase(corev1.PodRunning).Obj(),
+		*basePodWrapper.Clone().Name("pod2").StatusPhase(corev1.PodRunning).Obj(),
+	}
+	admittedWl := func() *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload("test-group", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+			PodSets(*utiltesting.MakePodSet("b990493b", 2).Request(corev1.ResourceCPU, "1").Obj()).
+			ReserveQuota(
+				utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "unit-test-flavor", "2").
+					AssignmentPodCount(2).
+					Obj(),
+			).
+			Admitted(true)
+	}
+
+	testCases := map[string]struct {
+		pods                 []corev1.Pod
+		workload             *kueue.Workload
+		aggregateGroupEvents bool
+		wantEvents           []utiltesting.EventRecord
+	}{
+		"aggregated start event": {
+			pods:                 gatedPods,
+			workload:             admittedWl().Obj(),
+			aggregateGroupEvents: true,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "test-group", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq, starting 2 members of the group",
+				},
+			},
+		},
+		"per pod start events": {
+			pods:     gatedPods,
+			workload: admittedWl().Obj(),
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "pod", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq",
+				},
+				{
+					Key:       types.NamespacedName{Name: "pod2", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Started",
+					Message:   "Admitted by clusterQueue cq",
+				},
+			},
+		},
+		"aggregated stop event": {
+			pods: runningPods,
+			workload: admittedWl().
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadEvicted,
+					Status:  metav1.ConditionTrue,
+					Reason:  "Preempted",
+					Message: "Preempted to accommodate a higher priority Workload",
+				}).
+				Obj(),
+			aggregateGroupEvents: true,
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Name: "test-group", Namespace: "ns"},
+					EventType: "Normal",
+					Reason:    "Stopped",
+					Message:   "Preempted to accommodate a higher priority Workload, stopping 2 members of the group",
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			clientBuilder := utiltesting.NewClientBuilder()
+			if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			kcBuilder := clientBuilder.
+				WithObjects(utiltesting.MakeResourceFlavor("unit-test-flavor").Obj()).
+				WithStatusSubresource(tc.workload)
+			for i := range tc.pods {
+				kcBuilder = kcBuilder.WithObjects(tc.pods[i].DeepCopy())
+			}
+			kClient := kcBuilder.Build()
+			for i := range tc.pods {
+				if err := controllerutil.SetOwnerReference(&tc.pods[i], tc.workload, kClient.Scheme()); err != nil {
+					t.Fatalf("Could not setup owner reference in Workloads: %v", err)
+				}
+			}
+			if err := kClient.Create(ctx, tc.workload); err != nil {
+				t.Fatalf("Could not create workload: %v", err)
+			}
+
+			recorder := &utiltesting.EventRecorder{}
+			reconciler := NewReconciler(kClient, recorder, jobframework.WithAggregateGroupEvents(tc.aggregateGroupEvents))
+			for i := range tc.pods {
+				if _, err := reconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: client.ObjectKeyFromObject(&tc.pods[i]),
+				}); err != nil {
+					t.Errorf("Reconcile returned error: %v", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantEvents, recorder.RecordedEvents); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconciler_GroupStartWrites(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	clientBuilder := utiltesting.NewClientBuilder()
+	if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+		t.Fatalf("Could not setup indexes: %v", err)
+	}
+
+	basePodWrapper := testingpod.MakePod("pod", "ns").
+		UID("test-uid").
+		Queue("user-queue").
+		Label("kueue.x-k8s.io/managed", "true").
+		KueueFinalizer().
+		KueueSchedulingGate().
+		Group("test-group").
+		GroupTotalCount("3").
+		Request(corev1.ResourceCPU, "1").
+		Image("", nil)
+	pods := []corev1.Pod{
+		*basePodWrapper.Clone().Name("pod1").Annotation("kueue.x-k8s.io/role-hash", "a").Obj(),
+		*basePodWrapper.Clone().Name("pod2").Annotation("kueue.x-k8s.io/role-hash", "a").Obj(),
+		*basePodWrapper.Clone().Name("pod3").Annotation("kueue.x-k8s.io/role-hash", "b").Obj(),
+	}
+	wl := utiltesting.MakeWorkload("test-group", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+		PodSets(
+			*utiltesting.MakePodSet("a", 2).Request(corev1.ResourceCPU, "1").Obj(),
+			*utiltesting.MakePodSet("b", 1).Request(corev1.ResourceCPU, "1").Obj(),
+		).
+		ReserveQuota(
+			utiltesting.MakeAdmission("cq").
+				PodSets(
+					kueue.PodSetAssignment{
+						Name:    "a",
+						Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "flavor-a"},
+						Count:   ptr.To[int32](2),
+					},
+					kueue.PodSetAssignment{
+						Name:    "b",
+						Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "flavor-b"},
+						Count:   ptr.To[int32](1),
+					},
+				).
+				Obj(),
+		).
+		Admitted(true).
+		Obj()
+
+	var podWrites atomic.Int32
+	kcBuilder := clientBuilder.
+		WithObjects(
+			utiltesting.MakeResourceFlavor("flavor-a").Label("zone", "a").Obj(),
+			utiltesting.MakeResourceFlavor("flavor-b").Label("zone", "b").Obj(),
+		).
+		WithStatusSubresource(wl).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, isPod := obj.(*corev1.Pod); isPod {
+					podWrites.Add(1)
+				}
+				return client.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, client client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if _, isPod := obj.(*corev1.Pod); isPod {
+					podWrites.Add(1)
+				}
+				return client.Patch(ctx, obj, patch, opts...)
+			},
+		})
+	for i := range pods {
+		kcBuilder = kcBuilder.WithObjects(pods[i].DeepCopy())
+	}
+	kClient := kcBuilder.Build()
+	for i := range pods {
+		if err := controllerutil.SetOwnerReference(&pods[i], wl, kClient.Scheme()); err != nil {
+			t.Fatalf("Could not setup owner reference in Workloads: %v", err)
+		}
+	}
+	if err := kClient.Create(ctx, wl); err != nil {
+		t.Fatalf("Could not create workload: %v", err)
+	}
+
+	recorder := &utiltesting.EventRecorder{}
+	reconciler := NewReconciler(kClient, recorder)
+	for i := range pods {
+		if _, err := reconciler.Reconcile(ctx, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&pods[i]),
+		}); err != nil {
+			t.Errorf("Reconcile returned error: %v", err)
+		}
+	}
+
+	if got := int(podWrites.Load()); got != len(pods) {
+		t.Errorf("Unexpected number of pod writes, got %d, want %d", got, len(pods))
+	}
+
+	wantNodeSelectors := map[string]map[string]string{
+		"pod1": {"zone": "a"},
+		"pod2": {"zone": "a"},
+		"pod3": {"zone": "b"},
+	}
+	var gotPods corev1.PodList
+	if err := kClient.List(ctx, &gotPods); err != nil {
+		t.Fatalf("Could not list pods: %v", err)
+	}
+	for _, pod := range gotPods.Items {
+		if gateIndex(&pod) != gateNotFound {
+			t.Errorf("Pod %q still has the scheduling gate", pod.Name)
+		}
+		if diff := cmp.Diff(wantNodeSelectors[pod.Name], pod.Spec.NodeSelector); diff != "" {
+			t.Errorf("Unexpected node selector for pod %q (-want,+got):\n%s", pod.Name, diff)
+		}
+	}
+}
+
+func TestReconciler_WorkloadCreationExpectations(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	clientBuilder := utiltesting.NewClientBuilder()
+	if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+		t.Fatalf("Could not setup indexes: %v", err)
+	}
+
+	basePodWrapper := testingpod.MakePod("pod", "ns").
+		UID("test-uid").
+		Queue("user-queue").
+		Label("kueue.x-k8s.io/managed", "true").
+		KueueFinalizer().
+		KueueSchedulingGate().
+		Group("test-group").
+		GroupTotalCount("2").
+		Request(corev1.ResourceCPU, "1").
+		Image("", nil)
+	kClient := clientBuilder.WithObjects(
+		basePodWrapper.Clone().Name("pod1").Obj(),
+		basePodWrapper.Clone().Name("pod2").Obj(),
+	).Build()
+
+	reconciler := NewReconciler(kClient, &utiltesting.EventRecorder{})
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "pod1"}}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	var workloads kueue.WorkloadList
+	if err := kClient.List(ctx, &workloads); err != nil {
+		t.Fatalf("Could not list workloads: %v", err)
+	}
+	if len(workloads.Items) != 1 {
+		t.Fatalf("Unexpected number of workloads after the first reconcile: %d", len(workloads.Items))
+	}
+
+	// Simulate a cache that didn't observe the created workload yet.
+	workloads.Items[0].Finalizers = nil
+	if err := kClient.Update(ctx, &workloads.Items[0]); err != nil {
+		t.Fatalf("Could not update workload: %v", err)
+	}
+	if err := kClient.Delete(ctx, &workloads.Items[0]); err != nil {
+		t.Fatalf("Could not delete workload: %v", err)
+	}
+	// The reconcile of another pod in the group doesn't create it again either.
+	result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "pod2"}})
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if result.RequeueAfter <= 0 {
+		t.Errorf("Unexpected requeue while waiting for the created workload: %v", result.RequeueAfter)
+	}
+	if err := kClient.List(ctx, &workloads); err != nil {
+		t.Fatalf("Could not list workloads: %v", err)
+	}
+	if len(workloads.Items) != 0 {
+		t.Errorf("The workload was created again before the created one was observed")
+	}
+}
+
+func TestReconciler_ReclaimablePodsUpdateWindow(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	clientBuilder := utiltesting.NewClientBuilder()
+	if err := SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+		t.Fatalf("Could not setup indexes: %v", err)
+	}
+
+	basePodWrapper := testingpod.MakePod("pod", "ns").
+		UID("test-uid").
+		Queue("user-queue").
+		Label("kueue.x-k8s.io/managed", "true").
+		KueueFinalizer().
+		Group("test-group").
+		GroupTotalCount("3").
+		Annotation("kueue.x-k8s.io/role-hash", "a").
+		Request(corev1.ResourceCPU, "1").
+		Image("", nil)
+	pods := []corev1.Pod{
+		*basePodWrapper.Clone().Name("pod1").StatusPhase(corev1.PodSucceeded).Obj(),
+		*basePodWrapper.Clone().Name("pod2").StatusPhase(corev1.PodRunning).Obj(),
+		*basePodWrapper.Clone().Name("pod3").StatusPhase(corev1.PodRunning).Obj(),
+	}
+	wl := utiltesting.MakeWorkload("test-group", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+		PodSets(*utiltesting.MakePodSet("a", 3).Request(corev1.ResourceCPU, "1").Obj()).
+		Queue("user-queue").
+		ReserveQuota(utiltesting.MakeAdmission("cq", "a").AssignmentPodCount(3).Obj()).
+		Admitted(true).
+		Obj()
+
+	kcBuilder := clientBuilder.WithStatusSubresource(wl)
+	for i := range pods {
+		kcBuilder = kcBuilder.WithObjects(pods[i].DeepCopy())
+	}
+	kClient := kcBuilder.Build()
+	if err := kClient.Create(ctx, wl); err != nil {
+		t.Fatalf("Could not create workload: %v", err)
+	}
+
+	reconciler := NewReconciler(kClient, &utiltesting.EventRecorder{}, jobframework.WithReclaimablePodsUpdateWindow(time.Hour))
+	req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pods[0])}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	wantReclaimablePods := []kueue.ReclaimablePod{{Name: "a", Count: 1}}
+	var gotWl kueue.Workload
+	if err := kClient.Get(ctx, client.ObjectKeyFromObject(wl), &gotWl); err != nil {
+		t.Fatalf("Could not get workload: %v", err)
+	}
+	if diff := cmp.Diff(wantReclaimablePods, gotWl.Status.ReclaimablePods); diff != "" {
+		t.Errorf("Unexpected reclaimable pods after the first update (-want,+got):\n%s", diff)
+	}
+
+	// The pod that succeeds within the window is not counted right away.
+	pod := pods[1].DeepCopy()
+	if err := kClient.Get(ctx, client.ObjectKeyFromObject(pod), pod); err != nil {
+		t.Fatalf("Could not get pod: %v", err)
+	}
+	pod.Status.Phase = corev1.PodSucceeded
+	if err := kClient.Status().Update(ctx, pod); err != nil {
+		t.Fatalf("Could not update pod: %v", err)
+	}
+	result, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pod)})
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Hour {
+		t.Errorf("Unexpected requeue after the update was delayed: %v", result.RequeueAfter)
+	}
+	if err := kClient.Get(ctx, client.ObjectKeyFromObject(wl), &gotWl); err != nil {
+		t.Fatalf("Could not get workload: %v", err)
+	}
+	if diff := cmp.Diff(wantReclaimablePods, gotWl.Status.ReclaimablePods); diff != "" {
+		t.Errorf("Unexpected reclaimable pods within the window (-want,+got):\n%s", diff)
+	}
+}
+
 func TestIsPodOwnerManagedByQueue(t *testing.T) {
 	testCases := map[string]struct {
 		ownerReference metav1.OwnerReference
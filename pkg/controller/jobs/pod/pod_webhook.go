@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -57,10 +58,13 @@ var (
 )
 
 type PodWebhook struct {
-	client                     client.Client
+	client client.Client
+	// apiReader is used to look up namespaces that are not in the
+	// client's cache yet, such as a namespace created right before its first pod.
+	apiReader                  client.Reader
 	manageJobsWithoutQueueName bool
-	namespaceSelector          *metav1.LabelSelector
-	podSelector                *metav1.LabelSelector
+	// dynamic holds the namespace and pod selectors.
+	dynamic *jobframework.DynamicOptions
 }
 
 // SetupWebhook configures the webhook for pods.
@@ -71,9 +75,9 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	}
 	wh := &PodWebhook{
 		client:                     mgr.GetClient(),
+		apiReader:                  mgr.GetAPIReader(),
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
-		namespaceSelector:          options.PodNamespaceSelector,
-		podSelector:                options.PodSelector,
+		dynamic:                    options.DynamicOptions(),
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&corev1.Pod{}).
@@ -100,13 +104,20 @@ func omitKueueLabels(l map[string]string) map[string]string {
 
 func containersShape(containers []corev1.Container) (result []map[string]interface{}) {
 	for _, c := range containers {
-		result = append(result, map[string]interface{}{
+		shape := map[string]interface{}{
 			"image": c.Image,
 			"resources": map[string]interface{}{
 				"requests": c.Resources.Requests,
 			},
 			"ports": c.Ports,
-		})
+		}
+		// The sidecars account for the pods differently from the other init
+		// containers. The restart policy is only included when set, to keep
+		// the role of the existing pods.
+		if c.RestartPolicy != nil {
+			shape["restartPolicy"] = *c.RestartPolicy
+		}
+		result = append(result, shape)
 	}
 
 	return result
@@ -147,7 +158,7 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	}
 
 	// Check for pod label selector match
-	podSelector, err := metav1.LabelSelectorAsSelector(w.podSelector)
+	podSelector, err := metav1.LabelSelectorAsSelector(w.dynamic.PodSelector())
 	if err != nil {
 		return fmt.Errorf("failed to parse pod selector: %w", err)
 	}
@@ -156,8 +167,15 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 	}
 
 	// Get pod namespace and check for namespace label selector match
-	ns := corev1.Namespace{}
-	err = w.client.Get(ctx, client.ObjectKey{Name: pod.pod.GetNamespace()}, &ns)
+	ns := metav1.PartialObjectMetadata{}
+	ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+	nsKey := client.ObjectKey{Name: pod.pod.GetNamespace()}
+	err = w.client.Get(ctx, nsKey, &ns)
+	if apierrors.IsNotFound(err) && w.apiReader != nil {
+		// The cache might not have observed the namespace yet.
+		log.V(5).Info("Namespace not found in the cache, reading it from the API server", "Namespace.Name", nsKey.Name)
+		err = w.apiReader.Get(ctx, nsKey, &ns)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to run mutating webhook on pod %s, error while getting namespace: %w",
 			pod.pod.GetName(),
@@ -165,7 +183,7 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 		)
 	}
 	log.V(5).Info("Found pod namespace", "Namespace.Name", ns.GetName())
-	nsSelector, err := metav1.LabelSelectorAsSelector(w.namespaceSelector)
+	nsSelector, err := metav1.LabelSelectorAsSelector(w.dynamic.PodNamespaceSelector())
 	if err != nil {
 		return fmt.Errorf("failed to parse namespace selector: %w", err)
 	}
@@ -173,6 +191,10 @@ func (w *PodWebhook) Default(ctx context.Context, obj runtime.Object) error {
 		return nil
 	}
 
+	if err := jobframework.ApplyDefaultLocalQueue(ctx, w.client, pod); err != nil {
+		return err
+	}
+
 	if jobframework.QueueName(pod) != "" || w.manageJobsWithoutQueueName {
 		controllerutil.AddFinalizer(pod.Object(), PodFinalizer)
 
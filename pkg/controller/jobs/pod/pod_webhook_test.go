@@ -27,11 +27,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs/kubeflow/jobs"
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs/mpijob"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
 )
@@ -58,10 +62,12 @@ func TestDefault(t *testing.T) {
 
 	testCases := map[string]struct {
 		initObjects                []client.Object
+		apiReaderObjects           []client.Object
 		pod                        *corev1.Pod
 		manageJobsWithoutQueueName bool
 		namespaceSelector          *metav1.LabelSelector
 		podSelector                *metav1.LabelSelector
+		enableDefaultLocalQueue    bool
 		want                       *corev1.Pod
 	}{
 		"pod with queue nil ns selector": {
@@ -100,6 +106,23 @@ func TestDefault(t *testing.T) {
 				KueueFinalizer().
 				Obj(),
 		},
+		"pod without queue matching ns selector with a default LocalQueue": {
+			initObjects: []client.Object{
+				defaultNamespace,
+				utiltesting.MakeLocalQueue("main", defaultNamespace.Name).Label(constants.DefaultLocalQueueLabel, "true").Obj(),
+			},
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Obj(),
+			namespaceSelector:       defaultNamespaceSelector,
+			podSelector:             &metav1.LabelSelector{},
+			enableDefaultLocalQueue: true,
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("main").
+				Label("kueue.x-k8s.io/managed", "true").
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
 		"pod with owner managed by kueue (Job)": {
 			initObjects:       []client.Object{defaultNamespace},
 			podSelector:       &metav1.LabelSelector{},
@@ -238,6 +261,20 @@ func TestDefault(t *testing.T) {
 				KueueFinalizer().
 				Obj(),
 		},
+		"pod in a namespace missing from the cache": {
+			apiReaderObjects: []client.Object{defaultNamespace},
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Obj(),
+			namespaceSelector: defaultNamespaceSelector,
+			podSelector:       &metav1.LabelSelector{},
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				Label("kueue.x-k8s.io/managed", "true").
+				KueueSchedulingGate().
+				KueueFinalizer().
+				Obj(),
+		},
 		"pod with a group name label": {
 			initObjects:       []client.Object{defaultNamespace},
 			podSelector:       &metav1.LabelSelector{},
@@ -259,15 +296,20 @@ func TestDefault(t *testing.T) {
 
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
+			defer features.SetFeatureGateDuringTest(t, features.DefaultLocalQueue, tc.enableDefaultLocalQueue)()
 			builder := utiltesting.NewClientBuilder()
 			builder = builder.WithObjects(tc.initObjects...)
 			cli := builder.Build()
+			apiReader := utiltesting.NewClientBuilder().WithObjects(tc.apiReaderObjects...).Build()
 
 			w := &PodWebhook{
 				client:                     cli,
+				apiReader:                  apiReader,
 				manageJobsWithoutQueueName: tc.manageJobsWithoutQueueName,
-				namespaceSelector:          tc.namespaceSelector,
-				podSelector:                tc.podSelector,
+				dynamic: jobframework.NewDynamicOptions(
+					jobframework.WithPodNamespaceSelector(tc.namespaceSelector),
+					jobframework.WithPodSelector(tc.podSelector),
+				),
 			}
 
 			ctx, _ := utiltesting.ContextWithLog(t)
@@ -314,6 +356,20 @@ func TestGetRoleHash(t *testing.T) {
 			},
 			wantEqualHash: true,
 		},
+		"sidecar restart policy should affect the role": {
+			pods: []*Pod{
+				{pod: *testingpod.MakePod("pod1", "test-ns").
+					InitContainer(corev1.Container{Name: "init", Image: "sidecar"}).
+					Obj()},
+				{pod: *testingpod.MakePod("pod1", "test-ns").
+					InitContainer(corev1.Container{
+						Name:          "init",
+						Image:         "sidecar",
+						RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+					}).
+					Obj()},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
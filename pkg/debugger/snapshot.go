@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kueue/pkg/audit"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	// SnapshotPath is the path the snapshot is served at.
+	SnapshotPath = "/debug/snapshot"
+
+	// defaultPendingHeadSize is the number of pending workloads listed per
+	// ClusterQueue, unless the "pending" query parameter is set.
+	defaultPendingHeadSize = 10
+)
+
+// Usage holds quantities per flavor and resource, as in the audit records.
+type Usage = audit.Usage
+
+// ClusterQueue is the dump of a ClusterQueue in the cache snapshot.
+type ClusterQueue struct {
+	Name   string `json:"name"`
+	Cohort string `json:"cohort,omitempty"`
+	Active bool   `json:"active"`
+	Usage  Usage  `json:"usage,omitempty"`
+	// Workloads are the keys of the workloads reserving quota in the
+	// ClusterQueue, sorted.
+	Workloads []string `json:"workloads,omitempty"`
+	// PendingWorkloads is the total number of pending workloads.
+	PendingWorkloads int `json:"pendingWorkloads"`
+	// PendingHead are the keys of the first pending workloads, in the order
+	// they are going to be considered for admission.
+	PendingHead []string `json:"pendingHead,omitempty"`
+}
+
+// Cohort is the dump of a cohort in the cache snapshot.
+type Cohort struct {
+	Name                 string   `json:"name"`
+	Members              []string `json:"members"`
+	RequestableResources Usage    `json:"requestableResources,omitempty"`
+	Usage                Usage    `json:"usage,omitempty"`
+}
+
+// Snapshot is the dump of the cache snapshot and of the pending workloads.
+type Snapshot struct {
+	ClusterQueues []ClusterQueue `json:"clusterQueues"`
+	Cohorts       []Cohort       `json:"cohorts"`
+}
+
+// SnapshotHandler serves the dump of the cache snapshot as JSON.
+// Cache and Queues must be set before the handler serves requests.
+type SnapshotHandler struct {
+	Cache  *cache.Cache
+	Queues *queue.Manager
+}
+
+var _ http.Handler = (*SnapshotHandler)(nil)
+
+func (h *SnapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.Cache == nil || h.Queues == nil {
+		http.Error(w, "the cache is not ready", http.StatusServiceUnavailable)
+		return
+	}
+	headSize := defaultPendingHeadSize
+	if v := r.URL.Query().Get("pending"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "the pending parameter must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		headSize = n
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(Dump(h.Cache.Snapshot(), h.Queues, headSize))
+}
+
+// Dump builds the dump of the snapshot, listing up to headSize pending
+// workloads per ClusterQueue.
+func Dump(snap cache.Snapshot, queues *queue.Manager, headSize int) *Snapshot {
+	cqNames := sets.KeySet(snap.ClusterQueues).Union(snap.InactiveClusterQueueSets)
+	dump := &Snapshot{
+		ClusterQueues: make([]ClusterQueue, 0, cqNames.Len()),
+	}
+	cohorts := make(map[string]*cache.Cohort)
+	for _, name := range sets.List(cqNames) {
+		dcq := ClusterQueue{Name: name}
+		if cq, active := snap.ClusterQueues[name]; active {
+			dcq.Active = true
+			dcq.Usage = audit.NewUsage(cq.Usage)
+			dcq.Workloads = sets.List(sets.KeySet(cq.Workloads))
+			if cq.Cohort != nil {
+				dcq.Cohort = cq.Cohort.Name
+				cohorts[cq.Cohort.Name] = cq.Cohort
+			}
+		}
+		pending := queues.PendingWorkloadsInfo(name)
+		dcq.PendingWorkloads = len(pending)
+		for i := 0; i < len(pending) && i < headSize; i++ {
+			dcq.PendingHead = append(dcq.PendingHead, workload.Key(pending[i].Obj))
+		}
+		dump.ClusterQueues = append(dump.ClusterQueues, dcq)
+	}
+	dump.Cohorts = make([]Cohort, 0, len(cohorts))
+	for name, cohort := range cohorts {
+		members := make([]string, 0, cohort.Members.Len())
+		for cq := range cohort.Members {
+			members = append(members, cq.Name)
+		}
+		sort.Strings(members)
+		dump.Cohorts = append(dump.Cohorts, Cohort{
+			Name:                 name,
+			Members:              members,
+			RequestableResources: audit.NewUsage(cohort.RequestableResources),
+			Usage:                audit.NewUsage(cohort.Usage),
+		})
+	}
+	sort.Slice(dump.Cohorts, func(i, j int) bool {
+		return dump.Cohorts[i].Name < dump.Cohorts[j].Name
+	})
+	return dump
+}
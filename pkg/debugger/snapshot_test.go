@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestSnapshotHandler(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().Build()
+	cCache := cache.New(cl)
+	queues := queue.NewManager(cl, cCache)
+
+	cCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cqs := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("a").
+			Cohort("team").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("b").
+			Cohort("team").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("inactive").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("missing").Resource(corev1.ResourceCPU, "2").Obj()).
+			Obj(),
+	}
+	for _, cq := range cqs {
+		if err := cCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue to the cache: %v", err)
+		}
+		if err := queues.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue to the queues: %v", err)
+		}
+	}
+	if err := queues.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("a").Obj()); err != nil {
+		t.Fatalf("Adding LocalQueue: %v", err)
+	}
+	cCache.AddOrUpdateWorkload(utiltesting.MakeWorkload("admitted", "ns").
+		Request(corev1.ResourceCPU, "1500m").
+		ReserveQuota(utiltesting.MakeAdmission("a").Assignment(corev1.ResourceCPU, "default", "1500m").Obj()).
+		Obj())
+	now := time.Now()
+	for i, name := range []string{"first", "second", "third"} {
+		queues.AddOrUpdateWorkload(utiltesting.MakeWorkload(name, "ns").
+			Queue("lq").
+			Request(corev1.ResourceCPU, "1").
+			Creation(now.Add(time.Duration(i) * time.Second)).
+			Obj())
+	}
+
+	cpuUsage := func(q string) Usage {
+		return Usage{"default": corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(q)}}
+	}
+	cases := map[string]struct {
+		query      string
+		wantStatus int
+		want       *Snapshot
+	}{
+		"default head size": {
+			wantStatus: http.StatusOK,
+			want: &Snapshot{
+				ClusterQueues: []ClusterQueue{
+					{
+						Name:             "a",
+						Cohort:           "team",
+						Active:           true,
+						Usage:            cpuUsage("1500m"),
+						Workloads:        []string{"ns/admitted"},
+						PendingWorkloads: 3,
+						PendingHead:      []string{"ns/first", "ns/second", "ns/third"},
+					},
+					{
+						Name:   "b",
+						Cohort: "team",
+						Active: true,
+						Usage:  cpuUsage("0"),
+					},
+					{
+						Name: "inactive",
+					},
+				},
+				Cohorts: []Cohort{
+					{
+						Name:                 "team",
+						Members:              []string{"a", "b"},
+						RequestableResources: cpuUsage("6"),
+						Usage:                cpuUsage("1500m"),
+					},
+				},
+			},
+		},
+		"limited head size": {
+			query:      "?pending=1",
+			wantStatus: http.StatusOK,
+			want: &Snapshot{
+				ClusterQueues: []ClusterQueue{
+					{
+						Name:             "a",
+						Cohort:           "team",
+						Active:           true,
+						Usage:            cpuUsage("1500m"),
+						Workloads:        []string{"ns/admitted"},
+						PendingWorkloads: 3,
+						PendingHead:      []string{"ns/first"},
+					},
+					{
+						Name:   "b",
+						Cohort: "team",
+						Active: true,
+						Usage:  cpuUsage("0"),
+					},
+					{
+						Name: "inactive",
+					},
+				},
+				Cohorts: []Cohort{
+					{
+						Name:                 "team",
+						Members:              []string{"a", "b"},
+						RequestableResources: cpuUsage("6"),
+						Usage:                cpuUsage("1500m"),
+					},
+				},
+			},
+		},
+		"invalid head size": {
+			query:      "?pending=-1",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	h := &SnapshotHandler{Cache: cCache, Queues: queues}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, SnapshotPath+tc.query, nil))
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("Unexpected status %d, want %d: %s", rec.Code, tc.wantStatus, rec.Body.String())
+			}
+			if tc.want == nil {
+				return
+			}
+			got := &Snapshot{}
+			if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+				t.Fatalf("Decoding the snapshot: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected snapshot (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSnapshotHandlerNotReady(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&SnapshotHandler{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, SnapshotPath, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Unexpected status %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
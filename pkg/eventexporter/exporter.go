@@ -0,0 +1,379 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexporter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const (
+	// CloudEventsSpecVersion is the version of the CloudEvents specification
+	// the exported events comply with.
+	CloudEventsSpecVersion = "1.0"
+
+	// ContentTypeCloudEventsJSON is the media type of an event sent in the
+	// structured content mode.
+	ContentTypeCloudEventsJSON = "application/cloudevents+json"
+
+	eventTypePrefix = "x-k8s.io.kueue.workload."
+
+	// EventTypeQueued is sent when the workload starts waiting for quota,
+	// either after being created or after losing its quota reservation.
+	EventTypeQueued = eventTypePrefix + "queued"
+	// EventTypeAdmitted is sent when the workload gets admitted.
+	EventTypeAdmitted = eventTypePrefix + "admitted"
+	// EventTypeEvicted is sent when the workload is evicted for a reason
+	// other than preemption.
+	EventTypeEvicted = eventTypePrefix + "evicted"
+	// EventTypePreempted is sent when the workload is evicted to make room
+	// for other workloads.
+	EventTypePreempted = eventTypePrefix + "preempted"
+	// EventTypeFinished is sent when the workload finishes.
+	EventTypeFinished = eventTypePrefix + "finished"
+)
+
+var realClock = clock.RealClock{}
+
+// Event is a CloudEvent in the structured content mode. The ID is derived
+// from the workload UID and the transition, so that the sink can deduplicate
+// the events exported more than once, e.g. after a leader failover.
+type Event struct {
+	SpecVersion     string       `json:"specversion"`
+	ID              string       `json:"id"`
+	Source          string       `json:"source"`
+	Type            string       `json:"type"`
+	Subject         string       `json:"subject"`
+	Time            time.Time    `json:"time"`
+	DataContentType string       `json:"datacontenttype"`
+	Data            WorkloadData `json:"data"`
+}
+
+// WorkloadData is the payload of the workload lifecycle events.
+type WorkloadData struct {
+	Namespace    string       `json:"namespace"`
+	Name         string       `json:"name"`
+	UID          types.UID    `json:"uid"`
+	LocalQueue   string       `json:"localQueue,omitempty"`
+	ClusterQueue string       `json:"clusterQueue,omitempty"`
+	Priority     *int32       `json:"priority,omitempty"`
+	Reason       string       `json:"reason,omitempty"`
+	Message      string       `json:"message,omitempty"`
+	PodSets      []PodSetData `json:"podSets,omitempty"`
+}
+
+// PodSetData describes the assignment of a podSet of an admitted workload.
+type PodSetData struct {
+	Name          string                                                `json:"name"`
+	Count         int32                                                 `json:"count"`
+	Flavors       map[corev1.ResourceName]kueue.ResourceFlavorReference `json:"flavors,omitempty"`
+	ResourceUsage corev1.ResourceList                                   `json:"resourceUsage,omitempty"`
+}
+
+type options struct {
+	source       string
+	headers      map[string]string
+	timeout      time.Duration
+	maxRetries   int32
+	bufferSize   int32
+	retryBackoff time.Duration
+	clock        clock.Clock
+}
+
+// Option configures the exporter.
+type Option func(*options)
+
+// WithSource sets the CloudEvents source attribute of the events.
+func WithSource(source string) Option {
+	return func(o *options) {
+		o.source = source
+	}
+}
+
+// WithHeaders sets additional HTTP headers sent with every event.
+func WithHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		o.headers = headers
+	}
+}
+
+// WithTimeout sets the timeout for delivering a single event.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithMaxRetries sets the number of delivery retries before an event is dropped.
+func WithMaxRetries(retries int32) Option {
+	return func(o *options) {
+		o.maxRetries = retries
+	}
+}
+
+// WithBufferSize sets the maximum number of events waiting to be delivered.
+func WithBufferSize(size int32) Option {
+	return func(o *options) {
+		o.bufferSize = size
+	}
+}
+
+// WithRetryBackoff sets the initial delay between delivery attempts, which
+// doubles after every failed attempt.
+func WithRetryBackoff(backoff time.Duration) Option {
+	return func(o *options) {
+		o.retryBackoff = backoff
+	}
+}
+
+// WithClock sets the clock used to record when the exporter starts leading.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+var defaultOptions = options{
+	source:       "kueue",
+	timeout:      10 * time.Second,
+	maxRetries:   3,
+	bufferSize:   1000,
+	retryBackoff: time.Second,
+	clock:        realClock,
+}
+
+// Exporter publishes the workload lifecycle transitions as CloudEvents to an
+// HTTP sink. It observes the workloads as a workload update watcher and
+// delivers the events asynchronously, so that reconciliation never blocks on
+// the sink. Only the leader replica buffers and delivers the events.
+type Exporter struct {
+	log          logr.Logger
+	sink         string
+	client       *http.Client
+	source       string
+	headers      map[string]string
+	maxRetries   int32
+	retryBackoff time.Duration
+	clock        clock.Clock
+	events       chan Event
+	// leadingSince is the time, in Unix nanoseconds, at which the exporter
+	// started leading, or 0 when it is not leading.
+	leadingSince atomic.Int64
+}
+
+// New creates an exporter delivering the events to the sink URL.
+func New(sink string, opts ...Option) *Exporter {
+	options := defaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &Exporter{
+		log:          ctrl.Log.WithName("event-exporter"),
+		sink:         sink,
+		client:       &http.Client{Timeout: options.timeout},
+		source:       options.source,
+		headers:      options.headers,
+		maxRetries:   options.maxRetries,
+		retryBackoff: options.retryBackoff,
+		clock:        options.clock,
+		events:       make(chan Event, options.bufferSize),
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface so that
+// only the leader replica publishes the events.
+func (e *Exporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start delivers the buffered events until the context is done.
+func (e *Exporter) Start(ctx context.Context) error {
+	e.startLeading()
+	defer e.leadingSince.Store(0)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev := <-e.events:
+			if err := e.deliver(ctx, ev); err != nil {
+				e.log.Error(err, "Dropping event", "type", ev.Type, "workload", ev.Subject)
+			}
+		}
+	}
+}
+
+func (e *Exporter) startLeading() {
+	e.leadingSince.Store(e.clock.Now().UnixNano())
+}
+
+// NotifyWorkloadUpdate enqueues the events for the lifecycle transitions
+// between the old and the new version of the workload. The updates are
+// ignored while the exporter is not leading.
+func (e *Exporter) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	since := e.leadingSince.Load()
+	if newWl == nil || since == 0 {
+		return
+	}
+	for _, ev := range e.transitions(oldWl, newWl) {
+		// Without an old version, the workload comes from the initial list
+		// of the informer, which also happens after a restart or a relist.
+		// Only the transitions that happened since the exporter started
+		// leading are exported then.
+		if oldWl == nil && ev.Time.Before(time.Unix(0, since).Truncate(time.Second)) {
+			continue
+		}
+		select {
+		case e.events <- ev:
+		default:
+			e.log.V(2).Info("Event buffer is full, dropping event", "type", ev.Type, "workload", klog.KObj(newWl))
+		}
+	}
+}
+
+func (e *Exporter) transitions(oldWl, newWl *kueue.Workload) []Event {
+	var events []Event
+	if becameTrue(oldWl, newWl, kueue.WorkloadFinished) {
+		return append(events, e.newEvent(EventTypeFinished, newWl, kueue.WorkloadFinished))
+	}
+	if becameTrue(oldWl, newWl, kueue.WorkloadEvicted) {
+		evType := EventTypeEvicted
+		if cond := apimeta.FindStatusCondition(newWl.Status.Conditions, kueue.WorkloadEvicted); cond.Reason == kueue.WorkloadEvictedByPreemption {
+			evType = EventTypePreempted
+		}
+		events = append(events, e.newEvent(evType, newWl, kueue.WorkloadEvicted))
+	}
+	if becameTrue(oldWl, newWl, kueue.WorkloadAdmitted) {
+		events = append(events, e.newEvent(EventTypeAdmitted, newWl, kueue.WorkloadAdmitted))
+	}
+	if !workload.HasQuotaReservation(newWl) && (oldWl == nil || workload.HasQuotaReservation(oldWl)) &&
+		!apimeta.IsStatusConditionTrue(newWl.Status.Conditions, kueue.WorkloadFinished) {
+		events = append(events, e.newEvent(EventTypeQueued, newWl, kueue.WorkloadQuotaReserved))
+	}
+	return events
+}
+
+func becameTrue(oldWl, newWl *kueue.Workload, condType string) bool {
+	if !apimeta.IsStatusConditionTrue(newWl.Status.Conditions, condType) {
+		return false
+	}
+	return oldWl == nil || !apimeta.IsStatusConditionTrue(oldWl.Status.Conditions, condType)
+}
+
+func (e *Exporter) newEvent(evType string, wl *kueue.Workload, condType string) Event {
+	data := WorkloadData{
+		Namespace:  wl.Namespace,
+		Name:       wl.Name,
+		UID:        wl.UID,
+		LocalQueue: wl.Spec.QueueName,
+		Priority:   wl.Spec.Priority,
+	}
+	transitionTime := wl.CreationTimestamp
+	if cond := apimeta.FindStatusCondition(wl.Status.Conditions, condType); cond != nil {
+		data.Reason = cond.Reason
+		data.Message = cond.Message
+		transitionTime = cond.LastTransitionTime
+	}
+	if wl.Status.Admission != nil {
+		data.ClusterQueue = string(wl.Status.Admission.ClusterQueue)
+		for _, psa := range wl.Status.Admission.PodSetAssignments {
+			psd := PodSetData{
+				Name:          psa.Name,
+				Flavors:       psa.Flavors,
+				ResourceUsage: psa.ResourceUsage,
+			}
+			if psa.Count != nil {
+				psd.Count = *psa.Count
+			}
+			data.PodSets = append(data.PodSets, psd)
+		}
+	}
+	return Event{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              eventID(wl.UID, condType, transitionTime),
+		Source:          e.source,
+		Type:            evType,
+		Subject:         workload.Key(wl),
+		Time:            transitionTime.UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+func eventID(uid types.UID, condType string, transitionTime metav1.Time) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", uid, condType, transitionTime.Unix())))
+	return hex.EncodeToString(sum[:16])
+}
+
+func (e *Exporter) deliver(ctx context.Context, ev Event) error {
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	backoff := e.retryBackoff
+	for attempt := int32(0); ; attempt++ {
+		err = e.send(ctx, body)
+		if err == nil || attempt >= e.maxRetries {
+			return err
+		}
+		e.log.V(3).Info("Failed to deliver event, retrying", "type", ev.Type, "workload", ev.Subject, "attempt", attempt+1, "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (e *Exporter) send(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.sink, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", ContentTypeCloudEventsJSON)
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sink responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,375 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventexporter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestTransitions(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	pending := utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).Creation(now).Obj()
+	reserved := utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Obj()
+	admitted := utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Admitted(true).
+		Obj()
+	evictedCondition := func(reason string) metav1.Condition {
+		return metav1.Condition{
+			Type:    kueue.WorkloadEvicted,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: "evicted by test",
+		}
+	}
+	wantAdmittedData := WorkloadData{
+		Namespace:    "ns",
+		Name:         "wl",
+		LocalQueue:   "lq",
+		ClusterQueue: "cq",
+		Priority:     ptr.To[int32](10),
+		Reason:       "ByTest",
+		Message:      "Admitted by ClusterQueue cq",
+		PodSets: []PodSetData{{
+			Name:          kueue.DefaultPodSetName,
+			Count:         1,
+			Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "default"},
+			ResourceUsage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		}},
+	}
+
+	cases := map[string]struct {
+		oldWl      *kueue.Workload
+		newWl      *kueue.Workload
+		notLeading bool
+		wantEvents []Event
+	}{
+		"created": {
+			newWl: pending,
+			wantEvents: []Event{{
+				Type:    EventTypeQueued,
+				Subject: "ns/wl",
+				Data: WorkloadData{
+					Namespace:  "ns",
+					Name:       "wl",
+					LocalQueue: "lq",
+					Priority:   ptr.To[int32](10),
+				},
+			}},
+		},
+		"created while not leading": {
+			newWl:      pending,
+			notLeading: true,
+		},
+		"listed after a restart": {
+			newWl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).
+				Creation(now.Add(-time.Hour)).
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:               kueue.WorkloadAdmitted,
+					Status:             metav1.ConditionTrue,
+					Reason:             "ByTest",
+					LastTransitionTime: metav1.NewTime(now.Add(-time.Minute)),
+				}).
+				Obj(),
+		},
+		"listed after a restart with a new transition": {
+			newWl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).
+				Creation(now.Add(-time.Hour)).
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:               kueue.WorkloadFinished,
+					Status:             metav1.ConditionTrue,
+					Reason:             "JobFinished",
+					Message:            "Job finished successfully",
+					LastTransitionTime: metav1.NewTime(now),
+				}).
+				Obj(),
+			wantEvents: []Event{{
+				Type:    EventTypeFinished,
+				Subject: "ns/wl",
+				Data: WorkloadData{
+					Namespace:    "ns",
+					Name:         "wl",
+					LocalQueue:   "lq",
+					ClusterQueue: "cq",
+					Priority:     ptr.To[int32](10),
+					Reason:       "JobFinished",
+					Message:      "Job finished successfully",
+					PodSets: []PodSetData{{
+						Name:          kueue.DefaultPodSetName,
+						Count:         1,
+						Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{},
+						ResourceUsage: corev1.ResourceList{},
+					}},
+				},
+			}},
+		},
+		"quota reserved": {
+			oldWl: pending,
+			newWl: reserved,
+		},
+		"admitted": {
+			oldWl: reserved,
+			newWl: admitted,
+			wantEvents: []Event{{
+				Type:    EventTypeAdmitted,
+				Subject: "ns/wl",
+				Data:    wantAdmittedData,
+			}},
+		},
+		"no transition": {
+			oldWl: admitted,
+			newWl: admitted,
+		},
+		"preempted": {
+			oldWl: admitted,
+			newWl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Condition(evictedCondition(kueue.WorkloadEvictedByPreemption)).
+				Obj(),
+			wantEvents: []Event{{
+				Type:    EventTypePreempted,
+				Subject: "ns/wl",
+				Data: WorkloadData{
+					Namespace:    "ns",
+					Name:         "wl",
+					LocalQueue:   "lq",
+					ClusterQueue: "cq",
+					Priority:     ptr.To[int32](10),
+					Reason:       kueue.WorkloadEvictedByPreemption,
+					Message:      "evicted by test",
+					PodSets: []PodSetData{{
+						Name:          kueue.DefaultPodSetName,
+						Count:         1,
+						Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{},
+						ResourceUsage: corev1.ResourceList{},
+					}},
+				},
+			}},
+		},
+		"evicted and requeued": {
+			oldWl: admitted,
+			newWl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).
+				Condition(evictedCondition(kueue.WorkloadEvictedByPodsReadyTimeout)).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadQuotaReserved,
+					Status:  metav1.ConditionFalse,
+					Reason:  "Pending",
+					Message: "waiting for quota",
+				}).
+				Obj(),
+			wantEvents: []Event{
+				{
+					Type:    EventTypeEvicted,
+					Subject: "ns/wl",
+					Data: WorkloadData{
+						Namespace:  "ns",
+						Name:       "wl",
+						LocalQueue: "lq",
+						Priority:   ptr.To[int32](10),
+						Reason:     kueue.WorkloadEvictedByPodsReadyTimeout,
+						Message:    "evicted by test",
+					},
+				},
+				{
+					Type:    EventTypeQueued,
+					Subject: "ns/wl",
+					Data: WorkloadData{
+						Namespace:  "ns",
+						Name:       "wl",
+						LocalQueue: "lq",
+						Priority:   ptr.To[int32](10),
+						Reason:     "Pending",
+						Message:    "waiting for quota",
+					},
+				},
+			},
+		},
+		"finished": {
+			oldWl: admitted,
+			newWl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Priority(10).
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Condition(metav1.Condition{
+					Type:    kueue.WorkloadFinished,
+					Status:  metav1.ConditionTrue,
+					Reason:  "JobFinished",
+					Message: "Job finished successfully",
+				}).
+				Obj(),
+			wantEvents: []Event{{
+				Type:    EventTypeFinished,
+				Subject: "ns/wl",
+				Data: WorkloadData{
+					Namespace:    "ns",
+					Name:         "wl",
+					LocalQueue:   "lq",
+					ClusterQueue: "cq",
+					Priority:     ptr.To[int32](10),
+					Reason:       "JobFinished",
+					Message:      "Job finished successfully",
+					PodSets: []PodSetData{{
+						Name:          kueue.DefaultPodSetName,
+						Count:         1,
+						Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{},
+						ResourceUsage: corev1.ResourceList{},
+					}},
+				},
+			}},
+		},
+		"deleted": {
+			oldWl: admitted,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exporter := New("http://sink", WithSource("test"), WithClock(testingclock.NewFakeClock(now)))
+			if !tc.notLeading {
+				exporter.startLeading()
+			}
+			exporter.NotifyWorkloadUpdate(tc.oldWl, tc.newWl)
+			close(exporter.events)
+			var gotEvents []Event
+			for ev := range exporter.events {
+				gotEvents = append(gotEvents, ev)
+			}
+			for i := range tc.wantEvents {
+				tc.wantEvents[i].SpecVersion = CloudEventsSpecVersion
+				tc.wantEvents[i].Source = "test"
+				tc.wantEvents[i].DataContentType = "application/json"
+			}
+			if diff := cmp.Diff(tc.wantEvents, gotEvents, cmpopts.IgnoreFields(Event{}, "ID", "Time")); diff != "" {
+				t.Errorf("Unexpected events (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEventIdentity(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	exporter := New("http://sink")
+	finished := func(uid types.UID, at time.Time) *kueue.Workload {
+		wl := utiltesting.MakeWorkload("wl", "ns").
+			SetOrReplaceCondition(metav1.Condition{
+				Type:               kueue.WorkloadFinished,
+				Status:             metav1.ConditionTrue,
+				Reason:             "JobFinished",
+				LastTransitionTime: metav1.NewTime(at),
+			}).
+			Obj()
+		wl.UID = uid
+		return wl
+	}
+	event := func(wl *kueue.Workload) Event {
+		return exporter.newEvent(EventTypeFinished, wl, kueue.WorkloadFinished)
+	}
+
+	got := event(finished("uid", now))
+	if !got.Time.Equal(now) {
+		t.Errorf("Unexpected event time, want %v, got %v", now, got.Time)
+	}
+	if again := event(finished("uid", now)); again.ID != got.ID {
+		t.Errorf("The same transition got different IDs %q and %q", got.ID, again.ID)
+	}
+	if other := event(finished("uid", now.Add(time.Minute))); other.ID == got.ID {
+		t.Errorf("A later transition got the same ID %q", got.ID)
+	}
+	if other := event(finished("other-uid", now)); other.ID == got.ID {
+		t.Errorf("A different workload got the same ID %q", got.ID)
+	}
+}
+
+func TestDelivery(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		attempts  int
+		delivered []Event
+	)
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if got := r.Header.Get("Content-Type"); got != ContentTypeCloudEventsJSON {
+			t.Errorf("Unexpected content type %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer token" {
+			t.Errorf("Unexpected authorization header %q", got)
+		}
+		var ev Event
+		if err := json.NewDecoder(r.Body).Decode(&ev); err != nil {
+			t.Errorf("Decoding event: %v", err)
+		}
+		delivered = append(delivered, ev)
+		w.WriteHeader(http.StatusAccepted)
+		close(done)
+	}))
+	defer server.Close()
+
+	exporter := New(server.URL,
+		WithHeaders(map[string]string{"Authorization": "Bearer token"}),
+		WithRetryBackoff(time.Millisecond),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = exporter.Start(ctx)
+	}()
+
+	for exporter.leadingSince.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	exporter.NotifyWorkloadUpdate(nil, utiltesting.MakeWorkload("wl", "ns").Queue("lq").Creation(time.Now()).Obj())
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the event to be delivered")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if attempts != 2 {
+		t.Errorf("Unexpected number of delivery attempts, want 2, got %d", attempts)
+	}
+	if len(delivered) != 1 || delivered[0].Type != EventTypeQueued || delivered[0].Subject != "ns/wl" {
+		t.Errorf("Unexpected delivered events: %+v", delivered)
+	}
+}
@@ -69,6 +69,64 @@ const (
 	//
 	// Enable priority sorting within the cohort.
 	PrioritySortingWithinCohort featuregate.Feature = "PrioritySortingWithinCohort"
+
+	// alpha: v0.6
+	//
+	// Enables scheduling the heads of independent cohorts concurrently.
+	ParallelCohortScheduling featuregate.Feature = "ParallelCohortScheduling"
+
+	// alpha: v0.6
+	//
+	// Enables admitting more than one workload from a ClusterQueue per
+	// scheduling cycle, when they fit without borrowing or preemption.
+	BatchAdmission featuregate.Feature = "BatchAdmission"
+
+	// alpha: v0.6
+	//
+	// Enables LocalQueues to fall back to other ClusterQueues for the
+	// workloads that stay pending in their ClusterQueue.
+	LocalQueueFallback featuregate.Feature = "LocalQueueFallback"
+
+	// alpha: v0.6
+	//
+	// Enables assigning the jobs submitted without a queue name to the
+	// LocalQueue marked as default in their namespace.
+	DefaultLocalQueue featuregate.Feature = "DefaultLocalQueue"
+
+	// alpha: v0.6
+	//
+	// Enables expressing the nominal quota of a ClusterQueue as a percentage
+	// of the capacity of its cohort.
+	CohortQuotaShares featuregate.Feature = "CohortQuotaShares"
+
+	// alpha: v0.6
+	//
+	// Enables applying the changes of the configuration file without
+	// restarting the manager.
+	ConfigHotReload featuregate.Feature = "ConfigHotReload"
+
+	// alpha: v0.6
+	//
+	// Enables keeping the workloads inadmissible while their pods don't fit
+	// in the ResourceQuotas of their namespace.
+	NamespaceResourceQuota featuregate.Feature = "NamespaceResourceQuota"
+
+	// alpha: v0.6
+	//
+	// Enables labeling the admitted jobs and their pods with the ClusterQueue,
+	// the cohort and the flavors assigned to them.
+	AdmissionLabels featuregate.Feature = "AdmissionLabels"
+
+	// alpha: v0.6
+	//
+	// Enables the default workload priority class of the LocalQueues.
+	LocalQueueDefaultPriority featuregate.Feature = "LocalQueueDefaultPriority"
+
+	// alpha: v0.6
+	//
+	// Enables preferring the flavors of the last admission of the workloads
+	// that are admitted again after an eviction.
+	FlavorStickiness featuregate.Feature = "FlavorStickiness"
 )
 
 func init() {
@@ -88,6 +146,16 @@ var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	ProvisioningACC:             {Default: false, PreRelease: featuregate.Alpha},
 	VisibilityOnDemand:          {Default: false, PreRelease: featuregate.Alpha},
 	PrioritySortingWithinCohort: {Default: true, PreRelease: featuregate.Beta},
+	ParallelCohortScheduling:    {Default: false, PreRelease: featuregate.Alpha},
+	BatchAdmission:              {Default: false, PreRelease: featuregate.Alpha},
+	LocalQueueFallback:          {Default: false, PreRelease: featuregate.Alpha},
+	DefaultLocalQueue:           {Default: false, PreRelease: featuregate.Alpha},
+	CohortQuotaShares:           {Default: false, PreRelease: featuregate.Alpha},
+	ConfigHotReload:             {Default: false, PreRelease: featuregate.Alpha},
+	NamespaceResourceQuota:      {Default: false, PreRelease: featuregate.Alpha},
+	AdmissionLabels:             {Default: false, PreRelease: featuregate.Alpha},
+	LocalQueueDefaultPriority:   {Default: false, PreRelease: featuregate.Alpha},
+	FlavorStickiness:            {Default: false, PreRelease: featuregate.Alpha},
 }
 
 func SetFeatureGateDuringTest(tb testing.TB, f featuregate.Feature, value bool) func() {
@@ -165,6 +165,31 @@ For a ClusterQueue, the metric only reports a value of 1 for one of the statuses
 			Help:      `Reports the cluster_queue's resource borrowing limit within all the flavors`,
 		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
 	)
+
+	ClusterQueueFairShare = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_fair_share",
+			Help:      `Reports the fraction of the cohort's nominal quota of the resource owned by the cluster_queue`,
+		}, []string{"cohort", "cluster_queue", "resource"},
+	)
+
+	ClusterQueueCohortUtilization = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_cohort_utilization",
+			Help:      `Reports the fraction of the cohort's nominal quota of the resource reserved by the cluster_queue`,
+		}, []string{"cohort", "cluster_queue", "resource"},
+	)
+
+	ClusterQueueWeightedShare = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "cluster_queue_weighted_share",
+			Help: `Reports the cluster_queue's utilization of its dominant resource relative to its fair share.
+A value above 1 means that the cluster_queue is borrowing quota from the cohort.`,
+		}, []string{"cohort", "cluster_queue"},
+	)
 )
 
 func AdmissionAttempt(result AdmissionResult, duration time.Duration) {
@@ -220,6 +245,26 @@ func ReportClusterQueueResourceUsage(cohort, queue, flavor, resource string, usa
 	ClusterQueueResourceUsage.WithLabelValues(cohort, queue, flavor, resource).Set(usage)
 }
 
+func ReportClusterQueueCohortShare(cohort, queue string, fairShare, utilization map[string]float64, weightedShare float64) {
+	ClearClusterQueueCohortShare(queue)
+	for resource, v := range fairShare {
+		ClusterQueueFairShare.WithLabelValues(cohort, queue, resource).Set(v)
+	}
+	for resource, v := range utilization {
+		ClusterQueueCohortUtilization.WithLabelValues(cohort, queue, resource).Set(v)
+	}
+	ClusterQueueWeightedShare.WithLabelValues(cohort, queue).Set(weightedShare)
+}
+
+func ClearClusterQueueCohortShare(cqName string) {
+	lbls := prometheus.Labels{
+		"cluster_queue": cqName,
+	}
+	ClusterQueueFairShare.DeletePartialMatch(lbls)
+	ClusterQueueCohortUtilization.DeletePartialMatch(lbls)
+	ClusterQueueWeightedShare.DeletePartialMatch(lbls)
+}
+
 func ClearClusterQueueResourceMetrics(cqName string) {
 	lbls := prometheus.Labels{
 		"cluster_queue": cqName,
@@ -228,6 +273,7 @@ func ClearClusterQueueResourceMetrics(cqName string) {
 	ClusterQueueResourceBorrowingLimit.DeletePartialMatch(lbls)
 	ClusterQueueResourceUsage.DeletePartialMatch(lbls)
 	ClusterQueueResourceReservations.DeletePartialMatch(lbls)
+	ClearClusterQueueCohortShare(cqName)
 }
 
 func ClearClusterQueueResourceQuotas(cqName, flavor, resource string) {
@@ -283,5 +329,8 @@ func Register() {
 		ClusterQueueResourceReservations,
 		ClusterQueueResourceNominalQuota,
 		ClusterQueueResourceBorrowingLimit,
+		ClusterQueueFairShare,
+		ClusterQueueCohortUtilization,
+		ClusterQueueWeightedShare,
 	)
 }
@@ -127,3 +127,23 @@ func TestReportAndCleanupClusterQueueUsage(t *testing.T) {
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 1, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 0, "cluster_queue", "queue", "flavor", "flavor", "resource", "res2")
 }
+
+func TestReportAndCleanupClusterQueueCohortShare(t *testing.T) {
+	ReportClusterQueueCohortShare("cohort", "queue", map[string]float64{"cpu": 0.5, "memory": 0.2}, map[string]float64{"cpu": 0.7, "memory": 0.1}, 1.4)
+
+	expectFilteredMetricsCount(t, ClusterQueueFairShare, 2, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueCohortUtilization, 2, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueWeightedShare, 1, "cluster_queue", "queue")
+
+	// memory is no longer covered
+	ReportClusterQueueCohortShare("cohort", "queue", map[string]float64{"cpu": 0.5}, map[string]float64{"cpu": 0.7}, 1.4)
+
+	expectFilteredMetricsCount(t, ClusterQueueFairShare, 1, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueCohortUtilization, 0, "cluster_queue", "queue", "resource", "memory")
+
+	ClearClusterQueueResourceMetrics("queue")
+
+	expectFilteredMetricsCount(t, ClusterQueueFairShare, 0, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueCohortUtilization, 0, "cluster_queue", "queue")
+	expectFilteredMetricsCount(t, ClusterQueueWeightedShare, 0, "cluster_queue", "queue")
+}
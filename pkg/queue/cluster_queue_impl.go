@@ -32,15 +32,17 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/util/heap"
+	"sigs.k8s.io/kueue/pkg/util/namespaceselector"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 // clusterQueueBase is an incomplete base implementation of ClusterQueue
 // interface. It can be inherited and overwritten by other types.
 type clusterQueueBase struct {
+	name              string
 	heap              heap.Heap
 	cohort            string
-	namespaceSelector labels.Selector
+	namespaceSelector namespaceselector.Selector
 	active            bool
 
 	// inadmissibleWorkloads are workloads that have been tried at least once and couldn't be admitted.
@@ -70,8 +72,9 @@ func newClusterQueueImpl(keyFunc func(obj interface{}) string, lessFunc func(a,
 func (c *clusterQueueBase) Update(apiCQ *kueue.ClusterQueue) error {
 	c.rwm.Lock()
 	defer c.rwm.Unlock()
+	c.name = apiCQ.Name
 	c.cohort = apiCQ.Spec.Cohort
-	nsSelector, err := metav1.LabelSelectorAsSelector(apiCQ.Spec.NamespaceSelector)
+	nsSelector, err := namespaceselector.ForClusterQueue(&apiCQ.Spec)
 	if err != nil {
 		return err
 	}
@@ -89,6 +92,13 @@ func (c *clusterQueueBase) AddFromLocalQueue(q *LocalQueue) bool {
 	defer c.rwm.Unlock()
 	added := false
 	for _, info := range q.items {
+		if len(q.FallbackClusterQueues) > 0 && q.queuedIn(info) != c.name {
+			continue
+		}
+		if workload.IsBackingOff(info.Obj) {
+			c.inadmissibleWorkloads[workload.Key(info.Obj)] = info
+			continue
+		}
 		if c.heap.PushIfNotPresent(info) {
 			added = true
 		}
@@ -107,13 +117,21 @@ func (c *clusterQueueBase) PushOrUpdate(wInfo *workload.Info) {
 		// which can affect the workloads order in the queue.
 		if equality.Semantic.DeepEqual(oldInfo.Obj.Spec, wInfo.Obj.Spec) &&
 			equality.Semantic.DeepEqual(apimeta.FindStatusCondition(oldInfo.Obj.Status.Conditions, kueue.WorkloadEvicted),
-				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadEvicted)) {
+				apimeta.FindStatusCondition(wInfo.Obj.Status.Conditions, kueue.WorkloadEvicted)) &&
+			equality.Semantic.DeepEqual(oldInfo.Obj.Status.RequeueState, wInfo.Obj.Status.RequeueState) {
 			c.inadmissibleWorkloads[key] = wInfo
 			return
 		}
 		// otherwise move or update in place in the queue.
 		delete(c.inadmissibleWorkloads, key)
 	}
+	if workload.IsBackingOff(wInfo.Obj) {
+		// The workload waits for its backoff as inadmissible, until the
+		// workload controller clears its requeueAt.
+		c.heap.Delete(key)
+		c.inadmissibleWorkloads[key] = wInfo
+		return
+	}
 	c.heap.PushOrUpdate(wInfo)
 }
 
@@ -180,19 +198,31 @@ func (c *clusterQueueBase) QueueInadmissibleWorkloads(ctx context.Context, clien
 	}
 
 	inadmissibleWorkloads := make(map[string]*workload.Info)
-	moved := false
+	// The namespace is checked once for all its workloads.
+	namespaceMatches := make(map[string]bool)
+	var toQueue []interface{}
 	for key, wInfo := range c.inadmissibleWorkloads {
-		ns := corev1.Namespace{}
-		err := client.Get(ctx, types.NamespacedName{Name: wInfo.Obj.Namespace}, &ns)
-		if err != nil || !c.namespaceSelector.Matches(labels.Set(ns.Labels)) {
+		if workload.IsBackingOff(wInfo.Obj) {
 			inadmissibleWorkloads[key] = wInfo
+			continue
+		}
+		matches, checked := namespaceMatches[wInfo.Obj.Namespace]
+		if !checked {
+			ns := metav1.PartialObjectMetadata{}
+			ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
+			err := client.Get(ctx, types.NamespacedName{Name: wInfo.Obj.Namespace}, &ns)
+			matches = err == nil && c.namespaceSelector.Matches(labels.Set(ns.Labels))
+			namespaceMatches[wInfo.Obj.Namespace] = matches
+		}
+		if matches {
+			toQueue = append(toQueue, wInfo)
 		} else {
-			moved = c.heap.PushIfNotPresent(wInfo) || moved
+			inadmissibleWorkloads[key] = wInfo
 		}
 	}
 
 	c.inadmissibleWorkloads = inadmissibleWorkloads
-	return moved
+	return c.heap.PushAllIfNotPresent(toQueue) > 0
 }
 
 func (c *clusterQueueBase) Pending() int {
@@ -56,6 +56,30 @@ func Test_PushOrUpdate(t *testing.T) {
 	}
 }
 
+func Test_PushOrUpdateBackingOff(t *testing.T) {
+	cq := newClusterQueueImpl(keyFunc, queueOrdering)
+	requeueAt := metav1.NewTime(time.Now().Add(time.Minute))
+	wl := utiltesting.MakeWorkload("workload-1", defaultNamespace).
+		RequeueState(1, &requeueAt).
+		Obj()
+	cq.PushOrUpdate(workload.NewInfo(wl))
+	if cq.PendingActive() != 0 || cq.PendingInadmissible() != 1 {
+		t.Fatalf("Workload backing off should be inadmissible, got %d active and %d inadmissible", cq.PendingActive(), cq.PendingInadmissible())
+	}
+
+	cl := utiltesting.NewFakeClient()
+	if cq.QueueInadmissibleWorkloads(context.Background(), cl) {
+		t.Errorf("Workload backing off should not be queued")
+	}
+
+	wl = wl.DeepCopy()
+	wl.Status.RequeueState.RequeueAt = nil
+	cq.PushOrUpdate(workload.NewInfo(wl))
+	if cq.PendingActive() != 1 || cq.PendingInadmissible() != 0 {
+		t.Errorf("Workload should be queued after its backoff, got %d active and %d inadmissible", cq.PendingActive(), cq.PendingInadmissible())
+	}
+}
+
 func Test_Pop(t *testing.T) {
 	cq := newClusterQueueImpl(keyFunc, queueOrdering)
 	now := time.Now()
@@ -18,11 +18,21 @@ package queue
 
 import (
 	"fmt"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
+// defaultFallbackAfterSeconds is how long a workload stays pending in a
+// ClusterQueue before it falls back to the next one, if not configured.
+const defaultFallbackAfterSeconds = 300
+
 func keyFunc(obj interface{}) string {
 	i := obj.(*workload.Info)
 	return workload.Key(i.Obj)
@@ -37,6 +47,11 @@ func Key(q *kueue.LocalQueue) string {
 type LocalQueue struct {
 	Key          string
 	ClusterQueue string
+	// FallbackClusterQueues are the ClusterQueues where the workloads are
+	// queued, in order, after they were pending for FallbackAfter in the
+	// previous one.
+	FallbackClusterQueues []string
+	FallbackAfter         time.Duration
 
 	items map[string]*workload.Info
 }
@@ -52,6 +67,57 @@ func newLocalQueue(q *kueue.LocalQueue) *LocalQueue {
 
 func (q *LocalQueue) update(apiQueue *kueue.LocalQueue) {
 	q.ClusterQueue = string(apiQueue.Spec.ClusterQueue)
+	q.FallbackClusterQueues = nil
+	if features.Enabled(features.LocalQueueFallback) {
+		for _, cq := range apiQueue.Spec.FallbackClusterQueues {
+			q.FallbackClusterQueues = append(q.FallbackClusterQueues, string(cq))
+		}
+	}
+	q.FallbackAfter = time.Duration(ptr.Deref(apiQueue.Spec.FallbackAfterSeconds, defaultFallbackAfterSeconds)) * time.Second
+}
+
+// clusterQueues returns the ClusterQueues where the workloads of the queue can
+// be queued, starting with the primary one.
+func (q *LocalQueue) clusterQueues() []string {
+	return append([]string{q.ClusterQueue}, q.FallbackClusterQueues...)
+}
+
+// queuedIn returns the ClusterQueue where the workload is queued.
+func (q *LocalQueue) queuedIn(info *workload.Info) string {
+	if len(q.FallbackClusterQueues) == 0 || info.ClusterQueue == "" {
+		return q.ClusterQueue
+	}
+	return info.ClusterQueue
+}
+
+// route sets the ClusterQueue where the pending workload is queued at the
+// given time, if the queue has fallback ClusterQueues. It returns how long until the workload falls back to the next
+// ClusterQueue, or 0 if there are no more ClusterQueues to fall back to.
+func (q *LocalQueue) route(info *workload.Info, now time.Time) time.Duration {
+	if len(q.FallbackClusterQueues) == 0 || q.FallbackAfter <= 0 {
+		return 0
+	}
+	pending := now.Sub(pendingSince(info.Obj).Time)
+	if pending < 0 {
+		pending = 0
+	}
+	step := int(pending / q.FallbackAfter)
+	cqs := q.clusterQueues()
+	if step >= len(cqs)-1 {
+		info.ClusterQueue = cqs[len(cqs)-1]
+		return 0
+	}
+	info.ClusterQueue = cqs[step]
+	return time.Duration(step+1)*q.FallbackAfter - pending
+}
+
+// pendingSince returns when the workload started waiting for quota, that is
+// when it was created or when it lost its last quota reservation.
+func pendingSince(w *kueue.Workload) *metav1.Time {
+	if c := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadQuotaReserved); c != nil && c.Status == metav1.ConditionFalse && c.LastTransitionTime.After(w.CreationTimestamp.Time) {
+		return &c.LastTransitionTime
+	}
+	return &w.CreationTimestamp
 }
 
 func (q *LocalQueue) AddOrUpdate(info *workload.Info) {
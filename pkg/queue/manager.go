@@ -20,11 +20,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -46,25 +52,80 @@ type Manager struct {
 
 	client        client.Client
 	statusChecker StatusChecker
+	clock         clock.Clock
 	clusterQueues map[string]ClusterQueue
 	localQueues   map[string]*LocalQueue
 
 	snapshotsMutex sync.RWMutex
 	snapshots      map[string][]kueue.ClusterQueuePendingWorkload
+	lqSnapshots    map[string][]kueue.LocalQueuePendingWorkload
+	// lqSnapshotParts are the pending workloads of each LocalQueue in each of
+	// its ClusterQueues, which are merged in the snapshot of the LocalQueue.
+	lqSnapshotParts map[string]map[string][]kueue.LocalQueuePendingWorkload
 
 	// Key is cohort's name. Value is a set of associated ClusterQueue names.
 	cohorts map[string]sets.Set[string]
+
+	workloadInfoOptions []workload.InfoOption
+}
+
+type options struct {
+	accountingPolicy        workload.AccountingPolicy
+	resourceTransformations workload.ResourceTransformations
+	initContainers          workload.InitContainersAccounting
+	ownersInitContainers    map[schema.GroupKind]workload.InitContainersAccounting
+}
+
+// Option configures the manager.
+type Option func(*options)
+
+// WithAccountingPolicy sets the policy used to account for the usage of the
+// resources of the pending workloads.
+func WithAccountingPolicy(p workload.AccountingPolicy) Option {
+	return func(o *options) {
+		o.accountingPolicy = p
+	}
+}
+
+// WithResourceTransformations sets the transformations applied to the
+// requests of the pending workloads.
+func WithResourceTransformations(t workload.ResourceTransformations) Option {
+	return func(o *options) {
+		o.resourceTransformations = t
+	}
+}
+
+// WithInitContainersAccounting sets how the requests of the init containers
+// account for the usage of the pending workloads, by default and for the
+// workloads whose controller is of the kinds in byOwner.
+func WithInitContainersAccounting(defaultAccounting workload.InitContainersAccounting, byOwner map[schema.GroupKind]workload.InitContainersAccounting) Option {
+	return func(o *options) {
+		o.initContainers = defaultAccounting
+		o.ownersInitContainers = byOwner
+	}
 }
 
-func NewManager(client client.Client, checker StatusChecker) *Manager {
+func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Manager {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
 	m := &Manager{
-		client:         client,
-		statusChecker:  checker,
-		localQueues:    make(map[string]*LocalQueue),
-		clusterQueues:  make(map[string]ClusterQueue),
-		cohorts:        make(map[string]sets.Set[string]),
-		snapshotsMutex: sync.RWMutex{},
-		snapshots:      make(map[string][]kueue.ClusterQueuePendingWorkload, 0),
+		client:          client,
+		statusChecker:   checker,
+		clock:           clock.RealClock{},
+		localQueues:     make(map[string]*LocalQueue),
+		clusterQueues:   make(map[string]ClusterQueue),
+		cohorts:         make(map[string]sets.Set[string]),
+		snapshotsMutex:  sync.RWMutex{},
+		snapshots:       make(map[string][]kueue.ClusterQueuePendingWorkload, 0),
+		lqSnapshots:     make(map[string][]kueue.LocalQueuePendingWorkload, 0),
+		lqSnapshotParts: make(map[string]map[string][]kueue.LocalQueuePendingWorkload, 0),
+		workloadInfoOptions: []workload.InfoOption{
+			workload.WithAccountingPolicy(options.accountingPolicy),
+			workload.WithResourceTransformations(options.resourceTransformations),
+			workload.WithInitContainersAccounting(options.initContainers, options.ownersInitContainers),
+		},
 	}
 	m.cond.L = &m.RWMutex
 	return m
@@ -103,6 +164,12 @@ func (m *Manager) AddClusterQueue(ctx context.Context, cq *kueue.ClusterQueue) e
 			addedWorkloads = addedWorkloads || added
 		}
 	}
+	for _, qImpl := range m.localQueues {
+		if slices.Contains(qImpl.FallbackClusterQueues, cq.Name) {
+			added := cqImpl.AddFromLocalQueue(qImpl)
+			addedWorkloads = addedWorkloads || added
+		}
+	}
 
 	queued := m.queueAllInadmissibleWorkloadsInCohort(ctx, cqImpl)
 	m.reportPendingWorkloads(cq.Name, cqImpl)
@@ -176,10 +243,11 @@ func (m *Manager) AddLocalQueue(ctx context.Context, q *kueue.LocalQueue) error
 			continue
 		}
 		workload.AdjustResources(ctx, m.client, &w)
-		qImpl.AddOrUpdate(workload.NewInfo(&w))
+		info := workload.NewInfo(&w, m.workloadInfoOptions...)
+		qImpl.route(info, m.clock.Now())
+		qImpl.AddOrUpdate(info)
 	}
-	cq := m.clusterQueues[qImpl.ClusterQueue]
-	if cq != nil && cq.AddFromLocalQueue(qImpl) {
+	if m.addFromLocalQueue(qImpl) {
 		m.Broadcast()
 	}
 	return nil
@@ -192,20 +260,38 @@ func (m *Manager) UpdateLocalQueue(q *kueue.LocalQueue) error {
 	if !ok {
 		return errQueueDoesNotExist
 	}
-	if qImpl.ClusterQueue != string(q.Spec.ClusterQueue) {
-		oldCQ := m.clusterQueues[qImpl.ClusterQueue]
-		if oldCQ != nil {
-			oldCQ.DeleteFromLocalQueue(qImpl)
+	oldCQs := qImpl.clusterQueues()
+	qImpl.update(q)
+	if !slices.Equal(oldCQs, qImpl.clusterQueues()) {
+		for _, cqName := range oldCQs {
+			if oldCQ := m.clusterQueues[cqName]; oldCQ != nil {
+				oldCQ.DeleteFromLocalQueue(qImpl)
+			}
+		}
+		now := m.clock.Now()
+		for _, info := range qImpl.items {
+			qImpl.route(info, now)
 		}
-		newCQ := m.clusterQueues[string(q.Spec.ClusterQueue)]
-		if newCQ != nil && newCQ.AddFromLocalQueue(qImpl) {
+		if m.addFromLocalQueue(qImpl) {
 			m.Broadcast()
 		}
 	}
-	qImpl.update(q)
 	return nil
 }
 
+// addFromLocalQueue pushes the workloads of the LocalQueue to the
+// ClusterQueues where they are queued. It returns whether any workload was
+// added.
+func (m *Manager) addFromLocalQueue(q *LocalQueue) bool {
+	added := false
+	for _, cqName := range q.clusterQueues() {
+		if cq := m.clusterQueues[cqName]; cq != nil && cq.AddFromLocalQueue(q) {
+			added = true
+		}
+	}
+	return added
+}
+
 func (m *Manager) DeleteLocalQueue(q *kueue.LocalQueue) {
 	m.Lock()
 	defer m.Unlock()
@@ -214,11 +300,13 @@ func (m *Manager) DeleteLocalQueue(q *kueue.LocalQueue) {
 	if qImpl == nil {
 		return
 	}
-	cq := m.clusterQueues[qImpl.ClusterQueue]
-	if cq != nil {
-		cq.DeleteFromLocalQueue(qImpl)
+	for _, cqName := range qImpl.clusterQueues() {
+		if cq := m.clusterQueues[cqName]; cq != nil {
+			cq.DeleteFromLocalQueue(qImpl)
+		}
 	}
 	delete(m.localQueues, key)
+	m.deleteLocalQueueSnapshot(key)
 }
 
 func (m *Manager) PendingWorkloads(q *kueue.LocalQueue) (int32, error) {
@@ -247,7 +335,8 @@ func (m *Manager) QueueForWorkloadExists(wl *kueue.Workload) bool {
 }
 
 // ClusterQueueForWorkload returns the name of the ClusterQueue where the
-// workload should be queued and whether it exists.
+// workload should be queued and whether it exists. For a workload with quota
+// reservation, it's the ClusterQueue that reserved the quota.
 // Returns empty string if the queue doesn't exist.
 func (m *Manager) ClusterQueueForWorkload(wl *kueue.Workload) (string, bool) {
 	m.RLock()
@@ -256,8 +345,62 @@ func (m *Manager) ClusterQueueForWorkload(wl *kueue.Workload) (string, bool) {
 	if !ok {
 		return "", false
 	}
-	_, ok = m.clusterQueues[q.ClusterQueue]
-	return q.ClusterQueue, ok
+	cqName := m.clusterQueueOf(q, wl)
+	_, ok = m.clusterQueues[cqName]
+	return cqName, ok
+}
+
+// clusterQueueOf returns the ClusterQueue of the LocalQueue where the workload
+// reserved quota, or where it's queued.
+func (m *Manager) clusterQueueOf(q *LocalQueue, wl *kueue.Workload) string {
+	if len(q.FallbackClusterQueues) == 0 {
+		return q.ClusterQueue
+	}
+	if workload.HasQuotaReservation(wl) && wl.Status.Admission != nil {
+		return string(wl.Status.Admission.ClusterQueue)
+	}
+	if info, found := q.items[workload.Key(wl)]; found {
+		return q.queuedIn(info)
+	}
+	info := workload.Info{Obj: wl}
+	q.route(&info, m.clock.Now())
+	return q.queuedIn(&info)
+}
+
+// RouteWorkload moves the pending workload to the ClusterQueue of its
+// LocalQueue where it should be queued now, if the LocalQueue has fallback
+// ClusterQueues. It returns how long until the workload falls back to the next
+// ClusterQueue, or 0 if it doesn't.
+func (m *Manager) RouteWorkload(wl *kueue.Workload) time.Duration {
+	m.Lock()
+	defer m.Unlock()
+	q := m.localQueues[workload.QueueKey(wl)]
+	if q == nil || len(q.FallbackClusterQueues) == 0 {
+		return 0
+	}
+	info, found := q.items[workload.Key(wl)]
+	if !found {
+		return 0
+	}
+	prevCQ := q.queuedIn(info)
+	wait := q.route(info, m.clock.Now())
+	if cqName := q.queuedIn(info); cqName != prevCQ {
+		m.moveWorkload(info, prevCQ, cqName)
+	}
+	return wait
+}
+
+// moveWorkload moves the workload between ClusterQueues.
+func (m *Manager) moveWorkload(info *workload.Info, fromCQ, toCQ string) {
+	if cq := m.clusterQueues[fromCQ]; cq != nil {
+		cq.Delete(info.Obj)
+		m.reportPendingWorkloads(fromCQ, cq)
+	}
+	if cq := m.clusterQueues[toCQ]; cq != nil {
+		cq.PushOrUpdate(info)
+		m.reportPendingWorkloads(toCQ, cq)
+		m.Broadcast()
+	}
 }
 
 // AddOrUpdateWorkload adds or updates workload to the corresponding queue.
@@ -274,14 +417,26 @@ func (m *Manager) addOrUpdateWorkload(w *kueue.Workload) bool {
 	if q == nil {
 		return false
 	}
-	wInfo := workload.NewInfo(w)
+	wInfo := workload.NewInfo(w, m.workloadInfoOptions...)
+	prevCQ := q.ClusterQueue
+	if prev, found := q.items[workload.Key(w)]; found {
+		prevCQ = q.queuedIn(prev)
+	}
+	q.route(wInfo, m.clock.Now())
 	q.AddOrUpdate(wInfo)
-	cq := m.clusterQueues[q.ClusterQueue]
+	cqName := q.queuedIn(wInfo)
+	if cqName != prevCQ {
+		if cq := m.clusterQueues[prevCQ]; cq != nil {
+			cq.Delete(w)
+			m.reportPendingWorkloads(prevCQ, cq)
+		}
+	}
+	cq := m.clusterQueues[cqName]
 	if cq == nil {
 		return false
 	}
 	cq.PushOrUpdate(wInfo)
-	m.reportPendingWorkloads(q.ClusterQueue, cq)
+	m.reportPendingWorkloads(cqName, cq)
 	m.Broadcast()
 	return true
 }
@@ -306,14 +461,23 @@ func (m *Manager) RequeueWorkload(ctx context.Context, info *workload.Info, reas
 		return false
 	}
 	info.Update(&w)
+	prevCQ := q.queuedIn(info)
+	q.route(info, m.clock.Now())
 	q.AddOrUpdate(info)
-	cq := m.clusterQueues[q.ClusterQueue]
+	cqName := q.queuedIn(info)
+	if cqName != prevCQ {
+		// The workload falls back to another ClusterQueue, where it wasn't
+		// tried yet.
+		m.moveWorkload(info, prevCQ, cqName)
+		return m.clusterQueues[cqName] != nil
+	}
+	cq := m.clusterQueues[cqName]
 	if cq == nil {
 		return false
 	}
 
 	added := cq.RequeueIfNotPresent(info, reason)
-	m.reportPendingWorkloads(q.ClusterQueue, cq)
+	m.reportPendingWorkloads(cqName, cq)
 	if added {
 		m.Broadcast()
 	}
@@ -332,10 +496,11 @@ func (m *Manager) deleteWorkloadFromQueueAndClusterQueue(w *kueue.Workload, qKey
 		return
 	}
 	delete(q.items, workload.Key(w))
-	cq := m.clusterQueues[q.ClusterQueue]
-	if cq != nil {
-		cq.Delete(w)
-		m.reportPendingWorkloads(q.ClusterQueue, cq)
+	for _, cqName := range q.clusterQueues() {
+		if cq := m.clusterQueues[cqName]; cq != nil {
+			cq.Delete(w)
+			m.reportPendingWorkloads(cqName, cq)
+		}
 	}
 }
 
@@ -356,7 +521,7 @@ func (m *Manager) QueueAssociatedInadmissibleWorkloadsAfter(ctx context.Context,
 	if q == nil {
 		return
 	}
-	cq := m.clusterQueues[q.ClusterQueue]
+	cq := m.clusterQueues[m.clusterQueueOf(q, w)]
 	if cq == nil {
 		return
 	}
@@ -497,27 +662,45 @@ func (m *Manager) DumpInadmissible() map[string]sets.Set[string] {
 	return dump
 }
 
+// PopHead removes the head of the ClusterQueue and returns it. Unlike Heads,
+// it doesn't block if the ClusterQueue is empty.
+func (m *Manager) PopHead(cqName string) (workload.Info, bool) {
+	m.Lock()
+	defer m.Unlock()
+	cq := m.clusterQueues[cqName]
+	if cq == nil {
+		return workload.Info{}, false
+	}
+	return m.popHead(cqName, cq)
+}
+
 func (m *Manager) heads() []workload.Info {
 	var workloads []workload.Info
 	for cqName, cq := range m.clusterQueues {
-		// Cache might be nil in tests, if cache is nil, we'll skip the check.
-		if m.statusChecker != nil && !m.statusChecker.ClusterQueueActive(cqName) {
-			continue
-		}
-		wl := cq.Pop()
-		if wl == nil {
-			continue
+		if wl, found := m.popHead(cqName, cq); found {
+			workloads = append(workloads, wl)
 		}
-		m.reportPendingWorkloads(cqName, cq)
-		wlCopy := *wl
-		wlCopy.ClusterQueue = cqName
-		workloads = append(workloads, wlCopy)
-		q := m.localQueues[workload.QueueKey(wl.Obj)]
-		delete(q.items, workload.Key(wl.Obj))
 	}
 	return workloads
 }
 
+func (m *Manager) popHead(cqName string, cq ClusterQueue) (workload.Info, bool) {
+	// Cache might be nil in tests, if cache is nil, we'll skip the check.
+	if m.statusChecker != nil && !m.statusChecker.ClusterQueueActive(cqName) {
+		return workload.Info{}, false
+	}
+	wl := cq.Pop()
+	if wl == nil {
+		return workload.Info{}, false
+	}
+	m.reportPendingWorkloads(cqName, cq)
+	wlCopy := *wl
+	wlCopy.ClusterQueue = cqName
+	q := m.localQueues[workload.QueueKey(wl.Obj)]
+	delete(q.items, workload.Key(wl.Obj))
+	return wlCopy, true
+}
+
 func (m *Manager) addCohort(cohort string, cqName string) {
 	if m.cohorts[cohort] == nil {
 		m.cohorts[cohort] = make(sets.Set[string])
@@ -587,32 +770,134 @@ func (m *Manager) ClusterQueueFromLocalQueue(lqName string) (string, error) {
 	return "", errQueueDoesNotExist
 }
 
-// UpdateSnapshot computes the new snapshot and replaces if it differs from the
-// previous version. It returns true if the snapshot was actually updated.
-func (m *Manager) UpdateSnapshot(cqName string, maxCount int32) bool {
+// SnapshotOptions controls the content of the snapshots of pending workloads.
+type SnapshotOptions struct {
+	// ClusterQueueMaxCount is the maximal number of workloads in the
+	// ClusterQueue snapshot.
+	ClusterQueueMaxCount int32
+	// LocalQueueMaxCount is the maximal number of workloads in the snapshot of
+	// each LocalQueue. When 0, the LocalQueue snapshots are not taken.
+	LocalQueueMaxCount int32
+	// IncludePositions indicates whether the positions of the workloads in the
+	// ClusterQueue and in their LocalQueue are recorded.
+	IncludePositions bool
+}
+
+// UpdateSnapshot computes the new snapshots of the ClusterQueue and of its
+// LocalQueues and replaces the ones that differ from the previous version.
+// The LocalQueues of the ClusterQueue include the ones that fall back to it,
+// and their snapshots merge the pending workloads in all their ClusterQueues.
+// It returns true if the ClusterQueue snapshot was actually updated, and the
+// keys of the LocalQueues whose snapshot was actually updated.
+func (m *Manager) UpdateSnapshot(cqName string, opts SnapshotOptions) (bool, []string) {
 	cq := m.getClusterQueue(cqName)
 	if cq == nil {
-		return false
+		return false, nil
 	}
 	newSnapshot := make([]kueue.ClusterQueuePendingWorkload, 0)
+	var newLqSnapshots map[string][]kueue.LocalQueuePendingWorkload
+	var lqClusterQueues map[string][]string
+	if opts.LocalQueueMaxCount > 0 {
+		newLqSnapshots = make(map[string][]kueue.LocalQueuePendingWorkload)
+		lqClusterQueues = m.localQueuesInClusterQueue(cqName)
+		for key := range lqClusterQueues {
+			newLqSnapshots[key] = make([]kueue.LocalQueuePendingWorkload, 0)
+		}
+	}
+	lqPositions := make(map[string]int32)
 	for index, info := range cq.Snapshot() {
-		if int32(index) >= maxCount {
+		if int32(index) >= opts.ClusterQueueMaxCount && newLqSnapshots == nil {
 			break
 		}
 		if info == nil {
 			continue
 		}
-		newSnapshot = append(newSnapshot, kueue.ClusterQueuePendingWorkload{
-			Name:      info.Obj.Name,
-			Namespace: info.Obj.Namespace,
-		})
+		lqKey := workload.QueueKey(info.Obj)
+		cqPosition, lqPosition := int32(index), lqPositions[lqKey]
+		lqPositions[lqKey]++
+		if cqPosition < opts.ClusterQueueMaxCount {
+			pending := kueue.ClusterQueuePendingWorkload{
+				Name:      info.Obj.Name,
+				Namespace: info.Obj.Namespace,
+			}
+			if opts.IncludePositions {
+				pending.PositionInClusterQueue = ptr.To(cqPosition)
+				pending.PositionInLocalQueue = ptr.To(lqPosition)
+			}
+			newSnapshot = append(newSnapshot, pending)
+		}
+		if lqSnapshot, found := newLqSnapshots[lqKey]; found && lqPosition < opts.LocalQueueMaxCount {
+			pending := kueue.LocalQueuePendingWorkload{
+				Name: info.Obj.Name,
+			}
+			if opts.IncludePositions {
+				pending.PositionInClusterQueue = ptr.To(cqPosition)
+				pending.PositionInLocalQueue = ptr.To(lqPosition)
+			}
+			newLqSnapshots[lqKey] = append(lqSnapshot, pending)
+		}
 	}
+	var updatedLqs []string
+	for lqKey, lqSnapshotPart := range newLqSnapshots {
+		lqSnapshot := m.mergeLocalQueueSnapshot(lqKey, cqName, lqSnapshotPart, lqClusterQueues[lqKey], opts)
+		if prev, found := m.GetLocalQueueSnapshot(lqKey); !found || !equality.Semantic.DeepEqual(prev, lqSnapshot) {
+			m.setLocalQueueSnapshot(lqKey, lqSnapshot)
+			updatedLqs = append(updatedLqs, lqKey)
+		}
+	}
+	sort.Strings(updatedLqs)
 	prevSnapshot := m.GetSnapshot(cqName)
 	if !equality.Semantic.DeepEqual(prevSnapshot, newSnapshot) {
 		m.setSnapshot(cqName, newSnapshot)
-		return true
+		return true, updatedLqs
+	}
+	return false, updatedLqs
+}
+
+// localQueuesInClusterQueue returns the ClusterQueues of the LocalQueues where
+// the workloads can be queued in the ClusterQueue, indexed by the keys of the
+// LocalQueues.
+func (m *Manager) localQueuesInClusterQueue(cqName string) map[string][]string {
+	m.RLock()
+	defer m.RUnlock()
+	queues := make(map[string][]string)
+	for key, q := range m.localQueues {
+		if cqs := q.clusterQueues(); slices.Contains(cqs, cqName) {
+			queues[key] = cqs
+		}
+	}
+	return queues
+}
+
+// mergeLocalQueueSnapshot records the pending workloads of the LocalQueue in
+// the ClusterQueue, and returns the snapshot of the LocalQueue with its
+// pending workloads in all its ClusterQueues, in the order of the
+// ClusterQueues.
+func (m *Manager) mergeLocalQueueSnapshot(lqKey, cqName string, part []kueue.LocalQueuePendingWorkload, cqs []string, opts SnapshotOptions) []kueue.LocalQueuePendingWorkload {
+	m.snapshotsMutex.Lock()
+	defer m.snapshotsMutex.Unlock()
+	parts := m.lqSnapshotParts[lqKey]
+	if parts == nil {
+		parts = make(map[string][]kueue.LocalQueuePendingWorkload)
+		m.lqSnapshotParts[lqKey] = parts
+	}
+	parts[cqName] = part
+	if len(cqs) == 1 {
+		return part
+	}
+	merged := make([]kueue.LocalQueuePendingWorkload, 0, len(part))
+	for _, name := range cqs {
+		for _, pending := range parts[name] {
+			if int32(len(merged)) >= opts.LocalQueueMaxCount {
+				return merged
+			}
+			if opts.IncludePositions {
+				pending.PositionInLocalQueue = ptr.To(int32(len(merged)))
+			}
+			merged = append(merged, pending)
+		}
 	}
-	return false
+	return merged
 }
 
 func (m *Manager) setSnapshot(cqName string, workloads []kueue.ClusterQueuePendingWorkload) {
@@ -632,3 +917,25 @@ func (m *Manager) DeleteSnapshot(cq *kueue.ClusterQueue) {
 	defer m.snapshotsMutex.Unlock()
 	delete(m.snapshots, cq.Name)
 }
+
+func (m *Manager) setLocalQueueSnapshot(lqKey string, workloads []kueue.LocalQueuePendingWorkload) {
+	m.snapshotsMutex.Lock()
+	defer m.snapshotsMutex.Unlock()
+	m.lqSnapshots[lqKey] = workloads
+}
+
+// GetLocalQueueSnapshot returns the snapshot of the pending workloads in the
+// LocalQueue and whether a snapshot was taken.
+func (m *Manager) GetLocalQueueSnapshot(lqKey string) ([]kueue.LocalQueuePendingWorkload, bool) {
+	m.snapshotsMutex.RLock()
+	defer m.snapshotsMutex.RUnlock()
+	snapshot, found := m.lqSnapshots[lqKey]
+	return snapshot, found
+}
+
+func (m *Manager) deleteLocalQueueSnapshot(lqKey string) {
+	m.snapshotsMutex.Lock()
+	defer m.snapshotsMutex.Unlock()
+	delete(m.lqSnapshots, lqKey)
+	delete(m.lqSnapshotParts, lqKey)
+}
@@ -30,9 +30,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -297,6 +300,84 @@ func TestUpdateLocalQueue(t *testing.T) {
 	}
 }
 
+// TestLocalQueueFallback tests that the pending workloads fall back to the
+// next ClusterQueue of their LocalQueue after the configured time.
+func TestLocalQueueFallback(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	cases := map[string]struct {
+		enableFallback bool
+		elapsed        time.Duration
+		wantWorkloads  map[string]sets.Set[string]
+		wantCQ         string
+		wantWait       time.Duration
+	}{
+		"pending in the primary ClusterQueue": {
+			enableFallback: true,
+			elapsed:        30 * time.Second,
+			wantWorkloads: map[string]sets.Set[string]{
+				"cq1": sets.New("/a"),
+			},
+			wantCQ:   "cq1",
+			wantWait: 30 * time.Second,
+		},
+		"falls back to the first fallback ClusterQueue": {
+			enableFallback: true,
+			elapsed:        90 * time.Second,
+			wantWorkloads: map[string]sets.Set[string]{
+				"cq2": sets.New("/a"),
+			},
+			wantCQ:   "cq2",
+			wantWait: 30 * time.Second,
+		},
+		"stays in the last fallback ClusterQueue": {
+			enableFallback: true,
+			elapsed:        10 * time.Minute,
+			wantWorkloads: map[string]sets.Set[string]{
+				"cq3": sets.New("/a"),
+			},
+			wantCQ: "cq3",
+		},
+		"feature disabled": {
+			elapsed: 10 * time.Minute,
+			wantWorkloads: map[string]sets.Set[string]{
+				"cq1": sets.New("/a"),
+			},
+			wantCQ: "cq1",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer features.SetFeatureGateDuringTest(t, features.LocalQueueFallback, tc.enableFallback)()
+			ctx := context.Background()
+			manager := NewManager(utiltesting.NewFakeClient(), nil)
+			fakeClock := testingclock.NewFakeClock(now)
+			manager.clock = fakeClock
+			for _, name := range []string{"cq1", "cq2", "cq3"} {
+				if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(name).Obj()); err != nil {
+					t.Fatalf("Failed adding clusterQueue %s: %v", name, err)
+				}
+			}
+			q := utiltesting.MakeLocalQueue("foo", "").ClusterQueue("cq1").FallbackClusterQueues("cq2", "cq3").FallbackAfter(60).Obj()
+			if err := manager.AddLocalQueue(ctx, q); err != nil {
+				t.Fatalf("Failed adding queue: %v", err)
+			}
+			wl := utiltesting.MakeWorkload("a", "").Queue("foo").Creation(now).Obj()
+			manager.AddOrUpdateWorkload(wl)
+
+			fakeClock.Step(tc.elapsed)
+			if diff := cmp.Diff(tc.wantWait, manager.RouteWorkload(wl)); diff != "" {
+				t.Errorf("Unexpected wait to fall back (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, manager.Dump()); diff != "" {
+				t.Errorf("Unexpected active workloads (-want,+got):\n%s", diff)
+			}
+			if cq, _ := manager.ClusterQueueForWorkload(wl); cq != tc.wantCQ {
+				t.Errorf("ClusterQueueForWorkload() = %q, want %q", cq, tc.wantCQ)
+			}
+		})
+	}
+}
+
 // TestDeleteLocalQueue tests that when a LocalQueue is deleted, all its
 // workloads are not listed in the ClusterQueue.
 func TestDeleteLocalQueue(t *testing.T) {
@@ -860,6 +941,53 @@ func TestHeads(t *testing.T) {
 	}
 }
 
+func TestPopHead(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), headsTimeout)
+	defer cancel()
+	now := time.Now().Truncate(time.Second)
+	manager := NewManager(utiltesting.NewFakeClient(), &fakeStatusChecker{})
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("active-fooCq").Obj(),
+		utiltesting.MakeClusterQueue("pending-barCq").Obj(),
+	} {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding clusterQueue %s to manager: %v", cq.Name, err)
+		}
+	}
+	for _, q := range []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("foo", "").ClusterQueue("active-fooCq").Obj(),
+		utiltesting.MakeLocalQueue("bar", "").ClusterQueue("pending-barCq").Obj(),
+	} {
+		if err := manager.AddLocalQueue(ctx, q); err != nil {
+			t.Fatalf("Failed adding queue %s: %s", q.Name, err)
+		}
+	}
+	manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("a1", "").Creation(now).Queue("foo").Obj())
+	manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("a2", "").Creation(now.Add(time.Hour)).Queue("foo").Obj())
+	manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("b", "").Creation(now).Queue("bar").Obj())
+
+	var got []string
+	for {
+		wl, found := manager.PopHead("active-fooCq")
+		if !found {
+			break
+		}
+		if wl.ClusterQueue != "active-fooCq" {
+			t.Errorf("PopHead returned workload %s with ClusterQueue %q", wl.Obj.Name, wl.ClusterQueue)
+		}
+		got = append(got, wl.Obj.Name)
+	}
+	if diff := cmp.Diff([]string{"a1", "a2"}, got); diff != "" {
+		t.Errorf("PopHead returned wrong workloads (-want,+got):\n%s", diff)
+	}
+	if wl, found := manager.PopHead("pending-barCq"); found {
+		t.Errorf("PopHead returned workload %s from an inactive ClusterQueue", wl.Obj.Name)
+	}
+	if _, found := manager.PopHead("missing"); found {
+		t.Error("PopHead returned a workload from a missing ClusterQueue")
+	}
+}
+
 var ignoreTypeMeta = cmpopts.IgnoreTypes(metav1.TypeMeta{})
 
 // TestHeadAsync ensures that Heads call is blocked until the queues are filled
@@ -1199,3 +1327,152 @@ func TestGetPendingWorkloadsInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateSnapshot(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		opts            SnapshotOptions
+		wantCqSnapshot  []kueue.ClusterQueuePendingWorkload
+		wantLqSnapshots map[string][]kueue.LocalQueuePendingWorkload
+		wantUpdatedLqs  []string
+	}{
+		"only cluster queue": {
+			opts: SnapshotOptions{ClusterQueueMaxCount: 2},
+			wantCqSnapshot: []kueue.ClusterQueuePendingWorkload{
+				{Name: "a", Namespace: "ns"},
+				{Name: "b", Namespace: "ns"},
+			},
+			wantLqSnapshots: map[string][]kueue.LocalQueuePendingWorkload{},
+		},
+		"cluster and local queues": {
+			opts: SnapshotOptions{ClusterQueueMaxCount: 10, LocalQueueMaxCount: 1},
+			wantCqSnapshot: []kueue.ClusterQueuePendingWorkload{
+				{Name: "a", Namespace: "ns"},
+				{Name: "b", Namespace: "ns"},
+				{Name: "c", Namespace: "ns"},
+			},
+			wantLqSnapshots: map[string][]kueue.LocalQueuePendingWorkload{
+				"ns/foo": {{Name: "a"}},
+				"ns/bar": {{Name: "b"}},
+				"ns/baz": {},
+			},
+			wantUpdatedLqs: []string{"ns/bar", "ns/baz", "ns/foo"},
+		},
+		"local queues with positions": {
+			opts: SnapshotOptions{ClusterQueueMaxCount: 1, LocalQueueMaxCount: 2, IncludePositions: true},
+			wantCqSnapshot: []kueue.ClusterQueuePendingWorkload{
+				{Name: "a", Namespace: "ns", PositionInClusterQueue: ptr.To[int32](0), PositionInLocalQueue: ptr.To[int32](0)},
+			},
+			wantLqSnapshots: map[string][]kueue.LocalQueuePendingWorkload{
+				"ns/foo": {
+					{Name: "a", PositionInClusterQueue: ptr.To[int32](0), PositionInLocalQueue: ptr.To[int32](0)},
+					{Name: "c", PositionInClusterQueue: ptr.To[int32](2), PositionInLocalQueue: ptr.To[int32](1)},
+				},
+				"ns/bar": {
+					{Name: "b", PositionInClusterQueue: ptr.To[int32](1), PositionInLocalQueue: ptr.To[int32](0)},
+				},
+				"ns/baz": {},
+			},
+			wantUpdatedLqs: []string{"ns/bar", "ns/baz", "ns/foo"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			manager := NewManager(utiltesting.NewFakeClient(), nil)
+			if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue("cq").Obj()); err != nil {
+				t.Fatalf("Failed adding clusterQueue: %v", err)
+			}
+			for _, lq := range []string{"foo", "bar", "baz"} {
+				if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lq, "ns").ClusterQueue("cq").Obj()); err != nil {
+					t.Fatalf("Failed adding queue %s: %v", lq, err)
+				}
+			}
+			manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("a", "ns").Queue("foo").Creation(now).Obj())
+			manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("b", "ns").Queue("bar").Creation(now.Add(time.Second)).Obj())
+			manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("c", "ns").Queue("foo").Creation(now.Add(2 * time.Second)).Obj())
+
+			cqUpdated, updatedLqs := manager.UpdateSnapshot("cq", tc.opts)
+			if !cqUpdated {
+				t.Error("Expected the ClusterQueue snapshot to be updated")
+			}
+			if diff := cmp.Diff(tc.wantUpdatedLqs, updatedLqs); diff != "" {
+				t.Errorf("Unexpected updated LocalQueues (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantCqSnapshot, manager.GetSnapshot("cq")); diff != "" {
+				t.Errorf("Unexpected ClusterQueue snapshot (-want,+got):\n%s", diff)
+			}
+			gotLqSnapshots := make(map[string][]kueue.LocalQueuePendingWorkload)
+			for _, lq := range []string{"ns/foo", "ns/bar", "ns/baz"} {
+				if snapshot, found := manager.GetLocalQueueSnapshot(lq); found {
+					gotLqSnapshots[lq] = snapshot
+				}
+			}
+			if diff := cmp.Diff(tc.wantLqSnapshots, gotLqSnapshots); diff != "" {
+				t.Errorf("Unexpected LocalQueue snapshots (-want,+got):\n%s", diff)
+			}
+
+			cqUpdated, updatedLqs = manager.UpdateSnapshot("cq", tc.opts)
+			if cqUpdated || len(updatedLqs) != 0 {
+				t.Errorf("Unexpected update of unchanged snapshots, clusterQueue: %t, localQueues: %v", cqUpdated, updatedLqs)
+			}
+		})
+	}
+}
+
+// TestUpdateSnapshotLocalQueueFallback tests that the snapshot of a LocalQueue
+// merges its pending workloads in the primary and the fallback ClusterQueues.
+func TestUpdateSnapshotLocalQueueFallback(t *testing.T) {
+	defer features.SetFeatureGateDuringTest(t, features.LocalQueueFallback, true)()
+	now := time.Now().Truncate(time.Second)
+	ctx := context.Background()
+	manager := NewManager(utiltesting.NewFakeClient(), nil)
+	manager.clock = testingclock.NewFakeClock(now)
+	for _, name := range []string{"cq1", "cq2"} {
+		if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(name).Obj()); err != nil {
+			t.Fatalf("Failed adding clusterQueue %s: %v", name, err)
+		}
+	}
+	if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("cq1").FallbackClusterQueues("cq2").FallbackAfter(60).Obj()); err != nil {
+		t.Fatalf("Failed adding queue foo: %v", err)
+	}
+	if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue("bar", "ns").ClusterQueue("cq2").Obj()); err != nil {
+		t.Fatalf("Failed adding queue bar: %v", err)
+	}
+	manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("a", "ns").Queue("foo").Creation(now.Add(-90 * time.Second)).Obj())
+	manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("b", "ns").Queue("foo").Creation(now).Obj())
+	manager.AddOrUpdateWorkload(utiltesting.MakeWorkload("c", "ns").Queue("bar").Creation(now.Add(-100 * time.Second)).Obj())
+	opts := SnapshotOptions{ClusterQueueMaxCount: 10, LocalQueueMaxCount: 10, IncludePositions: true}
+
+	if _, updatedLqs := manager.UpdateSnapshot("cq1", opts); !cmp.Equal(updatedLqs, []string{"ns/foo"}) {
+		t.Errorf("Unexpected updated LocalQueues for cq1: %v", updatedLqs)
+	}
+	if _, updatedLqs := manager.UpdateSnapshot("cq2", opts); !cmp.Equal(updatedLqs, []string{"ns/bar", "ns/foo"}) {
+		t.Errorf("Unexpected updated LocalQueues for cq2: %v", updatedLqs)
+	}
+	wantLqSnapshots := map[string][]kueue.LocalQueuePendingWorkload{
+		"ns/foo": {
+			{Name: "b", PositionInClusterQueue: ptr.To[int32](0), PositionInLocalQueue: ptr.To[int32](0)},
+			{Name: "a", PositionInClusterQueue: ptr.To[int32](1), PositionInLocalQueue: ptr.To[int32](1)},
+		},
+		"ns/bar": {
+			{Name: "c", PositionInClusterQueue: ptr.To[int32](0), PositionInLocalQueue: ptr.To[int32](0)},
+		},
+	}
+	gotLqSnapshots := make(map[string][]kueue.LocalQueuePendingWorkload)
+	for _, lq := range []string{"ns/foo", "ns/bar"} {
+		if snapshot, found := manager.GetLocalQueueSnapshot(lq); found {
+			gotLqSnapshots[lq] = snapshot
+		}
+	}
+	if diff := cmp.Diff(wantLqSnapshots, gotLqSnapshots); diff != "" {
+		t.Errorf("Unexpected LocalQueue snapshots (-want,+got):\n%s", diff)
+	}
+
+	for _, cq := range []string{"cq1", "cq2"} {
+		if cqUpdated, updatedLqs := manager.UpdateSnapshot(cq, opts); cqUpdated || len(updatedLqs) != 0 {
+			t.Errorf("Unexpected update of unchanged snapshots, clusterQueue %s: %t, localQueues: %v", cq, cqUpdated, updatedLqs)
+		}
+	}
+}
@@ -19,13 +19,16 @@ package flavorassigner
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
@@ -35,6 +38,8 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/util/resourceflavor"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -96,6 +101,22 @@ func (a *Assignment) Message() string {
 	return builder.String()
 }
 
+// Inadmissibility returns the structured reasons why the pod sets couldn't be
+// assigned flavors.
+func (a *Assignment) Inadmissibility() []kueue.PodSetInadmissibility {
+	var ret []kueue.PodSetInadmissibility
+	for _, ps := range a.PodSets {
+		if ps.Status == nil || len(ps.Status.flavors) == 0 {
+			continue
+		}
+		ret = append(ret, kueue.PodSetInadmissibility{
+			Name:    ps.Name,
+			Flavors: ps.Status.flavors,
+		})
+	}
+	return ret
+}
+
 func (a *Assignment) ToAPI() []kueue.PodSetAssignment {
 	psFlavors := make([]kueue.PodSetAssignment, len(a.PodSets))
 	for i := range psFlavors {
@@ -106,6 +127,8 @@ func (a *Assignment) ToAPI() []kueue.PodSetAssignment {
 
 type Status struct {
 	reasons []string
+	// flavors holds the structured details of the reasons, per flavor.
+	flavors []kueue.FlavorInadmissibility
 	err     error
 }
 
@@ -118,6 +141,26 @@ func (s *Status) append(r ...string) *Status {
 	return s
 }
 
+// appendFlavor adds the details for a flavor, merging the resources of
+// the flavor if it's already present.
+func (s *Status) appendFlavor(flavors ...kueue.FlavorInadmissibility) *Status {
+	for _, f := range flavors {
+		idx := slices.IndexFunc(s.flavors, func(e kueue.FlavorInadmissibility) bool { return e.Name == f.Name })
+		if idx < 0 {
+			s.flavors = append(s.flavors, f)
+			continue
+		}
+		if f.Reason != "" {
+			s.flavors[idx].Reason = f.Reason
+		}
+		s.flavors[idx].Resources = append(s.flavors[idx].Resources, f.Resources...)
+		slices.SortFunc(s.flavors[idx].Resources, func(a, b kueue.ResourceInadmissibility) int {
+			return strings.Compare(string(a.Name), string(b.Name))
+		})
+	}
+	return s
+}
+
 func (s *Status) Message() string {
 	if s == nil {
 		return ""
@@ -253,18 +296,70 @@ func AssignFlavors(log logr.Logger, wl *workload.Info, resourceFlavors map[kueue
 		}
 	}
 
+	podSets := podSetsAfterUpdates(wl.Obj)
 	if len(counts) == 0 {
-		return assignFlavors(log, wl.TotalRequests, wl.Obj.Spec.PodSets, resourceFlavors, cq, wl.LastAssignment)
+		return assignFlavors(log, wl.TotalRequests, podSets, resourceFlavors, cq, wl.LastAssignment, priority.Priority(wl.Obj), lastAdmission(wl, cq))
 	}
 
 	currentResources := make([]workload.PodSetResources, len(wl.TotalRequests))
 	for i := range wl.TotalRequests {
 		currentResources[i] = *wl.TotalRequests[i].ScaledTo(counts[i])
 	}
-	return assignFlavors(log, currentResources, wl.Obj.Spec.PodSets, resourceFlavors, cq, wl.LastAssignment)
+	return assignFlavors(log, currentResources, podSets, resourceFlavors, cq, wl.LastAssignment, priority.Priority(wl.Obj), lastAdmission(wl, cq))
+}
+
+// lastAdmission returns the last admission of the workload by the
+// ClusterQueue, whose flavors are preferred when the workload is admitted
+// again, or nil.
+func lastAdmission(wl *workload.Info, cq *cache.ClusterQueue) *kueue.Admission {
+	last := wl.Obj.Status.LastAdmission
+	if !features.Enabled(features.FlavorStickiness) || last == nil || string(last.ClusterQueue) != cq.Name {
+		return nil
+	}
+	return last
+}
+
+// lastFlavor returns the flavor assigned to the resource of the podSet in the
+// last admission, or an empty string.
+func lastFlavor(last *kueue.Admission, podSet string, rName corev1.ResourceName) kueue.ResourceFlavorReference {
+	if last == nil {
+		return ""
+	}
+	for i := range last.PodSetAssignments {
+		if last.PodSetAssignments[i].Name == podSet {
+			return last.PodSetAssignments[i].Flavors[rName]
+		}
+	}
+	return ""
+}
+
+// podSetsAfterUpdates returns the pod sets of the workload with the
+// tolerations that the podSetUpdates of its admission checks add to the pods.
+func podSetsAfterUpdates(wl *kueue.Workload) []kueue.PodSet {
+	var podSets []kueue.PodSet
+	for i := range wl.Status.AdmissionChecks {
+		for _, update := range wl.Status.AdmissionChecks[i].PodSetUpdates {
+			if len(update.Tolerations) == 0 {
+				continue
+			}
+			if podSets == nil {
+				podSets = slices.Clone(wl.Spec.PodSets)
+			}
+			for j := range podSets {
+				if podSets[j].Name == update.Name {
+					spec := &podSets[j].Template.Spec
+					spec.Tolerations = append(slices.Clip(spec.Tolerations), update.Tolerations...)
+				}
+			}
+		}
+	}
+	if podSets == nil {
+		return wl.Spec.PodSets
+	}
+	return podSets
 }
 
-func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets []kueue.PodSet, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, cq *cache.ClusterQueue, lastAssignment *workload.AssigmentClusterQueueState) Assignment {
+func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets []kueue.PodSet, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, cq *cache.ClusterQueue, lastAssignment *workload.AssigmentClusterQueueState, wlPriority int32, last *kueue.Admission) Assignment {
 	assignment := Assignment{
 		TotalBorrow: make(cache.FlavorResourceQuantities),
 		PodSets:     make([]PodSetAssignment, 0, len(requests)),
@@ -278,6 +373,7 @@ func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets
 	if cq.Cohort != nil {
 		assignment.LastState.CohortGeneration = cq.Cohort.AllocatableResourceGeneration
 	}
+	podSetsFlavors := filterFlavors(log, podSets, resourceFlavors, cq, lastAssignment, last)
 
 	for i, podSet := range requests {
 		if _, found := cq.RGByResource[corev1.ResourcePods]; found {
@@ -305,14 +401,8 @@ func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets
 				}
 				break
 			}
-			lastFlavorAssignment := -1
-			if lastAssignment != nil && len(lastAssignment.LastTriedFlavorIdx) > i {
-				idx, ok := lastAssignment.LastTriedFlavorIdx[i][resName]
-				if ok {
-					lastFlavorAssignment = idx
-				}
-			}
-			flavors, status := assignment.findFlavorForResourceGroup(log, rg, podSet.Requests, resourceFlavors, cq, &podSets[i].Template.Spec, lastFlavorAssignment)
+			lastFlavorAssignment := lastTriedFlavorIdx(lastAssignment, i, resName)
+			flavors, status := assignment.findFlavorForResourceGroup(rg, podSet.Requests, podSetsFlavors[i][rg], cq, lastFlavorAssignment, wlPriority, lastFlavor(last, podSet.Name, resName))
 			if status.IsError() || len(flavors) == 0 {
 				psAssignment.Flavors = nil
 				psAssignment.Status = status
@@ -342,6 +432,7 @@ func (psa *PodSetAssignment) append(flavors ResourceAssignment, status *Status)
 		psa.Status = status
 	} else if status != nil {
 		psa.Status.reasons = append(psa.Status.reasons, status.reasons...)
+		psa.Status.appendFlavor(status.flavors...)
 	}
 }
 
@@ -366,35 +457,141 @@ func (a *Assignment) append(requests workload.Requests, psAssignment *PodSetAssi
 	a.LastState.LastTriedFlavorIdx = append(a.LastState.LastTriedFlavorIdx, flavorIdx)
 }
 
-// findFlavorForResourceGroup finds the flavor which can satisfy the resource
-// request, along with the information about resources that need to be borrowed.
-// If the flavor cannot be immediately assigned, it returns a status with
-// reasons or failure.
-func (a *Assignment) findFlavorForResourceGroup(
-	log logr.Logger,
-	rg *cache.ResourceGroup,
-	requests workload.Requests,
-	resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor,
-	cq *cache.ClusterQueue,
-	spec *corev1.PodSpec,
-	lastAssignment int) (ResourceAssignment, *Status) {
-	status := &Status{}
-	requests = filterRequestedResources(requests, rg.CoveredResources)
+// lastTriedFlavorIdx returns the index of the flavor tried for the resource of
+// the podset in the last assignment, or -1.
+func lastTriedFlavorIdx(lastAssignment *workload.AssigmentClusterQueueState, podSet int, resName corev1.ResourceName) int {
+	if lastAssignment == nil || len(lastAssignment.LastTriedFlavorIdx) <= podSet {
+		return -1
+	}
+	if idx, ok := lastAssignment.LastTriedFlavorIdx[podSet][resName]; ok {
+		return idx
+	}
+	return -1
+}
 
-	var bestAssignment ResourceAssignment
-	bestAssignmentMode := NoFit
+// flavorFilter holds, for every flavor of a resource group, the reasons why
+// the flavor can't be assigned to a podset. It's nil for the flavors matching
+// the taints and node affinity of the podset, and for the flavors skipped as
+// they were tried in the last assignment.
+type flavorFilter []*Status
+
+// sameSchedulingConstraints returns whether the podsets have the same fields
+// used to filter flavors.
+func sameSchedulingConstraints(a, b *corev1.PodSpec) bool {
+	return equality.Semantic.DeepEqual(a.NodeSelector, b.NodeSelector) &&
+		equality.Semantic.DeepEqual(a.Affinity, b.Affinity) &&
+		equality.Semantic.DeepEqual(a.Tolerations, b.Tolerations)
+}
 
+// filterFlavors checks the flavors of every resource group of the ClusterQueue
+// against the taints and node affinity of every podset. The filtering doesn't
+// depend on the quota used by the other podsets, so the podsets with the same
+// constraints share the result.
+// The flavors that findFlavorForResourceGroup skips, as they were tried in the
+// last assignment, are not filtered.
+func filterFlavors(log logr.Logger, podSets []kueue.PodSet, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, cq *cache.ClusterQueue, lastAssignment *workload.AssigmentClusterQueueState, last *kueue.Admission) []map[*cache.ResourceGroup]flavorFilter {
+	result := make([]map[*cache.ResourceGroup]flavorFilter, len(podSets))
+	// sameAs holds the index of the first podset with the same constraints.
+	sameAs := make([]int, len(podSets))
+	var distinct []int
+	for i := range podSets {
+		sameAs[i] = i
+		for _, j := range distinct {
+			if sameSchedulingConstraints(&podSets[i].Template.Spec, &podSets[j].Template.Spec) {
+				sameAs[i] = j
+				break
+			}
+		}
+		if sameAs[i] == i {
+			distinct = append(distinct, i)
+		}
+	}
+
+	for _, i := range distinct {
+		spec := &podSets[i].Template.Spec
+		filters := make(map[*cache.ResourceGroup]flavorFilter, len(cq.ResourceGroups))
+		for _, rg := range cq.RGByResource {
+			if _, found := filters[rg]; !found {
+				filters[rg] = filterResourceGroupFlavors(log, rg, resourceFlavors, spec, skippedFlavors(rg, podSets, sameAs, i, lastAssignment, last))
+			}
+		}
+		result[i] = filters
+	}
+	for i := range podSets {
+		result[i] = result[sameAs[i]]
+	}
+	return result
+}
+
+// skippedFlavors returns the indexes of the flavors of the resource group that
+// findFlavorForResourceGroup skips for all the podsets sharing the filter of
+// the podset first. The flavors of the last admission are never skipped, as
+// they are tried first.
+func skippedFlavors(rg *cache.ResourceGroup, podSets []kueue.PodSet, sameAs []int, first int, lastAssignment *workload.AssigmentClusterQueueState, last *kueue.Admission) sets.Set[int] {
+	if !features.Enabled(features.FlavorFungibility) || lastAssignment == nil {
+		return nil
+	}
+	skipped := -1
+	preferred := sets.New[kueue.ResourceFlavorReference]()
+	for i := range sameAs {
+		if sameAs[i] != first {
+			continue
+		}
+		tried := false
+		if len(lastAssignment.LastTriedFlavorIdx) > i {
+			for resName, idx := range lastAssignment.LastTriedFlavorIdx[i] {
+				if !rg.CoveredResources.Has(resName) {
+					continue
+				}
+				tried = true
+				if skipped < 0 || idx < skipped {
+					skipped = idx
+				}
+				if flv := lastFlavor(last, podSets[i].Name, resName); flv != "" {
+					preferred.Insert(flv)
+				}
+			}
+		}
+		if !tried {
+			return nil
+		}
+	}
+	result := sets.New[int]()
+	for idx := 0; idx <= skipped && idx < len(rg.Flavors); idx++ {
+		if !preferred.Has(rg.Flavors[idx].Name) {
+			result.Insert(idx)
+		}
+	}
+	return result
+}
+
+func filterResourceGroupFlavors(log logr.Logger, rg *cache.ResourceGroup, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, spec *corev1.PodSpec, skipped sets.Set[int]) flavorFilter {
+	filter := make(flavorFilter, len(rg.Flavors))
 	// We will only check against the flavors' labels for the resource.
 	selector := flavorSelector(spec, rg.LabelKeys)
-	flavorIdx := -1
 	for idx, flvQuotas := range rg.Flavors {
-		if features.Enabled(features.FlavorFungibility) && idx <= lastAssignment {
+		if skipped.Has(idx) {
 			continue
 		}
+		status := &Status{}
 		flavor, exist := resourceFlavors[flvQuotas.Name]
 		if !exist {
 			log.Error(nil, "Flavor not found", "Flavor", flvQuotas.Name)
 			status.append(fmt.Sprintf("flavor %s not found", flvQuotas.Name))
+			status.appendFlavor(kueue.FlavorInadmissibility{Name: flvQuotas.Name, Reason: kueue.InadmissibilityReasonFlavorNotFound})
+			filter[idx] = status
+			continue
+		}
+		if ptr.Deref(flavor.Spec.StopPolicy, kueue.None) != kueue.None {
+			status.append(fmt.Sprintf("flavor %s is stopped", flvQuotas.Name))
+			status.appendFlavor(kueue.FlavorInadmissibility{Name: flvQuotas.Name, Reason: kueue.InadmissibilityReasonFlavorStopped})
+			filter[idx] = status
+			continue
+		}
+		if until, marked := resourceflavor.NotProvisionableUntil(flavor, time.Now()); marked {
+			status.append(fmt.Sprintf("flavor %s is not provisionable until %s", flvQuotas.Name, until.Format(time.RFC3339)))
+			status.appendFlavor(kueue.FlavorInadmissibility{Name: flvQuotas.Name, Reason: kueue.InadmissibilityReasonFlavorNotProvisionable})
+			filter[idx] = status
 			continue
 		}
 		taint, untolerated := corev1helpers.FindMatchingUntoleratedTaint(flavor.Spec.NodeTaints, spec.Tolerations, func(t *corev1.Taint) bool {
@@ -402,47 +599,78 @@ func (a *Assignment) findFlavorForResourceGroup(
 		})
 		if untolerated {
 			status.append(fmt.Sprintf("untolerated taint %s in flavor %s", taint, flvQuotas.Name))
+			status.appendFlavor(kueue.FlavorInadmissibility{Name: flvQuotas.Name, Reason: kueue.InadmissibilityReasonUntoleratedTaint})
+			filter[idx] = status
 			continue
 		}
 		if match, err := selector.Match(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: flavor.Spec.NodeLabels}}); !match || err != nil {
 			if err != nil {
 				status.err = err
-				return nil, status
+			} else {
+				status.append(fmt.Sprintf("flavor %s doesn't match node affinity", flvQuotas.Name))
+				status.appendFlavor(kueue.FlavorInadmissibility{Name: flvQuotas.Name, Reason: kueue.InadmissibilityReasonNodeAffinityMismatch})
 			}
-			status.append(fmt.Sprintf("flavor %s doesn't match node affinity", flvQuotas.Name))
-			continue
+			filter[idx] = status
 		}
+	}
+	return filter
+}
 
-		flavorIdx = idx
-		needsBorrowing := false
-		assignments := make(ResourceAssignment, len(requests))
-		// Calculate representativeMode for this assignment as the worst mode among all requests.
-		representativeMode := Fit
-		for rName, val := range requests {
-			resQuota := flvQuotas.Resources[rName]
-			// Check considering the flavor usage by previous pod sets.
-			mode, borrow, s := fitsResourceQuota(flvQuotas.Name, rName, val+a.Usage[flvQuotas.Name][rName], cq, resQuota)
-			if s != nil {
-				status.reasons = append(status.reasons, s.reasons...)
-			}
-			if mode < representativeMode {
-				representativeMode = mode
-			}
-			needsBorrowing = needsBorrowing || (mode == Fit && borrow > 0)
-			if representativeMode == NoFit {
-				// The flavor doesn't fit, no need to check other resources.
-				break
+// findFlavorForResourceGroup finds the flavor which can satisfy the resource
+// request, along with the information about resources that need to be borrowed.
+// The preferred flavor, if any, is assigned when the request fits in it.
+// If the flavor cannot be immediately assigned, it returns a status with
+// reasons or failure.
+func (a *Assignment) findFlavorForResourceGroup(
+	rg *cache.ResourceGroup,
+	requests workload.Requests,
+	filter flavorFilter,
+	cq *cache.ClusterQueue,
+	lastAssignment int,
+	wlPriority int32,
+	preferred kueue.ResourceFlavorReference) (ResourceAssignment, *Status) {
+	status := &Status{}
+	requests = filterRequestedResources(requests, rg.CoveredResources)
+
+	if idx := slices.IndexFunc(rg.Flavors, func(f cache.FlavorQuotas) bool { return f.Name == preferred }); idx >= 0 && filter[idx] == nil {
+		// The reasons are discarded, as the flavor is checked again below if
+		// the request doesn't fit.
+		assignments, mode := a.fitsFlavor(&rg.Flavors[idx], requests, cq, wlPriority, &Status{})
+		if mode == Fit && !(features.Enabled(features.FlavorFungibility) && shouldTryNextFlavor(mode, assignments, func(rName corev1.ResourceName) kueue.FlavorFungibility {
+			return rg.FlavorFungibilityFor(rName, cq.FlavorFungibility)
+		})) {
+			for _, assignment := range assignments {
+				assignment.TriedFlavorIdx = -1
 			}
+			return assignments, nil
+		}
+	}
 
-			assignments[rName] = &FlavorAssignment{
-				Name:   flvQuotas.Name,
-				Mode:   mode,
-				borrow: borrow,
+	var bestAssignment ResourceAssignment
+	bestAssignmentMode := NoFit
+
+	flavorIdx := -1
+	for idx := range rg.Flavors {
+		if features.Enabled(features.FlavorFungibility) && idx <= lastAssignment {
+			continue
+		}
+		if filtered := filter[idx]; filtered != nil {
+			if filtered.err != nil {
+				status.err = filtered.err
+				return nil, status
 			}
+			status.reasons = append(status.reasons, filtered.reasons...)
+			status.appendFlavor(filtered.flavors...)
+			continue
 		}
 
+		flavorIdx = idx
+		assignments, representativeMode := a.fitsFlavor(&rg.Flavors[idx], requests, cq, wlPriority, status)
+
 		if features.Enabled(features.FlavorFungibility) {
-			if !shouldTryNextFlavor(representativeMode, cq.FlavorFungibility, needsBorrowing) {
+			if !shouldTryNextFlavor(representativeMode, assignments, func(rName corev1.ResourceName) kueue.FlavorFungibility {
+				return rg.FlavorFungibilityFor(rName, cq.FlavorFungibility)
+			}) {
 				bestAssignment = assignments
 				bestAssignmentMode = representativeMode
 				break
@@ -479,21 +707,58 @@ func (a *Assignment) findFlavorForResourceGroup(
 	return bestAssignment, status
 }
 
-func shouldTryNextFlavor(representativeMode FlavorAssignmentMode, flavorFungibility kueue.FlavorFungibility, needsBorrowing bool) bool {
-	policyPreempt := flavorFungibility.WhenCanPreempt
-	policyBorrow := flavorFungibility.WhenCanBorrow
-	if representativeMode == Preempt && policyPreempt == kueue.Preempt {
-		return false
-	}
+// fitsFlavor returns the assignments of the requests to the flavor, and the
+// worst mode among them. The reasons for the requests not fitting are added to
+// the status.
+func (a *Assignment) fitsFlavor(flvQuotas *cache.FlavorQuotas, requests workload.Requests, cq *cache.ClusterQueue, wlPriority int32, status *Status) (ResourceAssignment, FlavorAssignmentMode) {
+	assignments := make(ResourceAssignment, len(requests))
+	// Calculate representativeMode for this assignment as the worst mode among all requests.
+	representativeMode := Fit
+	for rName, val := range requests {
+		resQuota := flvQuotas.Resources[rName]
+		// Check considering the flavor usage by previous pod sets.
+		mode, borrow, s := fitsResourceQuota(flvQuotas.Name, rName, val+a.Usage[flvQuotas.Name][rName], cq, resQuota, wlPriority)
+		if s != nil {
+			status.reasons = append(status.reasons, s.reasons...)
+			status.appendFlavor(s.flavors...)
+		}
+		if mode < representativeMode {
+			representativeMode = mode
+		}
+		if representativeMode == NoFit {
+			// The flavor doesn't fit, no need to check other resources.
+			break
+		}
 
-	if representativeMode == Fit && needsBorrowing && policyBorrow == kueue.Borrow {
-		return false
+		assignments[rName] = &FlavorAssignment{
+			Name:   flvQuotas.Name,
+			Mode:   mode,
+			borrow: borrow,
+		}
 	}
+	return assignments, representativeMode
+}
 
-	if representativeMode == Fit && !needsBorrowing {
+// shouldTryNextFlavor returns whether the next flavor should be tried,
+// considering the flavorFungibility of every resource that needs borrowing or
+// preemption in the current flavor.
+func shouldTryNextFlavor(representativeMode FlavorAssignmentMode, assignments ResourceAssignment, fungibility func(corev1.ResourceName) kueue.FlavorFungibility) bool {
+	switch representativeMode {
+	case Preempt:
+		for rName, assignment := range assignments {
+			if assignment.Mode == Preempt && fungibility(rName).WhenCanPreempt != kueue.Preempt {
+				return true
+			}
+		}
+		return false
+	case Fit:
+		for rName, assignment := range assignments {
+			if assignment.borrow > 0 && fungibility(rName).WhenCanBorrow != kueue.Borrow {
+				return true
+			}
+		}
 		return false
 	}
-
 	return true
 }
 
@@ -544,11 +809,12 @@ func flavorSelector(spec *corev1.PodSpec, allowedKeys sets.Set[string]) nodeaffi
 }
 
 // fitsResourceQuota returns how this flavor could be assigned to the resource,
-// according to the remaining quota in the ClusterQueue and cohort.
+// according to the remaining quota in the ClusterQueue and cohort, and to the
+// quota tiers for a workload with the priority.
 // If it fits, also returns any borrowing required.
 // If the flavor doesn't satisfy limits immediately (when waiting or preemption
 // could help), it returns a Status with reasons.
-func fitsResourceQuota(fName kueue.ResourceFlavorReference, rName corev1.ResourceName, val int64, cq *cache.ClusterQueue, rQuota *cache.ResourceQuota) (FlavorAssignmentMode, int64, *Status) {
+func fitsResourceQuota(fName kueue.ResourceFlavorReference, rName corev1.ResourceName, val int64, cq *cache.ClusterQueue, rQuota *cache.ResourceQuota, wlPriority int32) (FlavorAssignmentMode, int64, *Status) {
 	var status Status
 	used := cq.Usage[fName][rName]
 	mode := NoFit
@@ -558,10 +824,30 @@ func fitsResourceQuota(fName kueue.ResourceFlavorReference, rName corev1.Resourc
 		// ClusterQueue are preempted.
 		mode = Preempt
 	}
+	details := kueue.ResourceInadmissibility{
+		Name:                 rName,
+		BorrowingConsidered:  cq.Cohort != nil,
+		PreemptionConsidered: mode == Preempt,
+	}
 	if rQuota.BorrowingLimit != nil && used+val > rQuota.Nominal+*rQuota.BorrowingLimit {
 		status.append(fmt.Sprintf("borrowing limit for %s in flavor %s exceeded", rName, fName))
+		details.Reason = kueue.InadmissibilityReasonBorrowingLimitExceeded
+		details.Missing = ptr.To(workload.ResourceQuantity(rName, used+val-rQuota.Nominal-*rQuota.BorrowingLimit))
+		status.appendFlavor(kueue.FlavorInadmissibility{Name: fName, Resources: []kueue.ResourceInadmissibility{details}})
 		return mode, 0, &status
 	}
+	if tier, available, exceeded := cq.ExceededQuotaTier(fName, rName, rQuota.Nominal, val, wlPriority); exceeded {
+		// Preemption is not considered, as the workloads using the quota that
+		// is not reserved can have a higher priority than the workload.
+		availableQuantity := workload.ResourceQuantity(rName, available)
+		status.append(fmt.Sprintf("quota for %s in flavor %s is reserved by tier %s to higher priorities, %s available", rName, fName, tier, availableQuantity.String()))
+		details.Reason = kueue.InadmissibilityReasonQuotaReservedToHigherPriorities
+		details.PreemptionConsidered = false
+		details.BorrowingConsidered = false
+		details.Missing = ptr.To(workload.ResourceQuantity(rName, val-available))
+		status.appendFlavor(kueue.FlavorInadmissibility{Name: fName, Resources: []kueue.ResourceInadmissibility{details}})
+		return NoFit, 0, &status
+	}
 
 	cohortUsed := used
 	cohortAvailable := rQuota.Nominal
@@ -580,15 +866,19 @@ func fitsResourceQuota(fName kueue.ResourceFlavorReference, rName corev1.Resourc
 	}
 
 	lackQuantity := workload.ResourceQuantity(rName, lack)
+	details.Reason = kueue.InadmissibilityReasonInsufficientUnusedQuota
+	details.Missing = &lackQuantity
 	msg := fmt.Sprintf("insufficient unused quota in cohort for %s in flavor %s, %s more needed", rName, fName, &lackQuantity)
 	if cq.Cohort == nil {
 		if mode == NoFit {
+			details.Reason = kueue.InadmissibilityReasonInsufficientQuota
 			msg = fmt.Sprintf("insufficient quota for %s in flavor %s in ClusterQueue", rName, fName)
 		} else {
 			msg = fmt.Sprintf("insufficient unused quota for %s in flavor %s, %s more needed", rName, fName, &lackQuantity)
 		}
 	}
 	status.append(msg)
+	status.appendFlavor(kueue.FlavorInadmissibility{Name: fName, Resources: []kueue.ResourceInadmissibility{details}})
 	return mode, 0, &status
 }
 
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/testr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -31,6 +32,8 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -54,11 +57,19 @@ func TestAssignFlavors(t *testing.T) {
 				Value:  "spot",
 				Effect: corev1.TaintEffectNoSchedule,
 			}).Obj(),
+		"stopped": utiltesting.MakeResourceFlavor("stopped").StopPolicy(kueue.Hold).Obj(),
+		"not-provisionable": utiltesting.MakeResourceFlavor("not-provisionable").
+			Annotation(controllerconsts.NotProvisionableUntilAnnotation, "2999-01-01T00:00:00Z").
+			Obj(),
+		"provisionable-again": utiltesting.MakeResourceFlavor("provisionable-again").
+			Annotation(controllerconsts.NotProvisionableUntilAnnotation, "2023-01-01T00:00:00Z").
+			Obj(),
 	}
 
 	cases := map[string]struct {
 		wlPods            []kueue.PodSet
 		wlReclaimablePods []kueue.ReclaimablePod
+		wlAdmissionChecks []kueue.AdmissionCheckState
 		clusterQueue      cache.ClusterQueue
 		wantRepMode       FlavorAssignmentMode
 		wantAssignment    Assignment
@@ -148,6 +159,86 @@ func TestAssignFlavors(t *testing.T) {
 				},
 			},
 		},
+		"single flavor, doesn't fit tainted flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "tainted",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 4000},
+						},
+					}},
+				}},
+			},
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1000m"),
+					},
+					Status: &Status{
+						reasons: []string{"untolerated taint {instance spot NoSchedule <nil>} in flavor tainted"},
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{},
+			},
+		},
+		"single flavor, fits tainted flavor tolerated by the admission checks": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wlAdmissionChecks: []kueue.AdmissionCheckState{{
+				Name:  "check",
+				State: kueue.CheckStateReady,
+				PodSetUpdates: []kueue.PodSetUpdate{{
+					Name: "main",
+					Tolerations: []corev1.Toleration{{
+						Key:      "instance",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "spot",
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+				}},
+			}},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "tainted",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 4000},
+						},
+					}},
+				}},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "tainted", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1000m"),
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"tainted": {
+						corev1.ResourceCPU: 1000,
+					},
+				},
+			},
+		},
 		"single flavor, used resources, doesn't fit": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet("main", 1).
@@ -577,6 +668,144 @@ func TestAssignFlavors(t *testing.T) {
 				},
 			},
 		},
+		"multiple flavors, fits while skipping stopped flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{
+					{
+						CoveredResources: sets.New(corev1.ResourceCPU),
+						Flavors: []cache.FlavorQuotas{
+							{
+								Name: "stopped",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 4000},
+								},
+							},
+							{
+								Name: "two",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 4000},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3000m"),
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"two": map[corev1.ResourceName]int64{
+						corev1.ResourceCPU: 3000,
+					},
+				},
+			},
+		},
+		"multiple flavors, fits while skipping not provisionable flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{
+					{
+						CoveredResources: sets.New(corev1.ResourceCPU),
+						Flavors: []cache.FlavorQuotas{
+							{
+								Name: "not-provisionable",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 4000},
+								},
+							},
+							{
+								Name: "two",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 4000},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3000m"),
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"two": map[corev1.ResourceName]int64{
+						corev1.ResourceCPU: 3000,
+					},
+				},
+			},
+		},
+		"multiple flavors, fits in flavor whose not provisionable mark expired": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{
+					{
+						CoveredResources: sets.New(corev1.ResourceCPU),
+						Flavors: []cache.FlavorQuotas{
+							{
+								Name: "provisionable-again",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 4000},
+								},
+							},
+							{
+								Name: "two",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 4000},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "provisionable-again", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("3000m"),
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"provisionable-again": map[corev1.ResourceName]int64{
+						corev1.ResourceCPU: 3000,
+					},
+				},
+			},
+		},
 		"multiple flavors, skip missing ResourceFlavor": {
 			wlPods: []kueue.PodSet{
 				*utiltesting.MakePodSet("main", 1).
@@ -1847,36 +2076,862 @@ func TestAssignFlavors(t *testing.T) {
 				Usage: cache.FlavorResourceQuantities{"one": {"cpu": 9000, "pods": 1}},
 			},
 		},
-	}
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			log := testr.NewWithOptions(t, testr.Options{
-				Verbosity: 2,
-			})
-			wlInfo := workload.NewInfo(&kueue.Workload{
-				Spec: kueue.WorkloadSpec{
-					PodSets: tc.wlPods,
+		"borrow for a resource with the borrow policy, the other resource tries the next flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "9").
+					Request("example.com/gpu", "1").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				Cohort: &cache.Cohort{
+					Usage: cache.FlavorResourceQuantities{
+						"one": {corev1.ResourceCPU: 2000},
+					},
+					RequestableResources: cache.FlavorResourceQuantities{
+						"one": {corev1.ResourceCPU: 11000, "example.com/gpu": 2},
+						"two": {corev1.ResourceCPU: 10000, "example.com/gpu": 2},
+					},
 				},
-				Status: kueue.WorkloadStatus{
-					ReclaimablePods: tc.wlReclaimablePods,
+				FlavorFungibility: defaultFlavorFungibility,
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New[corev1.ResourceName](corev1.ResourceCPU, "example.com/gpu"),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "one",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 10000, BorrowingLimit: ptr.To[int64](1000)},
+							"example.com/gpu":  {Nominal: 2},
+						},
+					}, {
+						Name: "two",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 10000},
+							"example.com/gpu":  {Nominal: 2},
+						},
+					}},
+					ResourceFlavorFungibility: map[corev1.ResourceName]kueue.FlavorFungibility{
+						"example.com/gpu": {WhenCanBorrow: kueue.TryNextFlavor, WhenCanPreempt: kueue.TryNextFlavor},
+					},
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"one": {corev1.ResourceCPU: 2000},
 				},
-			})
-			if tc.clusterQueue.FlavorFungibility.WhenCanBorrow == "" {
-				tc.clusterQueue.FlavorFungibility.WhenCanBorrow = kueue.Borrow
-			}
-			if tc.clusterQueue.FlavorFungibility.WhenCanPreempt == "" {
-				tc.clusterQueue.FlavorFungibility.WhenCanPreempt = kueue.TryNextFlavor
-			}
-			tc.clusterQueue.UpdateWithFlavors(resourceFlavors)
-			tc.clusterQueue.UpdateRGByResource()
-			assignment := AssignFlavors(log, wlInfo, resourceFlavors, &tc.clusterQueue, nil)
-			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
-				t.Errorf("e.assignFlavors(_).RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
-			}
-
-			if diff := cmp.Diff(tc.wantAssignment, assignment, cmpopts.IgnoreUnexported(Assignment{}, FlavorAssignment{}), cmpopts.IgnoreFields(Assignment{}, "LastState"), cmpopts.IgnoreFields(FlavorAssignment{}, "TriedFlavorIdx")); diff != "" {
-				t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
-			}
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				TotalBorrow: cache.FlavorResourceQuantities{
+					"one": {corev1.ResourceCPU: 1000},
+				},
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "one", Mode: Fit},
+						"example.com/gpu":  {Name: "one", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("9000m"),
+						"example.com/gpu":  resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{"one": {"cpu": 9000, "example.com/gpu": 1}},
+			},
+		},
+		"try next flavor for a resource with the try next flavor policy that needs borrowing": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "1").
+					Request("example.com/gpu", "1").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				Cohort: &cache.Cohort{
+					Usage: cache.FlavorResourceQuantities{
+						"one": {"example.com/gpu": 1},
+					},
+					RequestableResources: cache.FlavorResourceQuantities{
+						"one": {corev1.ResourceCPU: 10000, "example.com/gpu": 2},
+						"two": {corev1.ResourceCPU: 10000, "example.com/gpu": 2},
+					},
+				},
+				FlavorFungibility: defaultFlavorFungibility,
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New[corev1.ResourceName](corev1.ResourceCPU, "example.com/gpu"),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "one",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 10000},
+							"example.com/gpu":  {Nominal: 1},
+						},
+					}, {
+						Name: "two",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 10000},
+							"example.com/gpu":  {Nominal: 2},
+						},
+					}},
+					ResourceFlavorFungibility: map[corev1.ResourceName]kueue.FlavorFungibility{
+						"example.com/gpu": {WhenCanBorrow: kueue.TryNextFlavor, WhenCanPreempt: kueue.TryNextFlavor},
+					},
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"one": {"example.com/gpu": 1},
+				},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit},
+						"example.com/gpu":  {Name: "two", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1000m"),
+						"example.com/gpu":  resource.MustParse("1"),
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{"two": {"cpu": 1000, "example.com/gpu": 1}},
+			},
+		},
+		"try next flavor with the policy of the resource group": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "9").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				Cohort: &cache.Cohort{
+					Usage: cache.FlavorResourceQuantities{
+						"one": {corev1.ResourceCPU: 2000},
+					},
+					RequestableResources: cache.FlavorResourceQuantities{
+						"one": {corev1.ResourceCPU: 11000},
+						"two": {corev1.ResourceCPU: 10000},
+					},
+				},
+				FlavorFungibility: defaultFlavorFungibility,
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "one",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 10000, BorrowingLimit: ptr.To[int64](1000)},
+						},
+					}, {
+						Name: "two",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 10000},
+						},
+					}},
+					FlavorFungibility: &kueue.FlavorFungibility{
+						WhenCanBorrow:  kueue.TryNextFlavor,
+						WhenCanPreempt: kueue.TryNextFlavor,
+					},
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"one": {corev1.ResourceCPU: 2000},
+				},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU: {Name: "two", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("9000m"),
+					},
+					Count: 1,
+				}},
+				Usage: cache.FlavorResourceQuantities{"two": {"cpu": 9000}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			log := testr.NewWithOptions(t, testr.Options{
+				Verbosity: 2,
+			})
+			wlInfo := workload.NewInfo(&kueue.Workload{
+				Spec: kueue.WorkloadSpec{
+					PodSets: tc.wlPods,
+				},
+				Status: kueue.WorkloadStatus{
+					ReclaimablePods: tc.wlReclaimablePods,
+					AdmissionChecks: tc.wlAdmissionChecks,
+				},
+			})
+			if tc.clusterQueue.FlavorFungibility.WhenCanBorrow == "" {
+				tc.clusterQueue.FlavorFungibility.WhenCanBorrow = kueue.Borrow
+			}
+			if tc.clusterQueue.FlavorFungibility.WhenCanPreempt == "" {
+				tc.clusterQueue.FlavorFungibility.WhenCanPreempt = kueue.TryNextFlavor
+			}
+			tc.clusterQueue.UpdateWithFlavors(resourceFlavors)
+			tc.clusterQueue.UpdateRGByResource()
+			assignment := AssignFlavors(log, wlInfo, resourceFlavors, &tc.clusterQueue, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
+				t.Errorf("e.assignFlavors(_).RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
+			}
+
+			if diff := cmp.Diff(tc.wantAssignment, assignment, cmpopts.IgnoreUnexported(Assignment{}, FlavorAssignment{}), cmpopts.IgnoreFields(Assignment{}, "LastState"), cmpopts.IgnoreFields(FlavorAssignment{}, "TriedFlavorIdx")); diff != "" {
+				t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFilterFlavors(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one": utiltesting.MakeResourceFlavor("one").Label("type", "one").Obj(),
+		"two": utiltesting.MakeResourceFlavor("two").Label("type", "two").Obj(),
+		"tainted": utiltesting.MakeResourceFlavor("tainted").
+			Taint(corev1.Taint{
+				Key:    "instance",
+				Value:  "spot",
+				Effect: corev1.TaintEffectNoSchedule,
+			}).Obj(),
+		"dedicated": utiltesting.MakeResourceFlavor("dedicated").
+			Label("type", "two").
+			Taint(corev1.Taint{
+				Key:    "dedicated",
+				Value:  "batch",
+				Effect: corev1.TaintEffectNoSchedule,
+			}).
+			Toleration(corev1.Toleration{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "batch",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}).Obj(),
+	}
+	toleration := corev1.Toleration{
+		Key:      "instance",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "spot",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	podSets := []kueue.PodSet{
+		*utiltesting.MakePodSet("driver", 1).NodeSelector(map[string]string{"type": "two"}).Obj(),
+		*utiltesting.MakePodSet("workers", 1).Toleration(toleration).Obj(),
+		*utiltesting.MakePodSet("ps", 1).NodeSelector(map[string]string{"type": "two"}).Obj(),
+		*utiltesting.MakePodSet("evaluator", 1).Obj(),
+	}
+	cq := cache.ClusterQueue{
+		ResourceGroups: []cache.ResourceGroup{{
+			CoveredResources: sets.New(corev1.ResourceCPU),
+			Flavors: []cache.FlavorQuotas{
+				{Name: "one"},
+				{Name: "tainted"},
+				{Name: "two"},
+				{Name: "missing"},
+				{Name: "dedicated"},
+			},
+		}},
+	}
+	cq.UpdateWithFlavors(resourceFlavors)
+	cq.UpdateRGByResource()
+	rg := cq.RGByResource[corev1.ResourceCPU]
+
+	got := filterFlavors(testr.New(t), podSets, resourceFlavors, &cq, nil, nil)
+	gotEligible := make([][]kueue.ResourceFlavorReference, len(got))
+	gotReasons := make([][]string, len(got))
+	for i := range got {
+		for idx, filtered := range got[i][rg] {
+			if filtered == nil {
+				gotEligible[i] = append(gotEligible[i], rg.Flavors[idx].Name)
+			} else {
+				gotReasons[i] = append(gotReasons[i], filtered.reasons...)
+			}
+		}
+	}
+	wantEligible := [][]kueue.ResourceFlavorReference{
+		{"two"},
+		{"one", "tainted", "two"},
+		{"two"},
+		{"one", "two"},
+	}
+	if diff := cmp.Diff(wantEligible, gotEligible); diff != "" {
+		t.Errorf("Unexpected eligible flavors (-want,+got):\n%s", diff)
+	}
+	// The tolerations of the dedicated flavor don't tolerate its taints.
+	wantReasons := [][]string{
+		{
+			"flavor one doesn't match node affinity",
+			"untolerated taint {instance spot NoSchedule <nil>} in flavor tainted",
+			"flavor missing not found",
+			"untolerated taint {dedicated batch NoSchedule <nil>} in flavor dedicated",
+		},
+		{
+			"flavor missing not found",
+			"untolerated taint {dedicated batch NoSchedule <nil>} in flavor dedicated",
+		},
+		{
+			"flavor one doesn't match node affinity",
+			"untolerated taint {instance spot NoSchedule <nil>} in flavor tainted",
+			"flavor missing not found",
+			"untolerated taint {dedicated batch NoSchedule <nil>} in flavor dedicated",
+		},
+		{
+			"untolerated taint {instance spot NoSchedule <nil>} in flavor tainted",
+			"flavor missing not found",
+			"untolerated taint {dedicated batch NoSchedule <nil>} in flavor dedicated",
+		},
+	}
+	if diff := cmp.Diff(wantReasons, gotReasons); diff != "" {
+		t.Errorf("Unexpected reasons (-want,+got):\n%s", diff)
+	}
+}
+
+func TestFilterFlavorsSkipsTriedFlavors(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one":   utiltesting.MakeResourceFlavor("one").Label("type", "one").Obj(),
+		"two":   utiltesting.MakeResourceFlavor("two").Label("type", "two").Obj(),
+		"three": utiltesting.MakeResourceFlavor("three").Label("type", "three").Obj(),
+	}
+	podSets := []kueue.PodSet{
+		*utiltesting.MakePodSet("driver", 1).NodeSelector(map[string]string{"type": "three"}).Obj(),
+		*utiltesting.MakePodSet("workers", 1).NodeSelector(map[string]string{"type": "three"}).Obj(),
+	}
+	cases := map[string]struct {
+		lastAssignment           *workload.AssigmentClusterQueueState
+		last                     *kueue.Admission
+		disableFlavorFungibility bool
+		wantReasons              []string
+	}{
+		"no last assignment": {
+			wantReasons: []string{
+				"flavor one doesn't match node affinity",
+				"flavor two doesn't match node affinity",
+			},
+		},
+		"flavors tried for all the podsets": {
+			lastAssignment: &workload.AssigmentClusterQueueState{
+				LastTriedFlavorIdx: []map[corev1.ResourceName]int{{corev1.ResourceCPU: 1}, {corev1.ResourceCPU: 1}},
+			},
+		},
+		"flavors tried for some of the podsets": {
+			lastAssignment: &workload.AssigmentClusterQueueState{
+				LastTriedFlavorIdx: []map[corev1.ResourceName]int{{corev1.ResourceCPU: 1}, {corev1.ResourceCPU: 0}},
+			},
+			wantReasons: []string{"flavor two doesn't match node affinity"},
+		},
+		"podset without tried flavors": {
+			lastAssignment: &workload.AssigmentClusterQueueState{
+				LastTriedFlavorIdx: []map[corev1.ResourceName]int{{corev1.ResourceCPU: 1}},
+			},
+			wantReasons: []string{
+				"flavor one doesn't match node affinity",
+				"flavor two doesn't match node affinity",
+			},
+		},
+		"flavor of the last admission": {
+			lastAssignment: &workload.AssigmentClusterQueueState{
+				LastTriedFlavorIdx: []map[corev1.ResourceName]int{{corev1.ResourceCPU: 1}, {corev1.ResourceCPU: 1}},
+			},
+			last: &kueue.Admission{
+				PodSetAssignments: []kueue.PodSetAssignment{{
+					Name:    "workers",
+					Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "two"},
+				}},
+			},
+			wantReasons: []string{"flavor two doesn't match node affinity"},
+		},
+		"flavor fungibility disabled": {
+			lastAssignment: &workload.AssigmentClusterQueueState{
+				LastTriedFlavorIdx: []map[corev1.ResourceName]int{{corev1.ResourceCPU: 1}, {corev1.ResourceCPU: 1}},
+			},
+			disableFlavorFungibility: true,
+			wantReasons: []string{
+				"flavor one doesn't match node affinity",
+				"flavor two doesn't match node affinity",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer features.SetFeatureGateDuringTest(t, features.FlavorFungibility, !tc.disableFlavorFungibility)()
+			cq := cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{
+						{Name: "one"},
+						{Name: "two"},
+						{Name: "three"},
+					},
+				}},
+			}
+			cq.UpdateWithFlavors(resourceFlavors)
+			cq.UpdateRGByResource()
+			rg := cq.RGByResource[corev1.ResourceCPU]
+
+			got := filterFlavors(testr.New(t), podSets, resourceFlavors, &cq, tc.lastAssignment, tc.last)
+			for i := range got {
+				var gotReasons []string
+				for _, filtered := range got[i][rg] {
+					if filtered != nil {
+						gotReasons = append(gotReasons, filtered.reasons...)
+					}
+				}
+				if diff := cmp.Diff(tc.wantReasons, gotReasons); diff != "" {
+					t.Errorf("Unexpected reasons for podset %d (-want,+got):\n%s", i, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPodSetsAfterUpdates(t *testing.T) {
+	toleration := corev1.Toleration{
+		Key:      "instance",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "spot",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).Obj(),
+			*utiltesting.MakePodSet("workers", 1).Obj(),
+		).
+		AdmissionCheck(kueue.AdmissionCheckState{
+			Name:  "check",
+			State: kueue.CheckStateReady,
+			PodSetUpdates: []kueue.PodSetUpdate{
+				{Name: "driver"},
+				{Name: "workers", Tolerations: []corev1.Toleration{toleration}},
+			},
+		}).
+		Obj()
+
+	got := podSetsAfterUpdates(wl)
+	wantTolerations := [][]corev1.Toleration{nil, {toleration}}
+	gotTolerations := make([][]corev1.Toleration, len(got))
+	for i := range got {
+		gotTolerations[i] = got[i].Template.Spec.Tolerations
+	}
+	if diff := cmp.Diff(wantTolerations, gotTolerations); diff != "" {
+		t.Errorf("Unexpected tolerations (-want,+got):\n%s", diff)
+	}
+	if len(wl.Spec.PodSets[1].Template.Spec.Tolerations) != 0 {
+		t.Errorf("The pod sets of the workload were modified")
+	}
+}
+
+func TestAssignmentInadmissibility(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one": utiltesting.MakeResourceFlavor("one").Obj(),
+		"two": utiltesting.MakeResourceFlavor("two").Obj(),
+		"tainted": utiltesting.MakeResourceFlavor("tainted").
+			Taint(corev1.Taint{
+				Key:    "instance",
+				Value:  "spot",
+				Effect: corev1.TaintEffectNoSchedule,
+			}).Obj(),
+	}
+	cases := map[string]struct {
+		wlPods              []kueue.PodSet
+		clusterQueue        cache.ClusterQueue
+		wantInadmissibility []kueue.PodSetInadmissibility
+	}{
+		"fits": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "one",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 1000},
+						},
+					}},
+				}},
+			},
+		},
+		"untolerated taint and insufficient quota without cohort": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "3").
+					Request(corev1.ResourceMemory, "1Mi").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU, corev1.ResourceMemory),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "tainted",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU:    {Nominal: 4000},
+							corev1.ResourceMemory: {Nominal: utiltesting.Mi},
+						},
+					}, {
+						Name: "one",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU:    {Nominal: 2000},
+							corev1.ResourceMemory: {Nominal: utiltesting.Mi},
+						},
+					}},
+				}},
+			},
+			wantInadmissibility: []kueue.PodSetInadmissibility{{
+				Name: "main",
+				Flavors: []kueue.FlavorInadmissibility{
+					{
+						Name:   "tainted",
+						Reason: kueue.InadmissibilityReasonUntoleratedTaint,
+					},
+					{
+						Name: "one",
+						Resources: []kueue.ResourceInadmissibility{{
+							Name:    corev1.ResourceCPU,
+							Reason:  kueue.InadmissibilityReasonInsufficientQuota,
+							Missing: ptr.To(resource.MustParse("1")),
+						}},
+					},
+				},
+			}},
+		},
+		"borrowing limit and insufficient unused quota in cohort": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 1).
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				Cohort: &cache.Cohort{
+					Usage: cache.FlavorResourceQuantities{
+						"one": {corev1.ResourceCPU: 1000},
+						"two": {corev1.ResourceCPU: 8000},
+					},
+					RequestableResources: cache.FlavorResourceQuantities{
+						"one": {corev1.ResourceCPU: 10000},
+						"two": {corev1.ResourceCPU: 10000},
+					},
+				},
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "one",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 2000, BorrowingLimit: ptr.To[int64](1000)},
+						},
+					}, {
+						Name: "two",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 5000},
+						},
+					}},
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"one": {corev1.ResourceCPU: 1000},
+				},
+			},
+			wantInadmissibility: []kueue.PodSetInadmissibility{{
+				Name: "main",
+				Flavors: []kueue.FlavorInadmissibility{
+					{
+						Name: "one",
+						Resources: []kueue.ResourceInadmissibility{{
+							Name:                corev1.ResourceCPU,
+							Reason:              kueue.InadmissibilityReasonBorrowingLimitExceeded,
+							Missing:             ptr.To(resource.MustParse("2")),
+							BorrowingConsidered: true,
+						}},
+					},
+					{
+						Name: "two",
+						Resources: []kueue.ResourceInadmissibility{{
+							Name:                 corev1.ResourceCPU,
+							Reason:               kueue.InadmissibilityReasonInsufficientUnusedQuota,
+							Missing:              ptr.To(resource.MustParse("2")),
+							BorrowingConsidered:  true,
+							PreemptionConsidered: true,
+						}},
+					},
+				},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			log := testr.NewWithOptions(t, testr.Options{
+				Verbosity: 2,
+			})
+			wlInfo := workload.NewInfo(&kueue.Workload{
+				Spec: kueue.WorkloadSpec{
+					PodSets: tc.wlPods,
+				},
+			})
+			tc.clusterQueue.FlavorFungibility = kueue.FlavorFungibility{
+				WhenCanBorrow:  kueue.Borrow,
+				WhenCanPreempt: kueue.TryNextFlavor,
+			}
+			tc.clusterQueue.UpdateWithFlavors(resourceFlavors)
+			tc.clusterQueue.UpdateRGByResource()
+			assignment := AssignFlavors(log, wlInfo, resourceFlavors, &tc.clusterQueue, nil)
+			if diff := cmp.Diff(tc.wantInadmissibility, assignment.Inadmissibility()); diff != "" {
+				t.Errorf("Unexpected inadmissibility (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsQuotaTiers(t *testing.T) {
+	flavors := []*kueue.ResourceFlavor{
+		utiltesting.MakeResourceFlavor("one").Obj(),
+	}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "10").Obj()).
+			Preemption(kueue.ClusterQueuePreemption{
+				WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+			}).
+			QuotaTiers(kueue.QuotaTier{Name: "production", MinPriority: 1000, ReservedQuotaPercent: 60}).
+			Obj(),
+		utiltesting.MakeClusterQueue("other").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("one").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	admitted := func(name, cq string, priority int32, cpu string) kueue.Workload {
+		return *utiltesting.MakeWorkload(name, "ns").
+			Priority(priority).
+			Request(corev1.ResourceCPU, cpu).
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "one", cpu).Obj()).
+			Obj()
+	}
+	cases := map[string]struct {
+		admitted            []kueue.Workload
+		wlPriority          int32
+		wlCPU               string
+		wantRepMode         FlavorAssignmentMode
+		wantBorrow          cache.FlavorResourceQuantities
+		wantInadmissibility []kueue.PodSetInadmissibility
+	}{
+		"quota reserved to higher priorities": {
+			admitted: []kueue.Workload{admitted("low", "cq", 0, "2")},
+			wlCPU:    "3",
+			wantInadmissibility: []kueue.PodSetInadmissibility{{
+				Name: "main",
+				Flavors: []kueue.FlavorInadmissibility{{
+					Name: "one",
+					Resources: []kueue.ResourceInadmissibility{{
+						Name:    corev1.ResourceCPU,
+						Reason:  kueue.InadmissibilityReasonQuotaReservedToHigherPriorities,
+						Missing: ptr.To(resource.MustParse("1")),
+					}},
+				}},
+			}},
+		},
+		"fits in the quota reserved to its priority": {
+			admitted:    []kueue.Workload{admitted("low", "cq", 0, "2")},
+			wlPriority:  1000,
+			wlCPU:       "3",
+			wantRepMode: Fit,
+		},
+		"doesn't preempt to fit in the quota not reserved to higher priorities": {
+			admitted: []kueue.Workload{
+				admitted("low", "cq", 0, "4"),
+				admitted("high", "cq", 1000, "6"),
+				admitted("other", "other", 0, "10"),
+			},
+			wlPriority: 10,
+			wlCPU:      "2",
+			wantInadmissibility: []kueue.PodSetInadmissibility{{
+				Name: "main",
+				Flavors: []kueue.FlavorInadmissibility{{
+					Name: "one",
+					Resources: []kueue.ResourceInadmissibility{{
+						Name:    corev1.ResourceCPU,
+						Reason:  kueue.InadmissibilityReasonQuotaReservedToHigherPriorities,
+						Missing: ptr.To(resource.MustParse("2")),
+					}},
+				}},
+			}},
+		},
+		"preempts in the quota reserved to its priority": {
+			admitted: []kueue.Workload{
+				admitted("low", "cq", 0, "4"),
+				admitted("high", "cq", 1000, "6"),
+				admitted("other", "other", 0, "10"),
+			},
+			wlPriority:  1000,
+			wlCPU:       "2",
+			wantRepMode: Preempt,
+			wantInadmissibility: []kueue.PodSetInadmissibility{{
+				Name: "main",
+				Flavors: []kueue.FlavorInadmissibility{{
+					Name: "one",
+					Resources: []kueue.ResourceInadmissibility{{
+						Name:                 corev1.ResourceCPU,
+						Reason:               kueue.InadmissibilityReasonInsufficientUnusedQuota,
+						Missing:              ptr.To(resource.MustParse("2")),
+						BorrowingConsidered:  true,
+						PreemptionConsidered: true,
+					}},
+				}},
+			}},
+		},
+		"borrows while higher priorities use the nominal quota": {
+			admitted:    []kueue.Workload{admitted("high", "cq", 1000, "10")},
+			wlCPU:       "4",
+			wantRepMode: Fit,
+			wantBorrow: cache.FlavorResourceQuantities{
+				"one": {corev1.ResourceCPU: 4_000},
+			},
+		},
+		"doesn't borrow beyond the quota not reserved to higher priorities": {
+			admitted: []kueue.Workload{admitted("high", "cq", 1000, "10")},
+			wlCPU:    "5",
+			wantInadmissibility: []kueue.PodSetInadmissibility{{
+				Name: "main",
+				Flavors: []kueue.FlavorInadmissibility{{
+					Name: "one",
+					Resources: []kueue.ResourceInadmissibility{{
+						Name:    corev1.ResourceCPU,
+						Reason:  kueue.InadmissibilityReasonQuotaReservedToHigherPriorities,
+						Missing: ptr.To(resource.MustParse("1")),
+					}},
+				}},
+			}},
+		},
+		"borrows in the quota reserved to its priority": {
+			admitted:    []kueue.Workload{admitted("high", "cq", 1000, "10")},
+			wlPriority:  1000,
+			wlCPU:       "5",
+			wantRepMode: Fit,
+			wantBorrow: cache.FlavorResourceQuantities{
+				"one": {corev1.ResourceCPU: 5_000},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, log := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
+				Build()
+			cqCache := cache.New(cl)
+			for _, flv := range flavors {
+				cqCache.AddOrUpdateResourceFlavor(flv)
+			}
+			for _, cq := range clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+				}
+			}
+			snapshot := cqCache.Snapshot()
+			wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+				Priority(tc.wlPriority).
+				Request(corev1.ResourceCPU, tc.wlCPU).
+				Obj())
+			wlInfo.ClusterQueue = "cq"
+
+			assignment := AssignFlavors(log, wlInfo, snapshot.ResourceFlavors, snapshot.ClusterQueues["cq"], nil)
+			if repMode := assignment.RepresentativeMode(); repMode != tc.wantRepMode {
+				t.Errorf("assignment.RepresentativeMode()=%s, want %s", repMode, tc.wantRepMode)
+			}
+			if diff := cmp.Diff(tc.wantBorrow, assignment.TotalBorrow, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected borrowing (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantInadmissibility, assignment.Inadmissibility()); diff != "" {
+				t.Errorf("Unexpected inadmissibility (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsLastAdmission(t *testing.T) {
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"one": utiltesting.MakeResourceFlavor("one").Obj(),
+		"two": utiltesting.MakeResourceFlavor("two").Obj(),
+	}
+	cases := map[string]struct {
+		disableFlavorStickiness bool
+		lastAdmission           *kueue.Admission
+		usage                   cache.FlavorResourceQuantities
+		wantFlavor              kueue.ResourceFlavorReference
+	}{
+		"no last admission": {
+			wantFlavor: "one",
+		},
+		"last flavor": {
+			lastAdmission: utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "two", "2").Obj(),
+			wantFlavor:    "two",
+		},
+		"request doesn't fit in the last flavor": {
+			lastAdmission: utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "two", "2").Obj(),
+			usage: cache.FlavorResourceQuantities{
+				"two": {corev1.ResourceCPU: 3000},
+			},
+			wantFlavor: "one",
+		},
+		"last admission by another ClusterQueue": {
+			lastAdmission: utiltesting.MakeAdmission("other-cq").Assignment(corev1.ResourceCPU, "two", "2").Obj(),
+			wantFlavor:    "one",
+		},
+		"FlavorStickiness disabled": {
+			disableFlavorStickiness: true,
+			lastAdmission:           utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "two", "2").Obj(),
+			wantFlavor:              "one",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer features.SetFeatureGateDuringTest(t, features.FlavorStickiness, !tc.disableFlavorStickiness)()
+			log := testr.NewWithOptions(t, testr.Options{
+				Verbosity: 2,
+			})
+			wlInfo := workload.NewInfo(&kueue.Workload{
+				Spec: kueue.WorkloadSpec{
+					PodSets: []kueue.PodSet{
+						*utiltesting.MakePodSet("main", 1).
+							Request(corev1.ResourceCPU, "2").
+							Obj(),
+					},
+				},
+				Status: kueue.WorkloadStatus{
+					LastAdmission: tc.lastAdmission,
+				},
+			})
+			clusterQueue := cache.ClusterQueue{
+				Name: "cq",
+				FlavorFungibility: kueue.FlavorFungibility{
+					WhenCanBorrow:  kueue.Borrow,
+					WhenCanPreempt: kueue.TryNextFlavor,
+				},
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "one",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 4000},
+						},
+					}, {
+						Name: "two",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 4000},
+						},
+					}},
+				}},
+				Usage: tc.usage,
+			}
+			clusterQueue.UpdateWithFlavors(resourceFlavors)
+			clusterQueue.UpdateRGByResource()
+			assignment := AssignFlavors(log, wlInfo, resourceFlavors, &clusterQueue, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != Fit {
+				t.Fatalf("Unexpected representative mode %s, want %s", repMode, Fit)
+			}
+			if got := assignment.PodSets[0].Flavors[corev1.ResourceCPU].Name; got != tc.wantFlavor {
+				t.Errorf("Assigned flavor %s, want %s", got, tc.wantFlavor)
+			}
 		})
 	}
 }
@@ -1951,3 +3006,65 @@ func TestLastAssignmentOutdated(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkAssignFlavors(b *testing.B) {
+	const flavors = 64
+	resourceFlavors := make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, flavors)
+	rg := cache.ResourceGroup{CoveredResources: sets.New(corev1.ResourceCPU)}
+	for i := 0; i < flavors; i++ {
+		name := kueue.ResourceFlavorReference(fmt.Sprintf("flavor-%d", i))
+		resourceFlavors[name] = utiltesting.MakeResourceFlavor(string(name)).
+			Label("zone", fmt.Sprintf("zone-%d", i)).
+			Taint(corev1.Taint{
+				Key:    "instance",
+				Value:  "spot",
+				Effect: corev1.TaintEffectNoSchedule,
+			}).Obj()
+		rg.Flavors = append(rg.Flavors, cache.FlavorQuotas{
+			Name: name,
+			Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+				corev1.ResourceCPU: {Nominal: 100_000},
+			},
+		})
+	}
+	toleration := corev1.Toleration{
+		Key:      "instance",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "spot",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	for _, count := range []int{1, 8, 32} {
+		for _, same := range []bool{false, true} {
+			podSets := make([]kueue.PodSet, count)
+			for i := range podSets {
+				// Every podset matches a single flavor, at the end of the list.
+				zone := flavors - 1 - i
+				if same {
+					zone = flavors - 1
+				}
+				podSets[i] = *utiltesting.MakePodSet(fmt.Sprintf("ps-%d", i), 1).
+					Request(corev1.ResourceCPU, "1").
+					NodeSelector(map[string]string{"zone": fmt.Sprintf("zone-%d", zone)}).
+					Toleration(toleration).
+					Obj()
+			}
+			wl := workload.NewInfo(&kueue.Workload{Spec: kueue.WorkloadSpec{PodSets: podSets}})
+			b.Run(fmt.Sprintf("podsets=%d/same=%t", count, same), func(b *testing.B) {
+				cq := cache.ClusterQueue{
+					ResourceGroups: []cache.ResourceGroup{rg},
+					FlavorFungibility: kueue.FlavorFungibility{
+						WhenCanBorrow:  kueue.Borrow,
+						WhenCanPreempt: kueue.TryNextFlavor,
+					},
+				}
+				cq.UpdateWithFlavors(resourceFlavors)
+				cq.UpdateRGByResource()
+				log := logr.Discard()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					AssignFlavors(log, wl, resourceFlavors, &cq, nil)
+				}
+			})
+		}
+	}
+}
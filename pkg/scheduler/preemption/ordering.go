@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preemption
+
+import (
+	"time"
+
+	"sigs.k8s.io/kueue/pkg/util/priority"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// Ordering decides the order in which the candidates for a preemption are
+// considered as victims. Kueue preempts the first candidates that make room
+// for the preemptor, and then skips the ones that turn out not to be needed.
+//
+// The candidates from the other ClusterQueues of the cohort, reclaiming the
+// quota they borrowed, are always considered before the candidates from the
+// ClusterQueue of the preemptor. An Ordering only sorts the candidates within
+// each of the two groups, so it cannot make Kueue preempt workloads that the
+// preemption policies of the ClusterQueues don't allow.
+//
+// Implementations are called by the scheduler while it holds a snapshot of
+// the cache, so they should not block. Information from external systems,
+// like the time of the last checkpoint of the workloads, should be gathered
+// beforehand, for example from the annotations of the workloads.
+//
+// The candidates hold the compact copies of the workloads kept in the cache,
+// see workload.Compact: the labels, the annotations other than the last
+// applied configuration of kubectl, the spec without the pod templates and
+// the status are available.
+type Ordering interface {
+	// Less reports whether the candidate a should be preempted before b.
+	Less(a, b *workload.Info, now time.Time) bool
+}
+
+// OrderingFunc adapts a function to the Ordering interface.
+type OrderingFunc func(a, b *workload.Info, now time.Time) bool
+
+// Less calls f(a, b, now).
+func (f OrderingFunc) Less(a, b *workload.Info, now time.Time) bool {
+	return f(a, b, now)
+}
+
+// DefaultOrdering considers the workloads with lower priority first, and the
+// workloads that were admitted more recently first among the workloads with
+// the same priority.
+var DefaultOrdering Ordering = OrderingFunc(func(a, b *workload.Info, now time.Time) bool {
+	pa := priority.Priority(a.Obj)
+	pb := priority.Priority(b.Obj)
+	if pa != pb {
+		return pa < pb
+	}
+	return quotaReservationTime(b.Obj, now).Before(quotaReservationTime(a.Obj, now))
+})
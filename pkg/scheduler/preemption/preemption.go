@@ -18,8 +18,9 @@ package preemption
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"sort"
-	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -45,6 +46,7 @@ const parallelPreemptions = 8
 type Preemptor struct {
 	client   client.Client
 	recorder record.EventRecorder
+	ordering Ordering
 
 	// stubs
 	applyPreemption func(context.Context, *kueue.Workload) error
@@ -54,11 +56,17 @@ func New(cl client.Client, recorder record.EventRecorder) *Preemptor {
 	p := &Preemptor{
 		client:   cl,
 		recorder: recorder,
+		ordering: DefaultOrdering,
 	}
 	p.applyPreemption = p.applyPreemptionWithSSA
 	return p
 }
 
+// SetOrdering replaces the DefaultOrdering of the candidates for preemption.
+func (p *Preemptor) SetOrdering(o Ordering) {
+	p.ordering = o
+}
+
 func (p *Preemptor) OverrideApply(f func(context.Context, *kueue.Workload) error) {
 	p.applyPreemption = f
 }
@@ -74,15 +82,20 @@ func candidatesOnlyFromQueue(candidates []*workload.Info, clusterQueue string) [
 }
 
 // GetTargets returns the list of workloads that should be evicted in order to make room for wl.
+// The targets are sorted by the order in which they were chosen as candidates.
 func (p *Preemptor) GetTargets(wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*workload.Info {
 	resPerFlv := resourcesRequiringPreemption(assignment)
 	cq := snapshot.ClusterQueues[wl.ClusterQueue]
+	if !snapshot.WorkloadPriorityClassOf(wl.Obj).CanPreempt {
+		return nil
+	}
 
-	candidates := findCandidates(wl.Obj, cq, resPerFlv)
+	now := time.Now()
+	candidates := findCandidates(wl.Obj, cq, resPerFlv, snapshot, now)
 	if len(candidates) == 0 {
 		return nil
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, time.Now()))
+	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, now, p.ordering))
 
 	sameQueueCandidates := candidatesOnlyFromQueue(candidates, wl.ClusterQueue)
 	var targets []*workload.Info
@@ -112,42 +125,83 @@ func (p *Preemptor) GetTargets(wl workload.Info, assignment flavorassigner.Assig
 	return targets
 }
 
-// IssuePreemptions marks the target workloads as evicted.
-func (p *Preemptor) IssuePreemptions(ctx context.Context, targets []*workload.Info, cq *cache.ClusterQueue) (int, error) {
+// IssuePreemptions marks the target workloads as evicted. The targets whose
+// ClusterQueue has a preemption gracePeriod are marked as preempting instead,
+// and they are evicted by the workload controller once the grace period expires.
+// It returns the targets that were successfully preempted, or whose preemption
+// was already ongoing.
+func (p *Preemptor) IssuePreemptions(ctx context.Context, targets []*workload.Info, cq *cache.ClusterQueue) ([]*workload.Info, error) {
 	log := ctrl.LoggerFrom(ctx)
 	errCh := routine.NewErrorChannel()
 	ctx, cancel := context.WithCancel(ctx)
-	var successfullyPreempted int64
+	successfullyPreempted := make([]bool, len(targets))
 	defer cancel()
 	workqueue.ParallelizeUntil(ctx, parallelPreemptions, len(targets), func(i int) {
 		target := targets[i]
-		if !meta.IsStatusConditionTrue(target.Obj.Status.Conditions, kueue.WorkloadEvicted) {
-			err := p.applyPreemption(ctx, target.Obj)
+		if !meta.IsStatusConditionTrue(target.Obj.Status.Conditions, kueue.WorkloadEvicted) && !workload.IsPreempting(target.Obj) {
+			origin := "ClusterQueue"
+			if cq.Name != target.ClusterQueue {
+				origin = "cohort"
+			}
+			w := target.Obj.DeepCopy()
+			gracePeriod := preemptionGracePeriod(target, cq)
+			if gracePeriod > 0 {
+				workload.SetPreemptingCondition(w, kueue.WorkloadEvictedByPreemption, fmt.Sprintf("Selected as a preemption target, evicting after the grace period of %s", gracePeriod))
+			} else {
+				workload.SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, "Preempted to accommodate a higher priority Workload")
+			}
+			err := p.applyPreemption(ctx, w)
 			if err != nil {
 				errCh.SendErrorWithCancel(err, cancel)
 				return
 			}
 
-			origin := "ClusterQueue"
-			if cq.Name != target.ClusterQueue {
-				origin = "cohort"
+			if gracePeriod > 0 {
+				log.V(3).Info("Preempting", "targetWorkload", klog.KObj(target.Obj), "gracePeriod", gracePeriod)
+				p.recorder.Eventf(target.Obj, corev1.EventTypeNormal, "Preempting", "Selected as a preemption target by another workload in the %s, evicting after the grace period of %s", origin, gracePeriod)
+			} else {
+				log.V(3).Info("Preempted", "targetWorkload", klog.KObj(target.Obj))
+				p.recorder.Eventf(target.Obj, corev1.EventTypeNormal, "Preempted", "Preempted by another workload in the %s", origin)
 			}
-			log.V(3).Info("Preempted", "targetWorkload", klog.KObj(target.Obj))
-			p.recorder.Eventf(target.Obj, corev1.EventTypeNormal, "Preempted", "Preempted by another workload in the %s", origin)
 		} else {
 			log.V(3).Info("Preemption ongoing", "targetWorkload", klog.KObj(target.Obj))
 		}
-		atomic.AddInt64(&successfullyPreempted, 1)
+		successfullyPreempted[i] = true
 	})
-	return int(successfullyPreempted), errCh.ReceiveError()
+	var preempted []*workload.Info
+	for i, target := range targets {
+		if successfullyPreempted[i] {
+			preempted = append(preempted, target)
+		}
+	}
+	return preempted, errCh.ReceiveError()
 }
 
 func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w *kueue.Workload) error {
-	w = w.DeepCopy()
-	workload.SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, "Preempted to accommodate a higher priority Workload")
 	return workload.ApplyAdmissionStatus(ctx, p.client, w, false)
 }
 
+// preemptionGracePeriod returns the preemption gracePeriod of the ClusterQueue
+// of the target, which is either cq or a ClusterQueue in its cohort.
+func preemptionGracePeriod(target *workload.Info, cq *cache.ClusterQueue) time.Duration {
+	targetCQ := cq
+	if target.ClusterQueue != cq.Name {
+		targetCQ = nil
+		if cq.Cohort != nil {
+			for member := range cq.Cohort.Members {
+				if member.Name == target.ClusterQueue {
+					targetCQ = member
+					break
+				}
+			}
+		}
+	}
+	if targetCQ == nil || targetCQ.Preemption.GracePeriod == nil {
+		return 0
+	}
+	return targetCQ.Preemption.GracePeriod.Duration
+}
+
 // minimalPreemptions implements a heuristic to find a minimal set of Workloads
 // to preempt.
 // The heuristic first removes candidates, in the input order, while their
@@ -185,9 +239,9 @@ func minimalPreemptions(wl *workload.Info, assignment flavorassigner.Assignment,
 	for i := len(targets) - 2; i >= 0; i-- {
 		snapshot.AddWorkload(targets[i])
 		if workloadFits(wlReq, cq, allowBorrowing) {
-			// O(1) deletion: copy the last element into index i and reduce size.
-			targets[i] = targets[len(targets)-1]
-			targets = targets[:len(targets)-1]
+			// Keep the order of the candidates, which is the order in which
+			// the targets are reported.
+			targets = slices.Delete(targets, i, i+1)
 		} else {
 			snapshot.RemoveWorkload(targets[i])
 		}
@@ -220,9 +274,11 @@ func resourcesRequiringPreemption(assignment flavorassigner.Assignment) resource
 }
 
 // findCandidates obtains candidates for preemption within the ClusterQueue and
-// cohort that respect the preemption policy and are using a resource that the
-// preempting workload needs.
-func findCandidates(wl *kueue.Workload, cq *cache.ClusterQueue, resPerFlv resourcesPerFlavor) []*workload.Info {
+// cohort that respect the preemption policy, are using a resource that the
+// preempting workload needs and are not protected by the minRuntime of their
+// ClusterQueue. The non-preemptible workloads are only candidates in the
+// ClusterQueues that borrow the quota reclaimed by the preempting workload.
+func findCandidates(wl *kueue.Workload, cq *cache.ClusterQueue, resPerFlv resourcesPerFlavor, snapshot *cache.Snapshot, now time.Time) []*workload.Info {
 	var candidates []*workload.Info
 	wlPriority := priority.Priority(wl)
 
@@ -240,7 +296,10 @@ func findCandidates(wl *kueue.Workload, cq *cache.ClusterQueue, resPerFlv resour
 				continue
 			}
 
-			if !workloadUsesResources(candidateWl, resPerFlv) {
+			if !workloadUsesResources(candidateWl, resPerFlv) || snapshot.WorkloadPriorityClassOf(candidateWl.Obj).NonPreemptible {
+				continue
+			}
+			if protectedByMinRuntime(candidateWl, cq, now) {
 				continue
 			}
 			candidates = append(candidates, candidateWl)
@@ -264,6 +323,9 @@ func findCandidates(wl *kueue.Workload, cq *cache.ClusterQueue, resPerFlv resour
 				if !workloadUsesResources(candidateWl, resPerFlv) {
 					continue
 				}
+				if protectedByMinRuntime(candidateWl, cohortCQ, now) {
+					continue
+				}
 				candidates = append(candidates, candidateWl)
 			}
 		}
@@ -271,6 +333,16 @@ func findCandidates(wl *kueue.Workload, cq *cache.ClusterQueue, resPerFlv resour
 	return candidates
 }
 
+// protectedByMinRuntime returns whether the workload was admitted by its
+// ClusterQueue less than the minRuntime of the ClusterQueue ago.
+func protectedByMinRuntime(wl *workload.Info, cq *cache.ClusterQueue, now time.Time) bool {
+	if cq.Preemption.MinRuntime == nil {
+		return false
+	}
+	until, protected := workload.PreemptionProtectedUntil(wl.Obj, cq.Preemption.MinRuntime.Duration)
+	return protected && now.Before(until)
+}
+
 func cqIsBorrowing(cq *cache.ClusterQueue, resPerFlv resourcesPerFlavor) bool {
 	if cq.Cohort == nil {
 		return false
@@ -352,9 +424,10 @@ func workloadFits(wlReq cache.FlavorResourceQuantities, cq *cache.ClusterQueue,
 // candidatesOrdering criteria:
 // 1. Workloads from other ClusterQueues in the cohort before the ones in the
 // same ClusterQueue as the preemptor.
-// 2. Workloads with lower priority first.
-// 3. Workloads admitted more recently first.
-func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time) func(int, int) bool {
+// 2. The order of the ordering, by default:
+//   - Workloads with lower priority first.
+//   - Workloads admitted more recently first.
+func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time, ordering Ordering) func(int, int) bool {
 	return func(i, j int) bool {
 		a := candidates[i]
 		b := candidates[j]
@@ -363,12 +436,7 @@ func candidatesOrdering(candidates []*workload.Info, cq string, now time.Time) f
 		if aInCQ != bInCQ {
 			return !aInCQ
 		}
-		pa := priority.Priority(a.Obj)
-		pb := priority.Priority(b.Obj)
-		if pa != pb {
-			return pa < pb
-		}
-		return quotaReservationTime(b.Obj, now).Before(quotaReservationTime(a.Obj, now))
+		return ordering.Less(a, b, now)
 	}
 }
 
@@ -18,6 +18,8 @@ package preemption
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"sort"
 	"sync"
 	"testing"
@@ -26,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -54,6 +57,15 @@ var snapCmpOpts = []cmp.Option{
 }
 
 func TestPreemption(t *testing.T) {
+	now := time.Now()
+	admittedAt := func(t time.Time) metav1.Condition {
+		return metav1.Condition{
+			Type:               kueue.WorkloadAdmitted,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Admitted",
+			LastTransitionTime: metav1.NewTime(t),
+		}
+	}
 	flavors := []*kueue.ResourceFlavor{
 		utiltesting.MakeResourceFlavor("default").Obj(),
 		utiltesting.MakeResourceFlavor("alpha").Obj(),
@@ -122,13 +134,24 @@ func TestPreemption(t *testing.T) {
 				WithinClusterQueue: kueue.PreemptionPolicyLowerOrNewerEqualPriority,
 			}).
 			Obj(),
+		utiltesting.MakeClusterQueue("minRuntime").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "6").
+				Obj(),
+			).
+			Preemption(kueue.ClusterQueuePreemption{
+				WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+				MinRuntime:         &metav1.Duration{Duration: 10 * time.Minute},
+			}).
+			Obj(),
 	}
 	cases := map[string]struct {
-		admitted      []kueue.Workload
-		incoming      *kueue.Workload
-		targetCQ      string
-		assignment    flavorassigner.Assignment
-		wantPreempted sets.Set[string]
+		admitted        []kueue.Workload
+		priorityClasses []*kueue.WorkloadPriorityClass
+		incoming        *kueue.Workload
+		targetCQ        string
+		assignment      flavorassigner.Assignment
+		wantPreempted   sets.Set[string]
 	}{
 		"preempt lowest priority": {
 			admitted: []kueue.Workload{
@@ -160,6 +183,66 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New("/low"),
 		},
+		"preempt the lowest priority workload not protected by minRuntime": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("recent", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("minRuntime").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					SetOrReplaceCondition(admittedAt(now.Add(-time.Minute))).
+					Obj(),
+				*utiltesting.MakeWorkload("old", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("minRuntime").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					SetOrReplaceCondition(admittedAt(now.Add(-20 * time.Minute))).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("minRuntime").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					SetOrReplaceCondition(admittedAt(now.Add(-20 * time.Minute))).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "minRuntime",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New("/old"),
+		},
+		"can't preempt the workloads protected by minRuntime": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("recent", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("minRuntime").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+					SetOrReplaceCondition(admittedAt(now.Add(-time.Minute))).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("minRuntime").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					SetOrReplaceCondition(admittedAt(now.Add(-20 * time.Minute))).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "minRuntime",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+		},
 		"preempt multiple": {
 			admitted: []kueue.Workload{
 				*utiltesting.MakeWorkload("low", "").
@@ -268,6 +351,66 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New("/low"),
 		},
+		"skip the non-preemptible workloads": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					PriorityClass("critical").
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					Obj(),
+			},
+			priorityClasses: []*kueue.WorkloadPriorityClass{
+				utiltesting.MakeWorkloadPriorityClass("critical").PriorityValue(-1).NonPreemptible().Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New("/mid"),
+		},
+		"workloads of a priority class that never preempts": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "6000m").Obj()).
+					Obj(),
+			},
+			priorityClasses: []*kueue.WorkloadPriorityClass{
+				utiltesting.MakeWorkloadPriorityClass("batch").PriorityValue(1).PreemptionPolicy(kueue.PreemptionPolicyNever).Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				PriorityClass("batch").
+				PriorityClassSource(constants.WorkloadPriorityClassSource).
+				Request(corev1.ResourceCPU, "2").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+		},
 		"minimal set excludes low priority": {
 			admitted: []kueue.Workload{
 				*utiltesting.MakeWorkload("low", "").
@@ -363,6 +506,41 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New("/c2-mid"),
 		},
+		"reclaim quota from a non-preemptible borrower": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("c1-low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "3000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c2-mid", "").
+					PriorityClass("critical").
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("c2").Assignment(corev1.ResourceCPU, "default", "3000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c2-high", "").
+					Priority(1).
+					Request(corev1.ResourceCPU, "6").
+					ReserveQuota(utiltesting.MakeAdmission("c2").Assignment(corev1.ResourceCPU, "default", "6000m").Obj()).
+					Obj(),
+			},
+			priorityClasses: []*kueue.WorkloadPriorityClass{
+				utiltesting.MakeWorkloadPriorityClass("critical").PriorityValue(0).NonPreemptible().Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				Request(corev1.ResourceCPU, "3").
+				Obj(),
+			targetCQ: "c1",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New("/c2-mid"),
+		},
 		"no workloads borrowing": {
 			admitted: []kueue.Workload{
 				*utiltesting.MakeWorkload("c1-high", "").
@@ -766,6 +944,9 @@ func TestPreemption(t *testing.T) {
 			for _, flv := range flavors {
 				cqCache.AddOrUpdateResourceFlavor(flv)
 			}
+			for _, wpc := range tc.priorityClasses {
+				cqCache.AddOrUpdateWorkloadPriorityClass(wpc)
+			}
 			for _, cq := range clusterQueues {
 				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
 					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
@@ -798,8 +979,8 @@ func TestPreemption(t *testing.T) {
 			if diff := cmp.Diff(tc.wantPreempted, gotPreempted, cmpopts.EquateEmpty()); diff != "" {
 				t.Errorf("Issued preemptions (-want,+got):\n%s", diff)
 			}
-			if preempted != tc.wantPreempted.Len() {
-				t.Errorf("Reported %d preemptions, want %d", preempted, tc.wantPreempted.Len())
+			if len(preempted) != tc.wantPreempted.Len() {
+				t.Errorf("Reported %d preemptions, want %d", len(preempted), tc.wantPreempted.Len())
 			}
 			if diff := cmp.Diff(startingSnapshot, snapshot, snapCmpOpts...); diff != "" {
 				t.Errorf("Snapshot was modified (-initial,+end):\n%s", diff)
@@ -814,6 +995,7 @@ func TestCandidatesOrdering(t *testing.T) {
 		workload.NewInfo(utiltesting.MakeWorkload("high", "").
 			ReserveQuota(utiltesting.MakeAdmission("self").Obj()).
 			Priority(10).
+			Annotations(map[string]string{"example.com/checkpoint": "2023-11-01T10:00:00Z"}).
 			Obj()),
 		workload.NewInfo(utiltesting.MakeWorkload("low", "").
 			ReserveQuota(utiltesting.MakeAdmission("self").Obj()).
@@ -826,6 +1008,7 @@ func TestCandidatesOrdering(t *testing.T) {
 			Obj()),
 		workload.NewInfo(utiltesting.MakeWorkload("old", "").
 			ReserveQuota(utiltesting.MakeAdmission("self").Obj()).
+			Annotations(map[string]string{"example.com/checkpoint": "2023-11-01T09:00:00Z"}).
 			Obj()),
 		workload.NewInfo(utiltesting.MakeWorkload("current", "").
 			ReserveQuota(utiltesting.MakeAdmission("self").Obj()).
@@ -836,14 +1019,152 @@ func TestCandidatesOrdering(t *testing.T) {
 			}).
 			Obj()),
 	}
-	sort.Slice(candidates, candidatesOrdering(candidates, "self", now))
-	gotNames := make([]string, len(candidates))
-	for i, c := range candidates {
-		gotNames[i] = workload.Key(c.Obj)
+	// Prefer the candidates that checkpointed more recently, then the default ordering.
+	checkpointed := OrderingFunc(func(a, b *workload.Info, now time.Time) bool {
+		ca, cb := a.Obj.Annotations["example.com/checkpoint"], b.Obj.Annotations["example.com/checkpoint"]
+		if ca != cb {
+			return ca > cb
+		}
+		return DefaultOrdering.Less(a, b, now)
+	})
+	cases := map[string]struct {
+		ordering       Ordering
+		wantCandidates []string
+	}{
+		"default ordering": {
+			ordering:       DefaultOrdering,
+			wantCandidates: []string{"/other", "/low", "/current", "/old", "/high"},
+		},
+		"custom ordering": {
+			ordering:       checkpointed,
+			wantCandidates: []string{"/other", "/high", "/old", "/low", "/current"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sorted := slices.Clone(candidates)
+			sort.Slice(sorted, candidatesOrdering(sorted, "self", now, tc.ordering))
+			gotNames := make([]string, len(sorted))
+			for i, c := range sorted {
+				gotNames[i] = workload.Key(c.Obj)
+			}
+			if diff := cmp.Diff(tc.wantCandidates, gotNames); diff != "" {
+				t.Errorf("Sorted with wrong order (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIssuePreemptionsWithGracePeriod(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			Resource(corev1.ResourceCPU, "6").
+			Obj(),
+		).
+		Preemption(kueue.ClusterQueuePreemption{
+			WithinClusterQueue: kueue.PreemptionPolicyLowerPriority,
+			GracePeriod:        &metav1.Duration{Duration: 5 * time.Minute},
+		}).
+		Obj()
+	cases := map[string]struct {
+		target      *kueue.Workload
+		wantApplied bool
+	}{
+		"marked as preempting": {
+			target: utiltesting.MakeWorkload("wl", "").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				Obj(),
+			wantApplied: true,
+		},
+		"already preempting": {
+			target: utiltesting.MakeWorkload("wl", "").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+				SetOrReplaceCondition(metav1.Condition{
+					Type:   kueue.WorkloadPreempting,
+					Status: metav1.ConditionTrue,
+					Reason: kueue.WorkloadEvictedByPreemption,
+				}).
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().Build()
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+			}
+			snapshot := cqCache.Snapshot()
+
+			broadcaster := record.NewBroadcaster()
+			recorder := broadcaster.NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+			preemptor := New(cl, recorder)
+			var applied *kueue.Workload
+			preemptor.applyPreemption = func(ctx context.Context, w *kueue.Workload) error {
+				applied = w
+				return nil
+			}
+
+			target := workload.NewInfo(tc.target)
+			target.ClusterQueue = "cq"
+			preempted, err := preemptor.IssuePreemptions(ctx, []*workload.Info{target}, snapshot.ClusterQueues["cq"])
+			if err != nil {
+				t.Fatalf("Failed doing preemption: %v", err)
+			}
+			if len(preempted) != 1 {
+				t.Errorf("Reported %d preemptions, want 1", len(preempted))
+			}
+			if gotApplied := applied != nil; gotApplied != tc.wantApplied {
+				t.Fatalf("Preemption applied: %t, want %t", gotApplied, tc.wantApplied)
+			}
+			if applied == nil {
+				return
+			}
+			if !workload.IsPreempting(applied) {
+				t.Errorf("The target is not preempting, conditions: %v", applied.Status.Conditions)
+			}
+			if apimeta.IsStatusConditionTrue(applied.Status.Conditions, kueue.WorkloadEvicted) {
+				t.Errorf("The target was evicted before its grace period")
+			}
+		})
+	}
+}
+
+func TestIssuePreemptionsReportsFailedTargets(t *testing.T) {
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().Build()
+	cqCache := cache.New(cl)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			Resource(corev1.ResourceCPU, "6").
+			Obj(),
+		).
+		Obj()
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+	}
+	snapshot := cqCache.Snapshot()
+
+	broadcaster := record.NewBroadcaster()
+	recorder := broadcaster.NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+	preemptor := New(cl, recorder)
+	preemptor.applyPreemption = func(ctx context.Context, w *kueue.Workload) error {
+		return errors.New("conflict")
+	}
+
+	target := workload.NewInfo(utiltesting.MakeWorkload("wl", "").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).
+		Obj())
+	target.ClusterQueue = "cq"
+	preempted, err := preemptor.IssuePreemptions(ctx, []*workload.Info{target}, snapshot.ClusterQueues["cq"])
+	if err == nil {
+		t.Errorf("Expected the preemption to fail")
 	}
-	wantCandidates := []string{"/other", "/low", "/current", "/old", "/high"}
-	if diff := cmp.Diff(wantCandidates, gotNames); diff != "" {
-		t.Errorf("Sorted with wrong order (-want,+got):\n%s", diff)
+	if len(preempted) != 0 {
+		t.Errorf("Reported %d preemptions, want 0", len(preempted))
 	}
 }
 
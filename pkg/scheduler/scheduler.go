@@ -22,23 +22,26 @@ import (
 	"maps"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/audit"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
@@ -50,12 +53,22 @@ import (
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/resource"
+	"sigs.k8s.io/kueue/pkg/util/resourcequota"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 const (
 	errCouldNotAdmitWL = "Could not admit Workload and assign flavors in apiserver"
+
+	// parallelCohorts is the number of cohorts scheduled concurrently when
+	// the ParallelCohortScheduling feature is enabled.
+	parallelCohorts = 8
+
+	// maxAdmissionsPerClusterQueue is the maximum number of workloads, including
+	// the head, admitted from a ClusterQueue in a cycle when the BatchAdmission
+	// feature is enabled.
+	maxAdmissionsPerClusterQueue = 8
 )
 
 type Scheduler struct {
@@ -65,16 +78,43 @@ type Scheduler struct {
 	recorder                record.EventRecorder
 	admissionRoutineWrapper routine.Wrapper
 	preemptor               *preemption.Preemptor
+	auditBackend            audit.Backend
+	// admissionMu serializes the admissions of the groups scheduled
+	// concurrently, so that blocking the admissions until the Pods of the
+	// admitted workloads are ready holds across cohorts.
+	admissionMu sync.Mutex
+	// snapshot is updated at the beginning of every cycle, reusing the parts
+	// of the cache that didn't change since the previous cycle.
+	snapshot cache.Snapshot
 	// Stubs.
 	applyAdmission func(context.Context, *kueue.Workload) error
 }
 
 type options struct {
+	auditBackend       audit.Backend
+	preemptionOrdering preemption.Ordering
 }
 
 // Option configures the reconciler.
 type Option func(*options)
 
+// WithAuditBackend sets the backend where a record of the admission and
+// preemption decisions is persisted.
+func WithAuditBackend(b audit.Backend) Option {
+	return func(o *options) {
+		o.auditBackend = b
+	}
+}
+
+// WithPreemptionOrdering sets the order in which the candidates for a
+// preemption are considered as victims, instead of the
+// preemption.DefaultOrdering.
+func WithPreemptionOrdering(ordering preemption.Ordering) Option {
+	return func(o *options) {
+		o.preemptionOrdering = ordering
+	}
+}
+
 var defaultOptions = options{}
 
 func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder record.EventRecorder, opts ...Option) *Scheduler {
@@ -89,6 +129,10 @@ func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder r
 		recorder:                recorder,
 		preemptor:               preemption.New(cl, recorder),
 		admissionRoutineWrapper: routine.DefaultWrapper,
+		auditBackend:            options.auditBackend,
+	}
+	if options.preemptionOrdering != nil {
+		s.preemptor.SetOrdering(options.preemptionOrdering)
 	}
 	s.applyAdmission = s.applyAdmissionWithSSA
 	return s
@@ -153,6 +197,30 @@ func (cu *cohortsUsage) hasCommonFlavorResources(cohort string, assigment cache.
 	return false
 }
 
+// namespacesUsage holds the ResourceQuota usage of the workloads admitted in a
+// cycle, by namespace, as the status of the quotas doesn't include them yet.
+// The ClusterQueues scheduled concurrently can admit workloads of the same
+// namespace, so it's safe for concurrent use.
+type namespacesUsage struct {
+	sync.Mutex
+	usage map[string]corev1.ResourceList
+}
+
+func (nu *namespacesUsage) add(namespace string, usage corev1.ResourceList) {
+	nu.Lock()
+	defer nu.Unlock()
+	if nu.usage == nil {
+		nu.usage = make(map[string]corev1.ResourceList)
+	}
+	nu.usage[namespace] = resource.MergeResourceListKeepSum(nu.usage[namespace], usage)
+}
+
+func (nu *namespacesUsage) get(namespace string) corev1.ResourceList {
+	nu.Lock()
+	defer nu.Unlock()
+	return nu.usage[namespace]
+}
+
 func (s *Scheduler) schedule(ctx context.Context) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -165,16 +233,58 @@ func (s *Scheduler) schedule(ctx context.Context) {
 	}
 	startTime := time.Now()
 
-	// 2. Take a snapshot of the cache.
-	snapshot := s.cache.Snapshot()
+	// 2. Update the snapshot of the cache.
+	s.cache.UpdateSnapshot(&s.snapshot)
+	snapshot := &s.snapshot
+
+	// 3. Nominate and admit the heads. The heads in different cohorts don't
+	// compete for the same quota, so they can be processed concurrently.
+	var entries []entry
+	cycleNamespacesUsage := &namespacesUsage{}
+	if features.Enabled(features.ParallelCohortScheduling) {
+		groups := groupByCohort(headWorkloads, snapshot)
+		groupEntries := make([][]entry, len(groups))
+		workqueue.ParallelizeUntil(ctx, parallelCohorts, len(groups), func(i int) {
+			groupEntries[i] = s.scheduleGroup(ctx, groups[i], snapshot, cycleNamespacesUsage)
+		})
+		for _, ge := range groupEntries {
+			entries = append(entries, ge...)
+		}
+	} else {
+		entries = s.scheduleGroup(ctx, headWorkloads, snapshot, cycleNamespacesUsage)
+	}
+
+	// 4. Requeue the heads that were not scheduled.
+	result := metrics.AdmissionResultInadmissible
+	for _, e := range entries {
+		log.V(3).Info("Workload evaluated for admission",
+			"workload", klog.KObj(e.Obj),
+			"clusterQueue", klog.KRef("", e.ClusterQueue),
+			"status", e.status,
+			"reason", e.inadmissibleMsg)
+		if e.status != assumed {
+			s.requeueAndUpdate(log, ctx, e)
+		} else {
+			result = metrics.AdmissionResultSuccess
+		}
+	}
+	metrics.AdmissionAttempt(result, time.Since(startTime))
+}
+
+// scheduleGroup nominates the heads and admits the ones that fit, in order.
+// The heads of the group must not share quota with the heads of the groups
+// scheduled concurrently, as the group updates the ClusterQueues and cohorts
+// of the snapshot.
+func (s *Scheduler) scheduleGroup(ctx context.Context, heads []workload.Info, snapshot *cache.Snapshot, nsUsage *namespacesUsage) []entry {
+	log := ctrl.LoggerFrom(ctx)
 
-	// 3. Calculate requirements (resource flavors, borrowing) for admitting workloads.
-	entries := s.nominate(ctx, headWorkloads, snapshot)
+	// 1. Calculate requirements (resource flavors, borrowing) for admitting workloads.
+	entries := s.nominate(ctx, heads, snapshot, nsUsage)
 
-	// 4. Sort entries based on borrowing, priorities (if enabled) and timestamps.
+	// 2. Sort entries based on borrowing, priorities (if enabled) and timestamps.
 	sort.Sort(entryOrdering(entries))
 
-	// 5. Admit entries, ensuring that no more than one workload gets
+	// 3. Admit entries, ensuring that no more than one workload gets
 	// admitted by a cohort (if borrowing).
 	// This is because there can be other workloads deeper in a clusterQueue whose
 	// head got admitted that should be scheduled in the cohort before the heads
@@ -215,15 +325,17 @@ func (s *Scheduler) schedule(ctx context.Context) {
 				if err != nil {
 					log.Error(err, "Failed to preempt workloads")
 				}
-				if preempted != 0 {
-					e.inadmissibleMsg += fmt.Sprintf(". Pending the preemption of %d workload(s)", preempted)
+				if len(preempted) != 0 {
+					e.inadmissibleMsg += fmt.Sprintf(". Pending the preemption of %d workload(s)", len(preempted))
 					e.requeueReason = queue.RequeueReasonPendingPreemption
+					s.auditPreemption(log, e, cq, preempted)
 				}
 			} else {
 				log.V(2).Info("Workload requires preemption, but there are no candidate workloads allowed for preemption", "preemptionReclaimWithinCohort", cq.Preemption.ReclaimWithinCohort, "preemptionWithinClusterQueue", cq.Preemption.WithinClusterQueue)
 			}
 			continue
 		}
+		s.admissionMu.Lock()
 		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
 			log.V(5).Info("Waiting for all admitted workloads to be in the PodsReady condition")
 			// If WaitForPodsReady is enabled and WaitForPodsReady.BlockAdmission is true
@@ -237,26 +349,122 @@ func (s *Scheduler) schedule(ctx context.Context) {
 			log.V(5).Info("Finished waiting for all admitted workloads to be in the PodsReady condition")
 		}
 		e.status = nominated
-		if err := s.admit(ctx, e, cq.AdmissionChecks); err != nil {
+		if err := s.admit(ctx, e, cq, nsUsage); err != nil {
 			e.inadmissibleMsg = fmt.Sprintf("Failed to admit workload: %v", err)
 		}
+		s.admissionMu.Unlock()
+	}
+	if features.Enabled(features.BatchAdmission) {
+		entries = append(entries, s.admitBatches(ctx, entries, snapshot, nsUsage)...)
 	}
+	return entries
+}
 
-	// 6. Requeue the heads that were not scheduled.
-	result := metrics.AdmissionResultInadmissible
-	for _, e := range entries {
-		log.V(3).Info("Workload evaluated for admission",
-			"workload", klog.KObj(e.Obj),
-			"clusterQueue", klog.KRef("", e.ClusterQueue),
-			"status", e.status,
-			"reason", e.inadmissibleMsg)
+// admitBatches admits more workloads from the ClusterQueues whose heads were
+// admitted without borrowing, as long as they fit in the remaining quota
+// without borrowing or preemption. A candidate that can't be admitted that way
+// is returned to its queue, to be evaluated as a head in the next cycle, and
+// no more workloads are admitted from its ClusterQueue in this cycle.
+func (s *Scheduler) admitBatches(ctx context.Context, heads []entry, snapshot *cache.Snapshot, nsUsage *namespacesUsage) []entry {
+	log := ctrl.LoggerFrom(ctx)
+	var cqNames []string
+	for i := range heads {
+		e := &heads[i]
 		if e.status != assumed {
-			s.requeueAndUpdate(log, ctx, e)
-		} else {
-			result = metrics.AdmissionResultSuccess
+			continue
+		}
+		// The candidates are assigned flavors against the quota that remains
+		// after the admissions of this cycle. The snapshot is refreshed in the
+		// next cycle, as assuming the workloads changed the cache.
+		snapshot.AddWorkload(assumedWorkloadInfo(e))
+		if !e.assignment.Borrows() {
+			cqNames = append(cqNames, e.ClusterQueue)
 		}
 	}
-	metrics.AdmissionAttempt(result, time.Since(startTime))
+
+	var entries []entry
+	for _, cqName := range cqNames {
+		cq := snapshot.ClusterQueues[cqName]
+		for admitted := 1; admitted < maxAdmissionsPerClusterQueue; admitted++ {
+			head, found := s.queues.PopHead(cqName)
+			if !found {
+				break
+			}
+			candidates := s.nominate(ctx, []workload.Info{head}, snapshot, nsUsage)
+			if len(candidates) == 0 {
+				continue
+			}
+			e := &candidates[0]
+			log := log.WithValues("workload", klog.KObj(e.Obj), "clusterQueue", klog.KRef("", e.ClusterQueue))
+			ctx := ctrl.LoggerInto(ctx, log)
+			if e.assignment.RepresentativeMode() != flavorassigner.Fit || e.assignment.Borrows() {
+				entries = append(entries, skipBatchCandidate(e, "the workload doesn't fit without borrowing or preemption"))
+				break
+			}
+			s.admissionMu.Lock()
+			if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+				s.admissionMu.Unlock()
+				entries = append(entries, skipBatchCandidate(e, "waiting for all admitted workloads to be in PodsReady condition"))
+				break
+			}
+			e.status = nominated
+			err := s.admit(ctx, e, cq, nsUsage)
+			s.admissionMu.Unlock()
+			if err != nil {
+				e.inadmissibleMsg = fmt.Sprintf("Failed to admit workload: %v", err)
+				entries = append(entries, *e)
+				break
+			}
+			snapshot.AddWorkload(assumedWorkloadInfo(e))
+			entries = append(entries, *e)
+		}
+	}
+	return entries
+}
+
+// skipBatchCandidate marks a workload considered for a batch admission as
+// skipped, so that it's returned to its queue as it was.
+func skipBatchCandidate(e *entry, msg string) entry {
+	e.status = skipped
+	if e.inadmissibleMsg == "" {
+		e.inadmissibleMsg = msg
+	}
+	e.LastAssignment = nil
+	return *e
+}
+
+// assumedWorkloadInfo returns the info of the workload of the entry, with the
+// requests of the flavors it was assigned.
+func assumedWorkloadInfo(e *entry) *workload.Info {
+	wl := *e.Obj
+	wl.Status.Admission = &kueue.Admission{
+		ClusterQueue:      kueue.ClusterQueueReference(e.ClusterQueue),
+		PodSetAssignments: e.assignment.ToAPI(),
+	}
+	return workload.NewInfo(&wl)
+}
+
+// groupByCohort splits the heads into groups that can be scheduled
+// independently: the heads of the ClusterQueues in the same cohort go in the
+// same group, and the heads of a ClusterQueue with no cohort go in a group of
+// their own. The groups keep the order of the heads.
+func groupByCohort(heads []workload.Info, snapshot *cache.Snapshot) [][]workload.Info {
+	var groups [][]workload.Info
+	index := make(map[string]int)
+	for _, h := range heads {
+		key := "cq/" + h.ClusterQueue
+		if cq := snapshot.ClusterQueues[h.ClusterQueue]; cq != nil && cq.Cohort != nil {
+			key = "cohort/" + cq.Cohort.Name
+		}
+		i, found := index[key]
+		if !found {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], h)
+	}
+	return groups
 }
 
 type entryStatus string
@@ -282,17 +490,23 @@ type entry struct {
 	inadmissibleMsg   string
 	requeueReason     queue.RequeueReason
 	preemptionTargets []*workload.Info
+	// quotaUsage is the usage of the ResourceQuotas of the namespace that the
+	// workload adds when admitted, and skippedQuotas the names of the
+	// ResourceQuotas with scopes, which are not checked.
+	quotaUsage    corev1.ResourceList
+	skippedQuotas []string
 }
 
 // nominate returns the workloads with their requirements (resource flavors, borrowing) if
 // they were admitted by the clusterQueues in the snapshot.
-func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, snap cache.Snapshot) []entry {
+func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, snap *cache.Snapshot, nsUsage *namespacesUsage) []entry {
 	log := ctrl.LoggerFrom(ctx)
 	entries := make([]entry, 0, len(workloads))
 	for _, w := range workloads {
 		log := log.WithValues("workload", klog.KObj(w.Obj), "clusterQueue", klog.KRef("", w.ClusterQueue))
 		cq := snap.ClusterQueues[w.ClusterQueue]
-		ns := corev1.Namespace{}
+		ns := metav1.PartialObjectMetadata{}
+		ns.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Namespace"))
 		e := entry{Info: w}
 		if s.cache.IsAssumedOrAdmittedWorkload(w) {
 			log.Info("Workload skipped from admission because it's already assumed or admitted", "workload", klog.KObj(w.Obj))
@@ -313,9 +527,21 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 		} else if err := s.validateLimitRange(ctx, &w); err != nil {
 			e.inadmissibleMsg = err.Error()
 		} else {
-			e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, &snap)
+			fitsResourceQuotas := func(assignment *flavorassigner.Assignment) bool {
+				_, _, err := s.checkResourceQuota(ctx, w.Obj, assignment, nsUsage)
+				return err == nil
+			}
+			e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, snap, fitsResourceQuotas)
 			e.inadmissibleMsg = e.assignment.Message()
 			e.Info.LastAssignment = &e.assignment.LastState
+			if e.assignment.RepresentativeMode() != flavorassigner.NoFit {
+				if err := s.validateResourceQuota(ctx, &e, nsUsage); err != nil {
+					e.inadmissibleMsg = err.Error()
+					e.assignment = flavorassigner.Assignment{}
+					e.preemptionTargets = nil
+					e.Info.LastAssignment = nil
+				}
+			}
 		}
 		entries = append(entries, e)
 	}
@@ -327,13 +553,17 @@ type partialAssignment struct {
 	preemptionTargets []*workload.Info
 }
 
-func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot) (flavorassigner.Assignment, []*workload.Info) {
+// getAssignments returns the assignment of flavors to the workload, and the
+// workloads to preempt for it. With partial admission, the counts of the pod
+// sets are reduced when the workload doesn't fit in the ClusterQueue, or when
+// its pods don't fit in the ResourceQuotas of the namespace.
+func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cache.Snapshot, fitsResourceQuotas func(*flavorassigner.Assignment) bool) (flavorassigner.Assignment, []*workload.Info) {
 	cq := snap.ClusterQueues[wl.ClusterQueue]
 	fullAssignment := flavorassigner.AssignFlavors(log, wl, snap.ResourceFlavors, cq, nil)
 	var fullAssignmentTargets []*workload.Info
 
 	arm := fullAssignment.RepresentativeMode()
-	if arm == flavorassigner.Fit {
+	if arm == flavorassigner.Fit && fitsResourceQuotas(&fullAssignment) {
 		return fullAssignment, nil
 	}
 
@@ -342,7 +572,7 @@ func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cac
 	}
 
 	// if the feature gate is not enabled or we can preempt
-	if !features.Enabled(features.PartialAdmission) || len(fullAssignmentTargets) > 0 {
+	if !features.Enabled(features.PartialAdmission) || (len(fullAssignmentTargets) > 0 && fitsResourceQuotas(&fullAssignment)) {
 		return fullAssignment, fullAssignmentTargets
 	}
 
@@ -350,10 +580,13 @@ func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cac
 		reducer := flavorassigner.NewPodSetReducer(wl.Obj.Spec.PodSets, func(nextCounts []int32) (*partialAssignment, bool) {
 			assignment := flavorassigner.AssignFlavors(log, wl, snap.ResourceFlavors, cq, nextCounts)
 			if assignment.RepresentativeMode() == flavorassigner.Fit {
+				if !fitsResourceQuotas(&assignment) {
+					return nil, false
+				}
 				return &partialAssignment{assignment: assignment}, true
 			}
 			preemptionTargets := s.preemptor.GetTargets(*wl, assignment, snap)
-			if len(preemptionTargets) > 0 {
+			if len(preemptionTargets) > 0 && fitsResourceQuotas(&assignment) {
 
 				return &partialAssignment{assignment: assignment, preemptionTargets: preemptionTargets}, true
 			}
@@ -364,7 +597,7 @@ func (s *Scheduler) getAssignments(log logr.Logger, wl *workload.Info, snap *cac
 			return pa.assignment, pa.preemptionTargets
 		}
 	}
-	return fullAssignment, nil
+	return fullAssignment, fullAssignmentTargets
 }
 
 // validateResources validates that requested resources are less or equal
@@ -426,11 +659,93 @@ func (s *Scheduler) validateLimitRange(ctx context.Context, wi *workload.Info) e
 	return nil
 }
 
+// validateResourceQuota validates that the pods of the workload, with the
+// counts of its assignment, fit in the headroom of the ResourceQuotas of its
+// namespace, along with the workloads of the namespace admitted in the cycle.
+// It records in the entry the usage that the workload adds, and the
+// ResourceQuotas that are not checked.
+func (s *Scheduler) validateResourceQuota(ctx context.Context, e *entry, nsUsage *namespacesUsage) error {
+	usage, skipped, err := s.checkResourceQuota(ctx, e.Obj, &e.assignment, nsUsage)
+	e.quotaUsage = usage
+	e.skippedQuotas = skipped
+	return err
+}
+
+// checkResourceQuota returns the usage of the ResourceQuotas of the namespace
+// that the pods of the workload add with the counts of the assignment, the
+// ResourceQuotas that are not checked, and an error if the pods don't fit.
+func (s *Scheduler) checkResourceQuota(ctx context.Context, wl *kueue.Workload, assignment *flavorassigner.Assignment, nsUsage *namespacesUsage) (corev1.ResourceList, []string, error) {
+	if !features.Enabled(features.NamespaceResourceQuota) {
+		return nil, nil, nil
+	}
+	// The client reads the quotas from the informer cache of the manager, which
+	// is not modified here.
+	list := corev1.ResourceQuotaList{}
+	if err := s.client.List(ctx, &list, client.InNamespace(wl.Namespace), client.UnsafeDisableDeepCopy); err != nil {
+		return nil, nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, nil, nil
+	}
+	usage, err := s.resourceQuotaUsage(ctx, wl, assignment)
+	if err != nil {
+		return nil, nil, err
+	}
+	reasons, skipped := resourcequota.Exceeded(list.Items, resource.MergeResourceListKeepSum(usage, nsUsage.get(wl.Namespace)))
+	if len(reasons) > 0 {
+		msg := strings.Join(reasons, "; ")
+		if len(skipped) > 0 {
+			msg += fmt.Sprintf("; the ResourceQuotas with scopes were not checked: %s", strings.Join(skipped, ", "))
+		}
+		return usage, skipped, fmt.Errorf("the pods wouldn't fit in the ResourceQuotas of the namespace: %s", msg)
+	}
+	return usage, skipped, nil
+}
+
+// resourceQuotaUsage returns the usage of the ResourceQuotas that the pods of
+// the workload add, with the counts of the assignment. The pods that already
+// exist, as the workload is owned by them, are part of the used quota, so they
+// are not counted again.
+func (s *Scheduler) resourceQuotaUsage(ctx context.Context, wl *kueue.Workload, assignment *flavorassigner.Assignment) (corev1.ResourceList, error) {
+	usage := corev1.ResourceList{}
+	for i := range wl.Spec.PodSets {
+		ps := &wl.Spec.PodSets[i]
+		count := ps.Count
+		if i < len(assignment.PodSets) {
+			count = assignment.PodSets[i].Count
+		}
+		usage = resource.MergeResourceListKeepSum(usage, resourcequota.PodsUsage(&ps.Template.Spec, count))
+	}
+	for _, owner := range wl.OwnerReferences {
+		if owner.APIVersion != "v1" || owner.Kind != "Pod" {
+			continue
+		}
+		var pod corev1.Pod
+		if err := s.client.Get(ctx, types.NamespacedName{Namespace: wl.Namespace, Name: owner.Name}, &pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		if pod.UID != owner.UID || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		usage = resourcequota.Subtract(usage, resourcequota.PodsUsage(&pod.Spec, 1))
+	}
+	return usage, nil
+}
+
 // admit sets the admitting clusterQueue and flavors into the workload of
 // the entry, and asynchronously updates the object in the apiserver after
 // assuming it in the cache.
-func (s *Scheduler) admit(ctx context.Context, e *entry, mustHaveChecks sets.Set[string]) error {
+func (s *Scheduler) admit(ctx context.Context, e *entry, cq *cache.ClusterQueue, nsUsage *namespacesUsage) error {
 	log := ctrl.LoggerFrom(ctx)
+	// The workloads of the namespace admitted concurrently, since the workload
+	// was nominated, could have used the headroom of its ResourceQuotas.
+	if err := s.validateResourceQuota(ctx, e, nsUsage); err != nil {
+		return err
+	}
+	mustHaveChecks := cq.AdmissionChecks
 	newWorkload := e.Obj.DeepCopy()
 	admission := &kueue.Admission{
 		ClusterQueue:      kueue.ClusterQueueReference(e.ClusterQueue),
@@ -447,6 +762,11 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, mustHaveChecks sets.Set
 	}
 	e.status = assumed
 	log.V(2).Info("Workload assumed in the cache")
+	nsUsage.add(newWorkload.Namespace, e.quotaUsage)
+	skippedQuotas := e.skippedQuotas
+	// The record is built before the admission is applied, as the snapshot
+	// holding the usage at the decision time is not valid afterwards.
+	auditRecord := s.newAdmissionAuditRecord(newWorkload, cq)
 
 	s.admissionRoutineWrapper.Run(func() {
 		err := s.applyAdmission(ctx, newWorkload)
@@ -460,7 +780,11 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, mustHaveChecks sets.Set
 			if workload.IsAdmitted(newWorkload) {
 				s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time since reservation was 0s ", admission.ClusterQueue)
 			}
+			if len(skippedQuotas) > 0 {
+				s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "ResourceQuotasNotChecked", "The ResourceQuotas with scopes were not checked: %s", strings.Join(skippedQuotas, ", "))
+			}
 			metrics.AdmittedWorkload(admission.ClusterQueue, waitTime)
+			s.writeAuditRecord(log, auditRecord)
 			log.V(2).Info("Workload successfully admitted and assigned flavors", "assignments", admission.PodSetAssignments)
 			return
 		}
@@ -479,6 +803,55 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, mustHaveChecks sets.Set
 	return nil
 }
 
+func (s *Scheduler) newAdmissionAuditRecord(wl *kueue.Workload, cq *cache.ClusterQueue) *audit.Record {
+	if s.auditBackend == nil {
+		return nil
+	}
+	record := newAuditRecord(audit.DecisionAdmission, wl, cq)
+	record.PodSets = audit.NewPodSets(wl.Status.Admission)
+	return record
+}
+
+func (s *Scheduler) auditPreemption(log logr.Logger, e *entry, cq *cache.ClusterQueue, preempted []*workload.Info) {
+	if s.auditBackend == nil {
+		return
+	}
+	record := newAuditRecord(audit.DecisionPreemption, e.Obj, cq)
+	record.Message = e.inadmissibleMsg
+	record.PodSets = audit.NewPodSets(&kueue.Admission{
+		ClusterQueue:      kueue.ClusterQueueReference(e.ClusterQueue),
+		PodSetAssignments: e.assignment.ToAPI(),
+	})
+	for _, target := range preempted {
+		record.Targets = append(record.Targets, audit.NewWorkloadRef(target.Obj))
+	}
+	s.writeAuditRecord(log, record)
+}
+
+func (s *Scheduler) writeAuditRecord(log logr.Logger, record *audit.Record) {
+	if s.auditBackend == nil || record == nil {
+		return
+	}
+	if err := s.auditBackend.Write(record); err != nil {
+		log.Error(err, "Could not write the audit record", "decision", record.Decision)
+	}
+}
+
+func newAuditRecord(decision audit.DecisionType, wl *kueue.Workload, cq *cache.ClusterQueue) *audit.Record {
+	record := &audit.Record{
+		Time:              time.Now().UTC(),
+		Decision:          decision,
+		Workload:          audit.NewWorkloadRef(wl),
+		ClusterQueue:      cq.Name,
+		ClusterQueueUsage: audit.NewUsage(cq.Usage),
+	}
+	if cq.Cohort != nil {
+		record.Cohort = cq.Cohort.Name
+		record.CohortUsage = audit.NewUsage(cq.Cohort.Usage)
+	}
+	return record
+}
+
 func (s *Scheduler) applyAdmissionWithSSA(ctx context.Context, w *kueue.Workload) error {
 	return workload.ApplyAdmissionStatus(ctx, s.client, w, false)
 }
@@ -533,6 +906,7 @@ func (s *Scheduler) requeueAndUpdate(log logr.Logger, ctx context.Context, e ent
 
 	if e.status == notNominated {
 		workload.UnsetQuotaReservationWithCondition(e.Obj, "Pending", e.inadmissibleMsg)
+		e.Obj.Status.Inadmissibility = e.assignment.Inadmissibility()
 		err := workload.ApplyAdmissionStatus(ctx, s.client, e.Obj, true)
 		if err != nil {
 			log.Error(err, "Could not update Workload status")
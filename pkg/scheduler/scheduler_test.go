@@ -37,6 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/audit"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/features"
@@ -196,8 +197,23 @@ func TestSchedule(t *testing.T) {
 		// disable partial admission
 		disablePartialAdmission bool
 
+		// enable scheduling the cohorts concurrently
+		enableParallelCohorts bool
+
+		// enable admitting more than one workload per ClusterQueue in a cycle
+		enableBatchAdmission bool
+
+		// enable checking the ResourceQuotas of the namespaces
+		enableNamespaceResourceQuota bool
+		resourceQuotas               []corev1.ResourceQuota
+		pods                         []corev1.Pod
+
 		// ignored if empty, the Message is ignored (it contains the duration)
 		wantEvents []utiltesting.EventRecord
+
+		// wantAuditDecisions is the decision recorded in the audit log for each
+		// workload key, ignored if nil.
+		wantAuditDecisions map[string]audit.DecisionType
 	}{
 		"workload fits in single clusterQueue, with check state ready": {
 			workloads: []kueue.Workload{
@@ -282,6 +298,201 @@ func TestSchedule(t *testing.T) {
 				},
 			},
 		},
+		"workload doesn't fit in the ResourceQuota of the namespace": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			enableNamespaceResourceQuota: true,
+			resourceQuotas: []corev1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "sales"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("20")},
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("15")},
+				},
+			}},
+			wantLeft: map[string]sets.Set[string]{
+				"sales": sets.New("sales/foo"),
+			},
+		},
+		"workload fits in the ResourceQuota of the namespace": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			enableNamespaceResourceQuota: true,
+			resourceQuotas: []corev1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "sales"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("20")},
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("10")},
+				},
+			}},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/foo": {
+					ClusterQueue: "sales",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("10000m"),
+							},
+							Count: ptr.To[int32](10),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"sales/foo"},
+		},
+		"workload partially admitted in the ResourceQuota of the namespace": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						SetMinimumCount(2).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			enableNamespaceResourceQuota: true,
+			resourceQuotas: []corev1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "sales"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("20")},
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("15")},
+				},
+			}},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/foo": {
+					ClusterQueue: "sales",
+					PodSetAssignments: []kueue.PodSetAssignment{
+						{
+							Name: "one",
+							Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+								corev1.ResourceCPU: "default",
+							},
+							ResourceUsage: corev1.ResourceList{
+								corev1.ResourceCPU: resource.MustParse("5000m"),
+							},
+							Count: ptr.To[int32](5),
+						},
+					},
+				},
+			},
+			wantScheduled: []string{"sales/foo"},
+		},
+		"workload not partially admitted below its minimum count in the ResourceQuota of the namespace": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						SetMinimumCount(6).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			enableNamespaceResourceQuota: true,
+			resourceQuotas: []corev1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "sales"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("20")},
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("15")},
+				},
+			}},
+			wantLeft: map[string]sets.Set[string]{
+				"sales": sets.New("sales/foo"),
+			},
+		},
+		"workload owned by its pods fits in the ResourceQuota of the namespace": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("main").
+					OwnerReference("v1", "Pod", "foo", "foo-uid", true, true).
+					PodSets(*utiltesting.MakePodSet("main", 1).
+						Request(corev1.ResourceCPU, "10").
+						Obj()).
+					Obj(),
+			},
+			pods: []corev1.Pod{{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "sales", UID: "foo-uid"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "c",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")},
+						},
+					}},
+				},
+			}},
+			enableNamespaceResourceQuota: true,
+			resourceQuotas: []corev1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "sales"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("20")},
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("15")},
+				},
+			}},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/foo": *utiltesting.MakeAdmission("sales", "main").
+					Assignment(corev1.ResourceCPU, "default", "10").
+					Obj(),
+			},
+			wantScheduled: []string{"sales/foo"},
+		},
+		"the ResourceQuotas with scopes are reported on admission": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("foo", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			enableNamespaceResourceQuota: true,
+			resourceQuotas: []corev1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "best-effort", Namespace: "sales"},
+				Spec: corev1.ResourceQuotaSpec{
+					Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort},
+				},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")},
+				},
+			}},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/foo": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "10").AssignmentPodCount(10).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/foo"},
+			wantEvents: []utiltesting.EventRecord{
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "foo"},
+					Reason:    "QuotaReserved",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "foo"},
+					Reason:    "Admitted",
+					EventType: corev1.EventTypeNormal,
+				},
+				{
+					Key:       types.NamespacedName{Namespace: "sales", Name: "foo"},
+					Reason:    "ResourceQuotasNotChecked",
+					EventType: corev1.EventTypeNormal,
+				},
+			},
+		},
 		"error during admission": {
 			workloads: []kueue.Workload{
 				*utiltesting.MakeWorkload("foo", "sales").
@@ -393,6 +604,162 @@ func TestSchedule(t *testing.T) {
 				},
 			},
 			wantScheduled: []string{"sales/new", "eng-alpha/new"},
+			wantAuditDecisions: map[string]audit.DecisionType{
+				"sales/new":     audit.DecisionAdmission,
+				"eng-alpha/new": audit.DecisionAdmission,
+			},
+		},
+		"admit one workload per ClusterQueue in a cycle": {
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl1", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-2 * time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("wl2", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("wl3", "sales").
+					Queue("main").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/wl1": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "20").AssignmentPodCount(20).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/wl1"},
+			wantLeft: map[string]sets.Set[string]{
+				"sales": sets.New("sales/wl2", "sales/wl3"),
+			},
+		},
+		"admit a batch of workloads from a ClusterQueue": {
+			enableBatchAdmission: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl1", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-2 * time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("wl2", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("wl3", "sales").
+					Queue("main").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/wl1": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "20").AssignmentPodCount(20).
+					Obj(),
+				"sales/wl2": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "20").AssignmentPodCount(20).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/wl1", "sales/wl2"},
+			wantLeft: map[string]sets.Set[string]{
+				"sales": sets.New("sales/wl3"),
+			},
+		},
+		"batch of workloads that don't fit together in the ResourceQuota of the namespace": {
+			enableBatchAdmission:         true,
+			enableNamespaceResourceQuota: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl1", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("wl2", "sales").
+					Queue("main").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			resourceQuotas: []corev1.ResourceQuota{{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "sales"},
+				Status: corev1.ResourceQuotaStatus{
+					Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("20")},
+					Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("5")},
+				},
+			}},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/wl1": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "10").AssignmentPodCount(10).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/wl1"},
+			wantLeft: map[string]sets.Set[string]{
+				"sales": sets.New("sales/wl2"),
+			},
+		},
+		"admit in different cohorts concurrently": {
+			enableParallelCohorts: true,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("new", "sales").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-alpha").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 45).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("new", "eng-beta").
+					Queue("main").
+					PodSets(*utiltesting.MakePodSet("one", 56).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("missing-flavor", "sales").
+					Queue("flavor-nonexistent-queue").
+					PodSets(*utiltesting.MakePodSet("one", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/new": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "1").AssignmentPodCount(1).
+					Obj(),
+				"eng-alpha/new": *utiltesting.MakeAdmission("eng-alpha", "one").
+					Assignment(corev1.ResourceCPU, "on-demand", "45").AssignmentPodCount(45).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/new", "eng-alpha/new"},
+			// The cohort eng is scheduled in a single group, so eng-beta/new
+			// can't borrow the quota that eng-alpha/new takes in the cycle.
+			wantLeft: map[string]sets.Set[string]{
+				"eng-beta":              sets.New("eng-beta/new"),
+				"flavor-nonexistent-cq": sets.New("sales/missing-flavor"),
+			},
 		},
 		"admit in same cohort with no borrowing": {
 			workloads: []kueue.Workload{
@@ -636,6 +1003,9 @@ func TestSchedule(t *testing.T) {
 				"eng-beta": sets.New("eng-beta/preemptor"),
 			},
 			wantPreempted: sets.New("eng-alpha/borrower", "eng-beta/low-2"),
+			wantAuditDecisions: map[string]audit.DecisionType{
+				"eng-beta/preemptor": audit.DecisionPreemption,
+			},
 			wantAssignments: map[string]kueue.Admission{
 				"eng-alpha/use-all-spot": *utiltesting.MakeAdmission("eng-alpha").Assignment(corev1.ResourceCPU, "spot", "100").Obj(),
 				"eng-beta/low-1":         *utiltesting.MakeAdmission("eng-beta").Assignment(corev1.ResourceCPU, "on-demand", "30").Obj(),
@@ -1102,13 +1472,22 @@ func TestSchedule(t *testing.T) {
 			if tc.disablePartialAdmission {
 				defer features.SetFeatureGateDuringTest(t, features.PartialAdmission, false)()
 			}
+			if tc.enableParallelCohorts {
+				defer features.SetFeatureGateDuringTest(t, features.ParallelCohortScheduling, true)()
+			}
+			if tc.enableBatchAdmission {
+				defer features.SetFeatureGateDuringTest(t, features.BatchAdmission, true)()
+			}
+			if tc.enableNamespaceResourceQuota {
+				defer features.SetFeatureGateDuringTest(t, features.NamespaceResourceQuota, true)()
+			}
 			ctx, _ := utiltesting.ContextWithLog(t)
 
 			allQueues := append(queues, tc.additionalLocalQueues...)
 			allClusterQueues := append(clusterQueues, tc.additionalClusterQueues...)
 
 			clientBuilder := utiltesting.NewClientBuilder().
-				WithLists(&kueue.WorkloadList{Items: tc.workloads}, &kueue.LocalQueueList{Items: allQueues}).
+				WithLists(&kueue.WorkloadList{Items: tc.workloads}, &kueue.LocalQueueList{Items: allQueues}, &corev1.ResourceQuotaList{Items: tc.resourceQuotas}, &corev1.PodList{Items: tc.pods}).
 				WithObjects(
 					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-alpha", Labels: map[string]string{"dep": "eng"}}},
 					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "eng-beta", Labels: map[string]string{"dep": "eng"}}},
@@ -1136,7 +1515,8 @@ func TestSchedule(t *testing.T) {
 					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
 				}
 			}
-			scheduler := New(qManager, cqCache, cl, recorder)
+			auditBackend := &fakeAuditBackend{}
+			scheduler := New(qManager, cqCache, cl, recorder, WithAuditBackend(auditBackend))
 			gotScheduled := make(map[string]kueue.Admission)
 			var mu sync.Mutex
 			scheduler.applyAdmission = func(ctx context.Context, w *kueue.Workload) error {
@@ -1215,10 +1595,32 @@ func TestSchedule(t *testing.T) {
 					t.Errorf("unexpected events (-want/+got):\n%s", diff)
 				}
 			}
+
+			if tc.wantAuditDecisions != nil {
+				gotAuditDecisions := make(map[string]audit.DecisionType, len(auditBackend.records))
+				for _, r := range auditBackend.records {
+					gotAuditDecisions[r.Workload.Namespace+"/"+r.Workload.Name] = r.Decision
+				}
+				if diff := cmp.Diff(tc.wantAuditDecisions, gotAuditDecisions); diff != "" {
+					t.Errorf("Unexpected audit decisions (-want,+got):\n%s", diff)
+				}
+			}
 		})
 	}
 }
 
+type fakeAuditBackend struct {
+	sync.Mutex
+	records []audit.Record
+}
+
+func (b *fakeAuditBackend) Write(r *audit.Record) error {
+	b.Lock()
+	defer b.Unlock()
+	b.records = append(b.records, *r)
+	return nil
+}
+
 func TestEntryOrdering(t *testing.T) {
 	now := time.Now()
 	input := []entry{
@@ -1365,6 +1767,42 @@ func TestEntryOrdering(t *testing.T) {
 	}
 }
 
+func TestGroupByCohort(t *testing.T) {
+	snapshot := cache.Snapshot{
+		ClusterQueues: map[string]*cache.ClusterQueue{
+			"a1":        {Name: "a1", Cohort: &cache.Cohort{Name: "a"}},
+			"a2":        {Name: "a2", Cohort: &cache.Cohort{Name: "a"}},
+			"b1":        {Name: "b1", Cohort: &cache.Cohort{Name: "b"}},
+			"no-cohort": {Name: "no-cohort"},
+		},
+	}
+	head := func(name, cq string) workload.Info {
+		return workload.Info{
+			Obj:          utiltesting.MakeWorkload(name, "ns").Obj(),
+			ClusterQueue: cq,
+		}
+	}
+	heads := []workload.Info{
+		head("w1", "a1"),
+		head("w2", "no-cohort"),
+		head("w3", "b1"),
+		head("w4", "a2"),
+		head("w5", "missing"),
+	}
+	got := make([][]string, 0)
+	for _, group := range groupByCohort(heads, &snapshot) {
+		names := make([]string, 0, len(group))
+		for _, h := range group {
+			names = append(names, h.Obj.Name)
+		}
+		got = append(got, names)
+	}
+	want := [][]string{{"w1", "w4"}, {"w2"}, {"w3"}, {"w5"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected groups (-want,+got):\n%s", diff)
+	}
+}
+
 func TestLastSchedulingContext(t *testing.T) {
 	resourceFlavors := []*kueue.ResourceFlavor{
 		{ObjectMeta: metav1.ObjectMeta{Name: "on-demand"}},
@@ -20,6 +20,7 @@ package heap
 
 import (
 	"container/heap"
+	"math/bits"
 )
 
 // lessFunc is a function that receives two items and returns true if the first
@@ -32,6 +33,9 @@ type keyFunc func(obj interface{}) string
 type heapItem struct {
 	obj   interface{}
 	index int
+	// deleted marks an item that was deleted but is still in the heap. It is
+	// dropped when it reaches the head of the heap or when the heap is compacted.
+	deleted bool
 }
 
 type itemKeyValue struct {
@@ -48,6 +52,8 @@ type data struct {
 	keys     []string
 	keyFunc  keyFunc
 	lessFunc lessFunc
+	// deleted is the number of items marked as deleted.
+	deleted int
 }
 
 var _ = heap.Interface(&data{})
@@ -107,17 +113,33 @@ func (h *data) Pop() interface{} {
 
 // Heap is a producer/consumer queue that implements a heap data structure.
 // It can be used to implement priority queues and similar data structures.
+//
+// Deleted items are only marked as such, and are dropped when they reach the
+// head of the heap, or when they are the majority of the items in the heap.
+// So the head of the heap is never a deleted item.
 type Heap struct {
 	data data
 }
 
+// revive replaces the object of an item, unmarking it if it was deleted, and
+// restores the heap invariant.
+func (h *Heap) revive(item *heapItem, obj interface{}) {
+	if item.deleted {
+		item.deleted = false
+		h.data.deleted--
+	}
+	item.obj = obj
+	heap.Fix(&h.data, item.index)
+	// Moving the item down can bring a deleted item to the head.
+	h.dropDeletedHead()
+}
+
 // PushOrUpdate inserts an item to the queue.
 // The item will be updated if it already exists.
 func (h *Heap) PushOrUpdate(obj interface{}) {
 	key := h.data.keyFunc(obj)
-	if _, exists := h.data.items[key]; exists {
-		h.data.items[key].obj = obj
-		heap.Fix(&h.data, h.data.items[key].index)
+	if item, exists := h.data.items[key]; exists {
+		h.revive(item, obj)
 	} else {
 		heap.Push(&h.data, &itemKeyValue{key, obj})
 	}
@@ -127,26 +149,99 @@ func (h *Heap) PushOrUpdate(obj interface{}) {
 // the key is present in the map, no changes is made to the item.
 func (h *Heap) PushIfNotPresent(obj interface{}) (added bool) {
 	key := h.data.keyFunc(obj)
-	if _, exists := h.data.items[key]; exists {
-		return false
+	if item, exists := h.data.items[key]; exists {
+		if !item.deleted {
+			return false
+		}
+		h.revive(item, obj)
+		return true
 	}
 
 	heap.Push(&h.data, &itemKeyValue{key, obj})
 	return true
 }
 
+// PushAllIfNotPresent inserts the items whose key isn't present in the queue,
+// and returns the number of items inserted. When the number of items is large
+// compared to the size of the queue, the heap is rebuilt once, instead of
+// inserting the items one by one.
+func (h *Heap) PushAllIfNotPresent(objs []interface{}) (added int) {
+	if len(objs)*bits.Len(uint(h.data.Len()+len(objs))) < h.data.Len()+len(objs) {
+		for _, obj := range objs {
+			if h.PushIfNotPresent(obj) {
+				added++
+			}
+		}
+		return added
+	}
+
+	for _, obj := range objs {
+		key := h.data.keyFunc(obj)
+		if item, exists := h.data.items[key]; exists {
+			if !item.deleted {
+				continue
+			}
+			item.deleted = false
+			h.data.deleted--
+			item.obj = obj
+		} else {
+			h.data.Push(&itemKeyValue{key, obj})
+		}
+		added++
+	}
+	if added > 0 {
+		heap.Init(&h.data)
+		h.dropDeletedHead()
+	}
+	return added
+}
+
 // Delete removes an item.
 func (h *Heap) Delete(key string) {
 	item, exists := h.data.items[key]
-	if !exists {
+	if !exists || item.deleted {
 		return
 	}
-	heap.Remove(&h.data, item.index)
+	item.deleted = true
+	h.data.deleted++
+	if h.data.deleted > h.data.Len()/2 {
+		h.compact()
+	} else {
+		h.dropDeletedHead()
+	}
+}
+
+// dropDeletedHead pops the deleted items from the head of the heap.
+func (h *Heap) dropDeletedHead() {
+	for h.data.Len() > 0 && h.data.items[h.data.keys[0]].deleted {
+		heap.Pop(&h.data)
+		h.data.deleted--
+	}
+}
+
+// compact drops all the deleted items and rebuilds the heap.
+func (h *Heap) compact() {
+	keys := h.data.keys[:0]
+	for _, key := range h.data.keys {
+		item := h.data.items[key]
+		if item.deleted {
+			delete(h.data.items, key)
+			continue
+		}
+		item.index = len(keys)
+		keys = append(keys, key)
+	}
+	clear(h.data.keys[len(keys):])
+	h.data.keys = keys
+	h.data.deleted = 0
+	heap.Init(&h.data)
 }
 
 // Pop returns the head of the heap and removes it.
 func (h *Heap) Pop() interface{} {
-	return heap.Pop(&h.data)
+	obj := heap.Pop(&h.data)
+	h.dropDeletedHead()
+	return obj
 }
 
 // Get returns the requested item, exists, error.
@@ -158,7 +253,7 @@ func (h *Heap) Get(obj interface{}) (item interface{}) {
 // GetByKey returns the requested item, or sets exists=false.
 func (h *Heap) GetByKey(key string) interface{} {
 	item, exists := h.data.items[key]
-	if !exists {
+	if !exists || item.deleted {
 		return nil
 	}
 	return item.obj
@@ -166,14 +261,16 @@ func (h *Heap) GetByKey(key string) interface{} {
 
 // Len returns the number of items in the heap.
 func (h *Heap) Len() int {
-	return h.data.Len()
+	return h.data.Len() - h.data.deleted
 }
 
 // List returns a list of all the items.
 func (h *Heap) List() []interface{} {
 	list := make([]interface{}, 0, h.Len())
 	for _, item := range h.data.items {
-		list = append(list, item.obj)
+		if !item.deleted {
+			list = append(list, item.obj)
+		}
 	}
 	return list
 }
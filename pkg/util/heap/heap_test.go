@@ -283,3 +283,115 @@ func TestHeap_List(t *testing.T) {
 		}
 	}
 }
+
+// TestHeap_LazyDelete tests that the deleted items are ignored by the rest of
+// the operations, and that they can be pushed again.
+func TestHeap_LazyDelete(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	for i := 1; i <= 10; i++ {
+		h.PushOrUpdate(mkHeapObj(string([]rune{'a', rune(i)}), i))
+	}
+	for i := 2; i <= 4; i++ {
+		h.Delete(string([]rune{'a', rune(i)}))
+	}
+	if e, a := 7, h.Len(); a != e {
+		t.Fatalf("expected %d items, got %d", e, a)
+	}
+	if h.GetByKey(string([]rune{'a', rune(3)})) != nil {
+		t.Errorf("expected the deleted item to be absent")
+	}
+	if e, a := 7, len(h.List()); a != e {
+		t.Errorf("expected %d items in the list, got %d", e, a)
+	}
+	if !h.PushIfNotPresent(mkHeapObj(string([]rune{'a', rune(3)}), 3)) {
+		t.Errorf("expected the deleted item to be pushed again")
+	}
+	h.PushOrUpdate(mkHeapObj(string([]rune{'a', rune(4)}), 20))
+
+	want := []int{1, 3, 5, 6, 7, 8, 9, 10, 20}
+	for _, e := range want {
+		item := h.Pop()
+		if a := item.(testHeapObject).val; a != e {
+			t.Fatalf("expected %d, got %d", e, a)
+		}
+	}
+	if h.Len() != 0 || h.data.Len() != 0 {
+		t.Fatalf("expected an empty heap.")
+	}
+}
+
+// TestHeap_Compact tests that the deleted items are dropped once they are the
+// majority of the items in the heap.
+func TestHeap_Compact(t *testing.T) {
+	h := New(testHeapObjectKeyFunc, compareInts)
+	const amount = 100
+	for i := 0; i < amount; i++ {
+		h.PushOrUpdate(mkHeapObj(string([]rune{'a', rune(i)}), i))
+	}
+	// Delete all the odd items, that never reach the head of the heap.
+	for i := amount - 1; i > 0; i -= 2 {
+		h.Delete(string([]rune{'a', rune(i)}))
+	}
+	h.Delete(string([]rune{'a', rune(amount - 2)}))
+	if e, a := amount/2-1, h.data.Len(); a != e {
+		t.Fatalf("expected %d items after compaction, got %d", e, a)
+	}
+	prevNum := -1
+	for h.Len() > 0 {
+		num := h.Pop().(testHeapObject).val.(int)
+		if num%2 != 0 || num == amount-2 || prevNum > num {
+			t.Errorf("got %v out of order or deleted, last was %v", num, prevNum)
+		}
+		prevNum = num
+	}
+}
+
+// TestHeap_PushAllIfNotPresent tests that the items are pushed, both one by one
+// and by rebuilding the heap, without replacing the present items.
+func TestHeap_PushAllIfNotPresent(t *testing.T) {
+	cases := map[string]struct {
+		present int
+		pushed  int
+	}{
+		"few items pushed": {
+			present: 100,
+			pushed:  2,
+		},
+		"many items pushed": {
+			present: 10,
+			pushed:  100,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := New(testHeapObjectKeyFunc, compareInts)
+			for i := 0; i < tc.present; i++ {
+				h.PushOrUpdate(mkHeapObj(string([]rune{'a', rune(i)}), 2*i))
+			}
+			h.Delete(string([]rune{'a', 0}))
+			objs := []interface{}{
+				// Present, not replaced.
+				mkHeapObj(string([]rune{'a', 1}), -1),
+				// Deleted, pushed again.
+				mkHeapObj(string([]rune{'a', 0}), 0),
+			}
+			for i := 2; i < tc.pushed; i++ {
+				objs = append(objs, mkHeapObj(string([]rune{'b', rune(i)}), 2*i+1))
+			}
+			if e, a := tc.pushed-1, h.PushAllIfNotPresent(objs); a != e {
+				t.Errorf("expected %d items added, got %d", e, a)
+			}
+			if e, a := tc.present+tc.pushed-2, h.Len(); a != e {
+				t.Errorf("expected %d items, got %d", e, a)
+			}
+			prevNum := -1
+			for h.Len() > 0 {
+				num := h.Pop().(testHeapObject).val.(int)
+				if prevNum > num {
+					t.Errorf("got %v out of order, last was %v", num, prevNum)
+				}
+				prevNum = num
+			}
+		})
+	}
+}
@@ -81,25 +81,58 @@ func (s Summary) validatePodSpecContainers(containers []corev1.Container, path *
 }
 
 // TotalRequests computes the total resource requests of a pod.
-// total = sum(max(sum(.containers[].requests), initContainers[].requests), overhead)
+// total = sum(max(sum(.containers[].requests) + sum(sidecars), initContainers[].requests + sum(previous sidecars)), overhead)
+// where the sidecars are the init containers with the Always restart policy,
+// which keep running along the following init containers and the containers.
 func TotalRequests(ps *corev1.PodSpec) corev1.ResourceList {
+	return podTotal(ps, func(c *corev1.Container) corev1.ResourceList {
+		return c.Resources.Requests
+	})
+}
+
+// TotalLimits computes the total resource limits of a pod, the same way as
+// the requests.
+func TotalLimits(ps *corev1.PodSpec) corev1.ResourceList {
+	return podTotal(ps, func(c *corev1.Container) corev1.ResourceList {
+		return c.Resources.Limits
+	})
+}
+
+func podTotal(ps *corev1.PodSpec, values func(*corev1.Container) corev1.ResourceList) corev1.ResourceList {
 	total := corev1.ResourceList{}
 
 	// add the resource from the main containers
 	for i := range ps.Containers {
-		total = resource.MergeResourceListKeepSum(total, ps.Containers[i].Resources.Requests)
+		total = resource.MergeResourceListKeepSum(total, values(&ps.Containers[i]))
 	}
 
-	// take into account the maximum of any init containers
+	// take into account the maximum of any init containers, running along
+	// the sidecars started before them
+	sidecars := corev1.ResourceList{}
+	initTotal := corev1.ResourceList{}
 	for i := range ps.InitContainers {
-		total = resource.MergeResourceListKeepMax(total, ps.InitContainers[i].Resources.Requests)
+		c := &ps.InitContainers[i]
+		if IsSidecar(c) {
+			sidecars = resource.MergeResourceListKeepSum(sidecars, values(c))
+			initTotal = resource.MergeResourceListKeepMax(initTotal, sidecars)
+		} else {
+			initTotal = resource.MergeResourceListKeepMax(initTotal, resource.MergeResourceListKeepSum(sidecars, values(c)))
+		}
 	}
+	total = resource.MergeResourceListKeepSum(total, sidecars)
+	total = resource.MergeResourceListKeepMax(total, initTotal)
 
 	// add the overhead
 	total = resource.MergeResourceListKeepSum(total, ps.Overhead)
 	return total
 }
 
+// IsSidecar returns whether the init container is a sidecar, which keeps
+// running along the containers of the pod.
+func IsSidecar(c *corev1.Container) bool {
+	return c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways
+}
+
 // ValidatePodSpec verifies if the provided podSpec (ps) first into the boundaries of the summary (s).
 func (s Summary) ValidatePodSpec(ps *corev1.PodSpec, path *field.Path) []string {
 	reasons := []string{}
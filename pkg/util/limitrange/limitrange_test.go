@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/field"
+	"k8s.io/utils/ptr"
 
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
 )
@@ -194,10 +195,41 @@ func TestTotalRequest(t *testing.T) {
 			},
 		},
 	}
+	sidecar := corev1.Container{
+		RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
 	cases := map[string]struct {
 		podSpec *corev1.PodSpec
 		want    corev1.ResourceList
 	}{
+		"sidecars add up to the main containers": {
+			podSpec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{sidecar},
+				Containers:     containers[:2],
+			},
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("3000m"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+				"example.com/gpu":     resource.MustParse("2"),
+			},
+		},
+		"init runs along the previous sidecars": {
+			podSpec: &corev1.PodSpec{
+				InitContainers: []corev1.Container{sidecar, containers[2]},
+				Containers:     containers[:2],
+			},
+			want: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("4500m"),
+				corev1.ResourceMemory: resource.MustParse("3Gi"),
+				"example.com/gpu":     resource.MustParse("2"),
+			},
+		},
 		"sum up main containers": {
 			podSpec: &corev1.PodSpec{
 				Containers: containers[:2],
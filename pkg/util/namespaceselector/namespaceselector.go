@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceselector
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// Selector matches the labels of the namespaces allowed to submit workloads
+// to a ClusterQueue.
+type Selector interface {
+	Matches(labels.Labels) bool
+}
+
+type selector struct {
+	terms    []labels.Selector
+	excluded []string
+}
+
+// ForClusterQueue returns the selector of the namespaces allowed to submit
+// workloads to the ClusterQueue. A namespace is allowed if it matches the
+// namespaceSelector or any of the namespaceSelectorTerms, and is not listed in
+// the excludedNamespaces. When the ClusterQueue only sets the
+// namespaceSelector, the returned selector is the plain label selector.
+func ForClusterQueue(spec *kueue.ClusterQueueSpec) (Selector, error) {
+	nsSelector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+	if err != nil {
+		return nil, err
+	}
+	if len(spec.NamespaceSelectorTerms) == 0 && len(spec.ExcludedNamespaces) == 0 {
+		return nsSelector, nil
+	}
+	s := &selector{
+		terms:    []labels.Selector{nsSelector},
+		excluded: spec.ExcludedNamespaces,
+	}
+	for i := range spec.NamespaceSelectorTerms {
+		term, err := metav1.LabelSelectorAsSelector(&spec.NamespaceSelectorTerms[i])
+		if err != nil {
+			return nil, err
+		}
+		s.terms = append(s.terms, term)
+	}
+	return s, nil
+}
+
+// Matches returns whether the namespace with the labels is allowed. The name
+// of the namespace is read from the kubernetes.io/metadata.name label.
+func (s *selector) Matches(ls labels.Labels) bool {
+	if IsExcluded(s.excluded, ls.Get(corev1.LabelMetadataName)) {
+		return false
+	}
+	for _, term := range s.terms {
+		if term.Matches(ls) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExcluded returns whether the name matches any of the excluded namespaces,
+// either exactly or, for the items ending with `*`, by prefix.
+func IsExcluded(excluded []string, name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, item := range excluded {
+		if prefix, isPattern := strings.CutSuffix(item, "*"); isPattern {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if item == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaceselector
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+func TestForClusterQueue(t *testing.T) {
+	nsLabels := func(name, team string) labels.Set {
+		ls := labels.Set{corev1.LabelMetadataName: name}
+		if team != "" {
+			ls["team"] = team
+		}
+		return ls
+	}
+	cases := map[string]struct {
+		spec kueue.ClusterQueueSpec
+		want map[string]bool
+	}{
+		"no selector": {
+			want: map[string]bool{
+				"team-a": false,
+			},
+		},
+		"namespaceSelector only": {
+			spec: kueue.ClusterQueueSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			},
+			want: map[string]bool{
+				"team-a":   true,
+				"team-b":   false,
+				"sandbox-": false,
+			},
+		},
+		"terms are evaluated as OR": {
+			spec: kueue.ClusterQueueSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				NamespaceSelectorTerms: []metav1.LabelSelector{
+					{MatchLabels: map[string]string{"team": "b"}},
+					{MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      corev1.LabelMetadataName,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"shared"},
+					}}},
+				},
+			},
+			want: map[string]bool{
+				"team-a": true,
+				"team-b": true,
+				"shared": true,
+				"other":  false,
+			},
+		},
+		"matchExpressions deny namespaces within a term": {
+			spec: kueue.ClusterQueueSpec{
+				NamespaceSelectorTerms: []metav1.LabelSelector{{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: metav1.LabelSelectorOpExists},
+						{Key: corev1.LabelMetadataName, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"team-b"}},
+					},
+				}},
+			},
+			want: map[string]bool{
+				"team-a": true,
+				"team-b": false,
+				"other":  false,
+			},
+		},
+		"excluded namespaces": {
+			spec: kueue.ClusterQueueSpec{
+				NamespaceSelector:  &metav1.LabelSelector{},
+				ExcludedNamespaces: []string{"sandbox-*", "team-b"},
+			},
+			want: map[string]bool{
+				"team-a":       true,
+				"team-b":       false,
+				"sandbox-a":    false,
+				"team-sandbox": true,
+			},
+		},
+	}
+	teams := map[string]string{"team-a": "a", "team-b": "b", "sandbox-a": "a", "team-sandbox": "a"}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s, err := ForClusterQueue(&tc.spec)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for ns, want := range tc.want {
+				if got := s.Matches(nsLabels(ns, teams[ns])); got != want {
+					t.Errorf("Matches(%q) = %t, want %t", ns, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parallelize
+
+import (
+	"context"
+
+	"k8s.io/client-go/util/workqueue"
+
+	"sigs.k8s.io/kueue/pkg/util/routine"
+)
+
+// maxParallelism is the number of workers used to process the pieces.
+const maxParallelism = 8
+
+// Until calls doWorkPiece for every piece in [0, pieces) using up to
+// maxParallelism workers. The processing stops at the first error, which is
+// returned.
+func Until(ctx context.Context, pieces int, doWorkPiece func(i int) error) error {
+	errCh := routine.NewErrorChannel()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	workqueue.ParallelizeUntil(ctx, maxParallelism, pieces, func(i int) {
+		if err := doWorkPiece(i); err != nil {
+			errCh.SendErrorWithCancel(err, cancel)
+		}
+	})
+	return errCh.ReceiveError()
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parallelize
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestUntil(t *testing.T) {
+	errTest := errors.New("test error")
+	cases := map[string]struct {
+		pieces    int
+		failPiece int
+		wantErr   error
+	}{
+		"no pieces": {
+			failPiece: -1,
+		},
+		"all pieces succeed": {
+			pieces:    100,
+			failPiece: -1,
+		},
+		"one piece fails": {
+			pieces:    100,
+			failPiece: 42,
+			wantErr:   errTest,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var done atomic.Int32
+			err := Until(context.Background(), tc.pieces, func(i int) error {
+				if i == tc.failPiece {
+					return errTest
+				}
+				done.Add(1)
+				return nil
+			})
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Until() returned error %v, want %v", err, tc.wantErr)
+			}
+			if tc.wantErr == nil && int(done.Load()) != tc.pieces {
+				t.Errorf("Until() processed %d pieces, want %d", done.Load(), tc.pieces)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// Adjustable is a token bucket rate limiter of the requests to the API server,
+// whose qps and burst can be changed while it's in use.
+type Adjustable struct {
+	mu      sync.RWMutex
+	limiter flowcontrol.RateLimiter
+	burst   int
+}
+
+var _ flowcontrol.RateLimiter = (*Adjustable)(nil)
+
+// NewAdjustable returns a rate limiter with the given qps and burst.
+func NewAdjustable(qps float32, burst int) *Adjustable {
+	return &Adjustable{
+		limiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+		burst:   burst,
+	}
+}
+
+// SetLimits replaces the token bucket if the qps or burst changed. The
+// requests waiting for a token of the previous bucket are not affected.
+func (a *Adjustable) SetLimits(qps float32, burst int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.limiter.QPS() == qps && a.burst == burst {
+		return
+	}
+	a.limiter.Stop()
+	a.limiter = flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+	a.burst = burst
+}
+
+func (a *Adjustable) current() flowcontrol.RateLimiter {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.limiter
+}
+
+// Burst returns the current burst.
+func (a *Adjustable) Burst() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.burst
+}
+
+func (a *Adjustable) TryAccept() bool {
+	return a.current().TryAccept()
+}
+
+func (a *Adjustable) Accept() {
+	a.current().Accept()
+}
+
+func (a *Adjustable) Wait(ctx context.Context) error {
+	return a.current().Wait(ctx)
+}
+
+func (a *Adjustable) Stop() {
+	a.current().Stop()
+}
+
+func (a *Adjustable) QPS() float32 {
+	return a.current().QPS()
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimiter
+
+import "testing"
+
+func TestAdjustable(t *testing.T) {
+	a := NewAdjustable(1, 2)
+	for i := 0; i < 2; i++ {
+		if !a.TryAccept() {
+			t.Fatalf("Request %d of the burst not accepted", i)
+		}
+	}
+	if a.TryAccept() {
+		t.Fatalf("Request accepted after the burst")
+	}
+
+	a.SetLimits(5, 3)
+	if a.QPS() != 5 || a.Burst() != 3 {
+		t.Errorf("Unexpected limits qps=%v burst=%d, want qps=5 burst=3", a.QPS(), a.Burst())
+	}
+	for i := 0; i < 3; i++ {
+		if !a.TryAccept() {
+			t.Fatalf("Request %d of the new burst not accepted", i)
+		}
+	}
+}
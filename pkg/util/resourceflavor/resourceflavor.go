@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceflavor
+
+import (
+	"time"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// NotProvisionableUntil returns the time until which the flavor is marked as
+// not provisionable, and whether it is still marked at now.
+func NotProvisionableUntil(rf *kueue.ResourceFlavor, now time.Time) (time.Time, bool) {
+	value, found := rf.Annotations[controllerconsts.NotProvisionableUntilAnnotation]
+	if !found {
+		return time.Time{}, false
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return until, now.Before(until)
+}
+
+// MarkNotProvisionable marks the flavor as not provisionable until the time,
+// unless it is already marked until a later time. It returns whether the
+// flavor changed.
+func MarkNotProvisionable(rf *kueue.ResourceFlavor, until time.Time) bool {
+	if current, marked := NotProvisionableUntil(rf, until); marked || current.Equal(until) {
+		return false
+	}
+	if rf.Annotations == nil {
+		rf.Annotations = make(map[string]string, 1)
+	}
+	rf.Annotations[controllerconsts.NotProvisionableUntilAnnotation] = until.UTC().Format(time.RFC3339)
+	return true
+}
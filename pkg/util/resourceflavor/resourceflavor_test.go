@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourceflavor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestNotProvisionableUntil(t *testing.T) {
+	now := time.Date(2023, 11, 1, 10, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		annotation string
+		wantUntil  time.Time
+		wantMarked bool
+	}{
+		"not marked": {},
+		"marked": {
+			annotation: "2023-11-01T10:10:00Z",
+			wantUntil:  now.Add(10 * time.Minute),
+			wantMarked: true,
+		},
+		"expired": {
+			annotation: "2023-11-01T09:50:00Z",
+			wantUntil:  now.Add(-10 * time.Minute),
+		},
+		"invalid": {
+			annotation: "10m",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rf := utiltesting.MakeResourceFlavor("rf")
+			if tc.annotation != "" {
+				rf.Annotation(controllerconsts.NotProvisionableUntilAnnotation, tc.annotation)
+			}
+			until, marked := NotProvisionableUntil(rf.Obj(), now)
+			if !until.Equal(tc.wantUntil) || marked != tc.wantMarked {
+				t.Errorf("NotProvisionableUntil() = (%v, %t), want (%v, %t)", until, marked, tc.wantUntil, tc.wantMarked)
+			}
+		})
+	}
+}
+
+func TestMarkNotProvisionable(t *testing.T) {
+	until := time.Date(2023, 11, 1, 10, 10, 0, 0, time.UTC)
+	cases := map[string]struct {
+		annotation      string
+		wantChanged     bool
+		wantAnnotations map[string]string
+	}{
+		"not marked": {
+			wantChanged:     true,
+			wantAnnotations: map[string]string{controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z"},
+		},
+		"marked until an earlier time": {
+			annotation:      "2023-11-01T10:05:00Z",
+			wantChanged:     true,
+			wantAnnotations: map[string]string{controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z"},
+		},
+		"marked until the same time": {
+			annotation:      "2023-11-01T10:10:00Z",
+			wantAnnotations: map[string]string{controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:10:00Z"},
+		},
+		"marked until a later time": {
+			annotation:      "2023-11-01T10:20:00Z",
+			wantAnnotations: map[string]string{controllerconsts.NotProvisionableUntilAnnotation: "2023-11-01T10:20:00Z"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rf := utiltesting.MakeResourceFlavor("rf")
+			if tc.annotation != "" {
+				rf.Annotation(controllerconsts.NotProvisionableUntilAnnotation, tc.annotation)
+			}
+			if changed := MarkNotProvisionable(rf.Obj(), until); changed != tc.wantChanged {
+				t.Errorf("MarkNotProvisionable() = %t, want %t", changed, tc.wantChanged)
+			}
+			if diff := cmp.Diff(tc.wantAnnotations, rf.Annotations); diff != "" {
+				t.Errorf("Unexpected annotations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
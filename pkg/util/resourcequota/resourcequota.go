@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcequota
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/kueue/pkg/util/limitrange"
+)
+
+const (
+	requestsPrefix = "requests."
+	limitsPrefix   = "limits."
+	countPods      = "count/pods"
+)
+
+// PodsUsage returns the usage of the ResourceQuota resources that count pods
+// with the spec would have.
+func PodsUsage(spec *corev1.PodSpec, count int32) corev1.ResourceList {
+	usage := corev1.ResourceList{
+		corev1.ResourcePods: *resource.NewQuantity(int64(count), resource.DecimalSI),
+		countPods:           *resource.NewQuantity(int64(count), resource.DecimalSI),
+	}
+	for name, q := range limitrange.TotalRequests(spec) {
+		total := scaled(q, count)
+		usage[requestsPrefix+name] = total
+		if isStandardRequest(name) {
+			usage[name] = total
+		}
+	}
+	for name, q := range limitrange.TotalLimits(spec) {
+		usage[limitsPrefix+name] = scaled(q, count)
+	}
+	return usage
+}
+
+// Subtract returns the usage minus the released usage, which is never below
+// zero for a resource.
+func Subtract(usage, released corev1.ResourceList) corev1.ResourceList {
+	result := make(corev1.ResourceList, len(usage))
+	for name, q := range usage {
+		remaining := q.DeepCopy()
+		if r, found := released[name]; found {
+			remaining.Sub(r)
+			if remaining.Sign() < 0 {
+				remaining = *resource.NewQuantity(0, q.Format)
+			}
+		}
+		result[name] = remaining
+	}
+	return result
+}
+
+// Exceeded returns the reasons why the usage doesn't fit in the headroom, hard
+// minus used, of the quotas. Quotas with scopes are not considered, their
+// names are returned as skipped.
+func Exceeded(quotas []corev1.ResourceQuota, usage corev1.ResourceList) (reasons []string, skipped []string) {
+	for i := range quotas {
+		quota := &quotas[i]
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			skipped = append(skipped, quota.Name)
+			continue
+		}
+		hard := quota.Status.Hard
+		if hard == nil {
+			hard = quota.Spec.Hard
+		}
+		names := make([]string, 0, len(hard))
+		for name := range hard {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			name := corev1.ResourceName(n)
+			requested, found := usage[name]
+			if !found {
+				continue
+			}
+			available := hard[name].DeepCopy()
+			available.Sub(quota.Status.Used[name])
+			if requested.Cmp(available) > 0 {
+				reasons = append(reasons, fmt.Sprintf("%s requested %s, available %s in ResourceQuota %s", name, requested.String(), available.String(), quota.Name))
+			}
+		}
+	}
+	sort.Strings(skipped)
+	return reasons, skipped
+}
+
+// HeadroomIncreased returns whether the headroom of any resource of the quota
+// is bigger in newQuota than in oldQuota.
+func HeadroomIncreased(oldQuota, newQuota *corev1.ResourceQuota) bool {
+	for name, hard := range newQuota.Status.Hard {
+		oldHard, found := oldQuota.Status.Hard[name]
+		if !found {
+			return true
+		}
+		headroom := hard.DeepCopy()
+		headroom.Sub(newQuota.Status.Used[name])
+		oldHeadroom := oldHard.DeepCopy()
+		oldHeadroom.Sub(oldQuota.Status.Used[name])
+		if headroom.Cmp(oldHeadroom) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func scaled(q resource.Quantity, count int32) resource.Quantity {
+	total := resource.NewMilliQuantity(q.MilliValue()*int64(count), q.Format)
+	return *total
+}
+
+// isStandardRequest returns whether the ResourceQuotas can limit the requests
+// of the resource without the "requests." prefix.
+func isStandardRequest(name corev1.ResourceName) bool {
+	switch name {
+	case corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage:
+		return true
+	}
+	return false
+}
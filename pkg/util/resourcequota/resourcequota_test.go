@@ -0,0 +1,195 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcequota
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodsUsage(t *testing.T) {
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			},
+		}},
+		Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					"example.com/gpu":     resource.MustParse("1"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+		}},
+	}
+	want := map[corev1.ResourceName]string{
+		corev1.ResourcePods:        "3",
+		"count/pods":               "3",
+		corev1.ResourceCPU:         "6",
+		"requests.cpu":             "6",
+		corev1.ResourceMemory:      "3Gi",
+		"requests.memory":          "3Gi",
+		"requests.example.com/gpu": "3",
+		"limits.memory":            "6Gi",
+	}
+	got := PodsUsage(spec, 3)
+	gotStrings := make(map[corev1.ResourceName]string, len(got))
+	for name, q := range got {
+		gotStrings[name] = q.String()
+	}
+	if diff := cmp.Diff(want, gotStrings); diff != "" {
+		t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+	}
+}
+
+func TestExceeded(t *testing.T) {
+	quota := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "compute"},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{
+				corev1.ResourceLimitsMemory: resource.MustParse("8Gi"),
+				corev1.ResourcePods:         resource.MustParse("10"),
+				corev1.ResourceRequestsCPU:  resource.MustParse("10"),
+			},
+			Used: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("8"),
+				corev1.ResourceRequestsCPU: resource.MustParse("4"),
+			},
+		},
+	}
+	scoped := corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "best-effort"},
+		Spec: corev1.ResourceQuotaSpec{
+			Scopes: []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort},
+		},
+		Status: corev1.ResourceQuotaStatus{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("0")},
+		},
+	}
+	cases := map[string]struct {
+		usage corev1.ResourceList
+		want  []string
+	}{
+		"fits": {
+			usage: corev1.ResourceList{
+				corev1.ResourcePods:        resource.MustParse("2"),
+				corev1.ResourceRequestsCPU: resource.MustParse("6"),
+			},
+		},
+		"exceeds the headroom": {
+			usage: corev1.ResourceList{
+				corev1.ResourcePods:         resource.MustParse("3"),
+				corev1.ResourceRequestsCPU:  resource.MustParse("6"),
+				corev1.ResourceLimitsMemory: resource.MustParse("9Gi"),
+			},
+			want: []string{
+				"limits.memory requested 9Gi, available 8Gi in ResourceQuota compute",
+				"pods requested 3, available 2 in ResourceQuota compute",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotSkipped := Exceeded([]corev1.ResourceQuota{quota, scoped}, tc.usage)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected reasons (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{"best-effort"}, gotSkipped); diff != "" {
+				t.Errorf("Unexpected skipped quotas (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubtract(t *testing.T) {
+	got := Subtract(corev1.ResourceList{
+		corev1.ResourcePods:        resource.MustParse("4"),
+		corev1.ResourceRequestsCPU: resource.MustParse("3"),
+		corev1.ResourceMemory:      resource.MustParse("1Gi"),
+	}, corev1.ResourceList{
+		corev1.ResourcePods:        resource.MustParse("1"),
+		corev1.ResourceRequestsCPU: resource.MustParse("5"),
+	})
+	want := map[corev1.ResourceName]string{
+		corev1.ResourcePods:        "3",
+		corev1.ResourceRequestsCPU: "0",
+		corev1.ResourceMemory:      "1Gi",
+	}
+	gotStrings := make(map[corev1.ResourceName]string, len(got))
+	for name, q := range got {
+		gotStrings[name] = q.String()
+	}
+	if diff := cmp.Diff(want, gotStrings); diff != "" {
+		t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+	}
+}
+
+func TestHeadroomIncreased(t *testing.T) {
+	quota := func(hard, used string) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse(hard)},
+				Used: corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse(used)},
+			},
+		}
+	}
+	cases := map[string]struct {
+		oldQuota *corev1.ResourceQuota
+		newQuota *corev1.ResourceQuota
+		want     bool
+	}{
+		"usage decreased": {
+			oldQuota: quota("10", "8"),
+			newQuota: quota("10", "6"),
+			want:     true,
+		},
+		"hard increased": {
+			oldQuota: quota("10", "8"),
+			newQuota: quota("12", "8"),
+			want:     true,
+		},
+		"usage increased": {
+			oldQuota: quota("10", "6"),
+			newQuota: quota("10", "8"),
+		},
+		"new resource": {
+			oldQuota: &corev1.ResourceQuota{},
+			newQuota: quota("10", "8"),
+			want:     true,
+		},
+		"unchanged": {
+			oldQuota: quota("10", "8"),
+			newQuota: quota("10", "8"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := HeadroomIncreased(tc.oldQuota, tc.newQuota); got != tc.want {
+				t.Errorf("HeadroomIncreased() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -108,6 +108,24 @@ func (w *WorkloadWrapper) Active(a bool) *WorkloadWrapper {
 	return w
 }
 
+func (w *WorkloadWrapper) Generation(g int64) *WorkloadWrapper {
+	w.ObjectMeta.Generation = g
+	return w
+}
+
+func (w *WorkloadWrapper) RetryPolicy(p *kueue.RetryPolicy) *WorkloadWrapper {
+	w.Spec.RetryPolicy = p
+	return w
+}
+
+func (w *WorkloadWrapper) RequeueState(count int32, requeueAt *metav1.Time) *WorkloadWrapper {
+	w.Status.RequeueState = &kueue.RequeueState{
+		Count:     ptr.To(count),
+		RequeueAt: requeueAt,
+	}
+	return w
+}
+
 // ReserveQuota sets workload admission and adds a "QuotaReserved" status condition
 func (w *WorkloadWrapper) ReserveQuota(a *kueue.Admission) *WorkloadWrapper {
 	w.Status.Admission = a
@@ -207,6 +225,11 @@ func (w *WorkloadWrapper) Labels(l map[string]string) *WorkloadWrapper {
 	return w
 }
 
+func (w *WorkloadWrapper) Annotations(a map[string]string) *WorkloadWrapper {
+	w.ObjectMeta.Annotations = a
+	return w
+}
+
 func (w *WorkloadWrapper) AdmissionChecks(checks ...kueue.AdmissionCheckState) *WorkloadWrapper {
 	w.Status.AdmissionChecks = checks
 	return w
@@ -398,12 +421,43 @@ func (q *LocalQueueWrapper) Obj() *kueue.LocalQueue {
 	return &q.LocalQueue
 }
 
+// Label sets the label key and value.
+func (q *LocalQueueWrapper) Label(k, v string) *LocalQueueWrapper {
+	if q.Labels == nil {
+		q.Labels = make(map[string]string)
+	}
+	q.Labels[k] = v
+	return q
+}
+
 // ClusterQueue updates the clusterQueue the queue points to.
 func (q *LocalQueueWrapper) ClusterQueue(c string) *LocalQueueWrapper {
 	q.Spec.ClusterQueue = kueue.ClusterQueueReference(c)
 	return q
 }
 
+// FallbackClusterQueues updates the clusterQueues the queue falls back to.
+func (q *LocalQueueWrapper) FallbackClusterQueues(cqs ...string) *LocalQueueWrapper {
+	q.Spec.FallbackClusterQueues = nil
+	for _, c := range cqs {
+		q.Spec.FallbackClusterQueues = append(q.Spec.FallbackClusterQueues, kueue.ClusterQueueReference(c))
+	}
+	return q
+}
+
+// FallbackAfter updates how long the workloads wait before falling back.
+func (q *LocalQueueWrapper) FallbackAfter(seconds int32) *LocalQueueWrapper {
+	q.Spec.FallbackAfterSeconds = &seconds
+	return q
+}
+
+// DefaultWorkloadPriorityClass updates the default workload priority class
+// of the queue.
+func (q *LocalQueueWrapper) DefaultWorkloadPriorityClass(name string) *LocalQueueWrapper {
+	q.Spec.DefaultWorkloadPriorityClass = name
+	return q
+}
+
 // PendingWorkloads updates the pendingWorkloads in status.
 func (q *LocalQueueWrapper) PendingWorkloads(n int32) *LocalQueueWrapper {
 	q.Status.PendingWorkloads = n
@@ -486,6 +540,18 @@ func (c *ClusterQueueWrapper) NamespaceSelector(s *metav1.LabelSelector) *Cluste
 	return c
 }
 
+// NamespaceSelectorTerms sets the additional namespace selectors.
+func (c *ClusterQueueWrapper) NamespaceSelectorTerms(terms ...metav1.LabelSelector) *ClusterQueueWrapper {
+	c.Spec.NamespaceSelectorTerms = terms
+	return c
+}
+
+// ExcludedNamespaces sets the excluded namespaces.
+func (c *ClusterQueueWrapper) ExcludedNamespaces(namespaces ...string) *ClusterQueueWrapper {
+	c.Spec.ExcludedNamespaces = namespaces
+	return c
+}
+
 // Preemption sets the preeemption policies.
 func (c *ClusterQueueWrapper) Preemption(p kueue.ClusterQueuePreemption) *ClusterQueueWrapper {
 	c.Spec.Preemption = &p
@@ -503,6 +569,12 @@ func (c *ClusterQueueWrapper) StopPolicy(p kueue.StopPolicy) *ClusterQueueWrappe
 	return c
 }
 
+// QuotaTiers sets the quota tiers.
+func (c *ClusterQueueWrapper) QuotaTiers(tiers ...kueue.QuotaTier) *ClusterQueueWrapper {
+	c.Spec.QuotaTiers = tiers
+	return c
+}
+
 func (c *ClusterQueueWrapper) Condition(conditionType string, status metav1.ConditionStatus, reason, message string) *ClusterQueueWrapper {
 	apimeta.SetStatusCondition(&c.Status.Conditions, metav1.Condition{
 		Type:    conditionType,
@@ -545,6 +617,16 @@ func (f *FlavorQuotasWrapper) Resource(name corev1.ResourceName, qs ...string) *
 	return f
 }
 
+// ResourcePercent adds a resource with a nominal quota expressed as a
+// percentage of the cohort capacity.
+func (f *FlavorQuotasWrapper) ResourcePercent(name corev1.ResourceName, percent int32) *FlavorQuotasWrapper {
+	f.Resources = append(f.Resources, kueue.ResourceQuota{
+		Name:                name,
+		NominalQuotaPercent: ptr.To(percent),
+	})
+	return f
+}
+
 // ResourceFlavorWrapper wraps a ResourceFlavor.
 type ResourceFlavorWrapper struct{ kueue.ResourceFlavor }
 
@@ -566,11 +648,26 @@ func (rf *ResourceFlavorWrapper) Obj() *kueue.ResourceFlavor {
 }
 
 // Label add a label kueue and value pair to the ResourceFlavor.
+// StopPolicy sets the stopPolicy of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) StopPolicy(p kueue.StopPolicy) *ResourceFlavorWrapper {
+	rf.Spec.StopPolicy = &p
+	return rf
+}
+
 func (rf *ResourceFlavorWrapper) Label(k, v string) *ResourceFlavorWrapper {
 	rf.Spec.NodeLabels[k] = v
 	return rf
 }
 
+// Annotation adds an annotation to the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) Annotation(k, v string) *ResourceFlavorWrapper {
+	if rf.Annotations == nil {
+		rf.Annotations = make(map[string]string)
+	}
+	rf.Annotations[k] = v
+	return rf
+}
+
 // Taint adds a taint to the ResourceFlavor.
 func (rf *ResourceFlavorWrapper) Taint(t corev1.Taint) *ResourceFlavorWrapper {
 	rf.Spec.NodeTaints = append(rf.Spec.NodeTaints, t)
@@ -721,6 +818,18 @@ func (p *WorkloadPriorityClassWrapper) PriorityValue(v int32) *WorkloadPriorityC
 	return p
 }
 
+// PreemptionPolicy updates the preemptionPolicy of WorkloadPriorityClass.
+func (p *WorkloadPriorityClassWrapper) PreemptionPolicy(policy kueue.PreemptionPolicy) *WorkloadPriorityClassWrapper {
+	p.WorkloadPriorityClass.PreemptionPolicy = policy
+	return p
+}
+
+// NonPreemptible marks the WorkloadPriorityClass as non-preemptible.
+func (p *WorkloadPriorityClassWrapper) NonPreemptible() *WorkloadPriorityClassWrapper {
+	p.WorkloadPriorityClass.NonPreemptible = true
+	return p
+}
+
 // Obj returns the inner WorkloadPriorityClass.
 func (p *WorkloadPriorityClassWrapper) Obj() *kueue.WorkloadPriorityClass {
 	return &p.WorkloadPriorityClass
@@ -121,6 +121,12 @@ func (p *PodWrapper) KueueSchedulingGate() *PodWrapper {
 	return p
 }
 
+// SchedulingGate adds a scheduling gate to the Pod
+func (p *PodWrapper) SchedulingGate(name string) *PodWrapper {
+	p.Spec.SchedulingGates = append(p.Spec.SchedulingGates, corev1.PodSchedulingGate{Name: name})
+	return p
+}
+
 // Finalizer adds a finalizer to the Pod
 func (p *PodWrapper) Finalizer(f string) *PodWrapper {
 	if p.ObjectMeta.Finalizers == nil {
@@ -210,3 +216,9 @@ func (p *PodWrapper) Volume(v corev1.Volume) *PodWrapper {
 	p.Pod.Spec.Volumes = append(p.Pod.Spec.Volumes, v)
 	return p
 }
+
+// InitContainer adds a new init container for the pod object
+func (p *PodWrapper) InitContainer(c corev1.Container) *PodWrapper {
+	p.Pod.Spec.InitContainers = append(p.Pod.Spec.InitContainers, c)
+	return p
+}
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 
@@ -42,10 +43,12 @@ func init() {
 	metav1.AddToGroupVersion(Scheme, schema.GroupVersion{Version: "v1"})
 }
 
-// Install installs API scheme defined in apis/v1alpha1 and registers storage
-func Install(server *genericapiserver.GenericAPIServer, kueueMgr *queue.Manager) error {
+// Install installs API scheme defined in apis/v1alpha1 and registers storage.
+// authz is used to filter the pending workloads by the namespaces the
+// requesting user has access to.
+func Install(server *genericapiserver.GenericAPIServer, kueueMgr *queue.Manager, authz authorizer.Authorizer) error {
 	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(v1alpha1.GroupVersion.Group, Scheme, ParameterCodec, Codecs)
-	pendingWorkloadsInCqREST := apirest.NewPendingWorkloadsInCqREST(kueueMgr)
+	pendingWorkloadsInCqREST := apirest.NewPendingWorkloadsInCqREST(kueueMgr, authz)
 	cqREST := apirest.NewCqREST()
 	pendingWorkloadsInLqREST := apirest.NewPendingWorkloadsInLqREST(kueueMgr)
 	lqREST := apirest.NewLqREST()
@@ -20,19 +20,25 @@ import (
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/apis/visibility/v1alpha1"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
 
 	_ "k8s.io/metrics/pkg/apis/metrics/install"
 )
 
 type pendingWorkloadsInCqREST struct {
 	queueMgr *queue.Manager
+	authz    authorizer.Authorizer
 	log      logr.Logger
 }
 
@@ -40,9 +46,14 @@ var _ rest.Storage = &pendingWorkloadsInCqREST{}
 var _ rest.GetterWithOptions = &pendingWorkloadsInCqREST{}
 var _ rest.Scoper = &pendingWorkloadsInCqREST{}
 
-func NewPendingWorkloadsInCqREST(kueueMgr *queue.Manager) *pendingWorkloadsInCqREST {
+// NewPendingWorkloadsInCqREST returns the storage of the pending workloads in
+// a ClusterQueue. The results only include the workloads from the namespaces
+// in which authz allows the requesting user to list workloads. If authz is
+// nil, the workloads from all the namespaces are included.
+func NewPendingWorkloadsInCqREST(kueueMgr *queue.Manager, authz authorizer.Authorizer) *pendingWorkloadsInCqREST {
 	return &pendingWorkloadsInCqREST{
 		queueMgr: kueueMgr,
+		authz:    authz,
 		log:      ctrl.Log.WithName("pending-workload-in-cq"),
 	}
 }
@@ -71,23 +82,70 @@ func (m *pendingWorkloadsInCqREST) Get(ctx context.Context, name string, opts ru
 		return nil, errors.NewNotFound(v1alpha1.Resource("clusterqueue"), name)
 	}
 
+	canList := m.namespaceFilter(ctx)
 	localQueuePositions := make(map[string]int32, 0)
+	visible := 0
 
-	for index := 0; index < int(offset+limit) && index < len(pendingWorkloadsInfo); index++ {
+	for index := 0; len(wls) < int(limit) && index < len(pendingWorkloadsInfo); index++ {
 		// Update positions in LocalQueue
 		wlInfo := pendingWorkloadsInfo[index]
-		queueName := wlInfo.Obj.Spec.QueueName
-		positionInLocalQueue := localQueuePositions[queueName]
-		localQueuePositions[queueName]++
-
-		if index >= int(offset) {
+		queueKey := workload.QueueKey(wlInfo.Obj)
+		positionInLocalQueue := localQueuePositions[queueKey]
+		localQueuePositions[queueKey]++
+
+		// The offset and limit apply to the workloads the user can see, but
+		// the positions account for all the workloads.
+		if !canList(wlInfo.Obj.Namespace) {
+			continue
+		}
+		if visible >= int(offset) {
 			// Add a workload to results
 			wls = append(wls, *newPendingWorkload(wlInfo, positionInLocalQueue, index))
 		}
+		visible++
 	}
 	return &v1alpha1.PendingWorkloadsSummary{Items: wls}, nil
 }
 
+// namespaceFilter returns a function telling whether the requesting user can
+// list the workloads in a namespace. The decisions are memoized for the
+// duration of the request.
+func (m *pendingWorkloadsInCqREST) namespaceFilter(ctx context.Context) func(string) bool {
+	if m.authz == nil {
+		return func(string) bool { return true }
+	}
+	user, ok := request.UserFrom(ctx)
+	if !ok {
+		return func(string) bool { return false }
+	}
+	decisions := make(map[string]bool)
+	allowed := func(namespace string) bool {
+		if allowed, found := decisions[namespace]; found {
+			return allowed
+		}
+		decision, reason, err := m.authz.Authorize(ctx, authorizer.AttributesRecord{
+			User:            user,
+			Verb:            "list",
+			Namespace:       namespace,
+			APIGroup:        kueue.GroupVersion.Group,
+			APIVersion:      kueue.GroupVersion.Version,
+			Resource:        "workloads",
+			ResourceRequest: true,
+		})
+		if err != nil {
+			m.log.Error(err, "Authorizing the access to workloads", "user", user.GetName(), "namespace", namespace, "reason", reason)
+		}
+		decisions[namespace] = decision == authorizer.DecisionAllow
+		return decisions[namespace]
+	}
+	// Users allowed to list the workloads in all the namespaces don't need a
+	// decision per namespace.
+	if allowed(metav1.NamespaceAll) {
+		return func(string) bool { return true }
+	}
+	return allowed
+}
+
 // NewGetOptions creates a new options object
 func (m *pendingWorkloadsInCqREST) NewGetOptions() (runtime.Object, bool, string) {
 	// If no query parameters were passed the generated defaults function are not executed so it's necessary to set default values here as well
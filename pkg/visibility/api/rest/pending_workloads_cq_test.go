@@ -24,6 +24,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/endpoints/request"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	visibility "sigs.k8s.io/kueue/apis/visibility/v1alpha1"
@@ -324,7 +328,7 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 			go manager.CleanUpOnContext(ctx)
-			pendingWorkloadsInCqRest := NewPendingWorkloadsInCqREST(manager)
+			pendingWorkloadsInCqRest := NewPendingWorkloadsInCqREST(manager, nil)
 			for _, cq := range tc.clusterQueues {
 				if err := manager.AddClusterQueue(ctx, cq); err != nil {
 					t.Fatalf("Adding cluster queue %s: %v", cq.Name, err)
@@ -355,3 +359,113 @@ func TestPendingWorkloadsInCQ(t *testing.T) {
 		})
 	}
 }
+
+type namespaceAuthorizer map[string]sets.Set[string]
+
+func (a namespaceAuthorizer) Authorize(_ context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	if attrs.GetVerb() == "list" && attrs.GetResource() == "workloads" && a[attrs.GetUser().GetName()].Has(attrs.GetNamespace()) {
+		return authorizer.DecisionAllow, "", nil
+	}
+	return authorizer.DecisionNoOpinion, "", nil
+}
+
+func TestPendingWorkloadsInCQAuthorization(t *testing.T) {
+	const (
+		cqName = "cq"
+		lqName = "lq"
+	)
+	authz := namespaceAuthorizer{
+		"admin":  sets.New(v1.NamespaceAll),
+		"tenant": sets.New("ns2"),
+	}
+	now := time.Now()
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", "ns1").Queue(lqName).Priority(4).Creation(now).Obj(),
+		utiltesting.MakeWorkload("b", "ns2").Queue(lqName).Priority(3).Creation(now).Obj(),
+		utiltesting.MakeWorkload("c", "ns1").Queue(lqName).Priority(2).Creation(now).Obj(),
+		utiltesting.MakeWorkload("d", "ns2").Queue(lqName).Priority(1).Creation(now).Obj(),
+	}
+	pending := func(name, ns string, priority, positionInCq, positionInLq int32) visibility.PendingWorkload {
+		return visibility.PendingWorkload{
+			ObjectMeta: v1.ObjectMeta{
+				Name:              name,
+				Namespace:         ns,
+				CreationTimestamp: v1.NewTime(now),
+			},
+			LocalQueueName:         lqName,
+			Priority:               priority,
+			PositionInClusterQueue: positionInCq,
+			PositionInLocalQueue:   positionInLq,
+		}
+	}
+
+	cases := map[string]struct {
+		user   string
+		noUser bool
+		opts   *visibility.PendingWorkloadOptions
+		want   []visibility.PendingWorkload
+	}{
+		"cluster-wide access": {
+			user: "admin",
+			opts: &visibility.PendingWorkloadOptions{Limit: 2},
+			want: []visibility.PendingWorkload{
+				pending("a", "ns1", 4, 0, 0),
+				pending("b", "ns2", 3, 1, 0),
+			},
+		},
+		"namespace access": {
+			user: "tenant",
+			opts: &visibility.PendingWorkloadOptions{Limit: constants.DefaultPendingWorkloadsLimit},
+			want: []visibility.PendingWorkload{
+				pending("b", "ns2", 3, 1, 0),
+				pending("d", "ns2", 1, 3, 1),
+			},
+		},
+		"namespace access with offset": {
+			user: "tenant",
+			opts: &visibility.PendingWorkloadOptions{Offset: 1, Limit: 1},
+			want: []visibility.PendingWorkload{
+				pending("d", "ns2", 1, 3, 1),
+			},
+		},
+		"no access": {
+			user: "other",
+			opts: &visibility.PendingWorkloadOptions{Limit: constants.DefaultPendingWorkloadsLimit},
+		},
+		"no user": {
+			noUser: true,
+			opts:   &visibility.PendingWorkloadOptions{Limit: constants.DefaultPendingWorkloadsLimit},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			manager := queue.NewManager(utiltesting.NewFakeClient(), nil)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go manager.CleanUpOnContext(ctx)
+			if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue(cqName).Obj()); err != nil {
+				t.Fatalf("Adding cluster queue: %v", err)
+			}
+			for _, ns := range []string{"ns1", "ns2"} {
+				if err := manager.AddLocalQueue(ctx, utiltesting.MakeLocalQueue(lqName, ns).ClusterQueue(cqName).Obj()); err != nil {
+					t.Fatalf("Adding queue in %q: %v", ns, err)
+				}
+			}
+			for _, w := range workloads {
+				manager.AddOrUpdateWorkload(w)
+			}
+			reqCtx := ctx
+			if !tc.noUser {
+				reqCtx = request.WithUser(ctx, &user.DefaultInfo{Name: tc.user})
+			}
+
+			info, err := NewPendingWorkloadsInCqREST(manager, authz).Get(reqCtx, cqName, tc.opts)
+			if err != nil {
+				t.Fatalf("Get() failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, info.(*visibility.PendingWorkloadsSummary).Items, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Pending workloads differ: (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -75,7 +75,7 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 				utiltesting.MakeLocalQueue(lqNameA, nsNameA).ClusterQueue(cqNameA).Obj(),
 			},
 			workloads: []*kueue.Workload{
-				utiltesting.MakeWorkload("a", nsNameA).Queue(lqNameA).Priority(highPrio).Creation(now).Obj(),
+				utiltesting.MakeWorkload("a", nsNameA).Queue(lqNameA).Priority(highPrio).Creation(now).Labels(map[string]string{"example.com/team": "ml"}).Obj(),
 				utiltesting.MakeWorkload("b", nsNameA).Queue(lqNameA).Priority(lowPrio).Creation(now).Obj(),
 			},
 			req: &req{
@@ -89,6 +89,7 @@ func TestPendingWorkloadsInLQ(t *testing.T) {
 						ObjectMeta: v1.ObjectMeta{
 							Name:              "a",
 							Namespace:         nsNameA,
+							Labels:            map[string]string{"example.com/team": "ml"},
 							CreationTimestamp: v1.NewTime(now),
 						},
 						LocalQueueName:         lqNameA,
@@ -35,6 +35,7 @@ func newPendingWorkload(wlInfo *workload.Info, positionInLq int32, positionInCq
 		ObjectMeta: metav1.ObjectMeta{
 			Name:              wlInfo.Obj.Name,
 			Namespace:         wlInfo.Obj.Namespace,
+			Labels:            wlInfo.Obj.Labels,
 			OwnerReferences:   ownerReferences,
 			CreationTimestamp: wlInfo.Obj.CreationTimestamp,
 		},
@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"sigs.k8s.io/kueue/apis/visibility/v1alpha1"
@@ -37,6 +39,16 @@ var (
 	setupLog = ctrl.Log.WithName("visibility-server")
 )
 
+const (
+	// CertDir is where an externally provisioned certificate of the server is
+	// mounted, e.g. one issued by cert-manager. A self-signed certificate is
+	// used if the directory doesn't hold one.
+	CertDir = "/visibility"
+	// certPairName is the base name of the certificate and key files, as in
+	// the kubernetes.io/tls secrets.
+	certPairName = "tls"
+)
+
 type server struct {
 	*genericapiserver.GenericAPIServer
 }
@@ -57,7 +69,7 @@ func CreateAndStartVisibilityServer(kueueMgr *queue.Manager, ctx context.Context
 		setupLog.Error(err, "Unable to create visibility server")
 	}
 
-	if err := api.Install(visibilityServer, kueueMgr); err != nil {
+	if err := api.Install(visibilityServer, kueueMgr, config.Authorization.Authorizer); err != nil {
 		setupLog.Error(err, "Unable to install visibility.kueue.x-k8s.io/v1alpha1 API")
 	}
 
@@ -71,6 +83,12 @@ func applyVisibilityServerOptions(config *genericapiserver.RecommendedConfig) er
 	o := genericoptions.NewRecommendedOptions("", api.Codecs.LegacyCodec(v1alpha1.SchemeGroupVersion))
 	o.Etcd = nil
 	o.SecureServing.BindPort = 8082
+	if hasCert(CertDir) {
+		// The certificate is reloaded when it's rotated.
+		o.SecureServing.ServerCert.CertKey.CertFile = filepath.Join(CertDir, certPairName+".crt")
+		o.SecureServing.ServerCert.CertKey.KeyFile = filepath.Join(CertDir, certPairName+".key")
+		return o.ApplyTo(config)
+	}
 	// The directory where TLS certs will be created
 	o.SecureServing.ServerCert.CertDirectory = "/tmp"
 
@@ -80,6 +98,16 @@ func applyVisibilityServerOptions(config *genericapiserver.RecommendedConfig) er
 	return o.ApplyTo(config)
 }
 
+// hasCert returns whether the directory holds a certificate and its key.
+func hasCert(dir string) bool {
+	for _, ext := range []string{".crt", ".key"} {
+		if _, err := os.Stat(filepath.Join(dir, certPairName+ext)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 func newVisibilityServerConfig() *genericapiserver.RecommendedConfig {
 	c := genericapiserver.NewRecommendedConfig(api.Codecs)
 	versionGet := version.Get()
@@ -18,6 +18,7 @@ package webhooks
 
 import (
 	"context"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -112,10 +114,66 @@ func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 	if len(cq.Spec.Cohort) != 0 {
 		allErrs = append(allErrs, validateNameReference(cq.Spec.Cohort, path.Child("cohort"))...)
 	}
-	allErrs = append(allErrs, validateResourceGroups(cq.Spec.ResourceGroups, path.Child("resourceGroups"))...)
+	allErrs = append(allErrs, validateResourceGroups(cq.Spec.ResourceGroups, cq.Spec.Cohort, path.Child("resourceGroups"))...)
 	allErrs = append(allErrs,
 		validation.ValidateLabelSelector(cq.Spec.NamespaceSelector, validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelector"))...)
+	for i := range cq.Spec.NamespaceSelectorTerms {
+		allErrs = append(allErrs,
+			validation.ValidateLabelSelector(&cq.Spec.NamespaceSelectorTerms[i], validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelectorTerms").Index(i))...)
+	}
+	for i, item := range cq.Spec.ExcludedNamespaces {
+		allErrs = append(allErrs, validateExcludedNamespace(item, path.Child("excludedNamespaces").Index(i))...)
+	}
+	if cq.Spec.Preemption != nil {
+		allErrs = append(allErrs, validatePreemption(cq.Spec.Preemption, path.Child("preemption"))...)
+	}
+	allErrs = append(allErrs, validateQuotaTiers(cq.Spec.QuotaTiers, path.Child("quotaTiers"))...)
+
+	return allErrs
+}
+
+func validateQuotaTiers(tiers []kueue.QuotaTier, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	var reservedPercent int32
+	minPriorities := sets.New[int32]()
+	for i, tier := range tiers {
+		if minPriorities.Has(tier.MinPriority) {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("minPriority"), tier.MinPriority))
+		}
+		minPriorities.Insert(tier.MinPriority)
+		reservedPercent += tier.ReservedQuotaPercent
+	}
+	if reservedPercent > 100 {
+		allErrs = append(allErrs, field.Invalid(path, reservedPercent, "the sum of the reservedQuotaPercent of the tiers must not exceed 100"))
+	}
+	return allErrs
+}
+
+// validateExcludedNamespace validates a namespace name, or a prefix followed
+// by `*`. A prefix is valid if a name can start with it.
+func validateExcludedNamespace(item string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	name := item
+	if prefix, isPattern := strings.CutSuffix(item, "*"); isPattern {
+		if prefix == "" {
+			return allErrs
+		}
+		name = prefix + "0"
+	}
+	for _, msg := range utilvalidation.IsDNS1123Label(name) {
+		allErrs = append(allErrs, field.Invalid(path, item, msg))
+	}
+	return allErrs
+}
 
+func validatePreemption(preemption *kueue.ClusterQueuePreemption, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if preemption.MinRuntime != nil && preemption.MinRuntime.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minRuntime"), preemption.MinRuntime.String(), isNegativeErrorMsg))
+	}
+	if preemption.GracePeriod != nil && preemption.GracePeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("gracePeriod"), preemption.GracePeriod.String(), isNegativeErrorMsg))
+	}
 	return allErrs
 }
 
@@ -130,7 +188,7 @@ func ValidateClusterQueueUpdate(newObj, oldObj *kueue.ClusterQueue) field.ErrorL
 	return allErrs
 }
 
-func validateResourceGroups(resourceGroups []kueue.ResourceGroup, path *field.Path) field.ErrorList {
+func validateResourceGroups(resourceGroups []kueue.ResourceGroup, cohort string, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	seenResources := sets.New[corev1.ResourceName]()
 	seenFlavors := sets.New[kueue.ResourceFlavorReference]()
@@ -148,18 +206,24 @@ func validateResourceGroups(resourceGroups []kueue.ResourceGroup, path *field.Pa
 		}
 		for j, fqs := range rg.Flavors {
 			path := path.Child("flavors").Index(j)
-			allErrs = append(allErrs, validateFlavorQuotas(fqs, rg.CoveredResources, path)...)
+			allErrs = append(allErrs, validateFlavorQuotas(fqs, rg.CoveredResources, cohort, path)...)
 			if seenFlavors.Has(fqs.Name) {
 				allErrs = append(allErrs, field.Duplicate(path.Child("name"), fqs.Name))
 			} else {
 				seenFlavors.Insert(fqs.Name)
 			}
 		}
+		coveredResources := sets.New(rg.CoveredResources...)
+		for j, rf := range rg.ResourceFlavorFungibility {
+			if !coveredResources.Has(rf.Name) {
+				allErrs = append(allErrs, field.Invalid(path.Child("resourceFlavorFungibility").Index(j).Child("name"), rf.Name, "must be one of the coveredResources of the resource group"))
+			}
+		}
 	}
 	return allErrs
 }
 
-func validateFlavorQuotas(flavorQuotas kueue.FlavorQuotas, coveredResources []corev1.ResourceName, path *field.Path) field.ErrorList {
+func validateFlavorQuotas(flavorQuotas kueue.FlavorQuotas, coveredResources []corev1.ResourceName, cohort string, path *field.Path) field.ErrorList {
 	allErrs := validateNameReference(string(flavorQuotas.Name), path.Child("name"))
 	if len(flavorQuotas.Resources) != len(coveredResources) {
 		allErrs = append(allErrs, field.Invalid(path.Child("resources"), field.OmitValueType{}, "must have the same number of resources as the coveredResources"))
@@ -177,6 +241,14 @@ func validateFlavorQuotas(flavorQuotas kueue.FlavorQuotas, coveredResources []co
 		if rq.BorrowingLimit != nil {
 			allErrs = append(allErrs, validateResourceQuantity(*rq.BorrowingLimit, path.Child("borrowingLimit"))...)
 		}
+		if rq.NominalQuotaPercent != nil {
+			if !rq.NominalQuota.IsZero() {
+				allErrs = append(allErrs, field.Invalid(path.Child("nominalQuota"), rq.NominalQuota.String(), "must be zero when nominalQuotaPercent is set"))
+			}
+			if len(cohort) == 0 {
+				allErrs = append(allErrs, field.Invalid(path.Child("nominalQuotaPercent"), *rq.NominalQuotaPercent, "must be nil when cohort is empty"))
+			}
+		}
 	}
 	return allErrs
 }
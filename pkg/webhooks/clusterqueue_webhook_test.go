@@ -18,12 +18,14 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
@@ -64,6 +66,36 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Invalid(specPath.Child("cohort"), "@prod", ""),
 			},
 		},
+		{
+			name: "preemption with minRuntime",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				Preemption(kueue.ClusterQueuePreemption{
+					MinRuntime: &metav1.Duration{Duration: 10 * time.Minute},
+				}).
+				Obj(),
+		},
+		{
+			name: "negative preemption minRuntime",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				Preemption(kueue.ClusterQueuePreemption{
+					MinRuntime: &metav1.Duration{Duration: -time.Minute},
+				}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("preemption", "minRuntime"), "-1m0s", ""),
+			},
+		},
+		{
+			name: "negative preemption gracePeriod",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				Preemption(kueue.ClusterQueuePreemption{
+					GracePeriod: &metav1.Duration{Duration: -time.Minute},
+				}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("preemption", "gracePeriod"), "-1m0s", ""),
+			},
+		},
 		{
 			name: "extended resources with qualified names",
 			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
@@ -119,6 +151,39 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("borrowingLimit"), "-1", ""),
 			},
 		},
+		{
+			name: "flavor quota with nominalQuotaPercent in cohort",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				Cohort("prod").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").ResourcePercent("cpu", 30).Obj()).
+				Obj(),
+		},
+		{
+			name: "flavor quota with nominalQuotaPercent and nominalQuota",
+			clusterQueue: func() *kueue.ClusterQueue {
+				cq := testingutil.MakeClusterQueue("cluster-queue").
+					Cohort("prod").
+					ResourceGroup(
+						*testingutil.MakeFlavorQuotas("x86").Resource("cpu", "1").Obj()).
+					Obj()
+				cq.Spec.ResourceGroups[0].Flavors[0].Resources[0].NominalQuotaPercent = ptr.To[int32](30)
+				return cq
+			}(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("nominalQuota"), "1", ""),
+			},
+		},
+		{
+			name: "flavor quota with nominalQuotaPercent without cohort",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(
+					*testingutil.MakeFlavorQuotas("x86").ResourcePercent("cpu", 30).Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("resources").Index(0).Child("nominalQuotaPercent"), int32(30), ""),
+			},
+		},
 		{
 			name: "empty queueing strategy is supported",
 			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
@@ -148,6 +213,56 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Required(specPath.Child("namespaceSelector", "matchExpressions").Index(0).Child("values"), ""),
 			},
 		},
+		{
+			name: "namespaceSelectorTerms with invalid expressions",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				NamespaceSelectorTerms(metav1.LabelSelector{}, metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{
+							Key:      "key",
+							Operator: "In",
+						},
+					},
+				}).Obj(),
+			wantErr: field.ErrorList{
+				field.Required(specPath.Child("namespaceSelectorTerms").Index(1).Child("matchExpressions").Index(0).Child("values"), ""),
+			},
+		},
+		{
+			name: "valid excludedNamespaces",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ExcludedNamespaces("sandbox-*", "kube-system", "*").Obj(),
+		},
+		{
+			name: "invalid excludedNamespaces",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ExcludedNamespaces("sandbox*-", "Team", "-*").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("excludedNamespaces").Index(0), "sandbox*-", ""),
+				field.Invalid(specPath.Child("excludedNamespaces").Index(1), "Team", ""),
+				field.Invalid(specPath.Child("excludedNamespaces").Index(2), "-*", ""),
+			},
+		},
+		{
+			name: "valid quotaTiers",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				QuotaTiers(
+					kueue.QuotaTier{Name: "production", MinPriority: 1000, ReservedQuotaPercent: 60},
+					kueue.QuotaTier{Name: "research", MinPriority: 100, ReservedQuotaPercent: 40},
+				).Obj(),
+		},
+		{
+			name: "invalid quotaTiers",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				QuotaTiers(
+					kueue.QuotaTier{Name: "production", MinPriority: 1000, ReservedQuotaPercent: 60},
+					kueue.QuotaTier{Name: "research", MinPriority: 1000, ReservedQuotaPercent: 50},
+				).Obj(),
+			wantErr: field.ErrorList{
+				field.Duplicate(specPath.Child("quotaTiers").Index(1).Child("minPriority"), int32(1000)),
+				field.Invalid(specPath.Child("quotaTiers"), int32(110), ""),
+			},
+		},
 		{
 			name: "multiple resource groups",
 			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
@@ -306,6 +421,69 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Duplicate(resourceGroupsPath.Index(1).Child("flavors").Index(0).Child("name"), nil),
 			},
 		},
+		{
+			name: "flavorFungibility of covered resources",
+			clusterQueue: &kueue.ClusterQueue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-queue",
+				},
+				Spec: kueue.ClusterQueueSpec{
+					ResourceGroups: []kueue.ResourceGroup{
+						{
+							CoveredResources: []corev1.ResourceName{"cpu", "example.com/gpu"},
+							Flavors: []kueue.FlavorQuotas{
+								*testingutil.MakeFlavorQuotas("alpha").
+									Resource("cpu", "0").
+									Resource("example.com/gpu", "0").
+									Obj(),
+							},
+							FlavorFungibility: &kueue.FlavorFungibility{
+								WhenCanBorrow: kueue.Borrow,
+							},
+							ResourceFlavorFungibility: []kueue.ResourceFlavorFungibility{
+								{
+									Name: "example.com/gpu",
+									FlavorFungibility: kueue.FlavorFungibility{
+										WhenCanBorrow: kueue.TryNextFlavor,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "flavorFungibility of a resource not covered by the resource group",
+			clusterQueue: &kueue.ClusterQueue{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-queue",
+				},
+				Spec: kueue.ClusterQueueSpec{
+					ResourceGroups: []kueue.ResourceGroup{
+						{
+							CoveredResources: []corev1.ResourceName{"cpu"},
+							Flavors: []kueue.FlavorQuotas{
+								*testingutil.MakeFlavorQuotas("alpha").
+									Resource("cpu", "0").
+									Obj(),
+							},
+							ResourceFlavorFungibility: []kueue.ResourceFlavorFungibility{
+								{
+									Name: "example.com/gpu",
+									FlavorFungibility: kueue.FlavorFungibility{
+										WhenCanBorrow: kueue.TryNextFlavor,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("resourceFlavorFungibility").Index(0).Child("name"), nil, ""),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -21,6 +21,7 @@ import (
 
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -69,9 +70,27 @@ func ValidateLocalQueue(q *kueue.LocalQueue) field.ErrorList {
 	var allErrs field.ErrorList
 	clusterQueuePath := field.NewPath("spec", "clusterQueue")
 	allErrs = append(allErrs, validateNameReference(string(q.Spec.ClusterQueue), clusterQueuePath)...)
+	allErrs = append(allErrs, validateFallbackClusterQueues(q, field.NewPath("spec", "fallbackClusterQueues"))...)
+	return allErrs
+}
+
+func validateFallbackClusterQueues(q *kueue.LocalQueue, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := sets.New(string(q.Spec.ClusterQueue))
+	for i, name := range q.Spec.FallbackClusterQueues {
+		idxPath := path.Index(i)
+		allErrs = append(allErrs, validateNameReference(string(name), idxPath)...)
+		if name == q.Spec.ClusterQueue {
+			allErrs = append(allErrs, field.Invalid(idxPath, name, "must be different from the clusterQueue"))
+		} else if seen.Has(string(name)) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, name))
+		}
+		seen.Insert(string(name))
+	}
 	return allErrs
 }
 
 func ValidateLocalQueueUpdate(newObj, oldObj *kueue.LocalQueue) field.ErrorList {
-	return apivalidation.ValidateImmutableField(newObj.Spec.ClusterQueue, oldObj.Spec.ClusterQueue, field.NewPath("spec", "clusterQueue"))
+	allErrs := apivalidation.ValidateImmutableField(newObj.Spec.ClusterQueue, oldObj.Spec.ClusterQueue, field.NewPath("spec", "clusterQueue"))
+	return append(allErrs, validateFallbackClusterQueues(newObj, field.NewPath("spec", "fallbackClusterQueues"))...)
 }
@@ -43,6 +43,17 @@ func TestValidateLocalQueueCreate(t *testing.T) {
 				field.Invalid(field.NewPath("spec").Child("clusterQueue"), "invalid_name", ""),
 			},
 		},
+		"should accept queue creation with fallback clusterQueues": {
+			queue: testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).ClusterQueue("foo").FallbackClusterQueues("bar", "baz").Obj(),
+		},
+		"should reject queue creation with invalid fallback clusterQueues": {
+			queue: testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).ClusterQueue("foo").FallbackClusterQueues("invalid_cluster_queue", "foo", "bar", "bar").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "fallbackClusterQueues").Index(0), "invalid_name", ""),
+				field.Invalid(field.NewPath("spec", "fallbackClusterQueues").Index(1), "foo", ""),
+				field.Duplicate(field.NewPath("spec", "fallbackClusterQueues").Index(3), "bar"),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -66,6 +77,18 @@ func TestValidateLocalQueueUpdate(t *testing.T) {
 				field.Invalid(field.NewPath("spec").Child("clusterQueue"), nil, ""),
 			},
 		},
+		"fallback clusterQueues can be updated": {
+			before:  testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).ClusterQueue("foo").FallbackClusterQueues("bar").Obj(),
+			after:   testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).ClusterQueue("foo").FallbackClusterQueues("baz").Obj(),
+			wantErr: field.ErrorList{},
+		},
+		"fallback clusterQueues are validated on update": {
+			before: testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).ClusterQueue("foo").Obj(),
+			after:  testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).ClusterQueue("foo").FallbackClusterQueues("foo").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "fallbackClusterQueues").Index(0), "foo", ""),
+			},
+		},
 		"status could be updated": {
 			before:  testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).Obj(),
 			after:   testingutil.MakeLocalQueue(testLocalQueueName, testLocalQueueNamespace).PendingWorkloads(10).Obj(),
@@ -74,7 +97,7 @@ func TestValidateLocalQueueUpdate(t *testing.T) {
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
-			errList := ValidateLocalQueueUpdate(tc.before, tc.after)
+			errList := ValidateLocalQueueUpdate(tc.after, tc.before)
 			if diff := cmp.Diff(tc.wantErr, errList, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
 				t.Errorf("ValidateLocalQueueUpdate() mismatch (-want +got):\n%s", diff)
 			}
@@ -18,11 +18,15 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -35,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -75,9 +80,28 @@ func (w *WorkloadWebhook) Default(ctx context.Context, obj runtime.Object) error
 			wl.Spec.PodSets[i].MinCount = nil
 		}
 	}
+
+	recordReactivation(ctx, wl)
 	return nil
 }
 
+// recordReactivation annotates the workload with the user that activates it
+// again, after it was deactivated for exhausting its retries.
+func recordReactivation(ctx context.Context, wl *kueue.Workload) {
+	if !ptr.Deref(wl.Spec.Active, true) || !apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadDeactivated) {
+		return
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || req.Operation != admissionv1.Update {
+		return
+	}
+	var oldWl kueue.Workload
+	if err := json.Unmarshal(req.OldObject.Raw, &oldWl); err != nil || ptr.Deref(oldWl.Spec.Active, true) {
+		return
+	}
+	metav1.SetMetaDataAnnotation(&wl.ObjectMeta, controllerconsts.ReactivatedByAnnotation, req.UserInfo.Username)
+}
+
 // +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta1-workload,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=workloads;workloads/status,verbs=create;update,versions=v1beta1,name=vworkload.kb.io,admissionReviewVersions=v1
 
 var _ webhook.CustomValidator = &WorkloadWebhook{}
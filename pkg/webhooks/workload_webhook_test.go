@@ -18,19 +18,25 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -40,10 +46,33 @@ const (
 )
 
 func TestWorkloadWebhookDefault(t *testing.T) {
+	deactivated := metav1.Condition{
+		Type:   kueue.WorkloadDeactivated,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadRetryLimitExceeded,
+	}
+	reactivatedWl := testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+		SetOrReplaceCondition(deactivated).
+		Obj()
+	inactiveWl := reactivatedWl.DeepCopy()
+	inactiveWl.Spec.Active = ptr.To(false)
+	annotatedWl := reactivatedWl.DeepCopy()
+	annotatedWl.Annotations = map[string]string{controllerconsts.ReactivatedByAnnotation: "admin"}
 	cases := map[string]struct {
-		wl     kueue.Workload
-		wantWl kueue.Workload
+		wl      kueue.Workload
+		request *admission.Request
+		wantWl  kueue.Workload
 	}{
+		"record the user reactivating a deactivated workload": {
+			wl:      *reactivatedWl.DeepCopy(),
+			request: reactivationRequest(t, inactiveWl, "admin"),
+			wantWl:  *annotatedWl,
+		},
+		"don't record the user updating a reactivated workload": {
+			wl:      *reactivatedWl.DeepCopy(),
+			request: reactivationRequest(t, reactivatedWl, "admin"),
+			wantWl:  *reactivatedWl.DeepCopy(),
+		},
 		"add default podSet name": {
 			wl: kueue.Workload{
 				Spec: kueue.WorkloadSpec{
@@ -83,7 +112,11 @@ func TestWorkloadWebhookDefault(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			wh := &WorkloadWebhook{}
 			wlCopy := tc.wl.DeepCopy()
-			if err := wh.Default(context.Background(), wlCopy); err != nil {
+			ctx := context.Background()
+			if tc.request != nil {
+				ctx = admission.NewContextWithRequest(ctx, *tc.request)
+			}
+			if err := wh.Default(ctx, wlCopy); err != nil {
 				t.Fatalf("Could not apply defaults: %v", err)
 			}
 			if diff := cmp.Diff(tc.wantWl, *wlCopy); diff != "" {
@@ -93,6 +126,19 @@ func TestWorkloadWebhookDefault(t *testing.T) {
 	}
 }
 
+func reactivationRequest(t *testing.T, oldWl *kueue.Workload, username string) *admission.Request {
+	t.Helper()
+	raw, err := json.Marshal(oldWl)
+	if err != nil {
+		t.Fatalf("Could not encode the old workload: %v", err)
+	}
+	return &admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Operation: admissionv1.Update,
+		OldObject: runtime.RawExtension{Raw: raw},
+		UserInfo:  authenticationv1.UserInfo{Username: username},
+	}}
+}
+
 func TestValidateWorkload(t *testing.T) {
 	specPath := field.NewPath("spec")
 	podSetsPath := specPath.Child("podSets")
@@ -102,13 +102,19 @@ func handleLimitsToRequests(wl *kueue.Workload) {
 
 // AdjustResources adjusts the resource requests of a workload based on:
 // - PodOverhead
-// - LimitRanges
 // - Limits
+// - LimitRanges
+// - Limits defaulted by the LimitRanges
+//
+// The limits of a container are used as its requests before applying the
+// LimitRanges, like the defaulting of the pods does, so that the default
+// requests of the LimitRanges don't replace them.
 func AdjustResources(ctx context.Context, cl client.Client, wl *kueue.Workload) {
 	log := ctrl.LoggerFrom(ctx)
 	for _, err := range handlePodOverhead(ctx, cl, wl) {
 		log.Error(err, "Failures adjusting requests for pod overhead")
 	}
+	handleLimitsToRequests(wl)
 	if err := handlePodLimitRange(ctx, cl, wl); err != nil {
 		log.Error(err, "Failed adjusting requests for LimitRanges")
 	}
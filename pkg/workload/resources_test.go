@@ -217,7 +217,7 @@ func TestAdjustResources(t *testing.T) {
 						Obj(),
 					*utiltesting.MakePodSet("b", 1).
 						Limit(corev1.ResourceCPU, "6").
-						Request(corev1.ResourceCPU, "3").
+						Request(corev1.ResourceCPU, "6").
 						InitContainers(corev1.Container{
 							Resources: corev1.ResourceRequirements{
 								Limits: corev1.ResourceList{
@@ -246,6 +246,37 @@ func TestAdjustResources(t *testing.T) {
 				).
 				Obj(),
 		},
+		"Handle container limit range without default requests": {
+			limitranges: []corev1.LimitRange{
+				utiltesting.MakeLimitRange("foo", "").
+					WithType(corev1.LimitTypeContainer).
+					WithValue(
+						"Default", corev1.ResourceCPU, "4",
+					).
+					LimitRange,
+			},
+			wl: utiltesting.MakeWorkload("foo", "").
+				PodSets(
+					*utiltesting.MakePodSet("a", 1).
+						Obj(),
+					*utiltesting.MakePodSet("b", 1).
+						Request(corev1.ResourceCPU, "1").
+						Obj(),
+				).
+				Obj(),
+			wantWl: utiltesting.MakeWorkload("foo", "").
+				PodSets(
+					*utiltesting.MakePodSet("a", 1).
+						Limit(corev1.ResourceCPU, "4").
+						Request(corev1.ResourceCPU, "4").
+						Obj(),
+					*utiltesting.MakePodSet("b", 1).
+						Limit(corev1.ResourceCPU, "4").
+						Request(corev1.ResourceCPU, "1").
+						Obj(),
+				).
+				Obj(),
+		},
 		"Handle pod limit range": {
 			limitranges: []corev1.LimitRange{
 				utiltesting.MakeLimitRange("foo", "").
@@ -21,22 +21,26 @@ import (
 	"fmt"
 	"maps"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
+	utilresource "sigs.k8s.io/kueue/pkg/util/resource"
 )
 
 var (
-	admissionManagedConditions = []string{kueue.WorkloadQuotaReserved, kueue.WorkloadEvicted, kueue.WorkloadAdmitted}
+	admissionManagedConditions = []string{kueue.WorkloadQuotaReserved, kueue.WorkloadEvicted, kueue.WorkloadAdmitted, kueue.WorkloadPreempting}
 )
 
 type AssigmentClusterQueueState struct {
@@ -105,7 +109,89 @@ func (psr *PodSetResources) ScaledTo(newCount int32) *PodSetResources {
 	return ret
 }
 
-func NewInfo(w *kueue.Workload) *Info {
+// ResourceAccounting indicates the values of the containers that account for
+// the usage of a resource.
+type ResourceAccounting string
+
+const (
+	AccountRequests               ResourceAccounting = "Requests"
+	AccountLimits                 ResourceAccounting = "Limits"
+	AccountMaxOfRequestsAndLimits ResourceAccounting = "MaxOfRequestsAndLimits"
+)
+
+// AccountingPolicy maps the names of the resources to their accounting. The
+// usage of the resources that are not in the policy is accounted from the
+// requests.
+type AccountingPolicy map[corev1.ResourceName]ResourceAccounting
+
+// ResourceTransformation indicates the quantities of the resources that
+// account for every unit of a requested resource.
+type ResourceTransformation struct {
+	Outputs corev1.ResourceList
+	// RetainInput indicates whether the requested resource is accounted along
+	// the outputs.
+	RetainInput bool
+}
+
+// ResourceTransformations maps the names of the requested resources to their
+// transformation.
+type ResourceTransformations map[corev1.ResourceName]ResourceTransformation
+
+// InitContainersAccounting indicates how the requests of the init containers
+// account for the usage of a pod.
+type InitContainersAccounting string
+
+const (
+	// InitContainersMax accounts for the greater of the requests of every
+	// init container and the sum of the requests of the containers.
+	InitContainersMax InitContainersAccounting = "Max"
+	// InitContainersSum accounts for the sum of the requests of the init
+	// containers and the containers.
+	InitContainersSum InitContainersAccounting = "Sum"
+)
+
+type infoOptions struct {
+	accountingPolicy         AccountingPolicy
+	resourceTransformations  ResourceTransformations
+	initContainersAccounting InitContainersAccounting
+	ownersInitContainers     map[schema.GroupKind]InitContainersAccounting
+}
+
+// InfoOption configures the computation of the Info.
+type InfoOption func(*infoOptions)
+
+// WithAccountingPolicy sets the policy used to compute the total requests of
+// the workloads that are not admitted.
+func WithAccountingPolicy(p AccountingPolicy) InfoOption {
+	return func(o *infoOptions) {
+		o.accountingPolicy = p
+	}
+}
+
+// WithResourceTransformations sets the transformations applied to the total
+// requests of the workloads that are not admitted.
+func WithResourceTransformations(t ResourceTransformations) InfoOption {
+	return func(o *infoOptions) {
+		o.resourceTransformations = t
+	}
+}
+
+// WithInitContainersAccounting sets how the requests of the init containers
+// account for the total requests of the workloads that are not admitted,
+// by default and for the workloads whose controller is of the kinds in
+// byOwner.
+func WithInitContainersAccounting(defaultAccounting InitContainersAccounting, byOwner map[schema.GroupKind]InitContainersAccounting) InfoOption {
+	return func(o *infoOptions) {
+		o.initContainersAccounting = defaultAccounting
+		o.ownersInitContainers = byOwner
+	}
+}
+
+func NewInfo(w *kueue.Workload, opts ...InfoOption) *Info {
+	var options infoOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	info := &Info{
 		Obj: w,
 	}
@@ -113,11 +199,52 @@ func NewInfo(w *kueue.Workload) *Info {
 		info.ClusterQueue = string(w.Status.Admission.ClusterQueue)
 		info.TotalRequests = totalRequestsFromAdmission(w)
 	} else {
-		info.TotalRequests = totalRequestsFromPodSets(w)
+		info.TotalRequests = totalRequestsFromPodSets(w, &options)
 	}
 	return info
 }
 
+// NewCompactInfo returns the Info of an admitted workload, holding a compact
+// copy of the object, see Compact.
+func NewCompactInfo(w *kueue.Workload) *Info {
+	return NewInfo(Compact(w))
+}
+
+// Compact returns a copy of the workload without the pod templates, the
+// managed fields and the last applied configuration of kubectl, which are not
+// needed to account for the usage of an admitted workload or to preempt it.
+// The labels and the other annotations are kept.
+// The usage of the compact workload is only correct if it has an admission.
+func Compact(w *kueue.Workload) *kueue.Workload {
+	c := &kueue.Workload{
+		TypeMeta: w.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              w.Name,
+			Namespace:         w.Namespace,
+			UID:               w.UID,
+			ResourceVersion:   w.ResourceVersion,
+			Generation:        w.Generation,
+			CreationTimestamp: w.CreationTimestamp,
+			DeletionTimestamp: w.DeletionTimestamp,
+			Labels:            maps.Clone(w.Labels),
+			Annotations:       maps.Clone(w.Annotations),
+		},
+		Spec:   w.Spec,
+		Status: *w.Status.DeepCopy(),
+	}
+	delete(c.Annotations, corev1.LastAppliedConfigAnnotation)
+	c.Spec.PodSets = make([]kueue.PodSet, len(w.Spec.PodSets))
+	for i := range w.Spec.PodSets {
+		ps := &w.Spec.PodSets[i]
+		c.Spec.PodSets[i] = kueue.PodSet{
+			Name:     ps.Name,
+			Count:    ps.Count,
+			MinCount: ps.MinCount,
+		}
+	}
+	return c
+}
+
 func (i *Info) Update(wl *kueue.Workload) {
 	i.Obj = wl
 }
@@ -174,11 +301,12 @@ func podSetsCountsAfterReclaim(wl *kueue.Workload) map[string]int32 {
 	return totalCounts
 }
 
-func totalRequestsFromPodSets(wl *kueue.Workload) []PodSetResources {
+func totalRequestsFromPodSets(wl *kueue.Workload, options *infoOptions) []PodSetResources {
 	if len(wl.Spec.PodSets) == 0 {
 		return nil
 	}
 	res := make([]PodSetResources, 0, len(wl.Spec.PodSets))
+	initContainers := initContainersAccounting(wl, options)
 	currentCounts := podSetsCountsAfterReclaim(wl)
 	for _, ps := range wl.Spec.PodSets {
 		count := currentCounts[ps.Name]
@@ -186,13 +314,92 @@ func totalRequestsFromPodSets(wl *kueue.Workload) []PodSetResources {
 			Name:  ps.Name,
 			Count: count,
 		}
-		setRes.Requests = newRequests(limitrange.TotalRequests(&ps.Template.Spec))
+		requests := accountedRequests(&ps.Template.Spec, options.accountingPolicy, initContainers)
+		setRes.Requests = newRequests(options.resourceTransformations.apply(requests))
 		setRes.Requests.scaleUp(int64(count))
 		res = append(res, setRes)
 	}
 	return res
 }
 
+// initContainersAccounting returns the accounting of the init containers of
+// the workload, from its controller.
+func initContainersAccounting(wl *kueue.Workload, options *infoOptions) InitContainersAccounting {
+	if owner := metav1.GetControllerOf(wl); owner != nil {
+		if gv, err := schema.ParseGroupVersion(owner.APIVersion); err == nil {
+			if accounting, found := options.ownersInitContainers[gv.WithKind(owner.Kind).GroupKind()]; found {
+				return accounting
+			}
+		}
+	}
+	return options.initContainersAccounting
+}
+
+// accountedRequests returns the total requests of the pod, replacing the
+// requests of the containers by their limits the way the policy indicates.
+func accountedRequests(spec *corev1.PodSpec, policy AccountingPolicy, initContainers InitContainersAccounting) corev1.ResourceList {
+	if len(policy) == 0 && initContainers != InitContainersSum {
+		return limitrange.TotalRequests(spec)
+	}
+	accounted := *spec
+	accounted.InitContainers = accountedContainers(spec.InitContainers, policy)
+	accounted.Containers = accountedContainers(spec.Containers, policy)
+	if initContainers == InitContainersSum {
+		accounted.Containers = append(accounted.Containers, accounted.InitContainers...)
+		accounted.InitContainers = nil
+	}
+	return limitrange.TotalRequests(&accounted)
+}
+
+func accountedContainers(containers []corev1.Container, policy AccountingPolicy) []corev1.Container {
+	accounted := make([]corev1.Container, len(containers))
+	for i := range containers {
+		// Only the requests are replaced, the rest of the container, like the
+		// restartPolicy of the sidecars, is kept.
+		accounted[i] = *containers[i].DeepCopy()
+		res := &containers[i].Resources
+		requests := maps.Clone(res.Requests)
+		for name, accounting := range policy {
+			limit, found := res.Limits[name]
+			if !found || accounting == AccountRequests {
+				continue
+			}
+			if request, found := requests[name]; accounting == AccountLimits || !found || limit.Cmp(request) > 0 {
+				if requests == nil {
+					requests = corev1.ResourceList{}
+				}
+				requests[name] = limit
+			}
+		}
+		accounted[i].Resources.Requests = requests
+	}
+	return accounted
+}
+
+// apply returns the requests with the transformed resources replaced by, or
+// added to, their outputs.
+func (t ResourceTransformations) apply(requests corev1.ResourceList) corev1.ResourceList {
+	if len(t) == 0 {
+		return requests
+	}
+	transformed := make(corev1.ResourceList, len(requests))
+	for name, q := range requests {
+		rt, found := t[name]
+		if !found || rt.RetainInput {
+			transformed = utilresource.MergeResourceListKeepSum(transformed, corev1.ResourceList{name: q})
+		}
+		if !found {
+			continue
+		}
+		outputs := make(corev1.ResourceList, len(rt.Outputs))
+		for outName, outQ := range rt.Outputs {
+			outputs[outName] = *resource.NewMilliQuantity(outQ.MilliValue()*q.MilliValue()/1000, outQ.Format)
+		}
+		transformed = utilresource.MergeResourceListKeepSum(transformed, outputs)
+	}
+	return transformed
+}
+
 func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
 	if wl.Status.Admission == nil {
 		return nil
@@ -308,6 +515,9 @@ func UnsetQuotaReservationWithCondition(wl *kueue.Workload, reason, message stri
 		Message:            api.TruncateConditionMessage(message),
 	}
 	apimeta.SetStatusCondition(&wl.Status.Conditions, condition)
+	if wl.Status.Admission != nil && features.Enabled(features.FlavorStickiness) {
+		wl.Status.LastAdmission = wl.Status.Admission
+	}
 	wl.Status.Admission = nil
 }
 
@@ -337,6 +547,7 @@ func BaseSSAWorkload(w *kueue.Workload) *kueue.Workload {
 // The WorkloadAdmitted and WorkloadEvicted are added or updated if necessary.
 func SetQuotaReservation(w *kueue.Workload, admission *kueue.Admission) {
 	w.Status.Admission = admission
+	w.Status.Inadmissibility = nil
 	admittedCond := metav1.Condition{
 		Type:               kueue.WorkloadQuotaReserved,
 		Status:             metav1.ConditionTrue,
@@ -351,6 +562,11 @@ func SetQuotaReservation(w *kueue.Workload, admission *kueue.Admission) {
 		evictedCond.Status = metav1.ConditionFalse
 		evictedCond.LastTransitionTime = metav1.Now()
 	}
+	// reset Preempting condition if present.
+	if preemptingCond := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadPreempting); preemptingCond != nil && preemptingCond.Status == metav1.ConditionTrue {
+		preemptingCond.Status = metav1.ConditionFalse
+		preemptingCond.LastTransitionTime = metav1.Now()
+	}
 }
 
 func SetEvictedCondition(w *kueue.Workload, reason string, message string) {
@@ -364,12 +580,37 @@ func SetEvictedCondition(w *kueue.Workload, reason string, message string) {
 	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
 }
 
+// SetPreemptingCondition marks the workload as a preemption target that will be
+// evicted after a grace period.
+func SetPreemptingCondition(w *kueue.Workload, reason string, message string) {
+	condition := metav1.Condition{
+		Type:               kueue.WorkloadPreempting,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
+}
+
+// IsPreempting returns whether the workload is a preemption target waiting for
+// its grace period before being evicted.
+func IsPreempting(w *kueue.Workload) bool {
+	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPreempting) &&
+		!apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadEvicted)
+}
+
 // admissionPatch creates a new object based on the input workload that contains
 // the admission and related conditions. The object can be used in Server-Side-Apply.
 func admissionPatch(w *kueue.Workload) *kueue.Workload {
 	wlCopy := BaseSSAWorkload(w)
 
 	wlCopy.Status.Admission = w.Status.Admission.DeepCopy()
+	for i := range w.Status.Inadmissibility {
+		wlCopy.Status.Inadmissibility = append(wlCopy.Status.Inadmissibility, *w.Status.Inadmissibility[i].DeepCopy())
+	}
+	wlCopy.Status.RequeueState = w.Status.RequeueState.DeepCopy()
+	wlCopy.Status.LastAdmission = w.Status.LastAdmission.DeepCopy()
 	for _, conditionName := range admissionManagedConditions {
 		if existing := apimeta.FindStatusCondition(w.Status.Conditions, conditionName); existing != nil {
 			wlCopy.Status.Conditions = append(wlCopy.Status.Conditions, *existing.DeepCopy())
@@ -398,6 +639,64 @@ func GetQueueOrderTimestamp(w *kueue.Workload) *metav1.Time {
 	return &w.CreationTimestamp
 }
 
+const (
+	defaultBackoffBaseSeconds = 10
+	defaultBackoffMaxSeconds  = 3600
+)
+
+// RequeueCount returns the number of times the workload was requeued after an
+// eviction, following its retryPolicy.
+func RequeueCount(w *kueue.Workload) int32 {
+	if w.Status.RequeueState == nil {
+		return 0
+	}
+	return ptr.Deref(w.Status.RequeueState.Count, 0)
+}
+
+// RetriesExhausted returns whether the workload was requeued as many times as
+// allowed by its retryPolicy.
+func RetriesExhausted(w *kueue.Workload) bool {
+	if w.Spec.RetryPolicy == nil || w.Spec.RetryPolicy.MaxRetries == nil {
+		return false
+	}
+	return RequeueCount(w) >= *w.Spec.RetryPolicy.MaxRetries
+}
+
+// UpdateRequeueState records a new requeue of the workload after an eviction,
+// to happen after the backoff of its retryPolicy.
+func UpdateRequeueState(w *kueue.Workload, now time.Time) {
+	count := RequeueCount(w) + 1
+	w.Status.RequeueState = &kueue.RequeueState{
+		Count:     &count,
+		RequeueAt: ptr.To(metav1.NewTime(now.Add(RequeueBackoff(w.Spec.RetryPolicy, count)))),
+	}
+}
+
+// RequeueBackoff returns the delay before the count-th requeue of a workload
+// with the retryPolicy.
+func RequeueBackoff(policy *kueue.RetryPolicy, count int32) time.Duration {
+	base, limit := int32(defaultBackoffBaseSeconds), int32(defaultBackoffMaxSeconds)
+	if policy != nil {
+		base = ptr.Deref(policy.BackoffBaseSeconds, base)
+		limit = ptr.Deref(policy.BackoffMaxSeconds, limit)
+	}
+	backoff := time.Duration(base) * time.Second
+	maxBackoff := time.Duration(limit) * time.Second
+	for i := int32(1); i < count && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+	return backoff
+}
+
+// IsBackingOff returns whether the workload waits for the backoff of its
+// retryPolicy before being requeued.
+func IsBackingOff(w *kueue.Workload) bool {
+	return w.Status.RequeueState != nil && w.Status.RequeueState.RequeueAt != nil
+}
+
 // HasQuotaReservation checks if workload is admitted based on conditions
 func HasQuotaReservation(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadQuotaReserved)
@@ -434,3 +733,17 @@ func ReclaimablePodsAreEqual(a, b []kueue.ReclaimablePod) bool {
 func IsAdmitted(w *kueue.Workload) bool {
 	return apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadAdmitted)
 }
+
+// PreemptionProtectedUntil returns the time until which the workload can't be
+// preempted, because it was admitted less than minRuntime ago. It returns
+// false if the workload is not admitted or minRuntime is not positive.
+func PreemptionProtectedUntil(w *kueue.Workload, minRuntime time.Duration) (time.Time, bool) {
+	if minRuntime <= 0 {
+		return time.Time{}, false
+	}
+	cond := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadAdmitted)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return time.Time{}, false
+	}
+	return cond.LastTransitionTime.Add(minRuntime), true
+}
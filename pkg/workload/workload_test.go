@@ -26,18 +26,183 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/features"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
 func TestNewInfo(t *testing.T) {
+	initContainersPodSet := utiltesting.MakePodSet("main", 1).
+		InitContainers(
+			corev1.Container{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+			}},
+			corev1.Container{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			}},
+		).
+		Request(corev1.ResourceCPU, "500m").
+		Obj()
 	cases := map[string]struct {
-		workload kueue.Workload
-		wantInfo Info
+		workload                kueue.Workload
+		accountingPolicy        AccountingPolicy
+		resourceTransformations ResourceTransformations
+		initContainers          InitContainersAccounting
+		ownersInitContainers    map[schema.GroupKind]InitContainersAccounting
+		wantInfo                Info
 	}{
+		"pending with the init containers summed": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(*initContainersPodSet.DeepCopy()).
+				Obj(),
+			initContainers: InitContainersSum,
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name:     "main",
+						Requests: Requests{corev1.ResourceCPU: 3000},
+						Count:    1,
+					},
+				},
+			},
+		},
+		"pending with the init containers summed for the kind of its owner": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(*initContainersPodSet.DeepCopy()).
+				OwnerReference("batch/v1", "Job", "job", "uid", true, true).
+				Obj(),
+			initContainers: InitContainersMax,
+			ownersInitContainers: map[schema.GroupKind]InitContainersAccounting{
+				{Group: "batch", Kind: "Job"}: InitContainersSum,
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name:     "main",
+						Requests: Requests{corev1.ResourceCPU: 3000},
+						Count:    1,
+					},
+				},
+			},
+		},
+		"pending with the max of the init containers": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(*initContainersPodSet.DeepCopy()).
+				OwnerReference("kubeflow.org/v1", "MPIJob", "job", "uid", true, true).
+				Obj(),
+			initContainers: InitContainersMax,
+			ownersInitContainers: map[schema.GroupKind]InitContainersAccounting{
+				{Group: "batch", Kind: "Job"}: InitContainersSum,
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name:     "main",
+						Requests: Requests{corev1.ResourceCPU: 2000},
+						Count:    1,
+					},
+				},
+			},
+		},
+		"pending with resource transformations": {
+			workload: *utiltesting.MakeWorkload("", "").
+				Request("example.com/gpu", "1").
+				Request("example.com/gpu-1g", "2").
+				Request("example.com/gpu-slice", "4").
+				Obj(),
+			resourceTransformations: ResourceTransformations{
+				"example.com/gpu-1g": {
+					Outputs: corev1.ResourceList{
+						"example.com/gpu":        resource.MustParse("500m"),
+						"example.com/gpu-memory": resource.MustParse("5Gi"),
+					},
+				},
+				"example.com/gpu-slice": {
+					Outputs:     corev1.ResourceList{"example.com/gpu": resource.MustParse("250m")},
+					RetainInput: true,
+				},
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "main",
+						Requests: Requests{
+							"example.com/gpu":        3,
+							"example.com/gpu-memory": 10 * 1024 * 1024 * 1024,
+							"example.com/gpu-slice":  4,
+						},
+						Count: 1,
+					},
+				},
+			},
+		},
+		"pending with accounting policy": {
+			workload: *utiltesting.MakeWorkload("", "").
+				Request(corev1.ResourceCPU, "10m").
+				Limit(corev1.ResourceCPU, "20m").
+				Request(corev1.ResourceMemory, "512Ki").
+				Limit(corev1.ResourceMemory, "1Mi").
+				Request("example.com/gpu", "1").
+				Limit("example.com/gpu", "2").
+				Request(corev1.ResourceEphemeralStorage, "1Ki").
+				Obj(),
+			accountingPolicy: AccountingPolicy{
+				corev1.ResourceCPU:              AccountRequests,
+				corev1.ResourceMemory:           AccountMaxOfRequestsAndLimits,
+				"example.com/gpu":               AccountLimits,
+				corev1.ResourceEphemeralStorage: AccountLimits,
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "main",
+						Requests: Requests{
+							corev1.ResourceCPU:              10,
+							corev1.ResourceMemory:           1024 * 1024,
+							"example.com/gpu":               2,
+							corev1.ResourceEphemeralStorage: 1024,
+						},
+						Count: 1,
+					},
+				},
+			},
+		},
+		"pending with sidecar and accounting policy": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet("main", 1).
+						Request(corev1.ResourceCPU, "10m").
+						Limit(corev1.ResourceCPU, "20m").
+						InitContainers(corev1.Container{
+							Name:          "sidecar",
+							RestartPolicy: ptr.To(corev1.ContainerRestartPolicyAlways),
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+								Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("20m")},
+							},
+						}).
+						Obj(),
+				).
+				Obj(),
+			accountingPolicy: AccountingPolicy{
+				corev1.ResourceCPU: AccountLimits,
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "main",
+						Requests: Requests{
+							corev1.ResourceCPU: 40,
+						},
+						Count: 1,
+					},
+				},
+			},
+		},
 		"pending": {
 			workload: *utiltesting.MakeWorkload("", "").
 				Request(corev1.ResourceCPU, "10m").
@@ -190,7 +355,8 @@ func TestNewInfo(t *testing.T) {
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			info := NewInfo(&tc.workload)
+			info := NewInfo(&tc.workload, WithAccountingPolicy(tc.accountingPolicy), WithResourceTransformations(tc.resourceTransformations),
+				WithInitContainersAccounting(tc.initContainers, tc.ownersInitContainers))
 			if diff := cmp.Diff(info, &tc.wantInfo, cmpopts.IgnoreFields(Info{}, "Obj")); diff != "" {
 				t.Errorf("NewInfo(_) = (-want,+got):\n%s", diff)
 			}
@@ -198,6 +364,67 @@ func TestNewInfo(t *testing.T) {
 	}
 }
 
+func TestCompact(t *testing.T) {
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		Queue("lq").
+		Priority(100).
+		Labels(map[string]string{"team": "a"}).
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).Request(corev1.ResourceCPU, "1").Obj(),
+			*utiltesting.MakePodSet("workers", 5).SetMinimumCount(3).Request(corev1.ResourceCPU, "2").Obj(),
+		).
+		ReserveQuota(utiltesting.MakeAdmission("cq").
+			PodSets(
+				kueue.PodSetAssignment{
+					Name:          "driver",
+					Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "default"},
+					ResourceUsage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					Count:         ptr.To[int32](1),
+				},
+				kueue.PodSetAssignment{
+					Name:          "workers",
+					Flavors:       map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "default"},
+					ResourceUsage: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")},
+					Count:         ptr.To[int32](5),
+				},
+			).
+			Obj()).
+		ReclaimablePods(kueue.ReclaimablePod{Name: "workers", Count: 2}).
+		Obj()
+	wl.Annotations = map[string]string{
+		corev1.LastAppliedConfigAnnotation: "{}",
+		"example.com/last-checkpoint":      "2023-12-01T10:00:00Z",
+	}
+	wl.Generation = 2
+
+	got := Compact(wl)
+	wl.Labels["team"] = "b"
+	want := &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "wl",
+			Namespace:   "ns",
+			Generation:  2,
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"example.com/last-checkpoint": "2023-12-01T10:00:00Z"},
+		},
+		Spec: kueue.WorkloadSpec{
+			PodSets: []kueue.PodSet{
+				{Name: "driver", Count: 1},
+				{Name: "workers", Count: 5, MinCount: ptr.To[int32](3)},
+			},
+			QueueName: "lq",
+			Priority:  ptr.To[int32](100),
+		},
+		Status: wl.Status,
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Unexpected compact workload (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(NewInfo(wl).TotalRequests, NewInfo(got).TotalRequests); diff != "" {
+		t.Errorf("Unexpected total requests of the compact workload (-want,+got):\n%s", diff)
+	}
+}
+
 var ignoreConditionTimestamps = cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")
 
 func TestUpdateWorkloadStatus(t *testing.T) {
@@ -331,6 +558,149 @@ func TestGetQueueOrderTimestamp(t *testing.T) {
 	}
 }
 
+func TestRequeueBackoff(t *testing.T) {
+	cases := map[string]struct {
+		policy *kueue.RetryPolicy
+		count  int32
+		want   time.Duration
+	}{
+		"defaults, first requeue": {
+			count: 1,
+			want:  10 * time.Second,
+		},
+		"defaults, third requeue": {
+			count: 3,
+			want:  40 * time.Second,
+		},
+		"capped": {
+			policy: &kueue.RetryPolicy{
+				BackoffBaseSeconds: ptr.To[int32](60),
+				BackoffMaxSeconds:  ptr.To[int32](300),
+			},
+			count: 4,
+			want:  300 * time.Second,
+		},
+		"many requeues": {
+			count: 1000,
+			want:  time.Hour,
+		},
+		"no backoff": {
+			policy: &kueue.RetryPolicy{
+				BackoffBaseSeconds: ptr.To[int32](0),
+			},
+			count: 3,
+			want:  0,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := RequeueBackoff(tc.policy, tc.count); got != tc.want {
+				t.Errorf("RequeueBackoff() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateRequeueState(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	wl := utiltesting.MakeWorkload("wl", "ns").
+		RetryPolicy(&kueue.RetryPolicy{MaxRetries: ptr.To[int32](2)}).
+		Obj()
+	for i := int32(1); i <= 2; i++ {
+		if RetriesExhausted(wl) {
+			t.Fatalf("Retries exhausted after %d requeues", i-1)
+		}
+		UpdateRequeueState(wl, now)
+		want := &kueue.RequeueState{
+			Count:     ptr.To(i),
+			RequeueAt: ptr.To(metav1.NewTime(now.Add(RequeueBackoff(nil, i)))),
+		}
+		if diff := cmp.Diff(want, wl.Status.RequeueState); diff != "" {
+			t.Errorf("Unexpected requeueState after %d requeues (-want,+got):\n%s", i, diff)
+		}
+	}
+	if !RetriesExhausted(wl) {
+		t.Errorf("Retries not exhausted after 2 requeues")
+	}
+}
+
+func TestUnsetQuotaReservationWithCondition(t *testing.T) {
+	admission := utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "one", "1").Obj()
+	cases := map[string]struct {
+		enableFlavorStickiness bool
+		lastAdmission          *kueue.Admission
+		wantLastAdmission      *kueue.Admission
+	}{
+		"FlavorStickiness enabled": {
+			enableFlavorStickiness: true,
+			wantLastAdmission:      admission,
+		},
+		"FlavorStickiness disabled": {},
+		"replaces the previous last admission": {
+			enableFlavorStickiness: true,
+			lastAdmission:          utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "two", "1").Obj(),
+			wantLastAdmission:      admission,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer features.SetFeatureGateDuringTest(t, features.FlavorStickiness, tc.enableFlavorStickiness)()
+			wl := utiltesting.MakeWorkload("wl", "ns").ReserveQuota(admission).Obj()
+			wl.Status.LastAdmission = tc.lastAdmission
+			UnsetQuotaReservationWithCondition(wl, "Pending", "Evicted")
+			if wl.Status.Admission != nil {
+				t.Errorf("Admission not cleared")
+			}
+			if HasQuotaReservation(wl) {
+				t.Errorf("QuotaReserved condition still true")
+			}
+			if diff := cmp.Diff(tc.wantLastAdmission, wl.Status.LastAdmission); diff != "" {
+				t.Errorf("Unexpected lastAdmission (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPreemptionProtectedUntil(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	admitted := utiltesting.MakeWorkload("wl", "ns").
+		SetOrReplaceCondition(metav1.Condition{
+			Type:               kueue.WorkloadAdmitted,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Admitted",
+			LastTransitionTime: metav1.NewTime(now),
+		}).
+		Obj()
+	cases := map[string]struct {
+		wl            *kueue.Workload
+		minRuntime    time.Duration
+		want          time.Time
+		wantProtected bool
+	}{
+		"admitted": {
+			wl:            admitted,
+			minRuntime:    10 * time.Minute,
+			want:          now.Add(10 * time.Minute),
+			wantProtected: true,
+		},
+		"no minRuntime": {
+			wl: admitted,
+		},
+		"not admitted": {
+			wl:         utiltesting.MakeWorkload("wl", "ns").Obj(),
+			minRuntime: 10 * time.Minute,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, protected := PreemptionProtectedUntil(tc.wl, tc.minRuntime)
+			if protected != tc.wantProtected || !got.Equal(tc.want) {
+				t.Errorf("PreemptionProtectedUntil() = (%v, %t), want (%v, %t)", got, protected, tc.want, tc.wantProtected)
+			}
+		})
+	}
+}
+
 func TestReclaimablePodsAreEqual(t *testing.T) {
 	cases := map[string]struct {
 		a, b       []kueue.ReclaimablePod
@@ -202,7 +202,7 @@ var _ = ginkgo.Describe("ClusterQueue controller", ginkgo.Ordered, ginkgo.Contin
 						Type:    kueue.ClusterQueueActive,
 						Status:  metav1.ConditionFalse,
 						Reason:  "FlavorNotFound",
-						Message: "Can't admit new workloads: FlavorNotFound",
+						Message: "Can't admit new workloads: FlavorNotFound; flavors not found: model-a (resourceGroups[1]), model-b (resourceGroups[1]), on-demand (resourceGroups[0]), spot (resourceGroups[0])",
 					},
 				},
 			}, ignoreConditionTimestamps, ignorePendingWorkloadsStatus))
@@ -597,7 +597,7 @@ var _ = ginkgo.Describe("ClusterQueue controller", ginkgo.Ordered, ginkgo.Contin
 					Type:    kueue.ClusterQueueActive,
 					Status:  metav1.ConditionFalse,
 					Reason:  "FlavorNotFound",
-					Message: "Can't admit new workloads: FlavorNotFound",
+					Message: "Can't admit new workloads: FlavorNotFound; flavors not found: arch-a (resourceGroups[0]), arch-b (resourceGroups[0])",
 				},
 			}, ignoreConditionTimestamps))
 
@@ -613,7 +613,7 @@ var _ = ginkgo.Describe("ClusterQueue controller", ginkgo.Ordered, ginkgo.Contin
 					Type:    kueue.ClusterQueueActive,
 					Status:  metav1.ConditionFalse,
 					Reason:  "FlavorNotFound",
-					Message: "Can't admit new workloads: FlavorNotFound",
+					Message: "Can't admit new workloads: FlavorNotFound; flavors not found: arch-b (resourceGroups[0])",
 				},
 			}, ignoreConditionTimestamps))
 
@@ -653,7 +653,7 @@ var _ = ginkgo.Describe("ClusterQueue controller", ginkgo.Ordered, ginkgo.Contin
 					Type:    kueue.ClusterQueueActive,
 					Status:  metav1.ConditionFalse,
 					Reason:  "CheckNotFoundOrInactive",
-					Message: "Can't admit new workloads: CheckNotFoundOrInactive",
+					Message: "Can't admit new workloads: CheckNotFoundOrInactive; admission checks not found: check1, check2",
 				},
 			}, ignoreConditionTimestamps))
 
@@ -670,7 +670,7 @@ var _ = ginkgo.Describe("ClusterQueue controller", ginkgo.Ordered, ginkgo.Contin
 					Type:    kueue.ClusterQueueActive,
 					Status:  metav1.ConditionFalse,
 					Reason:  "CheckNotFoundOrInactive",
-					Message: "Can't admit new workloads: CheckNotFoundOrInactive",
+					Message: "Can't admit new workloads: CheckNotFoundOrInactive; admission checks not found: check2",
 				},
 			}, ignoreConditionTimestamps))
 
@@ -686,7 +686,7 @@ var _ = ginkgo.Describe("ClusterQueue controller", ginkgo.Ordered, ginkgo.Contin
 					Type:    kueue.ClusterQueueActive,
 					Status:  metav1.ConditionFalse,
 					Reason:  "CheckNotFoundOrInactive",
-					Message: "Can't admit new workloads: CheckNotFoundOrInactive",
+					Message: "Can't admit new workloads: CheckNotFoundOrInactive; admission checks inactive: check2",
 				},
 			}, ignoreConditionTimestamps))
 
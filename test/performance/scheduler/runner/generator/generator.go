@@ -0,0 +1,222 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+const (
+	// ClassLabel holds the class of the generated workloads.
+	ClassLabel = "performance.kueue.x-k8s.io/class"
+	// RuntimeAnnotation holds how long a generated workload runs once admitted.
+	RuntimeAnnotation = "performance.kueue.x-k8s.io/runtime"
+
+	// Namespace is where the LocalQueues and workloads are created.
+	Namespace = "kueue-performance"
+	// ResourceFlavor is the flavor providing the quota of all the ClusterQueues.
+	ResourceFlavor = "default-flavor"
+)
+
+// WorkloadTemplate describes a class of workloads.
+type WorkloadTemplate struct {
+	ClassName string `json:"className"`
+	// RuntimeMs is how long the workload runs once admitted.
+	RuntimeMs uint  `json:"runtimeMs"`
+	Priority  int32 `json:"priority"`
+	// Request is the cpu requested by the workload.
+	Request string `json:"request"`
+}
+
+// WorkloadsSet creates Count rounds of its workloads in every ClusterQueue.
+type WorkloadsSet struct {
+	Count int `json:"count"`
+	// CreationIntervalMs is the time between two rounds.
+	CreationIntervalMs uint               `json:"creationIntervalMs"`
+	Workloads          []WorkloadTemplate `json:"workloads"`
+}
+
+// QueuesSet describes a class of ClusterQueues, each with its own LocalQueue.
+type QueuesSet struct {
+	ClassName           string                 `json:"className"`
+	Count               int                    `json:"count"`
+	NominalQuota        string                 `json:"nominalQuota"`
+	BorrowingLimit      string                 `json:"borrowingLimit,omitempty"`
+	ReclaimWithinCohort kueue.PreemptionPolicy `json:"reclaimWithinCohort,omitempty"`
+	WithinClusterQueue  kueue.PreemptionPolicy `json:"withinClusterQueue,omitempty"`
+	WorkloadsSets       []WorkloadsSet         `json:"workloadsSets"`
+}
+
+// CohortSet describes a class of cohorts.
+type CohortSet struct {
+	ClassName  string      `json:"className"`
+	Count      int         `json:"count"`
+	QueuesSets []QueuesSet `json:"queuesSets"`
+}
+
+// LoadConfig reads the cohort sets from a yaml file.
+func LoadConfig(path string) ([]CohortSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sets []CohortSet
+	if err := yaml.Unmarshal(data, &sets); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return sets, nil
+}
+
+type queue struct {
+	clusterQueue *kueue.ClusterQueue
+	localQueue   *kueue.LocalQueue
+	set          *QueuesSet
+}
+
+func queues(sets []CohortSet) []queue {
+	var result []queue
+	for i := range sets {
+		cSet := &sets[i]
+		for c := 0; c < cSet.Count; c++ {
+			cohort := fmt.Sprintf("%s-%d", cSet.ClassName, c)
+			for j := range cSet.QueuesSets {
+				qSet := &cSet.QueuesSets[j]
+				for q := 0; q < qSet.Count; q++ {
+					name := fmt.Sprintf("%s-%s-%d", cohort, qSet.ClassName, q)
+					quotas := []string{qSet.NominalQuota}
+					if qSet.BorrowingLimit != "" {
+						quotas = append(quotas, qSet.BorrowingLimit)
+					}
+					cq := utiltesting.MakeClusterQueue(name).
+						Cohort(cohort).
+						ResourceGroup(*utiltesting.MakeFlavorQuotas(ResourceFlavor).Resource(corev1.ResourceCPU, quotas...).Obj()).
+						Preemption(kueue.ClusterQueuePreemption{
+							ReclaimWithinCohort: defaultPolicy(qSet.ReclaimWithinCohort),
+							WithinClusterQueue:  defaultPolicy(qSet.WithinClusterQueue),
+						}).
+						Obj()
+					result = append(result, queue{
+						clusterQueue: cq,
+						localQueue:   utiltesting.MakeLocalQueue(name, Namespace).ClusterQueue(name).Obj(),
+						set:          qSet,
+					})
+				}
+			}
+		}
+	}
+	return result
+}
+
+func defaultPolicy(p kueue.PreemptionPolicy) kueue.PreemptionPolicy {
+	if p == "" {
+		return kueue.PreemptionPolicyNever
+	}
+	return p
+}
+
+func newWorkload(q *queue, set int, tmpl *WorkloadTemplate, round int) *kueue.Workload {
+	wl := utiltesting.MakeWorkload(fmt.Sprintf("%s-%s-%d-%d", q.localQueue.Name, tmpl.ClassName, set, round), Namespace).
+		Queue(q.localQueue.Name).
+		Priority(tmpl.Priority).
+		Request(corev1.ResourceCPU, tmpl.Request).
+		Labels(map[string]string{ClassLabel: tmpl.ClassName}).
+		Obj()
+	wl.Annotations = map[string]string{RuntimeAnnotation: strconv.FormatUint(uint64(tmpl.RuntimeMs), 10) + "ms"}
+	return wl
+}
+
+// Count returns the number of workloads generated for the cohort sets.
+func Count(sets []CohortSet) int {
+	total := 0
+	for _, q := range queues(sets) {
+		for _, wSet := range q.set.WorkloadsSets {
+			total += wSet.Count * len(wSet.Workloads)
+		}
+	}
+	return total
+}
+
+// Generate creates the objects described by the cohort sets. The workloads of
+// every ClusterQueue are created concurrently with the ones of the others.
+func Generate(ctx context.Context, c client.Client, sets []CohortSet) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: Namespace}}
+	if err := c.Create(ctx, ns); err != nil {
+		return err
+	}
+	if err := c.Create(ctx, utiltesting.MakeResourceFlavor(ResourceFlavor).Obj()); err != nil {
+		return err
+	}
+	qs := queues(sets)
+	for i := range qs {
+		if err := c.Create(ctx, qs[i].clusterQueue); err != nil {
+			return err
+		}
+		if err := c.Create(ctx, qs[i].localQueue); err != nil {
+			return err
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(qs))
+	for i := range qs {
+		wg.Add(1)
+		go func(q *queue) {
+			defer wg.Done()
+			errs <- createWorkloads(ctx, c, q)
+		}(&qs[i])
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createWorkloads(ctx context.Context, c client.Client, q *queue) error {
+	for i := range q.set.WorkloadsSets {
+		wSet := &q.set.WorkloadsSets[i]
+		for round := 0; round < wSet.Count; round++ {
+			for j := range wSet.Workloads {
+				if err := c.Create(ctx, newWorkload(q, i, &wSet.Workloads[j], round)); err != nil {
+					return err
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(wSet.CreationIntervalMs) * time.Millisecond):
+			}
+		}
+	}
+	return nil
+}
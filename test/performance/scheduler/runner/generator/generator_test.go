@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadDefaultConfig(t *testing.T) {
+	sets, err := LoadConfig("../../default_generator_config.yaml")
+	if err != nil {
+		t.Fatalf("Loading the default config: %v", err)
+	}
+	if got, want := Count(sets), 5*6*(350+100+50); got != want {
+		t.Errorf("Unexpected number of workloads, got %d, want %d", got, want)
+	}
+}
+
+func TestQueues(t *testing.T) {
+	sets := []CohortSet{{
+		ClassName: "cohort",
+		Count:     2,
+		QueuesSets: []QueuesSet{{
+			ClassName:      "cq",
+			Count:          2,
+			NominalQuota:   "10",
+			BorrowingLimit: "5",
+			WorkloadsSets: []WorkloadsSet{{
+				Count:     3,
+				Workloads: []WorkloadTemplate{{ClassName: "small", Request: "1"}},
+			}},
+		}},
+	}}
+	var gotNames, gotCohorts []string
+	for _, q := range queues(sets) {
+		gotNames = append(gotNames, q.clusterQueue.Name)
+		gotCohorts = append(gotCohorts, q.clusterQueue.Spec.Cohort)
+		if q.localQueue.Namespace != Namespace || string(q.localQueue.Spec.ClusterQueue) != q.clusterQueue.Name {
+			t.Errorf("LocalQueue %s/%s doesn't point to ClusterQueue %s", q.localQueue.Namespace, q.localQueue.Name, q.clusterQueue.Name)
+		}
+		quota := q.clusterQueue.Spec.ResourceGroups[0].Flavors[0].Resources[0]
+		if quota.NominalQuota.String() != "10" || quota.BorrowingLimit == nil || quota.BorrowingLimit.String() != "5" {
+			t.Errorf("Unexpected quota in ClusterQueue %s: %+v", q.clusterQueue.Name, quota)
+		}
+	}
+	if diff := cmp.Diff([]string{"cohort-0-cq-0", "cohort-0-cq-1", "cohort-1-cq-0", "cohort-1-cq-1"}, gotNames); diff != "" {
+		t.Errorf("Unexpected ClusterQueues (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"cohort-0", "cohort-0", "cohort-1", "cohort-1"}, gotCohorts); diff != "" {
+		t.Errorf("Unexpected cohorts (-want,+got):\n%s", diff)
+	}
+	if got := Count(sets); got != 12 {
+		t.Errorf("Unexpected number of workloads, got %d, want 12", got)
+	}
+
+	qs := queues(sets)
+	wl := newWorkload(&qs[0], 0, &sets[0].QueuesSets[0].WorkloadsSets[0].Workloads[0], 2)
+	if wl.Name != "cohort-0-cq-0-small-0-2" || wl.Spec.QueueName != "cohort-0-cq-0" || wl.Labels[ClassLabel] != "small" {
+		t.Errorf("Unexpected workload %s in queue %s with labels %v", wl.Name, wl.Spec.QueueName, wl.Labels)
+	}
+}
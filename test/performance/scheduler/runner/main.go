@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/yaml"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/controller/core"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/test/performance/scheduler/runner/generator"
+	"sigs.k8s.io/kueue/test/performance/scheduler/runner/recorder"
+)
+
+var (
+	scheme = runtime.NewScheme()
+	log    = ctrl.Log.WithName("runner")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kueue.AddToScheme(scheme))
+}
+
+func main() {
+	var (
+		kubeconfig      string
+		crdPath         string
+		generatorConfig string
+		output          string
+		timeout         time.Duration
+		qps             float64
+		burst           int
+	)
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Kubeconfig of a cluster running Kueue. If empty, an envtest apiserver is started and Kueue runs in the process.")
+	flag.StringVar(&crdPath, "crds", "config/components/crd/bases", "Directory of the Kueue CRDs, installed in the envtest apiserver.")
+	flag.StringVar(&generatorConfig, "generatorConfig", "test/performance/scheduler/default_generator_config.yaml", "Description of the queues and workloads to generate.")
+	flag.StringVar(&output, "o", "", "File where the summary is written. If empty, it's written to the standard output.")
+	flag.DurationVar(&timeout, "timeout", 10*time.Minute, "Maximum duration of the run.")
+	flag.Float64Var(&qps, "qps", 1000, "QPS of the clients.")
+	flag.IntVar(&burst, "burst", 2000, "Burst of the clients.")
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if err := run(kubeconfig, crdPath, generatorConfig, output, timeout, qps, burst); err != nil {
+		log.Error(err, "Run failed")
+		os.Exit(1)
+	}
+}
+
+func run(kubeconfig, crdPath, generatorConfig, output string, timeout time.Duration, qps float64, burst int) error {
+	sets, err := generator.LoadConfig(generatorConfig)
+	if err != nil {
+		return err
+	}
+
+	var cfg *rest.Config
+	inProcess := kubeconfig == ""
+	if inProcess {
+		testEnv := &envtest.Environment{
+			CRDDirectoryPaths:     []string{crdPath},
+			ErrorIfCRDPathMissing: true,
+		}
+		cfg, err = testEnv.Start()
+		if err != nil {
+			return fmt.Errorf("starting envtest: %w", err)
+		}
+		defer func() {
+			if err := testEnv.Stop(); err != nil {
+				log.Error(err, "Stopping envtest")
+			}
+		}()
+	} else {
+		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			return err
+		}
+	}
+	cfg.QPS = float32(qps)
+	cfg.Burst = burst
+
+	ctx, cancel := context.WithTimeout(ctrl.SetupSignalHandler(), timeout)
+	defer cancel()
+
+	mgr, err := ctrl.NewManager(cfg, manager.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		return err
+	}
+	if inProcess {
+		if err := setupKueue(ctx, mgr); err != nil {
+			return err
+		}
+	}
+	rec := recorder.New(mgr.GetClient(), clock.RealClock{})
+	if err := rec.SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	mgrErr := make(chan error, 1)
+	go func() {
+		mgrErr <- mgr.Start(ctx)
+	}()
+	if !mgr.GetCache().WaitForCacheSync(ctx) {
+		return errors.New("the caches didn't sync")
+	}
+
+	start := time.Now()
+	total := generator.Count(sets)
+	log.Info("Generating workloads", "count", total)
+	if err := generator.Generate(ctx, mgr.GetClient(), sets); err != nil {
+		return fmt.Errorf("generating the workloads: %w", err)
+	}
+	log.Info("Waiting for the workloads to finish")
+	err = wait.PollUntilContextCancel(ctx, time.Second, true, func(context.Context) (bool, error) {
+		return rec.Finished() >= total, nil
+	})
+	if err != nil {
+		log.Error(err, "Not all the workloads finished", "finished", rec.Finished(), "total", total)
+	}
+	log.Info("Run finished", "duration", time.Since(start))
+
+	summary := rec.Summary()
+	data, err := yaml.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		fmt.Print(string(data))
+	} else if err := os.WriteFile(output, data, 0o644); err != nil {
+		return err
+	}
+
+	cancel()
+	if err := <-mgrErr; err != nil {
+		return err
+	}
+	if summary.Admitted < total {
+		return fmt.Errorf("only %d of %d workloads were admitted", summary.Admitted, total)
+	}
+	return nil
+}
+
+// setupKueue runs the controllers and the scheduler of Kueue in the manager.
+func setupKueue(ctx context.Context, mgr manager.Manager) error {
+	if err := indexer.Setup(ctx, mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+	cCache := cache.New(mgr.GetClient())
+	queues := queue.NewManager(mgr.GetClient(), cCache)
+	if failedCtrl, err := core.SetupControllers(mgr, queues, cCache, &configapi.Configuration{}); err != nil {
+		return fmt.Errorf("setting up controller %s: %w", failedCtrl, err)
+	}
+	return mgr.Add(scheduler.New(queues, cCache, mgr.GetClient(), mgr.GetEventRecorderFor(constants.AdmissionName)))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+	"sigs.k8s.io/kueue/test/performance/scheduler/runner/generator"
+)
+
+// Recorder observes the generated workloads, recording when they are created
+// and admitted, and finishes them once they ran for their runtime.
+type Recorder struct {
+	client client.Client
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	workloads map[string]*workloadRecord
+	finished  int
+}
+
+type workloadRecord struct {
+	class    string
+	created  time.Time
+	admitted time.Time
+}
+
+func New(c client.Client, clock clock.Clock) *Recorder {
+	return &Recorder{
+		client:    c,
+		clock:     clock,
+		workloads: make(map[string]*workloadRecord),
+	}
+}
+
+func (r *Recorder) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("performance-recorder").
+		For(&kueue.Workload{}).
+		Complete(r)
+}
+
+func (r *Recorder) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var wl kueue.Workload
+	if err := r.client.Get(ctx, req.NamespacedName, &wl); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	class, isGenerated := wl.Labels[generator.ClassLabel]
+	if !isGenerated || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
+		return ctrl.Result{}, nil
+	}
+	now := r.clock.Now()
+	record := r.observe(workload.Key(&wl), class, wl.CreationTimestamp.Time, now, workload.HasQuotaReservation(&wl))
+	if record.admitted.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	runtime, err := time.ParseDuration(wl.Annotations[generator.RuntimeAnnotation])
+	if err != nil {
+		runtime = 0
+	}
+	if remaining := runtime - now.Sub(record.admitted); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+	err = workload.UpdateStatus(ctx, r.client, &wl, kueue.WorkloadFinished, metav1.ConditionTrue, "RuntimeEnded", "The workload ran for its runtime", "performance-recorder")
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	r.mu.Lock()
+	r.finished++
+	r.mu.Unlock()
+	return ctrl.Result{}, nil
+}
+
+func (r *Recorder) observe(key, class string, created, now time.Time, admitted bool) workloadRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record := r.workloads[key]
+	if record == nil {
+		// The creation timestamp has a resolution of seconds, the time of the
+		// first observation is more accurate unless the workload was missed.
+		record = &workloadRecord{class: class, created: now}
+		if created.After(now) || now.Sub(created) > time.Second {
+			record.created = created
+		}
+		r.workloads[key] = record
+	}
+	if admitted && record.admitted.IsZero() {
+		record.admitted = now
+	}
+	return *record
+}
+
+// Finished returns the number of workloads finished by the recorder.
+func (r *Recorder) Finished() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.finished
+}
+
+// Summary holds the results of a run.
+type Summary struct {
+	Workloads int `json:"workloads"`
+	Admitted  int `json:"admitted"`
+	// Throughput is the number of admissions per second, between the first
+	// workload creation and the last admission.
+	Throughput float64                 `json:"throughput"`
+	Classes    map[string]ClassSummary `json:"classes,omitempty"`
+}
+
+// ClassSummary holds the time from creation to admission of the workloads of
+// a class.
+type ClassSummary struct {
+	Workloads int             `json:"workloads"`
+	Admitted  int             `json:"admitted"`
+	P50       metav1.Duration `json:"p50"`
+	P90       metav1.Duration `json:"p90"`
+	P99       metav1.Duration `json:"p99"`
+	Max       metav1.Duration `json:"max"`
+}
+
+// Summary computes the results from the workloads observed so far.
+func (r *Recorder) Summary() Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	summary := Summary{Classes: make(map[string]ClassSummary)}
+	latencies := make(map[string][]time.Duration)
+	var first, last time.Time
+	for _, record := range r.workloads {
+		summary.Workloads++
+		cs := summary.Classes[record.class]
+		cs.Workloads++
+		if first.IsZero() || record.created.Before(first) {
+			first = record.created
+		}
+		if !record.admitted.IsZero() {
+			summary.Admitted++
+			cs.Admitted++
+			latencies[record.class] = append(latencies[record.class], record.admitted.Sub(record.created))
+			if record.admitted.After(last) {
+				last = record.admitted
+			}
+		}
+		summary.Classes[record.class] = cs
+	}
+	for class, ls := range latencies {
+		sort.Slice(ls, func(i, j int) bool { return ls[i] < ls[j] })
+		cs := summary.Classes[class]
+		cs.P50 = metav1.Duration{Duration: percentile(ls, 50)}
+		cs.P90 = metav1.Duration{Duration: percentile(ls, 90)}
+		cs.P99 = metav1.Duration{Duration: percentile(ls, 99)}
+		cs.Max = metav1.Duration{Duration: ls[len(ls)-1]}
+		summary.Classes[class] = cs
+	}
+	if elapsed := last.Sub(first); summary.Admitted > 0 && elapsed > 0 {
+		summary.Throughput = float64(summary.Admitted) / elapsed.Seconds()
+	}
+	return summary
+}
+
+// percentile returns the nearest-rank percentile p of the sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recorder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 10; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Second)
+	}
+	cases := map[int]time.Duration{
+		0:   time.Second,
+		50:  5 * time.Second,
+		90:  9 * time.Second,
+		99:  10 * time.Second,
+		100: 10 * time.Second,
+	}
+	for p, want := range cases {
+		if got := percentile(sorted, p); got != want {
+			t.Errorf("percentile(%d) = %v, want %v", p, got, want)
+		}
+	}
+	if got := percentile(nil, 50); got != 0 {
+		t.Errorf("percentile of no durations = %v, want 0", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	start := time.Now()
+	r := New(nil, nil)
+	r.observe("ns/small-0", "small", start, start, false)
+	r.observe("ns/small-0", "small", start, start.Add(time.Second), true)
+	r.observe("ns/small-1", "small", start, start.Add(time.Second), false)
+	r.observe("ns/small-1", "small", start, start.Add(3*time.Second), true)
+	// Observing the admission again doesn't change the admission time.
+	r.observe("ns/small-1", "small", start, start.Add(4*time.Second), true)
+	r.observe("ns/large-0", "large", start, start.Add(time.Second), false)
+
+	want := Summary{
+		Workloads:  3,
+		Admitted:   2,
+		Throughput: 2.0 / 3,
+		Classes: map[string]ClassSummary{
+			"small": {
+				Workloads: 2,
+				Admitted:  2,
+				P50:       metav1.Duration{Duration: time.Second},
+				P90:       metav1.Duration{Duration: 2 * time.Second},
+				P99:       metav1.Duration{Duration: 2 * time.Second},
+				Max:       metav1.Duration{Duration: 2 * time.Second},
+			},
+			"large": {
+				Workloads: 1,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, r.Summary()); diff != "" {
+		t.Errorf("Unexpected summary (-want,+got):\n%s", diff)
+	}
+}